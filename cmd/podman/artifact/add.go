@@ -0,0 +1,69 @@
+package artifact
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/spf13/cobra"
+)
+
+var (
+	addCmd = &cobra.Command{
+		Use:               "add [options] ARTIFACT PATH [PATH...]",
+		Short:             "Add files to an artifact",
+		Long:              "Create an OCI artifact in the local artifact store from one or more files, or add files to an existing artifact with --append.",
+		RunE:              add,
+		Args:              cobra.MinimumNArgs(2),
+		ValidArgsFunction: common.AutocompleteArtifactAdd,
+		Example: `podman artifact add quay.io/myuser/mymodel:v1 model.gguf
+  podman artifact add --type application/vnd.example.model quay.io/myuser/mymodel:v1 model.gguf config.json`,
+	}
+
+	addOpts        = entities.ArtifactAddOptions{}
+	addAnnotations []string
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: addCmd,
+		Parent:  artifactCmd,
+	})
+	flags := addCmd.Flags()
+
+	annotationFlagName := "annotation"
+	flags.StringArrayVar(&addAnnotations, annotationFlagName, nil, "Add an annotation to the artifact (`KEY=VALUE`)")
+	_ = addCmd.RegisterFlagCompletionFunc(annotationFlagName, completion.AutocompleteNone)
+
+	flags.BoolVarP(&addOpts.Append, "append", "a", false, "Add the files to the artifact if it already exists")
+
+	typeFlagName := "type"
+	flags.StringVar(&addOpts.ArtifactType, typeFlagName, "", "Artifact type of the artifact")
+	_ = addCmd.RegisterFlagCompletionFunc(typeFlagName, completion.AutocompleteNone)
+
+	fileTypeFlagName := "file-type"
+	flags.StringVar(&addOpts.FileType, fileTypeFlagName, "", "Media type of the added files")
+	_ = addCmd.RegisterFlagCompletionFunc(fileTypeFlagName, completion.AutocompleteNone)
+}
+
+func add(cmd *cobra.Command, args []string) error {
+	if len(addAnnotations) > 0 {
+		addOpts.Annotations = make(map[string]string, len(addAnnotations))
+		for _, annotation := range addAnnotations {
+			key, val, ok := strings.Cut(annotation, "=")
+			if !ok || key == "" {
+				return fmt.Errorf("annotation %q must be in the KEY=VALUE format", annotation)
+			}
+			addOpts.Annotations[key] = val
+		}
+	}
+	report, err := registry.ImageEngine().ArtifactAdd(registry.Context(), args[0], args[1:], addOpts)
+	if err != nil {
+		return err
+	}
+	fmt.Println(report.ArtifactDigest.Encoded())
+	return nil
+}
@@ -0,0 +1,26 @@
+package artifact
+
+import (
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/cmd/podman/validate"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// Pull in configured json library
+	json = registry.JSONLibrary()
+
+	// Command: podman _artifact_
+	artifactCmd = &cobra.Command{
+		Use:   "artifact",
+		Short: "Manage OCI artifacts",
+		Long:  "Add, pull, push, list, inspect, extract and remove OCI artifacts, files stored in registries next to images",
+		RunE:  validate.SubCommandExists,
+	}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: artifactCmd,
+	})
+}
@@ -0,0 +1,46 @@
+package artifact
+
+import (
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/spf13/cobra"
+)
+
+var (
+	extractCmd = &cobra.Command{
+		Use:   "extract [options] ARTIFACT TARGET",
+		Short: "Extract the files of an artifact",
+		Long: `Extract the files of an artifact from the local artifact store. If the target is a directory,
+  each file is written into it, named after its title. Otherwise the target is the file to write, and
+  the artifact must have a single file or one must be selected with --digest or --title.`,
+		RunE:              extract,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: common.AutocompleteArtifactExtract,
+		Example: `podman artifact extract quay.io/myuser/mymodel:v1 /tmp/model
+  podman artifact extract --title config.json quay.io/myuser/mymodel:v1 /tmp/config.json`,
+	}
+
+	extractOpts = entities.ArtifactExtractOptions{}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: extractCmd,
+		Parent:  artifactCmd,
+	})
+	flags := extractCmd.Flags()
+
+	digestFlagName := "digest"
+	flags.StringVar(&extractOpts.Digest, digestFlagName, "", "Only extract the file with this digest")
+	_ = extractCmd.RegisterFlagCompletionFunc(digestFlagName, completion.AutocompleteNone)
+
+	titleFlagName := "title"
+	flags.StringVar(&extractOpts.Title, titleFlagName, "", "Only extract the file with this title")
+	_ = extractCmd.RegisterFlagCompletionFunc(titleFlagName, completion.AutocompleteNone)
+}
+
+func extract(cmd *cobra.Command, args []string) error {
+	return registry.ImageEngine().ArtifactExtract(registry.Context(), args[0], args[1], extractOpts)
+}
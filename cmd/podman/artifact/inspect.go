@@ -0,0 +1,40 @@
+package artifact
+
+import (
+	"fmt"
+
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/spf13/cobra"
+)
+
+var inspectCmd = &cobra.Command{
+	Use:               "inspect ARTIFACT",
+	Short:             "Inspect an artifact",
+	Long:              "Display the name, digest and manifest of an artifact in the local artifact store.",
+	RunE:              inspect,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: common.AutocompleteArtifacts,
+	Example:           "podman artifact inspect quay.io/myuser/mymodel:v1",
+}
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: inspectCmd,
+		Parent:  artifactCmd,
+	})
+}
+
+func inspect(cmd *cobra.Command, args []string) error {
+	report, err := registry.ImageEngine().ArtifactInspect(registry.Context(), args[0], entities.ArtifactInspectOptions{})
+	if err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(report, "", "    ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(b))
+	return nil
+}
@@ -0,0 +1,118 @@
+package artifact
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/common/pkg/report"
+	"github.com/containers/image/v5/docker/reference"
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/cmd/podman/validate"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/docker/go-units"
+	"github.com/spf13/cobra"
+)
+
+var (
+	listCmd = &cobra.Command{
+		Use:               "ls [options]",
+		Aliases:           []string{"list"},
+		Short:             "List artifacts",
+		Long:              "List the OCI artifacts in the local artifact store.",
+		RunE:              list,
+		Args:              validate.NoArgs,
+		ValidArgsFunction: completion.AutocompleteNone,
+		Example:           "podman artifact ls",
+	}
+	listFlag = listFlagType{}
+)
+
+type listFlagType struct {
+	format    string
+	noHeading bool
+	quiet     bool
+}
+
+// artifactListOutput is a row of the artifact list
+type artifactListOutput struct {
+	Repository string
+	Tag        string
+	Digest     string
+	Size       string
+}
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: listCmd,
+		Parent:  artifactCmd,
+	})
+	flags := listCmd.Flags()
+
+	formatFlagName := "format"
+	flags.StringVar(&listFlag.format, formatFlagName, "{{range .}}{{.Repository}}\t{{.Tag}}\t{{.Digest}}\t{{.Size}}\n{{end -}}", "Pretty-print output to JSON or using a Go template")
+	_ = listCmd.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteFormat(&artifactListOutput{}))
+
+	flags.BoolVarP(&listFlag.noHeading, "noheading", "n", false, "Do not print headers")
+	flags.BoolVarP(&listFlag.quiet, "quiet", "q", false, "Print artifact names only")
+}
+
+func list(cmd *cobra.Command, args []string) error {
+	reports, err := registry.ImageEngine().ArtifactList(registry.Context(), entities.ArtifactListOptions{})
+	if err != nil {
+		return err
+	}
+
+	if listFlag.quiet && !cmd.Flags().Changed("format") {
+		for _, r := range reports {
+			fmt.Println(r.Name)
+		}
+		return nil
+	}
+
+	if report.IsJSON(listFlag.format) {
+		b, err := json.MarshalIndent(reports, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+		return nil
+	}
+
+	artifacts := make([]artifactListOutput, 0, len(reports))
+	for _, r := range reports {
+		out := artifactListOutput{
+			Repository: r.Name,
+			Tag:        "<none>",
+			Digest:     r.Digest.Encoded()[:12],
+			Size:       units.HumanSizeWithPrecision(float64(r.TotalSizeBytes()), 3),
+		}
+		if named, err := reference.ParseNormalizedNamed(r.Name); err == nil {
+			out.Repository = named.Name()
+			if tagged, ok := named.(reference.Tagged); ok {
+				out.Tag = tagged.Tag()
+			}
+		}
+		artifacts = append(artifacts, out)
+	}
+
+	rpt := report.New(os.Stdout, cmd.Name())
+	defer rpt.Flush()
+
+	if cmd.Flags().Changed("format") {
+		rpt, err = rpt.Parse(report.OriginUser, listFlag.format)
+	} else {
+		rpt, err = rpt.Parse(report.OriginPodman, listFlag.format)
+	}
+	if err != nil {
+		return err
+	}
+
+	if rpt.RenderHeaders && !listFlag.noHeading {
+		if err := rpt.Execute(report.Headers(artifactListOutput{}, nil)); err != nil {
+			return fmt.Errorf("failed to write report column headers: %w", err)
+		}
+	}
+	return rpt.Execute(artifacts)
+}
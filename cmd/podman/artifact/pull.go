@@ -0,0 +1,114 @@
+package artifact
+
+import (
+	"fmt"
+
+	"github.com/containers/common/pkg/auth"
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/image/v5/types"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/containers/podman/v5/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// pullOptionsWrapper wraps entities.ArtifactPullOptions and prevents leaking
+// CLI-only fields into the API types.
+type pullOptionsWrapper struct {
+	entities.ArtifactPullOptions
+	TLSVerifyCLI   bool // CLI only
+	CredentialsCLI string
+}
+
+var (
+	pullCmd = &cobra.Command{
+		Use:               "pull [options] ARTIFACT",
+		Short:             "Pull an artifact from a registry",
+		Long:              "Pull an OCI artifact from a registry and store it in the local artifact store. The signature policy of images applies to artifacts as well.",
+		RunE:              pull,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.AutocompleteNone,
+		Example:           "podman artifact pull quay.io/myuser/mymodel:v1",
+	}
+
+	pullOpts = pullOptionsWrapper{}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: pullCmd,
+		Parent:  artifactCmd,
+	})
+	flags := pullCmd.Flags()
+
+	authfileFlagName := "authfile"
+	flags.StringVar(&pullOpts.Authfile, authfileFlagName, auth.GetDefaultAuthFile(), "Path of the authentication file. Use REGISTRY_AUTH_FILE environment variable to override")
+	_ = pullCmd.RegisterFlagCompletionFunc(authfileFlagName, completion.AutocompleteDefault)
+
+	credsFlagName := "creds"
+	flags.StringVar(&pullOpts.CredentialsCLI, credsFlagName, "", "`Credentials` (USERNAME:PASSWORD) to use for authenticating to a registry")
+	_ = pullCmd.RegisterFlagCompletionFunc(credsFlagName, completion.AutocompleteNone)
+
+	flags.BoolVarP(&pullOpts.Quiet, "quiet", "q", false, "Suppress output information when pulling artifacts")
+
+	retryFlagName := "retry"
+	flags.Uint(retryFlagName, registry.RetryDefault(), "number of times to retry in case of failure when performing pull")
+	_ = pullCmd.RegisterFlagCompletionFunc(retryFlagName, completion.AutocompleteNone)
+	retryDelayFlagName := "retry-delay"
+	flags.String(retryDelayFlagName, registry.RetryDelayDefault(), "delay between retries in case of pull failures")
+	_ = pullCmd.RegisterFlagCompletionFunc(retryDelayFlagName, completion.AutocompleteNone)
+
+	flags.BoolVar(&pullOpts.TLSVerifyCLI, "tls-verify", true, "Require HTTPS and verify certificates when contacting registries")
+
+	if registry.IsRemote() {
+		_ = flags.MarkHidden("quiet")
+	} else {
+		certDirFlagName := "cert-dir"
+		flags.StringVar(&pullOpts.CertDir, certDirFlagName, "", "`Pathname` of a directory containing TLS certificates and keys")
+		_ = pullCmd.RegisterFlagCompletionFunc(certDirFlagName, completion.AutocompleteDefault)
+
+		signaturePolicyFlagName := "signature-policy"
+		flags.StringVar(&pullOpts.SignaturePolicy, signaturePolicyFlagName, "", "`Pathname` of signature policy file (not usually used)")
+		_ = flags.MarkHidden(signaturePolicyFlagName)
+	}
+}
+
+func pull(cmd *cobra.Command, args []string) error {
+	if cmd.Flags().Changed("tls-verify") {
+		pullOpts.SkipTLSVerify = types.NewOptionalBool(!pullOpts.TLSVerifyCLI)
+	}
+	if cmd.Flags().Changed("retry") {
+		retry, err := cmd.Flags().GetUint("retry")
+		if err != nil {
+			return err
+		}
+		pullOpts.Retry = &retry
+	}
+	if cmd.Flags().Changed("retry-delay") {
+		val, err := cmd.Flags().GetString("retry-delay")
+		if err != nil {
+			return err
+		}
+		pullOpts.RetryDelay = val
+	}
+	if cmd.Flags().Changed("authfile") {
+		if err := auth.CheckAuthFile(pullOpts.Authfile); err != nil {
+			return err
+		}
+	}
+	if pullOpts.CredentialsCLI != "" {
+		creds, err := util.ParseRegistryCreds(pullOpts.CredentialsCLI)
+		if err != nil {
+			return err
+		}
+		pullOpts.Username = creds.Username
+		pullOpts.Password = creds.Password
+	}
+
+	report, err := registry.ImageEngine().ArtifactPull(registry.Context(), args[0], pullOpts.ArtifactPullOptions)
+	if err != nil {
+		return err
+	}
+	fmt.Println(report.ArtifactDigest.Encoded())
+	return nil
+}
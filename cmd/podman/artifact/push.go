@@ -0,0 +1,148 @@
+package artifact
+
+import (
+	"fmt"
+
+	"github.com/containers/common/pkg/auth"
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/image/v5/types"
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/containers/podman/v5/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// pushOptionsWrapper wraps entities.ArtifactPushOptions and prevents leaking
+// CLI-only fields into the API types.
+type pushOptionsWrapper struct {
+	entities.ArtifactPushOptions
+	TLSVerifyCLI          bool // CLI only
+	CredentialsCLI        string
+	SignPassphraseFileCLI string
+}
+
+var (
+	pushCmd = &cobra.Command{
+		Use:               "push [options] ARTIFACT [DESTINATION]",
+		Short:             "Push an artifact to a registry",
+		Long:              "Push an OCI artifact from the local artifact store to a registry, to its own name unless a destination is given.",
+		RunE:              push,
+		Args:              cobra.RangeArgs(1, 2),
+		ValidArgsFunction: common.AutocompleteArtifacts,
+		Example: `podman artifact push quay.io/myuser/mymodel:v1
+  podman artifact push --sign-by-sigstore-private-key ./key.private mymodel quay.io/myuser/mymodel:v1`,
+	}
+
+	pushOpts = pushOptionsWrapper{}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: pushCmd,
+		Parent:  artifactCmd,
+	})
+	flags := pushCmd.Flags()
+
+	authfileFlagName := "authfile"
+	flags.StringVar(&pushOpts.Authfile, authfileFlagName, auth.GetDefaultAuthFile(), "Path of the authentication file. Use REGISTRY_AUTH_FILE environment variable to override")
+	_ = pushCmd.RegisterFlagCompletionFunc(authfileFlagName, completion.AutocompleteDefault)
+
+	certDirFlagName := "cert-dir"
+	flags.StringVar(&pushOpts.CertDir, certDirFlagName, "", "Path to a directory containing TLS certificates and keys")
+	_ = pushCmd.RegisterFlagCompletionFunc(certDirFlagName, completion.AutocompleteDefault)
+
+	credsFlagName := "creds"
+	flags.StringVar(&pushOpts.CredentialsCLI, credsFlagName, "", "`Credentials` (USERNAME:PASSWORD) to use for authenticating to a registry")
+	_ = pushCmd.RegisterFlagCompletionFunc(credsFlagName, completion.AutocompleteNone)
+
+	flags.BoolVarP(&pushOpts.Quiet, "quiet", "q", false, "Suppress output information when pushing artifacts")
+
+	retryFlagName := "retry"
+	flags.Uint(retryFlagName, registry.RetryDefault(), "number of times to retry in case of failure when performing push")
+	_ = pushCmd.RegisterFlagCompletionFunc(retryFlagName, completion.AutocompleteNone)
+	retryDelayFlagName := "retry-delay"
+	flags.String(retryDelayFlagName, registry.RetryDelayDefault(), "delay between retries in case of push failures")
+	_ = pushCmd.RegisterFlagCompletionFunc(retryDelayFlagName, completion.AutocompleteNone)
+
+	signByFlagName := "sign-by"
+	flags.StringVar(&pushOpts.SignBy, signByFlagName, "", "Add a signature at the destination using the specified key")
+	_ = pushCmd.RegisterFlagCompletionFunc(signByFlagName, completion.AutocompleteNone)
+
+	signBySigstorePrivateKeyFlagName := "sign-by-sigstore-private-key"
+	flags.StringVar(&pushOpts.SignBySigstorePrivateKeyFile, signBySigstorePrivateKeyFlagName, "", "Sign the artifact using a sigstore private key at `PATH`")
+	_ = pushCmd.RegisterFlagCompletionFunc(signBySigstorePrivateKeyFlagName, completion.AutocompleteDefault)
+
+	signPassphraseFileFlagName := "sign-passphrase-file"
+	flags.StringVar(&pushOpts.SignPassphraseFileCLI, signPassphraseFileFlagName, "", "Read a passphrase for signing an artifact from `PATH`")
+	_ = pushCmd.RegisterFlagCompletionFunc(signPassphraseFileFlagName, completion.AutocompleteDefault)
+
+	flags.BoolVar(&pushOpts.TLSVerifyCLI, "tls-verify", true, "Require HTTPS and verify certificates when contacting registries")
+
+	if registry.IsRemote() {
+		_ = flags.MarkHidden(certDirFlagName)
+		_ = flags.MarkHidden("quiet")
+		_ = flags.MarkHidden(signByFlagName)
+		_ = flags.MarkHidden(signBySigstorePrivateKeyFlagName)
+		_ = flags.MarkHidden(signPassphraseFileFlagName)
+	}
+}
+
+func push(cmd *cobra.Command, args []string) error {
+	destination := ""
+	if len(args) > 1 {
+		destination = args[1]
+	}
+
+	if cmd.Flags().Changed("tls-verify") {
+		pushOpts.SkipTLSVerify = types.NewOptionalBool(!pushOpts.TLSVerifyCLI)
+	}
+	if cmd.Flags().Changed("retry") {
+		retry, err := cmd.Flags().GetUint("retry")
+		if err != nil {
+			return err
+		}
+		pushOpts.Retry = &retry
+	}
+	if cmd.Flags().Changed("retry-delay") {
+		val, err := cmd.Flags().GetString("retry-delay")
+		if err != nil {
+			return err
+		}
+		pushOpts.RetryDelay = val
+	}
+	if cmd.Flags().Changed("authfile") {
+		if err := auth.CheckAuthFile(pushOpts.Authfile); err != nil {
+			return err
+		}
+	}
+	if pushOpts.CredentialsCLI != "" {
+		creds, err := util.ParseRegistryCreds(pushOpts.CredentialsCLI)
+		if err != nil {
+			return err
+		}
+		pushOpts.Username = creds.Username
+		pushOpts.Password = creds.Password
+	}
+
+	// Artifacts are signed like images, reuse the passphrase handling of
+	// image push
+	signOpts := entities.ImagePushOptions{
+		SignBy:                       pushOpts.SignBy,
+		SignBySigstorePrivateKeyFile: pushOpts.SignBySigstorePrivateKeyFile,
+	}
+	signingCleanup, err := common.PrepareSigning(&signOpts, pushOpts.SignPassphraseFileCLI, "")
+	if err != nil {
+		return err
+	}
+	defer signingCleanup()
+	pushOpts.SignPassphrase = signOpts.SignPassphrase
+	pushOpts.SignSigstorePrivateKeyPassphrase = signOpts.SignSigstorePrivateKeyPassphrase
+
+	report, err := registry.ImageEngine().ArtifactPush(registry.Context(), args[0], destination, pushOpts.ArtifactPushOptions)
+	if err != nil {
+		return err
+	}
+	fmt.Println(report.ArtifactDigest.Encoded())
+	return nil
+}
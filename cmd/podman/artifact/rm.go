@@ -0,0 +1,54 @@
+package artifact
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/cmd/podman/utils"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/spf13/cobra"
+)
+
+var (
+	rmCmd = &cobra.Command{
+		Use:               "rm [options] ARTIFACT [ARTIFACT...]",
+		Aliases:           []string{"remove"},
+		Short:             "Remove artifacts",
+		Long:              "Remove one or more artifacts from the local artifact store.",
+		RunE:              rm,
+		ValidArgsFunction: common.AutocompleteArtifacts,
+		Example: `podman artifact rm quay.io/myuser/mymodel:v1
+  podman artifact rm --all`,
+	}
+
+	rmOpts = entities.ArtifactRemoveOptions{}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: rmCmd,
+		Parent:  artifactCmd,
+	})
+	flags := rmCmd.Flags()
+	flags.BoolVarP(&rmOpts.All, "all", "a", false, "Remove all artifacts")
+	flags.BoolVarP(&rmOpts.Force, "force", "f", false, "Remove the containers using the artifacts")
+}
+
+func rm(cmd *cobra.Command, args []string) error {
+	if (len(args) > 0 && rmOpts.All) || (len(args) < 1 && !rmOpts.All) {
+		return errors.New("`podman artifact rm` requires one argument, or the --all flag")
+	}
+	var errs utils.OutputErrors
+	report, err := registry.ImageEngine().ArtifactRm(registry.Context(), args, rmOpts)
+	if report != nil {
+		for _, d := range report.ArtifactDigests {
+			fmt.Println(d.Encoded())
+		}
+	}
+	if err != nil {
+		errs = append(errs, err)
+	}
+	return errs.PrintErrors()
+}
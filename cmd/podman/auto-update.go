@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"slices"
 
 	"github.com/containers/common/pkg/auth"
 	"github.com/containers/common/pkg/completion"
@@ -27,18 +28,19 @@ var (
 	autoUpdateDescription = `Auto update containers according to their auto-update policy.
 
   Auto-update policies are specified with the "io.containers.autoupdate" label.
-  Containers are expected to run in systemd units created with "podman-generate-systemd --new",
-  or similar units that create new containers in order to run the updated images.
+  Containers running in systemd units, such as Quadlet units, are updated by restarting the units.
+  Other containers are recreated with the updated images if --recreate is set.
   Please refer to the podman-auto-update(1) man page for details.`
 	autoUpdateCommand = &cobra.Command{
-		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
 		Use:               "auto-update [options]",
 		Short:             "Auto update containers according to their auto-update policy",
 		Long:              autoUpdateDescription,
 		RunE:              autoUpdate,
 		ValidArgsFunction: completion.AutocompleteNone,
 		Example: `podman auto-update
-  podman auto-update --authfile ~/authfile.json`,
+  podman auto-update --authfile ~/authfile.json
+  podman auto-update --recreate
+  podman auto-update --require-signatures --soak-period 10m`,
 	}
 )
 
@@ -54,7 +56,13 @@ func init() {
 	_ = autoUpdateCommand.RegisterFlagCompletionFunc(authfileFlagName, completion.AutocompleteDefault)
 
 	flags.BoolVar(&autoUpdateOptions.DryRun, "dry-run", false, "Check for pending updates")
+	flags.BoolVar(&autoUpdateOptions.Recreate, "recreate", false, "Recreate containers which do not run in a systemd unit with the updated image")
 	flags.BoolVar(&autoUpdateOptions.Rollback, "rollback", true, "Rollback to previous image if update fails")
+	flags.BoolVar(&autoUpdateOptions.RequireSignatures, "require-signatures", false, "Refuse updates to images which are not signed according to the signature policy")
+
+	soakPeriodFlagName := "soak-period"
+	flags.DurationVar(&autoUpdateOptions.SoakPeriod, soakPeriodFlagName, 0, "Update one unit or container per image first and the others once it stayed healthy for the soak period")
+	_ = autoUpdateCommand.RegisterFlagCompletionFunc(soakPeriodFlagName, completion.AutocompleteNone)
 
 	flags.StringVar(&autoUpdateOptions.format, "format", "", "Change the output format to JSON or a Go template")
 	_ = autoUpdateCommand.RegisterFlagCompletionFunc("format", common.AutocompleteFormat(&autoUpdateOutput{}))
@@ -95,8 +103,10 @@ type autoUpdateOutput struct {
 	ContainerName string
 	ContainerID   string
 	Image         string
+	NewImage      string
 	Policy        string
 	Updated       string
+	Stage         string
 }
 
 func reportsToOutput(allReports []*entities.AutoUpdateReport) []autoUpdateOutput {
@@ -108,8 +118,10 @@ func reportsToOutput(allReports []*entities.AutoUpdateReport) []autoUpdateOutput
 			ContainerName: r.ContainerName,
 			ContainerID:   r.ContainerID,
 			Image:         r.ImageName,
+			NewImage:      r.NewImageName,
 			Policy:        r.Policy,
 			Updated:       r.Updated,
+			Stage:         r.Stage,
 		}
 	}
 	return output
@@ -123,7 +135,17 @@ func writeTemplate(allReports []*entities.AutoUpdateReport, inputFormat string)
 	var err error
 	switch inputFormat {
 	case "":
-		format := "{{range . }}\t{{.Unit}}\t{{.Container}}\t{{.Image}}\t{{.Policy}}\t{{.Updated}}\n{{end -}}"
+		columns := "{{.Unit}}\t{{.Container}}\t{{.Image}}\t"
+		// Only show the new images if a registry-semver policy chose any.
+		if slices.ContainsFunc(output, func(o autoUpdateOutput) bool { return o.NewImage != "" }) {
+			columns += "{{.NewImage}}\t"
+		}
+		columns += "{{.Policy}}\t{{.Updated}}"
+		// Only show the stages of staged rollouts.
+		if slices.ContainsFunc(output, func(o autoUpdateOutput) bool { return o.Stage != "" }) {
+			columns += "\t{{.Stage}}"
+		}
+		format := "{{range . }}\t" + columns + "\n{{end -}}"
 		rpt, err = rpt.Parse(report.OriginPodman, format)
 	case "json":
 		prettyJSON, err := json.MarshalIndent(output, "", "    ")
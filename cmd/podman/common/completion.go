@@ -7,6 +7,7 @@ import (
 	"path"
 	"path/filepath"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 	"unicode"
@@ -247,6 +248,50 @@ func getManifestListMembers(cmd *cobra.Command, list, toComplete string) ([]stri
 	return suggestions, cobra.ShellCompDirectiveNoFileComp
 }
 
+func getArtifacts(cmd *cobra.Command, toComplete string) ([]string, cobra.ShellCompDirective) {
+	suggestions := []string{}
+
+	engine, err := setupImageEngine(cmd)
+	if err != nil {
+		cobra.CompErrorln(err.Error())
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	artifacts, err := engine.ArtifactList(registry.GetContext(), entities.ArtifactListOptions{})
+	if err != nil {
+		cobra.CompErrorln(err.Error())
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	for _, a := range artifacts {
+		if strings.HasPrefix(a.Name, toComplete) {
+			suggestions = append(suggestions, a.Name)
+		}
+	}
+	return suggestions, cobra.ShellCompDirectiveNoFileComp
+}
+
+func getQuadlets(cmd *cobra.Command, toComplete string) ([]string, cobra.ShellCompDirective) {
+	suggestions := []string{}
+
+	engine, err := setupContainerEngine(cmd)
+	if err != nil {
+		cobra.CompErrorln(err.Error())
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	quadlets, err := engine.QuadletList(registry.GetContext(), entities.QuadletListOptions{})
+	if err != nil {
+		cobra.CompErrorln(err.Error())
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	for _, q := range quadlets {
+		if strings.HasPrefix(q.Name, toComplete) {
+			suggestions = append(suggestions, q.Name)
+		}
+	}
+	return suggestions, cobra.ShellCompDirectiveNoFileComp
+}
+
 func getSecrets(cmd *cobra.Command, toComplete string, cType completeType) ([]string, cobra.ShellCompDirective) {
 	suggestions := []string{}
 
@@ -611,6 +656,30 @@ func AutocompleteVolumes(cmd *cobra.Command, args []string, toComplete string) (
 	return getVolumes(cmd, toComplete)
 }
 
+// AutocompleteVolumeSnapshots - Autocomplete a volume and its snapshots.
+func AutocompleteVolumeSnapshots(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) == 0 {
+		return getVolumes(cmd, toComplete)
+	}
+	engine, err := setupContainerEngine(cmd)
+	if err != nil {
+		cobra.CompErrorln(err.Error())
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	snapshots, err := engine.VolumeSnapshotList(registry.GetContext(), args[0])
+	if err != nil {
+		cobra.CompErrorln(err.Error())
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	suggestions := []string{}
+	for _, s := range snapshots {
+		if strings.HasPrefix(s.Name, toComplete) && !slices.Contains(args[1:], s.Name) {
+			suggestions = append(suggestions, s.Name)
+		}
+	}
+	return suggestions, cobra.ShellCompDirectiveNoFileComp
+}
+
 // AutocompleteSecrets - Autocomplete secrets.
 func AutocompleteSecrets(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 	if !validCurrentCmdLine(cmd, args, toComplete) {
@@ -619,6 +688,41 @@ func AutocompleteSecrets(cmd *cobra.Command, args []string, toComplete string) (
 	return getSecrets(cmd, toComplete, completeDefault)
 }
 
+// AutocompleteArtifacts - Autocomplete artifacts.
+func AutocompleteArtifacts(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if !validCurrentCmdLine(cmd, args, toComplete) {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return getArtifacts(cmd, toComplete)
+}
+
+// AutocompleteArtifactAdd - Autocomplete artifact name, then files to add.
+func AutocompleteArtifactAdd(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) == 0 {
+		return getArtifacts(cmd, toComplete)
+	}
+	return nil, cobra.ShellCompDirectiveDefault
+}
+
+// AutocompleteArtifactExtract - Autocomplete artifact name, then the target path.
+func AutocompleteArtifactExtract(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	switch len(args) {
+	case 0:
+		return getArtifacts(cmd, toComplete)
+	case 1:
+		return nil, cobra.ShellCompDirectiveDefault
+	}
+	return nil, cobra.ShellCompDirectiveNoFileComp
+}
+
+// AutocompleteQuadlets - Autocomplete installed quadlets.
+func AutocompleteQuadlets(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if !validCurrentCmdLine(cmd, args, toComplete) {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return getQuadlets(cmd, toComplete)
+}
+
 func AutocompleteSecretCreate(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 	if len(args) == 1 {
 		return nil, cobra.ShellCompDirectiveDefault
@@ -626,6 +730,17 @@ func AutocompleteSecretCreate(cmd *cobra.Command, args []string, toComplete stri
 	return nil, cobra.ShellCompDirectiveNoFileComp
 }
 
+// AutocompleteSecretUpdate - Autocomplete the secret, then the file with the data.
+func AutocompleteSecretUpdate(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	switch len(args) {
+	case 0:
+		return getSecrets(cmd, toComplete, completeDefault)
+	case 1:
+		return nil, cobra.ShellCompDirectiveDefault
+	}
+	return nil, cobra.ShellCompDirectiveNoFileComp
+}
+
 // AutocompleteImages - Autocomplete images.
 func AutocompleteImages(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 	if !validCurrentCmdLine(cmd, args, toComplete) {
@@ -1029,21 +1144,31 @@ func AutocompleteImageVolume(cmd *cobra.Command, args []string, toComplete strin
 }
 
 // AutocompleteLogDriver - Autocomplete log-driver options.
-// -> "journald", "none", "k8s-file", "passthrough", "passthrough-tty"
+// -> "journald", "none", "k8s-file", "syslog", "gelf", "fluentd", "passthrough", "passthrough-tty"
 func AutocompleteLogDriver(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 	// don't show json-file
-	logDrivers := []string{define.JournaldLogging, define.NoLogging, define.KubernetesLogging}
+	logDrivers := []string{define.JournaldLogging, define.NoLogging, define.KubernetesLogging,
+		define.SyslogLogging, define.GELFLogging, define.FluentdLogging}
 	if !registry.IsRemote() {
 		logDrivers = append(logDrivers, define.PassthroughLogging, define.PassthroughTTYLogging)
 	}
 	return logDrivers, cobra.ShellCompDirectiveNoFileComp
 }
 
+// AutocompleteDatabaseBackend - Autocomplete database backends.
+// -> "sqlite", "boltdb"
+func AutocompleteDatabaseBackend(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return []string{config.DBBackendSQLite.String(), config.DBBackendBoltDB.String()}, cobra.ShellCompDirectiveNoFileComp
+}
+
 // AutocompleteLogOpt - Autocomplete log-opt options.
 // -> "path=", "tag="
 func AutocompleteLogOpt(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-	logOptions := []string{"path=", "tag=", "max-size="}
-	if strings.HasPrefix(toComplete, "path=") {
+	logOptions := []string{"path=", "tag=", "max-size=", "max-file=", "compress=", "mode=", "max-buffer-size=",
+		"syslog-address=", "syslog-facility=", "syslog-format=", "syslog-tls-ca-cert=", "syslog-tls-cert=", "syslog-tls-key=", "syslog-tls-skip-verify=",
+		"gelf-address=", "gelf-compression-type=", "fluentd-address="}
+	if strings.HasPrefix(toComplete, "path=") || strings.HasPrefix(toComplete, "syslog-tls-ca-cert=") ||
+		strings.HasPrefix(toComplete, "syslog-tls-cert=") || strings.HasPrefix(toComplete, "syslog-tls-key=") {
 		return nil, cobra.ShellCompDirectiveDefault
 	}
 	return logOptions, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
@@ -1580,7 +1705,7 @@ func AutocompleteImageSaveFormat(cmd *cobra.Command, args []string, toComplete s
 // -> "unknown", "configured", "created", "running", "stopped", "paused", "exited", "removing"
 func AutocompleteWaitCondition(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 	states := []string{"unknown", "configured", "created", "exited",
-		"healthy", "initialized", "paused", "removing", "running",
+		"healthy", "initialized", "paused", "ready", "removing", "running",
 		"stopped", "stopping", "unhealthy"}
 	return states, cobra.ShellCompDirectiveNoFileComp
 }
@@ -19,6 +19,7 @@ import (
 	"github.com/containers/podman/v5/cmd/podman/utils"
 	"github.com/containers/podman/v5/libpod/define"
 	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/containers/podman/v5/pkg/errorhandling"
 	"github.com/containers/podman/v5/pkg/specgen"
 	"github.com/containers/podman/v5/pkg/specgenutil"
 	"github.com/containers/podman/v5/pkg/util"
@@ -161,6 +162,10 @@ func create(cmd *cobra.Command, args []string) error {
 	}
 	s.RawImageName = rawImageName
 
+	if err := pullArtifacts(cmd, s, &cliVals); err != nil {
+		return err
+	}
+
 	if err := createPodIfNecessary(cmd, s, cliVals.Net); err != nil {
 		return err
 	}
@@ -413,6 +418,68 @@ func pullImage(cmd *cobra.Command, imageName string, cliVals *entities.Container
 	return imageName, nil
 }
 
+// pullArtifacts pulls the artifacts mounted into the container according to
+// the pull policy, with the same authentication as the image of the
+// container. The server then only checks that they exist.
+func pullArtifacts(cmd *cobra.Command, s *specgen.SpecGenerator, cliVals *entities.ContainerCreateOptions) error {
+	if len(s.ArtifactVolumes) == 0 {
+		return nil
+	}
+	pullPolicy, err := config.ParsePullPolicy(cliVals.Pull)
+	if err != nil {
+		return err
+	}
+	s.ArtifactPullPolicy = config.PullPolicyNever.String()
+	if pullPolicy == config.PullPolicyNever {
+		return nil
+	}
+
+	pullOptions := entities.ArtifactPullOptions{
+		Authfile:        cliVals.Authfile,
+		Quiet:           cliVals.Quiet,
+		SignaturePolicy: cliVals.SignaturePolicy,
+	}
+	if cliVals.TLSVerify.Present() {
+		pullOptions.SkipTLSVerify = types.NewOptionalBool(!cliVals.TLSVerify.Value())
+	}
+	if cmd.Flags().Changed("retry") {
+		retry, err := cmd.Flags().GetUint("retry")
+		if err != nil {
+			return err
+		}
+		pullOptions.Retry = &retry
+	}
+	if cmd.Flags().Changed("retry-delay") {
+		val, err := cmd.Flags().GetString("retry-delay")
+		if err != nil {
+			return err
+		}
+		pullOptions.RetryDelay = val
+	}
+
+	ctx := registry.GetContext()
+	for _, volume := range s.ArtifactVolumes {
+		_, inspectErr := registry.ImageEngine().ArtifactInspect(ctx, volume.Source, entities.ArtifactInspectOptions{})
+		if inspectErr != nil && !errorhandling.Contains(inspectErr, define.ErrNoSuchArtifact) {
+			return inspectErr
+		}
+		exists := inspectErr == nil
+		if exists && pullPolicy == config.PullPolicyMissing {
+			continue
+		}
+		if _, err := registry.ImageEngine().ArtifactPull(ctx, volume.Source, pullOptions); err != nil {
+			// A newer artifact is only pulled if available, the local
+			// one is used otherwise
+			if exists && pullPolicy == config.PullPolicyNewer {
+				logrus.Warnf("Unable to pull artifact %s, using the local artifact: %v", volume.Source, err)
+				continue
+			}
+			return err
+		}
+	}
+	return nil
+}
+
 func rmPodIfNecessary(cmd *cobra.Command, s *specgen.SpecGenerator) error {
 	if !strings.HasPrefix(cmd.Flag("pod").Value.String(), "new:") {
 		return nil
@@ -0,0 +1,89 @@
+package containers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/libpod/shutdown"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var (
+	logForwardDescription = `
+   podman container logforward
+
+   Forwards the logs of a container to the collector configured by its syslog, gelf or fluentd log driver until the conmon process of the container exits. This command is used internally when starting containers.
+`
+	logForwardCommand = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "logforward [options] CONTAINER",
+		Short:             "Forward the logs of a container to its log collector",
+		Long:              logForwardDescription,
+		RunE:              logForward,
+		Args:              cobra.ExactArgs(1),
+		Hidden:            true,
+		ValidArgsFunction: common.AutocompleteContainersRunning,
+	}
+)
+
+var (
+	logForwardOptions entities.ContainerLogForwardOptions
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Parent:  containerCmd,
+		Command: logForwardCommand,
+	})
+	flags := logForwardCommand.Flags()
+
+	tagFlagName := "tag"
+	flags.StringVar(&logForwardOptions.Tag, tagFlagName, "", "Tag of the forwarded log messages")
+	_ = logForwardCommand.RegisterFlagCompletionFunc(tagFlagName, completion.AutocompleteNone)
+}
+
+func logForward(cmd *cobra.Command, args []string) error {
+	logForwardOptions.Ready = logHelperReady
+	return runLogHelper(func(ctx context.Context) error {
+		return registry.ContainerEngine().ContainerLogForward(ctx, args[0], logForwardOptions)
+	})
+}
+
+// logHelperExitTimeout is the time a log helper is given to finish handling
+// the log after it was signaled, before it exits anyway. It is longer than the
+// time the log forwarder tries to send buffered messages.
+const logHelperExitTimeout = 45 * time.Second
+
+// runLogHelper runs a log helper until conmon exits or it receives SIGTERM or
+// SIGINT, and lets it finish handling the log before podman exits. Nobody
+// waits for a signaled helper, so it enforces its own deadline.
+func runLogHelper(run func(ctx context.Context) error) error {
+	// Stop the shutdown signal handler, which would exit right away.
+	if err := shutdown.Stop(); err != nil && err != shutdown.ErrNotStarted {
+		return err
+	}
+	ctx, stop := signal.NotifyContext(registry.GetContext(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		time.Sleep(logHelperExitTimeout)
+		logrus.Errorf("Log helper did not finish within %s, exiting", logHelperExitTimeout)
+		os.Exit(1)
+	}()
+	return run(ctx)
+}
+
+// logHelperReady tells the process starting the log helper that the helper
+// follows the log.
+func logHelperReady() {
+	fmt.Println("ready")
+}
@@ -0,0 +1,42 @@
+package containers
+
+import (
+	"context"
+
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/spf13/cobra"
+)
+
+var (
+	logRotateDescription = `
+   podman container logrotate
+
+   Keeps the rotated log files of a container using the max-file log option until the conmon process of the container exits. This command is used internally when starting containers.
+`
+	logRotateCommand = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "logrotate CONTAINER",
+		Short:             "Keep the rotated log files of a container",
+		Long:              logRotateDescription,
+		RunE:              logRotate,
+		Args:              cobra.ExactArgs(1),
+		Hidden:            true,
+		ValidArgsFunction: common.AutocompleteContainersRunning,
+	}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Parent:  containerCmd,
+		Command: logRotateCommand,
+	})
+}
+
+func logRotate(cmd *cobra.Command, args []string) error {
+	options := entities.ContainerLogRotateOptions{Ready: logHelperReady}
+	return runLogHelper(func(ctx context.Context) error {
+		return registry.ContainerEngine().ContainerLogRotate(ctx, args[0], options)
+	})
+}
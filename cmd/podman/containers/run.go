@@ -217,6 +217,10 @@ func run(cmd *cobra.Command, args []string) error {
 
 	runOpts.Spec = s
 
+	if err := pullArtifacts(cmd, s, &cliVals); err != nil {
+		return err
+	}
+
 	if err := createPodIfNecessary(cmd, s, cliVals.Net); err != nil {
 		return err
 	}
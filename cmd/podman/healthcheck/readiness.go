@@ -0,0 +1,38 @@
+package healthcheck
+
+import (
+	"context"
+
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/spf13/cobra"
+)
+
+var (
+	readinessDescription = `
+   podman healthcheck readiness
+
+   Runs the readiness probe of a container and updates its readiness. This command is used internally by the timer of the readiness probe.
+`
+	readinessCmd = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "readiness CONTAINER",
+		Short:             "Run the readiness probe of a container",
+		Long:              readinessDescription,
+		RunE:              readiness,
+		Args:              cobra.ExactArgs(1),
+		Hidden:            true,
+		ValidArgsFunction: common.AutocompleteContainersRunning,
+	}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: readinessCmd,
+		Parent:  healthCmd,
+	})
+}
+
+func readiness(cmd *cobra.Command, args []string) error {
+	return registry.ContainerEngine().HealthCheckReadiness(context.Background(), args[0])
+}
@@ -79,9 +79,13 @@ func generateFlags(cmd *cobra.Command, podmanConfig *entities.PodmanConfig) {
 	_ = cmd.RegisterFlagCompletionFunc(typeFlagName, completion.AutocompleteNone)
 
 	replicasFlagName := "replicas"
-	flags.Int32VarP(&generateOptions.Replicas, replicasFlagName, "r", 1, "Set the replicas number for Deployment kind")
+	flags.Int32VarP(&generateOptions.Replicas, replicasFlagName, "r", 1, "Set the replicas number for Deployment and StatefulSet kinds")
 	_ = cmd.RegisterFlagCompletionFunc(replicasFlagName, completion.AutocompleteNone)
 
+	scheduleFlagName := "schedule"
+	flags.StringVar(&generateOptions.Schedule, scheduleFlagName, "", "Set the cron schedule for CronJob kind")
+	_ = cmd.RegisterFlagCompletionFunc(scheduleFlagName, completion.AutocompleteNone)
+
 	noTruncAnnotationsFlagName := "no-trunc"
 	flags.BoolVar(&generateOptions.UseLongAnnotations, noTruncAnnotationsFlagName, false, "Don't truncate annotations to Kubernetes length (63 chars)")
 	_ = flags.MarkHidden(noTruncAnnotationsFlagName)
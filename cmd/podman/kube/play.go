@@ -539,6 +539,21 @@ func printPlayReport(report *entities.PlayKubeReport) error {
 		fmt.Println()
 	}
 
+	// Print CronJobs report
+	for i, cronJob := range report.CronJobs {
+		if i == 0 {
+			fmt.Println("CronJobs:")
+		}
+		switch {
+		case cronJob.Suspended:
+			fmt.Printf("%s (suspended)\n", cronJob.Name)
+		case cronJob.Timer != "":
+			fmt.Printf("%s (%s.timer)\n", cronJob.Name, cronJob.Timer)
+		default:
+			fmt.Printf("%s (%s, run by the Podman API service)\n", cronJob.Name, cronJob.Schedule)
+		}
+	}
+
 	if ctrsFailed > 0 {
 		return fmt.Errorf("failed to start %d containers", ctrsFailed)
 	}
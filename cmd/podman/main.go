@@ -7,6 +7,7 @@ import (
 	"strconv"
 	"strings"
 
+	_ "github.com/containers/podman/v5/cmd/podman/artifact"
 	_ "github.com/containers/podman/v5/cmd/podman/completion"
 	_ "github.com/containers/podman/v5/cmd/podman/farm"
 	_ "github.com/containers/podman/v5/cmd/podman/generate"
@@ -18,6 +19,7 @@ import (
 	_ "github.com/containers/podman/v5/cmd/podman/manifest"
 	_ "github.com/containers/podman/v5/cmd/podman/networks"
 	_ "github.com/containers/podman/v5/cmd/podman/pods"
+	_ "github.com/containers/podman/v5/cmd/podman/quadlet"
 	"github.com/containers/podman/v5/cmd/podman/registry"
 	_ "github.com/containers/podman/v5/cmd/podman/secrets"
 	_ "github.com/containers/podman/v5/cmd/podman/system"
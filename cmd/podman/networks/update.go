@@ -2,9 +2,12 @@ package network
 
 import (
 	"fmt"
+	"os"
 
+	"github.com/containers/common/libnetwork/types"
 	"github.com/containers/common/pkg/completion"
 	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/parse"
 	"github.com/containers/podman/v5/cmd/podman/registry"
 	"github.com/containers/podman/v5/pkg/domain/entities"
 	"github.com/spf13/cobra"
@@ -25,6 +28,12 @@ var (
 
 var (
 	networkUpdateOptions entities.NetworkUpdateOptions
+	subnetsToAdd         []string
+	subnetsToDrop        []string
+	ipRangesToAdd        []string
+	ipRangesToDrop       []string
+	labelsToAdd          []string
+	optsToAdd            []string
 )
 
 func networkUpdateFlags(cmd *cobra.Command) {
@@ -36,6 +45,37 @@ func networkUpdateFlags(cmd *cobra.Command) {
 	flags.StringSliceVar(&networkUpdateOptions.RemoveDNSServers, removeDNSServerFlagName, nil, "remove network level nameservers")
 	_ = cmd.RegisterFlagCompletionFunc(addDNSServerFlagName, completion.AutocompleteNone)
 	_ = cmd.RegisterFlagCompletionFunc(removeDNSServerFlagName, completion.AutocompleteNone)
+
+	addSubnetFlagName := "subnet-add"
+	flags.StringArrayVar(&subnetsToAdd, addSubnetFlagName, nil, "add subnets in CIDR format")
+	removeSubnetFlagName := "subnet-drop"
+	flags.StringArrayVar(&subnetsToDrop, removeSubnetFlagName, nil, "remove subnets in CIDR format")
+	_ = cmd.RegisterFlagCompletionFunc(addSubnetFlagName, completion.AutocompleteNone)
+	_ = cmd.RegisterFlagCompletionFunc(removeSubnetFlagName, completion.AutocompleteNone)
+
+	addIPRangeFlagName := "ip-range-add"
+	flags.StringArrayVar(&ipRangesToAdd, addIPRangeFlagName, nil, "set the range to allocate container IPs from for the matching subnet")
+	removeIPRangeFlagName := "ip-range-drop"
+	flags.StringArrayVar(&ipRangesToDrop, removeIPRangeFlagName, nil, "remove the range to allocate container IPs from")
+	_ = cmd.RegisterFlagCompletionFunc(addIPRangeFlagName, completion.AutocompleteNone)
+	_ = cmd.RegisterFlagCompletionFunc(removeIPRangeFlagName, completion.AutocompleteNone)
+
+	addLabelFlagName := "label-add"
+	flags.StringArrayVar(&labelsToAdd, addLabelFlagName, nil, "add metadata to the network")
+	removeLabelFlagName := "label-drop"
+	flags.StringSliceVar(&networkUpdateOptions.RemoveLabels, removeLabelFlagName, nil, "remove metadata from the network")
+	_ = cmd.RegisterFlagCompletionFunc(addLabelFlagName, completion.AutocompleteNone)
+	_ = cmd.RegisterFlagCompletionFunc(removeLabelFlagName, completion.AutocompleteNone)
+
+	addOptFlagName := "opt-add"
+	flags.StringArrayVar(&optsToAdd, addOptFlagName, nil, "add or change driver specific options")
+	removeOptFlagName := "opt-drop"
+	flags.StringSliceVar(&networkUpdateOptions.RemoveOptions, removeOptFlagName, nil, "remove driver specific options")
+	_ = cmd.RegisterFlagCompletionFunc(addOptFlagName, completion.AutocompleteNone)
+	_ = cmd.RegisterFlagCompletionFunc(removeOptFlagName, completion.AutocompleteNone)
+
+	flags.Bool("internal", false, "restrict external access from this network")
+	flags.Bool("disable-dns", false, "disable dns plugin")
 }
 func init() {
 	registry.Commands = append(registry.Commands, registry.CliCommand{
@@ -48,7 +88,65 @@ func init() {
 func networkUpdate(cmd *cobra.Command, args []string) error {
 	name := args[0]
 
-	err := registry.ContainerEngine().NetworkUpdate(registry.Context(), name, networkUpdateOptions)
+	var err error
+	for _, subnet := range subnetsToAdd {
+		s, err := types.ParseCIDR(subnet)
+		if err != nil {
+			return err
+		}
+		networkUpdateOptions.AddSubnets = append(networkUpdateOptions.AddSubnets, types.Subnet{Subnet: s})
+	}
+	for _, subnet := range subnetsToDrop {
+		s, err := types.ParseCIDR(subnet)
+		if err != nil {
+			return err
+		}
+		networkUpdateOptions.RemoveSubnets = append(networkUpdateOptions.RemoveSubnets, s)
+	}
+	for _, ipRange := range ipRangesToAdd {
+		leaseRange, err := parseRange(ipRange)
+		if err != nil {
+			return err
+		}
+		networkUpdateOptions.AddIPRanges = append(networkUpdateOptions.AddIPRanges, *leaseRange)
+	}
+	for _, ipRange := range ipRangesToDrop {
+		leaseRange, err := parseRange(ipRange)
+		if err != nil {
+			return err
+		}
+		networkUpdateOptions.RemoveIPRanges = append(networkUpdateOptions.RemoveIPRanges, *leaseRange)
+	}
+	networkUpdateOptions.AddLabels, err = parse.GetAllLabels([]string{}, labelsToAdd)
+	if err != nil {
+		return fmt.Errorf("failed to parse labels: %w", err)
+	}
+	networkUpdateOptions.AddOptions, err = parse.GetAllLabels([]string{}, optsToAdd)
+	if err != nil {
+		return fmt.Errorf("unable to parse options: %w", err)
+	}
+	if cmd.Flags().Changed("internal") {
+		internal, err := cmd.Flags().GetBool("internal")
+		if err != nil {
+			return err
+		}
+		networkUpdateOptions.Internal = &internal
+	}
+	if cmd.Flags().Changed("disable-dns") {
+		disableDNS, err := cmd.Flags().GetBool("disable-dns")
+		if err != nil {
+			return err
+		}
+		dnsEnabled := !disableDNS
+		networkUpdateOptions.DNSEnabled = &dnsEnabled
+	}
+
+	report, err := registry.ContainerEngine().NetworkUpdate(registry.Context(), name, networkUpdateOptions)
+	if report != nil {
+		for _, ctr := range report.RestartRequired {
+			fmt.Fprintf(os.Stderr, "Container %s must be restarted to use the updated network\n", ctr)
+		}
+	}
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,84 @@
+package pods
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/containers/podman/v5/pkg/rootless"
+	"github.com/containers/storage/pkg/archive"
+	"github.com/spf13/cobra"
+)
+
+var (
+	podCheckpointDescription = `Checkpoints all containers of a pod.
+
+  All containers of the pod are frozen at one consistent point in time and written, together with the configuration of the pod and of its infra container, into a single archive.`
+
+	checkpointCommand = &cobra.Command{
+		Use:               "checkpoint [options] POD",
+		Short:             "Checkpoint a pod",
+		Long:              podCheckpointDescription,
+		RunE:              checkpoint,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: common.AutocompletePodsRunning,
+		Example: `podman pod checkpoint --export=/tmp/mypod.tar.zst mypod
+  podman pod checkpoint --leave-running --compress=gzip --export=/tmp/mypod.tar.gz mypod`,
+	}
+)
+
+var (
+	checkpointOptions entities.PodCheckpointOptions
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: checkpointCommand,
+		Parent:  podCmd,
+	})
+	flags := checkpointCommand.Flags()
+	flags.BoolVarP(&checkpointOptions.Keep, "keep", "k", false, "Keep all temporary checkpoint files")
+	flags.BoolVarP(&checkpointOptions.LeaveRunning, "leave-running", "R", false, "Leave the containers of the pod running after writing the checkpoint to disk")
+	flags.BoolVar(&checkpointOptions.TCPEstablished, "tcp-established", false, "Checkpoint containers with established TCP connections")
+	flags.BoolVar(&checkpointOptions.FileLocks, "file-locks", false, "Checkpoint containers with file locks")
+
+	exportFlagName := "export"
+	flags.StringVarP(&checkpointOptions.Export, exportFlagName, "e", "", "Export the checkpoint of the pod to a tar archive")
+	_ = checkpointCommand.RegisterFlagCompletionFunc(exportFlagName, completion.AutocompleteDefault)
+	_ = checkpointCommand.MarkFlagRequired(exportFlagName)
+
+	flags.BoolVar(&checkpointOptions.IgnoreRootFS, "ignore-rootfs", false, "Do not include root file-system changes when exporting")
+	flags.BoolVar(&checkpointOptions.IgnoreVolumes, "ignore-volumes", false, "Do not export volumes associated with the containers of the pod")
+
+	flags.StringP("compress", "c", "zstd", "Select compression algorithm (gzip, none, zstd) for checkpoint archive.")
+	_ = checkpointCommand.RegisterFlagCompletionFunc("compress", common.AutocompleteCheckpointCompressType)
+}
+
+func checkpoint(cmd *cobra.Command, args []string) error {
+	compress, _ := cmd.Flags().GetString("compress")
+	switch strings.ToLower(compress) {
+	case "none":
+		checkpointOptions.Compression = archive.Uncompressed
+	case "gzip":
+		checkpointOptions.Compression = archive.Gzip
+	case "zstd":
+		checkpointOptions.Compression = archive.Zstd
+	default:
+		return fmt.Errorf("selected compression algorithm (%q) not supported. Please select one from: gzip, none, zstd", compress)
+	}
+	if rootless.IsRootless() {
+		return errors.New("checkpointing a pod requires root")
+	}
+
+	report, err := registry.ContainerEngine().PodCheckpoint(context.Background(), args[0], checkpointOptions)
+	if err != nil {
+		return err
+	}
+	fmt.Println(report.RawInput)
+	return nil
+}
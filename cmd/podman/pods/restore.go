@@ -0,0 +1,77 @@
+package pods
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/cmd/podman/validate"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/containers/podman/v5/pkg/rootless"
+	"github.com/spf13/cobra"
+)
+
+var (
+	podRestoreDescription = `Restores a pod from a checkpoint archive written by podman pod checkpoint.
+
+  The pod and its infra container are recreated, optionally under a new name, and all containers of the pod are restored into it.`
+
+	restoreCommand = &cobra.Command{
+		Use:               "restore [options]",
+		Short:             "Restore a pod from a checkpoint",
+		Long:              podRestoreDescription,
+		RunE:              restore,
+		Args:              validate.NoArgs,
+		ValidArgsFunction: completion.AutocompleteNone,
+		Example: `podman pod restore --import=/tmp/mypod.tar.zst
+  podman pod restore --import=/tmp/mypod.tar.zst --name=mypod-copy --publish=8081:80`,
+	}
+)
+
+var (
+	restoreOptions entities.PodRestoreOptions
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: restoreCommand,
+		Parent:  podCmd,
+	})
+	flags := restoreCommand.Flags()
+	flags.BoolVarP(&restoreOptions.Keep, "keep", "k", false, "Keep all temporary checkpoint files")
+	flags.BoolVar(&restoreOptions.TCPEstablished, "tcp-established", false, "Restore containers with established TCP connections")
+	flags.BoolVar(&restoreOptions.FileLocks, "file-locks", false, "Restore containers with file locks")
+
+	importFlagName := "import"
+	flags.StringVarP(&restoreOptions.Import, importFlagName, "i", "", "Restore from exported pod checkpoint archive")
+	_ = restoreCommand.RegisterFlagCompletionFunc(importFlagName, completion.AutocompleteDefault)
+	_ = restoreCommand.MarkFlagRequired(importFlagName)
+
+	nameFlagName := "name"
+	flags.StringVarP(&restoreOptions.Name, nameFlagName, "n", "", "Specify new name for the restored pod")
+	_ = restoreCommand.RegisterFlagCompletionFunc(nameFlagName, completion.AutocompleteNone)
+
+	flags.BoolVar(&restoreOptions.IgnoreRootFS, "ignore-rootfs", false, "Do not apply root file-system changes when importing from exported checkpoint")
+	flags.BoolVar(&restoreOptions.IgnoreStaticIP, "ignore-static-ip", false, "Ignore IP addresses of the pod saved in the checkpoint")
+	flags.BoolVar(&restoreOptions.IgnoreStaticMAC, "ignore-static-mac", false, "Ignore MAC addresses of the pod saved in the checkpoint")
+	flags.BoolVar(&restoreOptions.IgnoreVolumes, "ignore-volumes", false, "Do not restore volumes associated with the containers of the pod")
+
+	publishFlagName := "publish"
+	flags.StringSliceVarP(&restoreOptions.PublishPorts, publishFlagName, "p", []string{}, "Publish a port of the pod, or a range of ports, to the host (default [])")
+	_ = restoreCommand.RegisterFlagCompletionFunc(publishFlagName, completion.AutocompleteNone)
+}
+
+func restore(_ *cobra.Command, _ []string) error {
+	if rootless.IsRootless() {
+		return errors.New("restoring a pod requires root")
+	}
+
+	report, err := registry.ContainerEngine().PodRestore(context.Background(), restoreOptions)
+	if err != nil {
+		return err
+	}
+	fmt.Println(report.Id)
+	return nil
+}
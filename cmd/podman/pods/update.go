@@ -0,0 +1,181 @@
+package pods
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/containers/podman/v5/pkg/specgen"
+	"github.com/containers/podman/v5/pkg/specgenutil"
+	"github.com/containers/podman/v5/pkg/util"
+	"github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/spf13/cobra"
+)
+
+var (
+	podUpdateDescription = `Updates the resource limits of an existing pod.
+
+  The limits are set on the cgroup of the pod and shared by all of its containers. The restart policy and the healthcheck on-failure action can be changed for all containers of the pod at once.`
+
+	updateCommand = &cobra.Command{
+		Use:               "update [options] POD",
+		Short:             "Update an existing pod",
+		Long:              podUpdateDescription,
+		RunE:              update,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: common.AutocompletePods,
+		Example: `podman pod update --cpus=2 --memory=1g mypod
+  podman pod update --restart=always --health-on-failure=kill mypod`,
+	}
+)
+
+var (
+	updateOpts entities.ContainerCreateOptions
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: updateCommand,
+		Parent:  podCmd,
+	})
+	flags := updateCommand.Flags()
+
+	// -1 means the system default, only set swappiness when the flag is used
+	updateOpts.MemorySwappiness = -1
+
+	cpusFlagName := "cpus"
+	flags.Float64Var(&updateOpts.CPUS, cpusFlagName, 0, "Number of CPUs of the pod")
+	_ = updateCommand.RegisterFlagCompletionFunc(cpusFlagName, completion.AutocompleteNone)
+
+	cpuSharesFlagName := "cpu-shares"
+	flags.Uint64VarP(&updateOpts.CPUShares, cpuSharesFlagName, "c", 0, "CPU shares (relative weight)")
+	_ = updateCommand.RegisterFlagCompletionFunc(cpuSharesFlagName, completion.AutocompleteNone)
+
+	cpuPeriodFlagName := "cpu-period"
+	flags.Uint64Var(&updateOpts.CPUPeriod, cpuPeriodFlagName, 0, "Limit the CPU CFS (Completely Fair Scheduler) period")
+	_ = updateCommand.RegisterFlagCompletionFunc(cpuPeriodFlagName, completion.AutocompleteNone)
+
+	cpuQuotaFlagName := "cpu-quota"
+	flags.Int64Var(&updateOpts.CPUQuota, cpuQuotaFlagName, 0, "Limit the CPU CFS (Completely Fair Scheduler) quota")
+	_ = updateCommand.RegisterFlagCompletionFunc(cpuQuotaFlagName, completion.AutocompleteNone)
+
+	cpusetCpusFlagName := "cpuset-cpus"
+	flags.StringVar(&updateOpts.CPUSetCPUs, cpusetCpusFlagName, "", "CPUs in which to allow execution (0-3, 0,1)")
+	_ = updateCommand.RegisterFlagCompletionFunc(cpusetCpusFlagName, completion.AutocompleteNone)
+
+	cpusetMemsFlagName := "cpuset-mems"
+	flags.StringVar(&updateOpts.CPUSetMems, cpusetMemsFlagName, "", "Memory nodes (MEMs) in which to allow execution (0-3, 0,1). Only effective on NUMA systems.")
+	_ = updateCommand.RegisterFlagCompletionFunc(cpusetMemsFlagName, completion.AutocompleteNone)
+
+	memoryFlagName := "memory"
+	flags.StringVarP(&updateOpts.Memory, memoryFlagName, "m", "", "Memory limit (format: <number>[<unit>], where unit = b (bytes), k (kibibytes), m (mebibytes), or g (gibibytes))")
+	_ = updateCommand.RegisterFlagCompletionFunc(memoryFlagName, completion.AutocompleteNone)
+
+	memoryReservationFlagName := "memory-reservation"
+	flags.StringVar(&updateOpts.MemoryReservation, memoryReservationFlagName, "", "Memory soft limit (format: <number>[<unit>], where unit = b (bytes), k (kibibytes), m (mebibytes), or g (gibibytes))")
+	_ = updateCommand.RegisterFlagCompletionFunc(memoryReservationFlagName, completion.AutocompleteNone)
+
+	memorySwapFlagName := "memory-swap"
+	flags.StringVar(&updateOpts.MemorySwap, memorySwapFlagName, "", "Swap limit equal to memory plus swap: '-1' to enable unlimited swap")
+	_ = updateCommand.RegisterFlagCompletionFunc(memorySwapFlagName, completion.AutocompleteNone)
+
+	blkioWeightFlagName := "blkio-weight"
+	flags.StringVar(&updateOpts.BlkIOWeight, blkioWeightFlagName, "", "Block IO weight (relative weight) accepts a weight value between 10 and 1000.")
+	_ = updateCommand.RegisterFlagCompletionFunc(blkioWeightFlagName, completion.AutocompleteNone)
+
+	blkioWeightDeviceFlagName := "blkio-weight-device"
+	flags.StringArrayVar(&updateOpts.BlkIOWeightDevice, blkioWeightDeviceFlagName, []string{}, "Block IO weight (relative device weight, format: `DEVICE_NAME:WEIGHT`)")
+	_ = updateCommand.RegisterFlagCompletionFunc(blkioWeightDeviceFlagName, completion.AutocompleteDefault)
+
+	deviceReadBpsFlagName := "device-read-bps"
+	flags.StringArrayVar(&updateOpts.DeviceReadBPs, deviceReadBpsFlagName, []string{}, "Limit read rate (bytes per second) from a device (e.g. --device-read-bps=/dev/sda:1mb)")
+	_ = updateCommand.RegisterFlagCompletionFunc(deviceReadBpsFlagName, completion.AutocompleteDefault)
+
+	deviceWriteBpsFlagName := "device-write-bps"
+	flags.StringArrayVar(&updateOpts.DeviceWriteBPs, deviceWriteBpsFlagName, []string{}, "Limit write rate (bytes per second) to a device (e.g. --device-write-bps=/dev/sda:1mb)")
+	_ = updateCommand.RegisterFlagCompletionFunc(deviceWriteBpsFlagName, completion.AutocompleteDefault)
+
+	deviceReadIopsFlagName := "device-read-iops"
+	flags.StringArrayVar(&updateOpts.DeviceReadIOPs, deviceReadIopsFlagName, []string{}, "Limit read rate (IO per second) from a device (e.g. --device-read-iops=/dev/sda:1000)")
+	_ = updateCommand.RegisterFlagCompletionFunc(deviceReadIopsFlagName, completion.AutocompleteDefault)
+
+	deviceWriteIopsFlagName := "device-write-iops"
+	flags.StringArrayVar(&updateOpts.DeviceWriteIOPs, deviceWriteIopsFlagName, []string{}, "Limit write rate (IO per second) to a device (e.g. --device-write-iops=/dev/sda:1000)")
+	_ = updateCommand.RegisterFlagCompletionFunc(deviceWriteIopsFlagName, completion.AutocompleteDefault)
+
+	pidsLimitFlagName := "pids-limit"
+	flags.Int64(pidsLimitFlagName, 0, "Tune the pids limit of the pod (set -1 for unlimited)")
+	_ = updateCommand.RegisterFlagCompletionFunc(pidsLimitFlagName, completion.AutocompleteNone)
+
+	restartFlagName := "restart"
+	flags.StringVar(&updateOpts.Restart, restartFlagName, "", `Restart policy to apply to the pod and its containers ("always"|"no"|"never"|"on-failure"|"unless-stopped")`)
+	_ = updateCommand.RegisterFlagCompletionFunc(restartFlagName, common.AutocompleteRestartOption)
+
+	healthOnFailureFlagName := "health-on-failure"
+	flags.StringVar(&updateOpts.HealthOnFailure, healthOnFailureFlagName, "", "action to take once a container of the pod turns unhealthy")
+	_ = updateCommand.RegisterFlagCompletionFunc(healthOnFailureFlagName, common.AutocompleteHealthOnFailure)
+}
+
+func update(cmd *cobra.Command, args []string) error {
+	var err error
+	// use a specgen since this is the easiest way to hold resource info
+	s := &specgen.SpecGenerator{}
+	s.ResourceLimits = &specs.LinuxResources{}
+
+	if cmd.Flags().Changed("pids-limit") {
+		val := cmd.Flag("pids-limit").Value.String()
+		// Convert -1 to 0, so that -1 maps to unlimited pids limit
+		if val == "-1" {
+			val = "0"
+		}
+		pidsLimit, err := strconv.ParseInt(val, 10, 32)
+		if err != nil {
+			return err
+		}
+		updateOpts.PIDsLimit = &pidsLimit
+	}
+
+	if updateOpts.Restart != "" {
+		policy, retries, err := util.ParseRestartPolicy(updateOpts.Restart)
+		if err != nil {
+			return err
+		}
+		s.RestartPolicy = policy
+		if policy == define.RestartPolicyOnFailure {
+			s.RestartRetries = &retries
+		}
+	}
+
+	// we need to pass the whole specgen since throttle devices are parsed later due to cross compat.
+	s.ResourceLimits, err = specgenutil.GetResources(s, &updateOpts)
+	if err != nil {
+		return err
+	}
+
+	opts := &entities.PodUpdateOptions{
+		NameOrID: args[0],
+		Specgen:  s,
+	}
+	if cmd.Flags().Changed("health-on-failure") {
+		opts.HealthOnFailure = &updateOpts.HealthOnFailure
+	}
+
+	if s.ResourceLimits == nil && s.RestartPolicy == "" && len(s.WeightDevice) == 0 &&
+		len(s.ThrottleReadBpsDevice) == 0 && len(s.ThrottleWriteBpsDevice) == 0 &&
+		len(s.ThrottleReadIOPSDevice) == 0 && len(s.ThrottleWriteIOPSDevice) == 0 && opts.HealthOnFailure == nil {
+		return errors.New("no changes specified, set at least one option to update the pod")
+	}
+
+	rep, err := registry.ContainerEngine().PodUpdate(context.Background(), opts)
+	if err != nil {
+		return err
+	}
+	fmt.Println(rep)
+	return nil
+}
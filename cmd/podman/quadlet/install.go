@@ -0,0 +1,56 @@
+package quadlet
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/spf13/cobra"
+)
+
+var (
+	installDescription = `Install Quadlets and the files they reference into the Quadlet directory of the current user.
+
+  The arguments are Quadlet files, or directories containing Quadlet files. Kube YAML, ConfigMap and environment files referenced with relative paths are installed alongside the Quadlets.`
+
+	installCmd = &cobra.Command{
+		Use:               "install [options] PATH [PATH...]",
+		Short:             "Install Quadlets",
+		Long:              installDescription,
+		RunE:              install,
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: completion.AutocompleteDefault,
+		Example: `podman quadlet install myapp.container
+  podman quadlet install --replace ./myapp/`,
+	}
+
+	installOptions = entities.QuadletInstallOptions{}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: installCmd,
+		Parent:  quadletCmd,
+	})
+	flags := installCmd.Flags()
+	flags.BoolVar(&installOptions.Replace, "replace", false, "Replace Quadlets and files which are already installed")
+	flags.BoolVar(&installOptions.ReloadSystemd, "reload-systemd", true, "Reload systemd after installing the Quadlets")
+}
+
+func install(cmd *cobra.Command, args []string) error {
+	report, err := registry.ContainerEngine().QuadletInstall(context.Background(), args, installOptions)
+	if report != nil {
+		installed := make([]string, 0, len(report.InstalledQuadlets))
+		for _, path := range report.InstalledQuadlets {
+			installed = append(installed, path)
+		}
+		sort.Strings(installed)
+		for _, path := range installed {
+			fmt.Println(path)
+		}
+	}
+	return err
+}
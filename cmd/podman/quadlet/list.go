@@ -0,0 +1,100 @@
+package quadlet
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/common/pkg/report"
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/cmd/podman/validate"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/spf13/cobra"
+)
+
+var (
+	listCmd = &cobra.Command{
+		Use:               "list [options]",
+		Aliases:           []string{"ls"},
+		Short:             "List installed Quadlets",
+		Long:              "List the installed Quadlets, with the name and state of the systemd services generated from them.",
+		RunE:              list,
+		Args:              validate.NoArgs,
+		ValidArgsFunction: completion.AutocompleteNone,
+		Example:           "podman quadlet list",
+	}
+	listFlag = listFlagType{}
+)
+
+type listFlagType struct {
+	format    string
+	noHeading bool
+	quiet     bool
+}
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: listCmd,
+		Parent:  quadletCmd,
+	})
+	flags := listCmd.Flags()
+
+	formatFlagName := "format"
+	flags.StringVar(&listFlag.format, formatFlagName, "{{range .}}{{.Name}}\t{{.UnitName}}\t{{.Path}}\t{{.Status}}\n{{end -}}", "Pretty-print output to JSON or using a Go template")
+	_ = listCmd.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteFormat(&entities.ListQuadlet{}))
+
+	flags.BoolVarP(&listFlag.noHeading, "noheading", "n", false, "Do not print headers")
+	flags.BoolVarP(&listFlag.quiet, "quiet", "q", false, "Print Quadlet names only")
+}
+
+func list(cmd *cobra.Command, args []string) error {
+	quadlets, err := registry.ContainerEngine().QuadletList(context.Background(), entities.QuadletListOptions{})
+	if err != nil {
+		return err
+	}
+
+	if listFlag.quiet && !cmd.Flags().Changed("format") {
+		for _, q := range quadlets {
+			fmt.Println(q.Name)
+		}
+		return nil
+	}
+
+	if report.IsJSON(listFlag.format) {
+		return printJSON(quadlets)
+	}
+
+	headers := report.Headers(entities.ListQuadlet{}, map[string]string{
+		"UnitName": "UNIT NAME",
+	})
+
+	rpt := report.New(os.Stdout, cmd.Name())
+	defer rpt.Flush()
+
+	if cmd.Flags().Changed("format") {
+		rpt, err = rpt.Parse(report.OriginUser, listFlag.format)
+	} else {
+		rpt, err = rpt.Parse(report.OriginPodman, listFlag.format)
+	}
+	if err != nil {
+		return err
+	}
+
+	if rpt.RenderHeaders && !listFlag.noHeading {
+		if err := rpt.Execute(headers); err != nil {
+			return fmt.Errorf("failed to write report column headers: %w", err)
+		}
+	}
+	return rpt.Execute(quadlets)
+}
+
+func printJSON(quadlets []*entities.ListQuadlet) error {
+	b, err := json.MarshalIndent(quadlets, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(b))
+	return nil
+}
@@ -0,0 +1,38 @@
+package quadlet
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/spf13/cobra"
+)
+
+var (
+	printCmd = &cobra.Command{
+		Use:               "print QUADLET",
+		Short:             "Display the contents of a Quadlet",
+		Long:              "Display the contents of an installed Quadlet file.",
+		RunE:              printQuadlet,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: common.AutocompleteQuadlets,
+		Example:           "podman quadlet print myapp.container",
+	}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: printCmd,
+		Parent:  quadletCmd,
+	})
+}
+
+func printQuadlet(cmd *cobra.Command, args []string) error {
+	content, err := registry.ContainerEngine().QuadletPrint(context.Background(), args[0])
+	if err != nil {
+		return err
+	}
+	fmt.Print(content)
+	return nil
+}
@@ -0,0 +1,26 @@
+package quadlet
+
+import (
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/cmd/podman/validate"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// Pull in configured json library
+	json = registry.JSONLibrary()
+
+	// Command: podman _quadlet_
+	quadletCmd = &cobra.Command{
+		Use:   "quadlet",
+		Short: "Manage Quadlets",
+		Long:  "Install, list, print and remove Quadlets, the unit files systemd services for containers, pods, volumes and networks are generated from",
+		RunE:  validate.SubCommandExists,
+	}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: quadletCmd,
+	})
+}
@@ -0,0 +1,66 @@
+package quadlet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/cmd/podman/utils"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/spf13/cobra"
+)
+
+var (
+	rmCmd = &cobra.Command{
+		Use:               "rm [options] QUADLET [QUADLET...]",
+		Aliases:           []string{"remove"},
+		Short:             "Remove installed Quadlets",
+		Long:              "Remove installed Quadlets and the files installed alongside them. The systemd services of the Quadlets must not be running, unless --force is given.",
+		RunE:              rm,
+		ValidArgsFunction: common.AutocompleteQuadlets,
+		Example: `podman quadlet rm myapp.container
+  podman quadlet rm --force --all`,
+	}
+
+	rmOptions = entities.QuadletRemoveOptions{}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: rmCmd,
+		Parent:  quadletCmd,
+	})
+	flags := rmCmd.Flags()
+	flags.BoolVarP(&rmOptions.All, "all", "a", false, "Remove all installed Quadlets")
+	flags.BoolVarP(&rmOptions.Force, "force", "f", false, "Stop running Quadlet services before removing them")
+	flags.BoolVarP(&rmOptions.Ignore, "ignore", "i", false, "Ignore errors when a specified Quadlet is not installed")
+	flags.BoolVar(&rmOptions.ReloadSystemd, "reload-systemd", true, "Reload systemd after removing the Quadlets")
+}
+
+func rm(cmd *cobra.Command, args []string) error {
+	var errs utils.OutputErrors
+	if (len(args) > 0 && rmOptions.All) || (len(args) < 1 && !rmOptions.All) {
+		return errors.New("`podman quadlet rm` requires one argument, or the --all flag")
+	}
+	report, err := registry.ContainerEngine().QuadletRemove(context.Background(), args, rmOptions)
+	if report != nil {
+		for _, name := range report.Removed {
+			fmt.Println(name)
+		}
+		failed := make([]string, 0, len(report.Errors))
+		for name := range report.Errors {
+			failed = append(failed, name)
+		}
+		sort.Strings(failed)
+		for _, name := range failed {
+			errs = append(errs, errors.New(report.Errors[name]))
+		}
+	}
+	if err != nil {
+		errs = append(errs, err)
+	}
+	return errs.PrintErrors()
+}
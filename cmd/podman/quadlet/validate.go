@@ -0,0 +1,107 @@
+package quadlet
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/common/pkg/report"
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/pkg/rootless"
+	systemdquadlet "github.com/containers/podman/v5/pkg/systemd/quadlet"
+	"github.com/spf13/cobra"
+)
+
+var (
+	validateCmd = &cobra.Command{
+		Use:   "validate [options] [DIRECTORY...]",
+		Short: "Validate Quadlets",
+		Long: `Check the Quadlet units, with their drop-ins, for unknown keys, invalid values, references to missing units and dependency cycles.
+  The units in the Quadlet unit directories of the user are checked unless directories are given.`,
+		RunE:              validateQuadlets,
+		ValidArgsFunction: completion.AutocompleteDefault,
+		Example: `podman quadlet validate
+  podman quadlet validate --format json ./myapp`,
+	}
+	validateFormat string
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: validateCmd,
+		Parent:  quadletCmd,
+	})
+	flags := validateCmd.Flags()
+
+	formatFlagName := "format"
+	flags.StringVar(&validateFormat, formatFlagName, "", "Print the problems found as JSON or using a Go template")
+	_ = validateCmd.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteFormat(&systemdquadlet.Diagnostic{}))
+}
+
+// validateQuadlets runs the Quadlet generator in validate mode, which loads
+// the units exactly as it does when generating the services.
+func validateQuadlets(cmd *cobra.Command, args []string) error {
+	quadletPath, err := registry.PodmanConfig().ContainersConfDefaultsRO.FindHelperBinary("quadlet", false)
+	if err != nil {
+		return err
+	}
+
+	quadletArgs := []string{"-validate", "-json"}
+	if rootless.IsRootless() {
+		quadletArgs = append(quadletArgs, "-user")
+	}
+	generator := exec.Command(quadletPath, quadletArgs...)
+	generator.Env = os.Environ()
+	if len(args) > 0 {
+		generator.Env = append(generator.Env, "QUADLET_UNIT_DIRS="+strings.Join(args, ":"))
+	}
+	var stdout, stderr bytes.Buffer
+	generator.Stdout = &stdout
+	generator.Stderr = &stderr
+
+	// The generator exits with 1 when errors were found
+	if err := generator.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) || exitErr.ExitCode() != 1 || stdout.Len() == 0 {
+			return fmt.Errorf("running %s: %w: %s", quadletPath, err, strings.TrimSpace(stderr.String()))
+		}
+	}
+
+	diags := []systemdquadlet.Diagnostic{}
+	if err := json.Unmarshal(stdout.Bytes(), &diags); err != nil {
+		return fmt.Errorf("parsing output of %s: %w", quadletPath, err)
+	}
+
+	for _, d := range diags {
+		if d.Severity == systemdquadlet.SeverityError {
+			registry.SetExitCode(1)
+			break
+		}
+	}
+
+	switch {
+	case report.IsJSON(validateFormat):
+		b, err := json.MarshalIndent(diags, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+	case validateFormat != "":
+		rpt, err := report.New(os.Stdout, cmd.Name()).Parse(report.OriginUser, validateFormat)
+		if err != nil {
+			return err
+		}
+		defer rpt.Flush()
+		return rpt.Execute(diags)
+	default:
+		for _, d := range diags {
+			fmt.Println(d.String())
+		}
+	}
+	return nil
+}
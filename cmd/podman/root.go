@@ -252,7 +252,8 @@ func persistentPreRunE(cmd *cobra.Command, args []string) error {
 		// To make it easier for users we will look into the checkpoint archive and
 		// set the runtime to the one used during checkpointing.
 		if cmd.Name() == "restore" {
-			if cmd.Flag("import").Changed {
+			// podman volume snapshot restore has no --import flag.
+			if importFlag := cmd.Flag("import"); importFlag != nil && importFlag.Changed {
 				runtime, err := crutils.CRGetRuntimeFromArchive(cmd.Flag("import").Value.String())
 				if err != nil {
 					return fmt.Errorf(
@@ -40,7 +40,12 @@ Labels:
 {{- end }}{{ end }}
 Driver:            {{.Spec.Driver.Name}}
 Created at:        {{.CreatedAt}}
-Updated at:        {{.UpdatedAt}}`
+Updated at:        {{.UpdatedAt}}
+{{- if .Versions }}
+Versions:
+{{- range .Versions }}
+ - {{ .Version }}: {{ .CreatedAt }}
+{{- end }}{{ end }}`
 )
 
 var inspectOpts = entities.SecretInspectOptions{}
@@ -62,7 +67,10 @@ func init() {
 }
 
 func inspect(cmd *cobra.Command, args []string) error {
-	inspected, errs, _ := registry.ContainerEngine().SecretInspect(context.Background(), args, inspectOpts)
+	inspected, errs, err := registry.ContainerEngine().SecretInspect(context.Background(), args, inspectOpts)
+	if err != nil {
+		return err
+	}
 
 	// always print valid list
 	if len(inspected) == 0 {
@@ -0,0 +1,64 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/cmd/podman/utils"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/spf13/cobra"
+)
+
+var (
+	rekeyCmd = &cobra.Command{
+		Use:   "rekey [options] SECRET [SECRET...]",
+		Short: "Encrypt one or more secrets with a new key",
+		Long:  "Encrypt the data of one or more secrets of the file driver with a new key without recreating them. The given driver options are merged into the existing ones.",
+		RunE:  rekey,
+		Example: `podman secret rekey --driver-opts keyfile=/etc/podman/secrets-new.key mysecret
+  podman secret rekey --all --driver-opts encrypt=env,keyfile=`,
+		ValidArgsFunction: common.AutocompleteSecrets,
+	}
+)
+
+var (
+	rekeyOptions = entities.SecretRekeyOptions{}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: rekeyCmd,
+		Parent:  secretCmd,
+	})
+	flags := rekeyCmd.Flags()
+	flags.BoolVarP(&rekeyOptions.All, "all", "a", false, "Rekey all secrets of the file driver")
+
+	optsFlagName := "driver-opts"
+	flags.StringToStringVar(&rekeyOptions.DriverOpts, optsFlagName, nil, "Specify driver specific options")
+	_ = rekeyCmd.RegisterFlagCompletionFunc(optsFlagName, completion.AutocompleteNone)
+}
+
+func rekey(cmd *cobra.Command, args []string) error {
+	var (
+		errs utils.OutputErrors
+	)
+	if (len(args) > 0 && rekeyOptions.All) || (len(args) < 1 && !rekeyOptions.All) {
+		return errors.New("`podman secret rekey` requires one argument, or the --all flag")
+	}
+	responses, err := registry.ContainerEngine().SecretRekey(context.Background(), args, rekeyOptions)
+	if err != nil {
+		return err
+	}
+	for _, r := range responses {
+		if r.Err == nil {
+			fmt.Println(r.ID)
+		} else {
+			errs = append(errs, r.Err)
+		}
+	}
+	return errs.PrintErrors()
+}
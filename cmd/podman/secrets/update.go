@@ -0,0 +1,53 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/spf13/cobra"
+)
+
+var (
+	updateCmd = &cobra.Command{
+		Use:   "update [options] SECRET FILE|-",
+		Short: "Add a new version of a secret",
+		Long:  "Store new data for a secret as a new version of it. Input can be a path to a file or \"-\" (read from stdin). Running containers which mounted the secret with the rotate option get the new data.",
+		RunE:  update,
+		Args:  cobra.ExactArgs(2),
+		Example: `podman secret update mysecret /path/to/secret
+  printf "secretdata" | podman secret update mysecret -`,
+		ValidArgsFunction: common.AutocompleteSecretUpdate,
+	}
+)
+
+var (
+	updateEnv = false
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: updateCmd,
+		Parent:  secretCmd,
+	})
+	flags := updateCmd.Flags()
+
+	envFlagName := "env"
+	flags.BoolVar(&updateEnv, envFlagName, false, "Read secret data from environment variable")
+}
+
+func update(cmd *cobra.Command, args []string) error {
+	reader, err := openSecretData(args[1], updateEnv)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	report, err := registry.ContainerEngine().SecretUpdate(context.Background(), args[0], reader)
+	if err != nil {
+		return err
+	}
+	fmt.Println(report.ID)
+	return nil
+}
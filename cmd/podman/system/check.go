@@ -19,7 +19,7 @@ var (
 	checkDescription = `
 	podman system check
 
-        Check storage for consistency and remove anything that looks damaged
+        Check storage and the database for consistency and repair or remove anything that looks damaged
 `
 
 	checkCommand = &cobra.Command{
@@ -40,8 +40,8 @@ func init() {
 	})
 	flags := checkCommand.Flags()
 	flags.BoolVarP(&checkOptions.Quick, "quick", "q", false, "Skip time-consuming checks. The default is to include time-consuming checks")
-	flags.BoolVarP(&checkOptions.Repair, "repair", "r", false, "Remove inconsistent images")
-	flags.BoolVarP(&checkOptions.RepairLossy, "force", "f", false, "Remove inconsistent images and containers")
+	flags.BoolVarP(&checkOptions.Repair, "repair", "r", false, "Remove inconsistent images and repair the database")
+	flags.BoolVarP(&checkOptions.RepairLossy, "force", "f", false, "Remove inconsistent images, containers and pods")
 	flags.DurationP("max", "m", 24*time.Hour, "Maximum allowed age of unreferenced layers")
 	_ = checkCommand.RegisterFlagCompletionFunc("max", completion.AutocompleteNone)
 }
@@ -134,5 +134,41 @@ func printSystemCheckResults(report *types.SystemCheckReport) error {
 	for removedContainer := range report.RemovedContainers {
 		fmt.Printf("Deleted damaged container: %s\n", removedContainer)
 	}
+	for damagedContainer, errorsSlice := range report.DBContainers {
+		merr := multierror.Append(nil, errorSlice(errorsSlice)...)
+		if err := merr.ErrorOrNil(); err != nil {
+			fmt.Printf("Inconsistent container %s in database:\n%s", damagedContainer, err)
+		}
+	}
+	for damagedPod, errorsSlice := range report.Pods {
+		merr := multierror.Append(nil, errorSlice(errorsSlice)...)
+		if err := merr.ErrorOrNil(); err != nil {
+			fmt.Printf("Inconsistent pod %s in database:\n%s", damagedPod, err)
+		}
+	}
+	for removedPod := range report.RemovedPods {
+		fmt.Printf("Deleted inconsistent pod: %s\n", removedPod)
+	}
+	for _, repairedPod := range report.RepairedPods {
+		fmt.Printf("Removed missing containers from pod: %s\n", repairedPod)
+	}
+	for damagedVolume, errorsSlice := range report.Volumes {
+		merr := multierror.Append(nil, errorSlice(errorsSlice)...)
+		if err := merr.ErrorOrNil(); err != nil {
+			fmt.Printf("Damaged volume %s:\n%s", damagedVolume, err)
+		}
+	}
+	for _, repairedVolume := range report.RepairedVolumes {
+		fmt.Printf("Recreated mountpoint of volume: %s\n", repairedVolume)
+	}
+	for staleSession, errorsSlice := range report.ExecSessions {
+		merr := multierror.Append(nil, errorSlice(errorsSlice)...)
+		if err := merr.ErrorOrNil(); err != nil {
+			fmt.Printf("Stale exec session %s:\n%s", staleSession, err)
+		}
+	}
+	for _, removedSession := range report.RemovedExecSessions {
+		fmt.Printf("Deleted stale exec session: %s\n", removedSession)
+	}
 	return nil
 }
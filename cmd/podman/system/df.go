@@ -225,7 +225,7 @@ func printVerbose(cmd *cobra.Command, reports *entities.SystemDfReport) error {
 	hdrs = report.Headers(entities.SystemDfVolumeReport{}, map[string]string{
 		"VolumeName": "VOLUME NAME",
 	})
-	volumeRow := "{{range .}}{{.VolumeName}}\t{{.Links}}\t{{.Size}}\n{{end -}}"
+	volumeRow := "{{range .}}{{.VolumeName}}\t{{.Links}}\t{{.Size}}\t{{.Quota}}\n{{end -}}"
 	rpt, err = rpt.Parse(report.OriginPodman, volumeRow)
 	if err != nil {
 		return err
@@ -298,6 +298,13 @@ func (d *dfVolume) Size() string {
 	return units.HumanSize(float64(d.SystemDfVolumeReport.Size))
 }
 
+func (d *dfVolume) Quota() string {
+	if d.SystemDfVolumeReport.Quota == 0 {
+		return "-"
+	}
+	return units.HumanSize(float64(d.SystemDfVolumeReport.Quota))
+}
+
 type dfSummary struct {
 	Type           string
 	Total          int
@@ -7,6 +7,7 @@ import (
 	"os"
 
 	"github.com/containers/common/pkg/completion"
+	"github.com/containers/podman/v5/cmd/podman/common"
 	"github.com/containers/podman/v5/cmd/podman/registry"
 	"github.com/containers/podman/v5/cmd/podman/validate"
 	"github.com/containers/podman/v5/libpod/define"
@@ -18,7 +19,8 @@ var (
 	migrateDescription = `
         podman system migrate
 
-        Migrate existing containers to a new version of Podman.
+        Migrate existing containers to a new version of Podman,
+        to a new OCI runtime or to a new database backend.
 `
 
 	migrateCommand = &cobra.Command{
@@ -50,6 +52,10 @@ func init() {
 	newRuntimeFlagName := "new-runtime"
 	flags.StringVar(&migrateOptions.NewRuntime, newRuntimeFlagName, "", "Specify a new runtime for all containers")
 	_ = migrateCommand.RegisterFlagCompletionFunc(newRuntimeFlagName, completion.AutocompleteNone)
+
+	databaseFlagName := "database"
+	flags.StringVar(&migrateOptions.Database, databaseFlagName, "", "Migrate the database to a new backend (boltdb, sqlite)")
+	_ = migrateCommand.RegisterFlagCompletionFunc(databaseFlagName, common.AutocompleteDatabaseBackend)
 }
 
 func migrate(cmd *cobra.Command, args []string) {
@@ -41,6 +41,8 @@ Enable a listening service for API access to Podman commands.
 
 	srvArgs = struct {
 		CorsHeaders string
+		Metrics     bool
+		MetricsAddr string
 		PProfAddr   string
 		Timeout     uint
 	}{}
@@ -64,6 +66,13 @@ func init() {
 	flags.StringVarP(&srvArgs.CorsHeaders, "cors", "", "", "Set CORS Headers")
 	_ = srvCmd.RegisterFlagCompletionFunc("cors", completion.AutocompleteNone)
 
+	flags.BoolVar(&srvArgs.Metrics, "metrics", false, "Expose Prometheus metrics on the /metrics endpoint")
+
+	metricsAddrFlagName := "metrics-address"
+	flags.StringVar(&srvArgs.MetricsAddr, metricsAddrFlagName, "",
+		"Binding network address for an additional /metrics endpoint, localhost unless a host is given, implies --metrics")
+	_ = srvCmd.RegisterFlagCompletionFunc(metricsAddrFlagName, completion.AutocompleteNone)
+
 	flags.StringVarP(&srvArgs.PProfAddr, "pprof-address", "", "",
 		"Binding network address for pprof profile endpoints, default: do not expose endpoints")
 	_ = flags.MarkHidden("pprof-address")
@@ -101,6 +110,8 @@ func service(cmd *cobra.Command, args []string) error {
 
 	return restService(cmd.Flags(), registry.PodmanConfig(), entities.ServiceOptions{
 		CorsHeaders: srvArgs.CorsHeaders,
+		Metrics:     srvArgs.Metrics,
+		MetricsAddr: srvArgs.MetricsAddr,
 		PProfAddr:   srvArgs.PProfAddr,
 		Timeout:     time.Duration(srvArgs.Timeout) * time.Second,
 		URI:         apiURI,
@@ -0,0 +1,57 @@
+package volumes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cloneDescription = `Create a new volume with the configuration and a copy of the data of a volume using the local driver.
+
+  The data is copied with reflinks if the file system supports them. With --snapshot the data of a snapshot of the volume is copied instead.`
+
+	cloneCommand = &cobra.Command{
+		Use:               "clone [options] VOLUME [NAME]",
+		Args:              cobra.RangeArgs(1, 2),
+		Short:             "Clone a volume",
+		Long:              cloneDescription,
+		RunE:              clone,
+		ValidArgsFunction: common.AutocompleteVolumes,
+		Example: `podman volume clone myvol myvol-copy
+  podman volume clone --snapshot before-upgrade myvol myvol-old`,
+	}
+)
+
+var (
+	cloneOpts = entities.VolumeCloneOptions{}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: cloneCommand,
+		Parent:  volumeCmd,
+	})
+	flags := cloneCommand.Flags()
+
+	snapshotFlagName := "snapshot"
+	flags.StringVar(&cloneOpts.Snapshot, snapshotFlagName, "", "Clone the data of a snapshot of the volume")
+	_ = cloneCommand.RegisterFlagCompletionFunc(snapshotFlagName, completion.AutocompleteNone)
+}
+
+func clone(cmd *cobra.Command, args []string) error {
+	if len(args) > 1 {
+		cloneOpts.Name = args[1]
+	}
+	response, err := registry.ContainerEngine().VolumeClone(context.Background(), args[0], cloneOpts)
+	if err != nil {
+		return err
+	}
+	fmt.Println(response.IDOrName)
+	return nil
+}
@@ -0,0 +1,204 @@
+package volumes
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/containers/common/pkg/report"
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/cmd/podman/utils"
+	"github.com/containers/podman/v5/cmd/podman/validate"
+	"github.com/docker/go-units"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// Command: podman volume _snapshot_
+	snapshotCmd = &cobra.Command{
+		Use:   "snapshot",
+		Short: "Manage volume snapshots",
+		Long:  "Snapshots are point in time copies of the data of volumes using the local driver",
+		RunE:  validate.SubCommandExists,
+	}
+
+	snapshotCreateCommand = &cobra.Command{
+		Use:               "create [options] VOLUME [SNAPSHOT]",
+		Short:             "Take a snapshot of a volume",
+		Long:              "Take a snapshot of the data of a volume. If no snapshot name is given, a name based on the current time is used.",
+		RunE:              snapshotCreate,
+		Args:              cobra.RangeArgs(1, 2),
+		ValidArgsFunction: common.AutocompleteVolumes,
+		Example: `podman volume snapshot create myvol
+  podman volume snapshot create myvol before-upgrade`,
+	}
+
+	snapshotLsCommand = &cobra.Command{
+		Use:               "ls [options] VOLUME",
+		Aliases:           []string{"list"},
+		Short:             "List the snapshots of a volume",
+		Long:              "List the snapshots of a volume, oldest first.",
+		RunE:              snapshotList,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: common.AutocompleteVolumes,
+		Example:           `podman volume snapshot ls myvol`,
+	}
+
+	snapshotRestoreCommand = &cobra.Command{
+		Use:               "restore VOLUME SNAPSHOT",
+		Short:             "Restore a snapshot of a volume",
+		Long:              "Replace the data of a volume with the data of one of its snapshots. The volume must not be used by a running container.",
+		RunE:              snapshotRestore,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: common.AutocompleteVolumeSnapshots,
+		Example:           `podman volume snapshot restore myvol before-upgrade`,
+	}
+
+	snapshotRmCommand = &cobra.Command{
+		Use:               "rm VOLUME SNAPSHOT [SNAPSHOT...]",
+		Aliases:           []string{"remove"},
+		Short:             "Remove one or more snapshots of a volume",
+		Long:              "Remove one or more snapshots of a volume.",
+		RunE:              snapshotRm,
+		Args:              cobra.MinimumNArgs(2),
+		ValidArgsFunction: common.AutocompleteVolumeSnapshots,
+		Example:           `podman volume snapshot rm myvol before-upgrade`,
+	}
+)
+
+var (
+	snapshotLsOpts = struct {
+		Format string
+		Quiet  bool
+	}{}
+)
+
+// volumeSnapshotListReport is the output of podman volume snapshot ls.
+type volumeSnapshotListReport struct {
+	Name      string
+	Created   string
+	CreatedAt time.Time
+}
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: snapshotCmd,
+		Parent:  volumeCmd,
+	})
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: snapshotCreateCommand,
+		Parent:  snapshotCmd,
+	})
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: snapshotLsCommand,
+		Parent:  snapshotCmd,
+	})
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: snapshotRestoreCommand,
+		Parent:  snapshotCmd,
+	})
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: snapshotRmCommand,
+		Parent:  snapshotCmd,
+	})
+
+	flags := snapshotLsCommand.Flags()
+	formatFlagName := "format"
+	flags.StringVar(&snapshotLsOpts.Format, formatFlagName, "{{range .}}{{.Name}}\t{{.Created}}\n{{end -}}", "Format snapshot output using Go template")
+	_ = snapshotLsCommand.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteFormat(&volumeSnapshotListReport{}))
+
+	flags.BoolP("noheading", "n", false, "Do not print headers")
+	flags.BoolVarP(&snapshotLsOpts.Quiet, "quiet", "q", false, "Print snapshot names only")
+}
+
+func snapshotCreate(cmd *cobra.Command, args []string) error {
+	name := ""
+	if len(args) > 1 {
+		name = args[1]
+	}
+	response, err := registry.ContainerEngine().VolumeSnapshotCreate(context.Background(), args[0], name)
+	if err != nil {
+		return err
+	}
+	fmt.Println(response.Name)
+	return nil
+}
+
+func snapshotList(cmd *cobra.Command, args []string) error {
+	responses, err := registry.ContainerEngine().VolumeSnapshotList(context.Background(), args[0])
+	if err != nil {
+		return err
+	}
+
+	if report.IsJSON(snapshotLsOpts.Format) {
+		b, err := json.MarshalIndent(responses, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+		return nil
+	}
+
+	listed := make([]*volumeSnapshotListReport, 0, len(responses))
+	for _, response := range responses {
+		listed = append(listed, &volumeSnapshotListReport{
+			Name:      response.Name,
+			Created:   units.HumanDuration(time.Since(response.CreatedAt)) + " ago",
+			CreatedAt: response.CreatedAt,
+		})
+	}
+
+	rpt := report.New(os.Stdout, cmd.Name())
+	defer rpt.Flush()
+
+	switch {
+	case cmd.Flag("format").Changed:
+		rpt, err = rpt.Parse(report.OriginUser, snapshotLsOpts.Format)
+	case snapshotLsOpts.Quiet:
+		rpt, err = rpt.Parse(report.OriginUser, "{{range .}}{{.Name}}\n{{end -}}")
+	default:
+		rpt, err = rpt.Parse(report.OriginPodman, snapshotLsOpts.Format)
+	}
+	if err != nil {
+		return err
+	}
+
+	noHeading, _ := cmd.Flags().GetBool("noheading")
+	if rpt.RenderHeaders && !noHeading {
+		headers := report.Headers(volumeSnapshotListReport{}, nil)
+		if err := rpt.Execute(headers); err != nil {
+			return fmt.Errorf("failed to write report column headers: %w", err)
+		}
+	}
+	return rpt.Execute(listed)
+}
+
+func snapshotRestore(cmd *cobra.Command, args []string) error {
+	if err := registry.ContainerEngine().VolumeSnapshotRestore(context.Background(), args[0], args[1]); err != nil {
+		setExitCode(err)
+		return err
+	}
+	fmt.Println(args[1])
+	return nil
+}
+
+func snapshotRm(cmd *cobra.Command, args []string) error {
+	var (
+		errs utils.OutputErrors
+	)
+	responses, err := registry.ContainerEngine().VolumeSnapshotRm(context.Background(), args[0], args[1:])
+	if err != nil {
+		setExitCode(err)
+		return err
+	}
+	for _, r := range responses {
+		if r.Err == nil {
+			fmt.Println(r.Name)
+		} else {
+			errs = append(errs, r.Err)
+		}
+	}
+	return errs.PrintErrors()
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
@@ -28,11 +29,13 @@ import (
 // for more details.
 
 var (
-	verboseFlag bool // True if -v passed
-	noKmsgFlag  bool
-	isUserFlag  bool // True if run as quadlet-user-generator executable
-	dryRunFlag  bool // True if -dryrun is used
-	versionFlag bool // True if -version is used
+	verboseFlag  bool // True if -v passed
+	noKmsgFlag   bool
+	isUserFlag   bool // True if run as quadlet-user-generator executable
+	dryRunFlag   bool // True if -dryrun is used
+	versionFlag  bool // True if -version is used
+	validateFlag bool // True if -validate is used
+	jsonFlag     bool // True if -json is used
 )
 
 var (
@@ -43,17 +46,6 @@ var (
 
 var (
 	void struct{}
-	// Key: Extension
-	// Value: Processing order for resource naming dependencies
-	supportedExtensions = map[string]int{
-		".container": 4,
-		".volume":    2,
-		".kube":      4,
-		".network":   2,
-		".image":     1,
-		".build":     3,
-		".pod":       5,
-	}
 )
 
 // We log directly to /dev/kmsg, because that is the only way to get information out
@@ -308,12 +300,26 @@ func getUserLevelFilter(resolvedUnitDirAdminUser string) func(string, bool) bool
 
 func isExtSupported(filename string) bool {
 	ext := filepath.Ext(filename)
-	_, ok := supportedExtensions[ext]
+	_, ok := quadlet.SupportedExtensions[ext]
 	return ok
 }
 
 var seen = make(map[string]struct{})
 
+// loadError is an error parsing the unit or drop-in file at path
+type loadError struct {
+	path string
+	err  error
+}
+
+func (e *loadError) Error() string {
+	return fmt.Sprintf("error loading %q, %s", e.path, e.err)
+}
+
+func (e *loadError) Unwrap() error {
+	return e.err
+}
+
 func loadUnitsFromDir(sourcePath string) ([]*parser.UnitFile, error) {
 	var prevError error
 	files, err := os.ReadDir(sourcePath)
@@ -334,12 +340,7 @@ func loadUnitsFromDir(sourcePath string) ([]*parser.UnitFile, error) {
 			Debugf("Loading source unit file %s", path)
 
 			if f, err := parser.ParseUnitFile(path); err != nil {
-				err = fmt.Errorf("error loading %q, %w", path, err)
-				if prevError == nil {
-					prevError = err
-				} else {
-					prevError = fmt.Errorf("%s\n%s", prevError, err)
-				}
+				prevError = errors.Join(prevError, &loadError{path: path, err: err})
 			} else {
 				seen[name] = void
 				units = append(units, f)
@@ -353,10 +354,7 @@ func loadUnitsFromDir(sourcePath string) ([]*parser.UnitFile, error) {
 func loadUnitDropins(unit *parser.UnitFile, sourcePaths []string) error {
 	var prevError error
 	reportError := func(err error) {
-		if prevError != nil {
-			err = fmt.Errorf("%s\n%s", prevError, err)
-		}
-		prevError = err
+		prevError = errors.Join(prevError, err)
 	}
 
 	dropinDirs := []string{}
@@ -409,7 +407,7 @@ func loadUnitDropins(unit *parser.UnitFile, sourcePaths []string) error {
 		Debugf("Loading source drop-in file %s", dropinPath)
 
 		if f, err := parser.ParseUnitFile(dropinPath); err != nil {
-			reportError(fmt.Errorf("error loading %q, %w", dropinPath, err))
+			reportError(&loadError{path: dropinPath, err: err})
 		} else {
 			unit.Merge(f)
 		}
@@ -581,49 +579,61 @@ func warnIfAmbiguousName(unit *parser.UnitFile, group string) {
 	}
 }
 
-func generateUnitsInfoMap(units []*parser.UnitFile) map[string]*quadlet.UnitInfo {
-	unitsInfoMap := make(map[string]*quadlet.UnitInfo)
+// validate loads the units in sourcePaths with their drop-ins and prints all
+// problems found in them. An error is returned if any problem is an error.
+func validate(sourcePaths []string) error {
+	var diags []quadlet.Diagnostic
+	addLoadErrors := func(err error) {
+		if err == nil {
+			return
+		}
+		errs := []error{err}
+		if joined, ok := err.(interface{ Unwrap() []error }); ok {
+			errs = joined.Unwrap()
+		}
+		for _, err := range errs {
+			var loadErr *loadError
+			if errors.As(err, &loadErr) {
+				diags = append(diags, quadlet.ErrorDiagnostic(loadErr.path, nil, loadErr.err))
+			} else {
+				diags = append(diags, quadlet.Diagnostic{Severity: quadlet.SeverityError, Message: err.Error()})
+			}
+		}
+	}
+
+	var units []*parser.UnitFile
+	for _, d := range sourcePaths {
+		result, err := loadUnitsFromDir(d)
+		addLoadErrors(err)
+		units = append(units, result...)
+	}
 	for _, unit := range units {
-		var serviceName string
-		var containers []string
-		var resourceName string
+		addLoadErrors(loadUnitDropins(unit, sourcePaths))
+	}
+	diags = append(quadlet.ValidateUnits(units, isUserFlag), diags...)
 
-		switch {
-		case strings.HasSuffix(unit.Filename, ".container"):
-			serviceName = quadlet.GetContainerServiceName(unit)
-			// Prefill resouceNames for .container files. This solves network reusing.
-			resourceName = quadlet.GetContainerResourceName(unit)
-		case strings.HasSuffix(unit.Filename, ".volume"):
-			serviceName = quadlet.GetVolumeServiceName(unit)
-		case strings.HasSuffix(unit.Filename, ".kube"):
-			serviceName = quadlet.GetKubeServiceName(unit)
-		case strings.HasSuffix(unit.Filename, ".network"):
-			serviceName = quadlet.GetNetworkServiceName(unit)
-		case strings.HasSuffix(unit.Filename, ".image"):
-			serviceName = quadlet.GetImageServiceName(unit)
-		case strings.HasSuffix(unit.Filename, ".build"):
-			serviceName = quadlet.GetBuildServiceName(unit)
-			// Prefill resouceNames for .build files. This is significantly less complex than
-			// pre-computing all resourceNames for all Quadlet types (which is rather complex for a few
-			// types), but still breaks the dependency cycle between .volume and .build ([Volume] can
-			// have Image=some.build, and [Build] can have Volume=some.volume:/some-volume)
-			resourceName = quadlet.GetBuiltImageName(unit)
-		case strings.HasSuffix(unit.Filename, ".pod"):
-			serviceName = quadlet.GetPodServiceName(unit)
-			containers = make([]string, 0)
-		default:
-			Logf("Unsupported file type %q", unit.Filename)
-			continue
+	if jsonFlag {
+		data, err := json.MarshalIndent(diags, "", "  ")
+		if err != nil {
+			return err
 		}
-
-		unitsInfoMap[unit.Filename] = &quadlet.UnitInfo{
-			ServiceName:       serviceName,
-			ContainersToStart: containers,
-			ResourceName:      resourceName,
+		fmt.Println(string(data))
+	} else {
+		for _, d := range diags {
+			fmt.Println(d.String())
 		}
 	}
 
-	return unitsInfoMap
+	errorCount := 0
+	for _, d := range diags {
+		if d.Severity == quadlet.SeverityError {
+			errorCount++
+		}
+	}
+	if errorCount > 0 {
+		return fmt.Errorf("found %d errors in %d units", errorCount, len(units))
+	}
+	return nil
 }
 
 func main() {
@@ -651,10 +661,14 @@ func process() error {
 		enableDebug()
 	}
 
-	if noKmsgFlag || dryRunFlag {
+	if noKmsgFlag || dryRunFlag || validateFlag {
 		noKmsg = true
 	}
 
+	if validateFlag {
+		return validate(getUnitDirs(isUserFlag))
+	}
+
 	reportError := func(err error) {
 		if prevError != nil {
 			err = fmt.Errorf("%s\n%s", prevError, err)
@@ -712,7 +726,7 @@ func process() error {
 	sort.Slice(units, func(i, j int) bool {
 		getOrder := func(i int) int {
 			ext := filepath.Ext(units[i].Filename)
-			order, ok := supportedExtensions[ext]
+			order, ok := quadlet.SupportedExtensions[ext]
 			if !ok {
 				return 0
 			}
@@ -722,7 +736,7 @@ func process() error {
 	})
 
 	// Generate the PodsInfoMap to allow containers to link to their pods and add themselves to the pod's containers list
-	unitsInfoMap := generateUnitsInfoMap(units)
+	unitsInfoMap := quadlet.GenerateUnitsInfoMap(units)
 
 	for _, unit := range units {
 		var service *parser.UnitFile
@@ -781,4 +795,6 @@ func init() {
 	flag.BoolVar(&isUserFlag, "user", false, "Run as systemd user")
 	flag.BoolVar(&dryRunFlag, "dryrun", false, "Run in dryrun mode printing debug information")
 	flag.BoolVar(&versionFlag, "version", false, "Print version information and exit")
+	flag.BoolVar(&validateFlag, "validate", false, "Validate the units and report all problems found instead of generating services")
+	flag.BoolVar(&jsonFlag, "json", false, "Report the problems found by -validate as JSON")
 }
@@ -74,6 +74,18 @@ type BoltState struct {
 
 // NewBoltState creates a new bolt-backed state database
 func NewBoltState(path string, runtime *Runtime) (State, error) {
+	// To continue testing in CI, allow creation iff an undocumented env
+	// var is set.
+	allowCreate := os.Getenv("CI_DESIRED_DATABASE") == "boltdb"
+	if allowCreate {
+		logrus.Debugf("Allowing deprecated database backend due to CI_DESIRED_DATABASE.")
+	}
+	return newBoltState(path, runtime, allowCreate)
+}
+
+// newBoltState opens the bolt-backed state database at path. The database is
+// only created if it does not exist yet when allowCreate is set.
+func newBoltState(path string, runtime *Runtime, allowCreate bool) (State, error) {
 	logrus.Info("Using boltdb as database backend")
 	state := new(BoltState)
 	state.dbPath = path
@@ -84,14 +96,10 @@ func NewBoltState(path string, runtime *Runtime) (State, error) {
 	// BoltDB is deprecated and, as of Podman 5.0, we no longer allow the
 	// creation of new Bolt states.
 	// If the DB does not already exist, error out.
-	// To continue testing in CI, allow creation iff an undocumented env
-	// var is set.
-	if os.Getenv("CI_DESIRED_DATABASE") != "boltdb" {
+	if !allowCreate {
 		if err := fileutils.Exists(path); err != nil && errors.Is(err, fs.ErrNotExist) {
 			return nil, fmt.Errorf("the BoltDB backend has been deprecated, no new BoltDB databases can be created: %w", define.ErrInvalidArg)
 		}
-	} else {
-		logrus.Debugf("Allowing deprecated database backend due to CI_DESIRED_DATABASE.")
 	}
 
 	db, err := bolt.Open(path, 0600, nil)
@@ -1432,6 +1440,41 @@ func (s *BoltState) GetContainerExitCode(id string) (int32, error) {
 	})
 }
 
+// AllContainerExitCodes returns the exit codes of all containers, including
+// removed ones, by container ID.
+func (s *BoltState) AllContainerExitCodes() (map[string]int32, error) {
+	if !s.valid {
+		return nil, define.ErrDBClosed
+	}
+
+	db, err := s.getDBCon()
+	if err != nil {
+		return nil, err
+	}
+	defer s.deferredCloseDBCon(db)
+
+	exitCodes := make(map[string]int32)
+	err = db.View(func(tx *bolt.Tx) error {
+		exitCodeBucket, err := getExitCodeBucket(tx)
+		if err != nil {
+			return err
+		}
+
+		return exitCodeBucket.ForEach(func(rawID, rawExitCode []byte) error {
+			exitCode, err := strconv.Atoi(string(rawExitCode))
+			if err != nil {
+				return fmt.Errorf("converting raw exit code %v of container %s: %w", rawExitCode, string(rawID), err)
+			}
+			exitCodes[string(rawID)] = int32(exitCode)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return exitCodes, nil
+}
+
 // GetContainerExitCodeTimeStamp returns the time stamp when the exit code of
 // the specified container was added to the database.
 func (s *BoltState) GetContainerExitCodeTimeStamp(id string) (*time.Time, error) {
@@ -3353,6 +3396,56 @@ func (s *BoltState) RemoveContainerFromPod(pod *Pod, ctr *Container) error {
 	return err
 }
 
+// RemoveDanglingPodContainer removes the ID of a container which does not
+// exist in the state from the containers of a pod.
+func (s *BoltState) RemoveDanglingPodContainer(pod *Pod, ctrID string) error {
+	if ctrID == "" {
+		return define.ErrEmptyID
+	}
+
+	if !s.valid {
+		return define.ErrDBClosed
+	}
+
+	if !pod.valid {
+		return define.ErrPodRemoved
+	}
+
+	db, err := s.getDBCon()
+	if err != nil {
+		return err
+	}
+	defer s.deferredCloseDBCon(db)
+
+	return db.Update(func(tx *bolt.Tx) error {
+		ctrBucket, err := getCtrBucket(tx)
+		if err != nil {
+			return err
+		}
+		if ctrBucket.Bucket([]byte(ctrID)) != nil {
+			return fmt.Errorf("container %s of pod %s exists: %w", ctrID, pod.ID(), define.ErrCtrExists)
+		}
+
+		podBkt, err := getPodBucket(tx)
+		if err != nil {
+			return err
+		}
+		podDB := podBkt.Bucket([]byte(pod.ID()))
+		if podDB == nil {
+			pod.valid = false
+			return fmt.Errorf("pod %s not found in database: %w", pod.ID(), define.ErrNoSuchPod)
+		}
+		podCtrs := podDB.Bucket(containersBkt)
+		if podCtrs == nil {
+			return fmt.Errorf("pod %s missing containers bucket in DB: %w", pod.ID(), define.ErrInternal)
+		}
+		if err := podCtrs.Delete([]byte(ctrID)); err != nil {
+			return fmt.Errorf("removing container %s from pod %s: %w", ctrID, pod.ID(), err)
+		}
+		return nil
+	})
+}
+
 // UpdatePod updates a pod's state from the database
 func (s *BoltState) UpdatePod(pod *Pod) error {
 	if !s.valid {
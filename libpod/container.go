@@ -9,6 +9,7 @@ import (
 	"io"
 	"net"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -19,6 +20,7 @@ import (
 	"github.com/containers/image/v5/manifest"
 	"github.com/containers/podman/v5/libpod/define"
 	"github.com/containers/podman/v5/libpod/lock"
+	"github.com/containers/podman/v5/pkg/secretstore"
 	"github.com/containers/storage"
 	spec "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/sirupsen/logrus"
@@ -189,6 +191,9 @@ type ContainerState struct {
 	// StoppedByUser indicates whether the container was stopped by an
 	// explicit call to the Stop() API.
 	StoppedByUser bool `json:"stoppedByUser,omitempty"`
+	// AutoUpdateTag is the image tag auto-update chose for the container
+	// with the registry-semver policy.
+	AutoUpdateTag string `json:"autoUpdateTag,omitempty"`
 	// RestartPolicyMatch indicates whether the conditions for restart
 	// policy have been met.
 	RestartPolicyMatch bool `json:"restartPolicyMatch,omitempty"`
@@ -207,9 +212,20 @@ type ContainerState struct {
 	// healthcheck. The container will be restarted if this exceed a set
 	// number in the startup HC config.
 	StartupHCFailureCount int `json:"startupHCFailureCount,omitempty"`
+	// Ready indicates that the readiness probe of the container passed.
+	Ready bool `json:"ready,omitempty"`
+	// ReadinessSuccessCount indicates the number of consecutive successes
+	// of the readiness probe.
+	ReadinessSuccessCount int `json:"readinessSuccessCount,omitempty"`
+	// ReadinessFailureCount indicates the number of consecutive failures
+	// of the readiness probe.
+	ReadinessFailureCount int `json:"readinessFailureCount,omitempty"`
 	// HCUnitName records the name of the healthcheck unit.
 	// Automatically generated when the healthcheck is started.
 	HCUnitName string `json:"hcUnitName,omitempty"`
+	// ReadinessUnitName records the name of the readiness probe unit.
+	// Automatically generated when the readiness probe is started.
+	ReadinessUnitName string `json:"readinessUnitName,omitempty"`
 
 	// ExtensionStageHooks holds hooks which will be executed by libpod
 	// and not delegated to the OCI runtime.
@@ -280,6 +296,22 @@ type ContainerImageVolume struct {
 	SubPath string `json:"subPath,omitempty"`
 }
 
+// ContainerArtifactVolume is a volume based on an OCI artifact in the local
+// artifact store. The files of the artifact are bind-mounted read-only into
+// the container.
+type ContainerArtifactVolume struct {
+	// Source is the name or digest of the artifact.
+	Source string `json:"source"`
+	// Dest is the absolute path of the mount in the container.
+	Dest string `json:"dest"`
+	// Title selects the file of the artifact with this title, which is
+	// mounted at Dest. Otherwise all files are mounted in Dest.
+	Title string `json:"title,omitempty"`
+	// Digest selects the file of the artifact with this digest, which is
+	// mounted at Dest. Otherwise all files are mounted in Dest.
+	Digest string `json:"digest,omitempty"`
+}
+
 // ContainerSecret is a secret that is mounted in a container
 type ContainerSecret struct {
 	// Secret is the secret
@@ -292,6 +324,30 @@ type ContainerSecret struct {
 	Mode uint32
 	// Secret target inside container
 	Target string
+	// Rotate is set if the secret file is updated in the running container
+	// when a new version of the secret is stored
+	Rotate bool
+	// RotateSignal is the signal sent to the container after the secret
+	// file was updated, 0 to send none
+	RotateSignal uint
+}
+
+// secretIsEncrypted returns true if the secret data is encrypted at rest and
+// must not be copied to the container's static dir.
+func secretIsEncrypted(secr *ContainerSecret) bool {
+	return secr.Driver == "file" && secr.DriverOptions[secretstore.EncryptOption] != ""
+}
+
+// secretSkipsStaticDir returns true if the secret data is not copied to the
+// container's static dir at create time but written on every start.
+func secretSkipsStaticDir(secr *ContainerSecret) bool {
+	return secr.Rotate || secretIsEncrypted(secr)
+}
+
+// encryptedSecretsPath is the dir in the container's run dir where the data
+// of encrypted secrets is decrypted to.
+func (c *Container) encryptedSecretsPath() string {
+	return filepath.Join(c.state.RunDir, "secrets")
 }
 
 // ContainerNetworkDescriptions describes the relationship between the CNI
@@ -430,6 +486,17 @@ func (c *Container) StaticDir() string {
 	return c.config.StaticDir
 }
 
+// ArtifactVolumes returns the container's artifact volumes.
+func (c *Container) ArtifactVolumes() []*ContainerArtifactVolume {
+	volumes := make([]*ContainerArtifactVolume, 0, len(c.config.ArtifactVolumes))
+	for _, vol := range c.config.ArtifactVolumes {
+		newVol := *vol
+		volumes = append(volumes, &newVol)
+	}
+
+	return volumes
+}
+
 // NamedVolumes returns the container's named volumes.
 // The name of each is guaranteed to point to a valid libpod Volume present in
 // the state.
@@ -982,6 +1049,21 @@ func (c *Container) StoppedByUser() (bool, error) {
 	return c.state.StoppedByUser, nil
 }
 
+// AutoUpdateTag returns the image tag auto-update chose for the container
+// with the registry-semver policy, if any.
+func (c *Container) AutoUpdateTag() (string, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return "", err
+		}
+	}
+
+	return c.state.AutoUpdateTag, nil
+}
+
 // StartupHCPassed returns whether the container's startup healthcheck passed.
 func (c *Container) StartupHCPassed() (bool, error) {
 	if !c.batched {
@@ -996,6 +1078,33 @@ func (c *Container) StartupHCPassed() (bool, error) {
 	return c.state.StartupHCPassed, nil
 }
 
+// IsReady returns whether the container is ready.
+// A running container without readiness probe is always ready, a container
+// with a readiness probe is ready once the probe passed.
+func (c *Container) IsReady() (bool, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return false, err
+		}
+	}
+
+	return c.isReady(), nil
+}
+
+// Internal, non-locking function to determine the readiness of the container.
+func (c *Container) isReady() bool {
+	if c.state.State != define.ContainerStateRunning {
+		return false
+	}
+	if c.config.ReadinessProbeConfig == nil {
+		return true
+	}
+	return c.state.Ready
+}
+
 // Misc Accessors
 // Most will require locking
 
@@ -1246,6 +1355,11 @@ func (c *Container) HealthCheckConfig() *manifest.Schema2HealthConfig {
 	return c.config.HealthCheckConfig
 }
 
+// HasReadinessProbe returns whether a readiness probe is defined for the container
+func (c *Container) HasReadinessProbe() bool {
+	return c.config.ReadinessProbeConfig != nil
+}
+
 // AutoRemove indicates whether the container will be removed after it is executed
 func (c *Container) AutoRemove() bool {
 	spec := c.config.Spec
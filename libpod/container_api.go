@@ -112,6 +112,9 @@ func (c *Container) Start(ctx context.Context, recursive bool) (finalErr error)
 	if err := c.start(); err != nil {
 		return err
 	}
+	if err := c.runPostStartHook(); err != nil {
+		return err
+	}
 	return c.waitForHealthy(ctx)
 }
 
@@ -254,6 +257,9 @@ func (c *Container) Attach(ctx context.Context, streams *define.AttachStreams, k
 	}
 
 	if start {
+		if err := c.runPostStartHook(); err != nil {
+			return nil, err
+		}
 		if err := c.waitForHealthy(ctx); err != nil {
 			return nil, err
 		}
@@ -358,6 +364,27 @@ func (c *Container) Kill(signal uint) error {
 	return c.save()
 }
 
+// RotateSecret updates the files of the secret with the given name in the
+// running container if it was mounted with the rotate option and sends the
+// signal configured for it. It returns true if the container got the new
+// secret data.
+func (c *Container) RotateSecret(secretName string) (bool, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return false, err
+		}
+	}
+
+	rotated, err := c.rotateSecret(secretName)
+	if err != nil {
+		return rotated, fmt.Errorf("container %s: %w", c.ID(), err)
+	}
+	return rotated, nil
+}
+
 // HTTPAttach forwards an attach session over a hijacked HTTP session.
 // HTTPAttach will consume and close the included httpCon, which is expected to
 // be sourced from a hijacked HTTP connection.
@@ -720,6 +747,7 @@ func (c *Container) WaitForConditionWithInterval(ctx context.Context, waitTimeou
 	waitForExit := false
 	wantedStates := make(map[define.ContainerStatus]bool, len(conditions))
 	wantedHealthStates := make(map[string]bool)
+	waitForReady := false
 
 	for _, rawCondition := range conditions {
 		switch rawCondition {
@@ -728,6 +756,11 @@ func (c *Container) WaitForConditionWithInterval(ctx context.Context, waitTimeou
 				return -1, fmt.Errorf("cannot use condition %q: container %s has no healthcheck", rawCondition, c.ID())
 			}
 			wantedHealthStates[rawCondition] = true
+		case define.ReadinessReady:
+			if !c.HasReadinessProbe() {
+				return -1, fmt.Errorf("cannot use condition %q: container %s has no readiness probe", rawCondition, c.ID())
+			}
+			waitForReady = true
 		default:
 			condition, err := define.StringToContainerStatus(rawCondition)
 			if err != nil {
@@ -756,7 +789,7 @@ func (c *Container) WaitForConditionWithInterval(ctx context.Context, waitTimeou
 		}()
 	}
 
-	if len(wantedStates) > 0 || len(wantedHealthStates) > 0 {
+	if len(wantedStates) > 0 || len(wantedHealthStates) > 0 || waitForReady {
 		go func() {
 			stoppedCount := 0
 			for {
@@ -778,10 +811,10 @@ func (c *Container) WaitForConditionWithInterval(ctx context.Context, waitTimeou
 						return
 					}
 				}
-				if len(wantedHealthStates) > 0 {
+				if len(wantedHealthStates) > 0 || waitForReady {
 					// even if we are interested only in the health check
-					// check that the container is still running to avoid
-					// waiting until the timeout expires.
+					// or readiness, check that the container is still
+					// running to avoid waiting until the timeout expires.
 					if stoppedCount > 0 {
 						stoppedCount++
 					} else {
@@ -794,14 +827,27 @@ func (c *Container) WaitForConditionWithInterval(ctx context.Context, waitTimeou
 							stoppedCount++
 						}
 					}
-					status, err := c.HealthCheckStatus()
-					if err != nil {
-						trySend(-1, err)
-						return
+					if len(wantedHealthStates) > 0 {
+						status, err := c.HealthCheckStatus()
+						if err != nil {
+							trySend(-1, err)
+							return
+						}
+						if _, found := wantedHealthStates[status]; found {
+							trySend(-1, nil)
+							return
+						}
 					}
-					if _, found := wantedHealthStates[status]; found {
-						trySend(-1, nil)
-						return
+					if waitForReady {
+						ready, err := c.IsReady()
+						if err != nil {
+							trySend(-1, err)
+							return
+						}
+						if ready {
+							trySend(-1, nil)
+							return
+						}
 					}
 					// wait for another waitTimeout interval to give the health check process some time
 					// to record the healthy status.
@@ -1091,6 +1137,10 @@ func (c *Container) Checkpoint(ctx context.Context, options ContainerCheckpointO
 			return nil, 0, err
 		}
 	}
+
+	if c.state.State != define.ContainerStateRunning {
+		return nil, 0, fmt.Errorf("%q is not running, cannot checkpoint: %w", c.state.State, define.ErrCtrStateInvalid)
+	}
 	return c.checkpoint(ctx, options)
 }
 
@@ -1192,6 +1242,25 @@ func (c *Container) Stat(ctx context.Context, containerPath string) (*define.Fil
 	return info, err
 }
 
+// SetAutoUpdateTag records the image tag auto-update chose for the container
+// with the registry-semver policy. The tag is shown by inspect.
+func (c *Container) SetAutoUpdateTag(tag string) error {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return err
+		}
+	}
+
+	if c.state.AutoUpdateTag == tag {
+		return nil
+	}
+	c.state.AutoUpdateTag = tag
+	return c.save()
+}
+
 func saveContainerError(c *Container, err error) error {
 	c.state.Error = err.Error()
 	return c.save()
@@ -162,6 +162,9 @@ type ContainerRootFSConfig struct {
 	// moved out of Libpod into pkg/specgen).
 	// Please DO NOT reuse the `imageVolumes` name in container JSON again.
 	ImageVolumes []*ContainerImageVolume `json:"ctrImageVolumes,omitempty"`
+	// ArtifactVolumes lists the artifact volumes to mount into the
+	// container.
+	ArtifactVolumes []*ContainerArtifactVolume `json:"artifactVolumes,omitempty"`
 	// CreateWorkingDir indicates that Libpod should create the container's
 	// working directory if it does not exist. Some OCI runtimes do this by
 	// default, but others do not.
@@ -375,8 +378,16 @@ type ContainerMiscConfig struct {
 	LogTag string `json:"logTag"`
 	// LogSize is the tag used for logging
 	LogSize int64 `json:"logSize"`
+	// LogMaxFile is the number of log files kept when the log is rotated
+	// after reaching LogSize, including the current one.
+	LogMaxFile uint `json:"logMaxFile,omitempty"`
+	// LogCompress compresses the rotated log files.
+	LogCompress bool `json:"logCompress,omitempty"`
 	// LogDriver driver for logs
 	LogDriver string `json:"logDriver"`
+	// LogOptions are the options of log drivers forwarding logs to a
+	// collector.
+	LogOptions map[string]string `json:"logOptions,omitempty"`
 	// File containing the conmon PID
 	ConmonPidFile string `json:"conmonPidFile,omitempty"`
 	// RestartPolicy indicates what action the container will take upon
@@ -424,6 +435,16 @@ type ContainerMiscConfig struct {
 	// healthcheck for the container. This will run before the regular HC
 	// runs, and when it passes the regular HC will be activated.
 	StartupHealthCheckConfig *define.StartupHealthCheck `json:"startupHealthCheck,omitempty"`
+	// ReadinessProbeConfig is the configuration of the readiness probe of
+	// the container. It is run alongside the regular HC, once the startup
+	// HC (if any) passed, and only toggles the readiness of the container.
+	ReadinessProbeConfig *define.ReadinessProbe `json:"readinessProbe,omitempty"`
+	// PostStartHook is a command executed inside the container right after
+	// it has been started. If it fails, the container is killed.
+	PostStartHook []string `json:"postStartHook,omitempty"`
+	// PreStopHook is a command executed inside the container before it is
+	// stopped. It has to complete within the stop timeout of the container.
+	PreStopHook []string `json:"preStopHook,omitempty"`
 	// PreserveFDs is a number of additional file descriptors (in addition
 	// to 0, 1, 2) that will be passed to the executed process. The total FDs
 	// passed will be 3 + PreserveFDs.
@@ -809,7 +809,71 @@ func (c *Container) exec(config *ExecConfig, streams *define.AttachStreams, resi
 	if err := c.execStartAndAttach(sessionID, streams, size, isHealthcheck); err != nil {
 		return -1, err
 	}
+	return c.execExitCode(sessionID)
+}
+
+// execWithTimeout runs an exec session like exec(), without a terminal. If
+// the session does not complete within timeout, it is stopped and
+// define.ErrExecTimeout is returned once it exited. A timeout of 0 waits for
+// the session to complete.
+// The container must not be locked by the caller unless it is batched.
+func (c *Container) execWithTimeout(config *ExecConfig, streams *define.AttachStreams, timeout time.Duration, isHealthcheck bool) (exitCode int, retErr error) {
+	if timeout == 0 {
+		return c.exec(config, streams, nil, isHealthcheck)
+	}
+	sessionID, err := c.ExecCreate(config)
+	if err != nil {
+		return -1, err
+	}
+	defer func() {
+		if err := c.ExecRemove(sessionID, false); err != nil {
+			if retErr == nil && !errors.Is(err, define.ErrNoSuchExecSession) {
+				exitCode = -1
+				retErr = err
+			}
+		}
+	}()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- c.execStartAndAttach(sessionID, streams, nil, isHealthcheck)
+	}()
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case err := <-done:
+		if err != nil {
+			return -1, err
+		}
+		return c.execExitCode(sessionID)
+	case <-timer.C:
+	}
+
+	stopTimeout := uint(0)
+	for {
+		err := c.ExecStop(sessionID, &stopTimeout)
+		if !errors.Is(err, define.ErrExecSessionStateInvalid) {
+			if err != nil {
+				logrus.Errorf("Stopping container %s exec session %s after timeout: %v", c.ID(), sessionID, err)
+			}
+			break
+		}
+		// The session did not start yet or it exited already.
+		select {
+		case <-done:
+			return -1, fmt.Errorf("exec session %s of container %s exceeded timeout of %s: %w", sessionID, c.ID(), timeout, define.ErrExecTimeout)
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+	// Do not return while the session may still write to the streams.
+	if err := <-done; err != nil {
+		logrus.Debugf("Container %s exec session %s stopped after timeout: %v", c.ID(), sessionID, err)
+	}
+	return -1, fmt.Errorf("exec session %s of container %s exceeded timeout of %s: %w", sessionID, c.ID(), timeout, define.ErrExecTimeout)
+}
 
+// execExitCode returns the exit code of an exec session which completed.
+func (c *Container) execExitCode(sessionID string) (int, error) {
 	session, err := c.execSessionNoCopy(sessionID)
 	if err != nil {
 		if errors.Is(err, define.ErrNoSuchExecSession) {
@@ -5,6 +5,7 @@ package libpod
 import (
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/containers/podman/v5/libpod/define"
@@ -144,6 +145,7 @@ func (c *Container) getContainerInspectData(size bool, driverData *define.Driver
 			CheckpointLog:  runtimeInfo.CheckpointLog,
 			RestoreLog:     runtimeInfo.RestoreLog,
 			StoppedByUser:  c.state.StoppedByUser,
+			AutoUpdateTag:  c.state.AutoUpdateTag,
 		},
 		Image:                   config.RootfsImageID,
 		ImageName:               config.RootfsImageName,
@@ -206,6 +208,11 @@ func (c *Container) getContainerInspectData(size bool, driverData *define.Driver
 		data.State.Health = nil
 	}
 
+	if c.config.ReadinessProbeConfig != nil {
+		ready := c.isReady()
+		data.State.Ready = &ready
+	}
+
 	networkConfig, err := c.getContainerNetworkInfo()
 	if err != nil {
 		return nil, err
@@ -290,6 +297,15 @@ func (c *Container) GetMounts(namedVolumes []*ContainerNamedVolume, imageVolumes
 		inspectMounts = append(inspectMounts, mountStruct)
 	}
 
+	for _, volume := range c.config.ArtifactVolumes {
+		mountStruct := define.InspectMount{}
+		mountStruct.Type = "artifact"
+		mountStruct.Destination = volume.Dest
+		mountStruct.Source = volume.Source
+
+		inspectMounts = append(inspectMounts, mountStruct)
+	}
+
 	for _, mount := range mounts {
 		// It's a mount.
 		// Is it a tmpfs? If so, discard.
@@ -433,6 +449,12 @@ func (c *Container) generateInspectContainerConfig(spec *spec.Spec) *define.Insp
 
 	ctrConfig.Healthcheck = c.config.HealthCheckConfig
 
+	ctrConfig.ReadinessProbe = c.config.ReadinessProbeConfig
+
+	ctrConfig.PostStartHook = c.config.PostStartHook
+
+	ctrConfig.PreStopHook = c.config.PreStopHook
+
 	ctrConfig.HealthcheckOnFailureAction = c.config.HealthCheckOnFailureAction.String()
 
 	ctrConfig.HealthLogDestination = c.config.HealthLogDestination
@@ -510,6 +532,18 @@ func (c *Container) generateInspectContainerHostConfig(ctrSpec *spec.Spec, named
 	logConfig.Path = c.config.LogPath
 	logConfig.Size = units.HumanSize(float64(c.config.LogSize))
 	logConfig.Tag = c.config.LogTag
+	if len(c.config.LogOptions) > 0 || c.config.LogMaxFile > 0 || c.config.LogCompress {
+		logConfig.Config = make(map[string]string, len(c.config.LogOptions)+2)
+		for key, value := range c.config.LogOptions {
+			logConfig.Config[key] = value
+		}
+		if c.config.LogMaxFile > 0 {
+			logConfig.Config["max-file"] = strconv.FormatUint(uint64(c.config.LogMaxFile), 10)
+		}
+		if c.config.LogCompress {
+			logConfig.Config["compress"] = "true"
+		}
+	}
 
 	hostConfig.LogConfig = logConfig
 
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"io"
 	"io/fs"
+	"math"
 	"os"
 	"path/filepath"
 	"slices"
@@ -23,6 +24,7 @@ import (
 	"github.com/containers/buildah/pkg/overlay"
 	butil "github.com/containers/buildah/util"
 	"github.com/containers/common/libnetwork/etchosts"
+	"github.com/containers/common/libnetwork/types"
 	"github.com/containers/common/pkg/cgroups"
 	"github.com/containers/common/pkg/chown"
 	"github.com/containers/common/pkg/config"
@@ -36,6 +38,7 @@ import (
 	"github.com/containers/podman/v5/pkg/ctime"
 	"github.com/containers/podman/v5/pkg/lookup"
 	"github.com/containers/podman/v5/pkg/rootless"
+	"github.com/containers/podman/v5/pkg/secretstore"
 	"github.com/containers/podman/v5/pkg/selinux"
 	"github.com/containers/podman/v5/pkg/systemd/notifyproxy"
 	"github.com/containers/podman/v5/pkg/util"
@@ -280,6 +283,11 @@ func (c *Container) handleRestartPolicy(ctx context.Context) (_ bool, retErr err
 			return false, err
 		}
 	}
+	if c.config.ReadinessProbeConfig != nil {
+		if err := c.removeReadinessTimer(ctx); err != nil {
+			return false, err
+		}
+	}
 
 	// Is the container running again?
 	// If so, we don't have to do anything
@@ -337,6 +345,9 @@ func (c *Container) handleRestartPolicy(ctx context.Context) (_ bool, retErr err
 	if err := c.start(); err != nil {
 		return false, err
 	}
+	if err := c.runPostStartHook(); err != nil {
+		return false, err
+	}
 	return true, c.waitForHealthy(ctx)
 }
 
@@ -655,6 +666,9 @@ func resetContainerState(state *ContainerState) {
 	state.StartupHCPassed = false
 	state.StartupHCSuccessCount = 0
 	state.StartupHCFailureCount = 0
+	state.Ready = false
+	state.ReadinessSuccessCount = 0
+	state.ReadinessFailureCount = 0
 	state.HCUnitName = ""
 	state.NetNS = ""
 	state.NetworkStatus = nil
@@ -839,6 +853,15 @@ func (c *Container) prepareToStart(ctx context.Context, recursive bool) (retErr
 		}
 	}
 
+	if err := c.waitForDependenciesReady(ctx); err != nil {
+		return err
+	}
+	// The container has been unlocked while waiting, make sure nobody
+	// else started it in the meantime.
+	if !c.ensureState(define.ContainerStateConfigured, define.ContainerStateCreated, define.ContainerStateStopped, define.ContainerStateExited) {
+		return fmt.Errorf("container %s changed state to %s while waiting for its dependencies: %w", c.ID(), c.state.State.String(), define.ErrCtrStateInvalid)
+	}
+
 	defer func() {
 		if retErr != nil {
 			if err := c.cleanup(ctx); err != nil {
@@ -932,6 +955,62 @@ func (c *Container) startDependencies(ctx context.Context) error {
 	return nil
 }
 
+// waitForDependenciesReady waits for all dependencies with a readiness probe
+// to turn ready.  Dependencies without a readiness probe are considered ready
+// as soon as they are running.
+// The function unlocks the container lock while waiting, so it must be called
+// from the same thread that locks the container.
+func (c *Container) waitForDependenciesReady(ctx context.Context) error {
+	var notReady []*Container
+	for _, depID := range c.Dependencies() {
+		dep, err := c.runtime.state.Container(depID)
+		if err != nil {
+			return fmt.Errorf("retrieving dependency %s of container %s from state: %w", depID, c.ID(), err)
+		}
+		if !dep.HasReadinessProbe() {
+			continue
+		}
+		// An interval of 0 means that the readiness timer is
+		// disabled, the probe will never run so there is no point
+		// in waiting.
+		if dep.config.ReadinessProbeConfig.Interval <= 0 {
+			logrus.Warnf("Readiness probe of dependency %s of container %s is never run, not waiting for it", dep.ID(), c.ID())
+			continue
+		}
+		notReady = append(notReady, dep)
+	}
+	if len(notReady) == 0 {
+		return nil
+	}
+
+	if !c.batched {
+		c.lock.Unlock()
+		defer func() {
+			c.lock.Lock()
+			if err := c.syncContainer(); err != nil {
+				logrus.Errorf("Syncing container %s state: %v", c.ID(), err)
+			}
+		}()
+	}
+
+	for _, dep := range notReady {
+		logrus.Debugf("Waiting for dependency %s of container %s to be ready", dep.ID(), c.ID())
+		probe := dep.config.ReadinessProbeConfig
+		attempts := max(probe.Retries, 1) + max(probe.Successes, 1)
+		timeout := probe.StartPeriod + time.Duration(attempts)*probe.Interval
+		waitCtx, cancel := context.WithTimeout(ctx, timeout)
+		_, err := dep.WaitForConditionWithInterval(waitCtx, DefaultWaitInterval, define.ReadinessReady)
+		cancel()
+		if err != nil {
+			if errors.Is(err, define.ErrCanceled) && ctx.Err() == nil {
+				return fmt.Errorf("dependency %s of container %s did not turn ready within %s", dep.ID(), c.ID(), timeout)
+			}
+			return fmt.Errorf("waiting for dependency %s of container %s to be ready: %w", dep.ID(), c.ID(), err)
+		}
+	}
+	return nil
+}
+
 // getAllDependencies is a precursor to starting dependencies.
 // To start a container with all of its dependencies, we need to recursively find all dependencies
 // a container has, as well as each of those containers' dependencies, and so on
@@ -1115,6 +1194,9 @@ func (c *Container) init(ctx context.Context, retainRetries bool) error {
 	c.state.StartupHCFailureCount = 0
 	c.state.StartupHCSuccessCount = 0
 	c.state.StartupHCPassed = false
+	c.state.Ready = false
+	c.state.ReadinessSuccessCount = 0
+	c.state.ReadinessFailureCount = 0
 
 	if !retainRetries {
 		c.state.RestartCount = 0
@@ -1141,6 +1223,11 @@ func (c *Container) init(ctx context.Context, retainRetries bool) error {
 			logrus.Error(err)
 		}
 	}
+	if c.config.ReadinessProbeConfig != nil {
+		if err := c.createReadinessTimer(); err != nil {
+			logrus.Error(err)
+		}
+	}
 
 	defer c.newContainerEvent(events.Init)
 	return c.completeNetworkSetup()
@@ -1257,6 +1344,9 @@ func (c *Container) initAndStart(ctx context.Context) (retErr error) {
 	if err := c.start(); err != nil {
 		return err
 	}
+	if err := c.runPostStartHook(); err != nil {
+		return err
+	}
 	return c.waitForHealthy(ctx)
 }
 
@@ -1266,7 +1356,12 @@ func (c *Container) start() error {
 		logrus.Debugf("Starting container %s with command %v", c.ID(), c.config.Spec.Process.Args)
 	}
 
+	logHelpers, err := c.startLogHelpers()
+	if err != nil {
+		return err
+	}
 	if err := c.ociRuntime.StartContainer(c); err != nil {
+		c.killLogHelpers(logHelpers)
 		return err
 	}
 	logrus.Debugf("Started container %s", c.ID())
@@ -1299,6 +1394,11 @@ func (c *Container) start() error {
 			logrus.Error(err)
 		}
 	}
+	if c.config.ReadinessProbeConfig != nil {
+		if err := c.startReadinessTimer(); err != nil {
+			logrus.Error(err)
+		}
+	}
 
 	c.newContainerEvent(events.Start)
 
@@ -1337,6 +1437,89 @@ func (c *Container) waitForHealthy(ctx context.Context) error {
 	return nil
 }
 
+// runPostStartHook executes the postStart hook of the container, if any.
+// If the hook fails, the container is killed and an error is returned.
+// The function unlocks the container lock, so it must be called from the same
+// thread that locks the container.
+func (c *Container) runPostStartHook() error {
+	if len(c.config.PostStartHook) == 0 {
+		return nil
+	}
+
+	hookErr := c.runLifecycleHook("postStart", c.config.PostStartHook, 0)
+	if hookErr == nil {
+		return nil
+	}
+
+	if c.state.State == define.ContainerStateRunning {
+		if err := c.ociRuntime.KillContainer(c, uint(unix.SIGKILL), false); err != nil {
+			logrus.Errorf("Killing container %s after postStart hook failure: %v", c.ID(), err)
+		}
+	}
+	return hookErr
+}
+
+// runPreStopHook executes the preStop hook of the container, if any.
+// Failures are only logged as the container is stopped regardless.
+// As the termination grace period in Kubernetes, the stop timeout includes
+// the time taken by the hook: the returned timeout is what remains of it to
+// stop the container. A timeout of 0 kills the container without running the
+// hook.
+// The function unlocks the container lock, so it must be called from the same
+// thread that locks the container.
+func (c *Container) runPreStopHook(timeout uint) uint {
+	if len(c.config.PreStopHook) == 0 || c.state.State != define.ContainerStateRunning || timeout == 0 {
+		return timeout
+	}
+
+	start := time.Now()
+	if err := c.runLifecycleHook("preStop", c.config.PreStopHook, time.Duration(timeout)*time.Second); err != nil {
+		logrus.Warnf("%v", err)
+	}
+	elapsed := uint(math.Ceil(time.Since(start).Seconds()))
+	if elapsed >= timeout {
+		return 0
+	}
+	return timeout - elapsed
+}
+
+// runLifecycleHook executes the given command inside the container and
+// returns an error if it could not be run or exited non-zero.  A timeout of
+// 0 waits for the command to complete.
+// The function unlocks the container lock, so it must be called from the same
+// thread that locks the container.
+func (c *Container) runLifecycleHook(name string, command []string, timeout time.Duration) error {
+	logrus.Debugf("Running %s hook %v of container %s", name, command, c.ID())
+
+	if !c.batched {
+		if err := c.save(); err != nil {
+			return err
+		}
+		c.lock.Unlock()
+		defer func() {
+			c.lock.Lock()
+			if err := c.syncContainer(); err != nil {
+				logrus.Errorf("Syncing container %s state: %v", c.ID(), err)
+			}
+		}()
+	}
+
+	output := &bytes.Buffer{}
+	config := new(ExecConfig)
+	config.Command = command
+	exitCode, err := c.execWithTimeout(config, healthCheckStreams(output), timeout, false)
+	if errors.Is(err, define.ErrExecTimeout) {
+		return fmt.Errorf("%s hook of container %s did not complete within %s", name, c.ID(), timeout)
+	}
+	if err != nil {
+		return fmt.Errorf("running %s hook of container %s: %w", name, c.ID(), err)
+	}
+	if exitCode != 0 {
+		return fmt.Errorf("%s hook of container %s exited with code %d: %s", name, c.ID(), exitCode, strings.TrimSpace(output.String()))
+	}
+	return nil
+}
+
 // Whether a container should use `all` when stopping
 func (c *Container) stopWithAll() (bool, error) {
 	// If the container is running in a PID Namespace, then killing the
@@ -1387,6 +1570,14 @@ func (c *Container) stop(timeout uint) error {
 		cannotStopErr = fmt.Errorf("can only stop created or running containers. %s is in state %s: %w", c.ID(), c.state.State.String(), define.ErrCtrStateInvalid)
 	}
 
+	if cannotStopErr == nil && c.state.State == define.ContainerStateRunning {
+		timeout = c.runPreStopHook(timeout)
+		// The hook unlocked the container, it may have exited meanwhile.
+		if c.ensureState(define.ContainerStateStopped, define.ContainerStateExited) {
+			cannotStopErr = define.ErrCtrStopped
+		}
+	}
+
 	c.state.StoppedByUser = true
 	if cannotStopErr == nil {
 		// Set the container state to "stopping" and unlock the container
@@ -1597,6 +1788,11 @@ func (c *Container) restartWithTimeout(ctx context.Context, timeout uint) (retEr
 				logrus.Error(err.Error())
 			}
 		}
+		if c.config.ReadinessProbeConfig != nil {
+			if err := c.removeReadinessTimer(context.Background()); err != nil {
+				logrus.Error(err.Error())
+			}
+		}
 		// Ensure we tear down the container network so it will be
 		// recreated - otherwise, behavior of restart differs from stop
 		// and start
@@ -1630,6 +1826,9 @@ func (c *Container) restartWithTimeout(ctx context.Context, timeout uint) (retEr
 	if err := c.start(); err != nil {
 		return err
 	}
+	if err := c.runPostStartHook(); err != nil {
+		return err
+	}
 	return c.waitForHealthy(ctx)
 }
 
@@ -2068,12 +2267,28 @@ func (c *Container) cleanup(ctx context.Context) error {
 			logrus.Errorf("Removing timer for container %s healthcheck: %v", c.ID(), err)
 		}
 	}
+	if c.config.ReadinessProbeConfig != nil {
+		if err := c.removeReadinessTimer(ctx); err != nil {
+			logrus.Errorf("Removing timer for container %s readiness probe: %v", c.ID(), err)
+		}
+	}
+
+	// Let the log helpers handle the last lines of the log
+	c.waitLogHelpers()
 
 	// Clean up network namespace, if present
 	if err := c.cleanupNetwork(); err != nil {
 		lastError = fmt.Errorf("removing container %s network: %w", c.ID(), err)
 	}
 
+	// Remove the decrypted data of encrypted secrets
+	if c.state.RunDir != "" {
+		if err := os.RemoveAll(c.encryptedSecretsPath()); err != nil {
+			logrus.Errorf("Removing decrypted secrets of container %s: %v", c.ID(), err)
+		}
+		c.removeRotatedSecrets()
+	}
+
 	// cleanup host entry if it is shared
 	if c.config.NetNsCtr != "" {
 		if hoststFile, ok := c.state.BindMounts[config.DefaultHostsFile]; ok {
@@ -2517,12 +2732,12 @@ func (c *Container) canWithPrevious() error {
 	return fileutils.Exists(c.PreCheckPointPath())
 }
 
-// prepareCheckpointExport writes the config and spec to
-// JSON files for later export
-func (c *Container) prepareCheckpointExport() error {
+// checkpointNetworks returns the networks of the container as stored in the
+// database, for inclusion in an exported checkpoint.
+func (c *Container) checkpointNetworks() (map[string]types.PerNetworkOptions, error) {
 	networks, err := c.networks()
 	if err != nil {
-		return err
+		return nil, err
 	}
 	// make sure to exclude the short ID alias since the container gets a new ID on restore
 	for net, opts := range networks {
@@ -2535,6 +2750,16 @@ func (c *Container) prepareCheckpointExport() error {
 		opts.Aliases = newAliases
 		networks[net] = opts
 	}
+	return networks, nil
+}
+
+// prepareCheckpointExport writes the config and spec to
+// JSON files for later export
+func (c *Container) prepareCheckpointExport() error {
+	networks, err := c.checkpointNetworks()
+	if err != nil {
+		return err
+	}
 
 	// add the networks from the db to the config so that the exported checkpoint still stores all current networks
 	c.config.Networks = networks
@@ -2575,12 +2800,20 @@ func (c *Container) SortUserVolumes(ctrSpec *spec.Spec) ([]*ContainerNamedVolume
 	for _, mount := range ctrSpec.Mounts {
 		mounts[mount.Destination] = mount
 	}
+	// Artifact volumes are only added to the spec when the container
+	// starts and are listed separately.
+	artifactVolumes := make(map[string]bool)
+	for _, artifactVol := range c.config.ArtifactVolumes {
+		artifactVolumes[artifactVol.Dest] = true
+	}
 
 	for _, vol := range c.config.UserVolumes {
 		if volume, ok := namedVolumes[vol]; ok {
 			namedUserVolumes = append(namedUserVolumes, volume)
 		} else if mount, ok := mounts[vol]; ok {
 			userMounts = append(userMounts, mount)
+		} else if artifactVolumes[vol] {
+			continue
 		} else {
 			logrus.Warnf("Could not find mount at destination %q when parsing user volumes for container %s", vol, c.ID())
 		}
@@ -2632,8 +2865,35 @@ func (c *Container) hasNamespace(namespace spec.LinuxNamespaceType) bool {
 	return false
 }
 
-// extractSecretToCtrStorage copies a secret's data from the secrets manager to the container's static dir
-func (c *Container) extractSecretToCtrStorage(secr *ContainerSecret) error {
+// validateSecretsRestartPolicy returns an error if the given restart policy
+// restarts the container and the container uses a secret encrypted with a key
+// from the environment. The processes restarting the container, such as the
+// cleanup process, do not have the key.
+func (c *Container) validateSecretsRestartPolicy(policy string) error {
+	if policy == define.RestartPolicyNone || policy == define.RestartPolicyNo || len(c.config.Secrets) == 0 {
+		return nil
+	}
+	manager, err := c.runtime.SecretsManager()
+	if err != nil {
+		return err
+	}
+	for _, secr := range c.config.Secrets {
+		secret, err := manager.Lookup(secr.Name)
+		if err != nil {
+			return err
+		}
+		if secretstore.KeyFromEnvironment(secret.Driver, secret.DriverOptions) {
+			return fmt.Errorf("secret %s is encrypted with a key from the environment, which is not available when the container is restarted by restart policy %s: %w", secr.Name, policy, define.ErrInvalidArg)
+		}
+	}
+	return nil
+}
+
+// extractSecretToCtrStorage copies a secret's data from the secrets manager to
+// the given file, which is in the container's static dir or for encrypted and
+// rotated secrets in the container's run dir. The file is replaced
+// atomically, so a running container never sees partial data.
+func (c *Container) extractSecretToCtrStorage(secr *ContainerSecret, secretFile string) (retErr error) {
 	manager, err := c.runtime.SecretsManager()
 	if err != nil {
 		return err
@@ -2642,26 +2902,37 @@ func (c *Container) extractSecretToCtrStorage(secr *ContainerSecret) error {
 	if err != nil {
 		return err
 	}
-	secretFile := filepath.Join(c.config.SecretsPath, secr.Name)
 
 	hostUID, hostGID, err := butil.GetHostIDs(util.IDtoolsToRuntimeSpec(c.config.IDMappings.UIDMap), util.IDtoolsToRuntimeSpec(c.config.IDMappings.GIDMap), secr.UID, secr.GID)
 	if err != nil {
 		return fmt.Errorf("unable to extract secret: %w", err)
 	}
-	err = os.WriteFile(secretFile, data, 0644)
+	tmpFile, err := os.CreateTemp(filepath.Dir(secretFile), ".secret-")
+	if err != nil {
+		return fmt.Errorf("unable to create %s: %w", secretFile, err)
+	}
+	defer func() {
+		if retErr != nil {
+			_ = os.Remove(tmpFile.Name())
+		}
+	}()
+	_, err = tmpFile.Write(data)
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
+	}
 	if err != nil {
 		return fmt.Errorf("unable to create %s: %w", secretFile, err)
 	}
-	if err := idtools.SafeLchown(secretFile, int(hostUID), int(hostGID)); err != nil {
+	if err := idtools.SafeLchown(tmpFile.Name(), int(hostUID), int(hostGID)); err != nil {
 		return err
 	}
-	if err := os.Chmod(secretFile, os.FileMode(secr.Mode)); err != nil {
+	if err := os.Chmod(tmpFile.Name(), os.FileMode(secr.Mode)); err != nil {
 		return err
 	}
-	if err := c.relabel(secretFile, c.config.MountLabel, false); err != nil {
+	if err := c.relabel(tmpFile.Name(), c.config.MountLabel, false); err != nil {
 		return err
 	}
-	return nil
+	return os.Rename(tmpFile.Name(), secretFile)
 }
 
 // Update a container's resources or restart policy after creation.
@@ -2693,6 +2964,9 @@ func (c *Container) update(resources *spec.LinuxResources, restartPolicy *string
 				return fmt.Errorf("cannot set restart policy retries unless policy is on-failure: %w", define.ErrInvalidArg)
 			}
 		}
+		if err := c.validateSecretsRestartPolicy(*restartPolicy); err != nil {
+			return err
+		}
 
 		c.config.RestartPolicy = *restartPolicy
 		if restartRetries != nil {
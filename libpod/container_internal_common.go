@@ -42,6 +42,7 @@ import (
 	"github.com/containers/podman/v5/pkg/annotations"
 	"github.com/containers/podman/v5/pkg/checkpoint/crutils"
 	"github.com/containers/podman/v5/pkg/criu"
+	"github.com/containers/podman/v5/pkg/libartifact"
 	"github.com/containers/podman/v5/pkg/lookup"
 	"github.com/containers/podman/v5/pkg/rootless"
 	"github.com/containers/podman/v5/pkg/util"
@@ -497,6 +498,42 @@ func (c *Container) generateSpec(ctx context.Context) (s *spec.Spec, cleanupFunc
 		g.AddMount(overlayMount)
 	}
 
+	if len(c.config.ArtifactVolumes) > 0 {
+		artStore, err := c.runtime.ArtifactStore()
+		if err != nil {
+			return nil, nil, err
+		}
+		// Bind mount the blobs of the artifacts read-only
+		for _, volume := range c.config.ArtifactVolumes {
+			extractOpts := &libartifact.ExtractOptions{
+				Title:  volume.Title,
+				Digest: volume.Digest,
+			}
+			mountPaths, err := artStore.BlobMountPaths(ctx, volume.Source, extractOpts)
+			if err != nil {
+				return nil, nil, fmt.Errorf("creating artifact volume %q:%q: %w", volume.Source, volume.Dest, err)
+			}
+			// A selected file is mounted at the destination, all files
+			// are mounted in the destination directory
+			singleFile := volume.Title != "" || volume.Digest != ""
+			if singleFile && len(mountPaths) != 1 {
+				return nil, nil, fmt.Errorf("creating artifact volume %q:%q: %d files match the given digest or title", volume.Source, volume.Dest, len(mountPaths))
+			}
+			for _, mountPath := range mountPaths {
+				dest := volume.Dest
+				if !singleFile {
+					dest = filepath.Join(volume.Dest, mountPath.Name)
+				}
+				g.AddMount(spec.Mount{
+					Destination: dest,
+					Type:        define.TypeBind,
+					Source:      mountPath.SourcePath,
+					Options:     []string{define.TypeBind, "ro", "private"},
+				})
+			}
+		}
+	}
+
 	err = c.setHomeEnvIfNeeded()
 	if err != nil {
 		return nil, nil, err
@@ -1095,7 +1132,8 @@ func (c *Container) exportCheckpoint(options ContainerCheckpointOptions) error {
 	}
 
 	if c.LogDriver() == define.KubernetesLogging ||
-		c.LogDriver() == define.JSONLogging {
+		c.LogDriver() == define.JSONLogging ||
+		define.IsForwardingLogDriver(c.LogDriver()) {
 		includeFiles = append(includeFiles, "ctr.log")
 	}
 	if options.PreCheckPoint {
@@ -1220,7 +1258,8 @@ func (c *Container) checkpoint(ctx context.Context, options ContainerCheckpointO
 		return nil, 0, err
 	}
 
-	if c.state.State != define.ContainerStateRunning {
+	// Containers of a pod are paused while they are checkpointed together.
+	if !c.ensureState(define.ContainerStateRunning, define.ContainerStatePaused) {
 		return nil, 0, fmt.Errorf("%q is not running, cannot checkpoint: %w", c.state.State, define.ErrCtrStateInvalid)
 	}
 
@@ -2092,7 +2131,34 @@ rootless=%d
 					base = ""
 				}
 			}
+			if secret.Rotate {
+				// Rotated secrets are written to the dir mounted at
+				// /run/secrets instead of being bind mounted, so
+				// RotateSecret can replace them atomically.
+				secretFile, err := c.rotatedSecretPath(secret)
+				if err != nil {
+					return err
+				}
+				if err := os.MkdirAll(filepath.Dir(secretFile), 0o755); err != nil {
+					return err
+				}
+				if err := c.extractSecretToCtrStorage(secret, secretFile); err != nil {
+					return fmt.Errorf("writing secret %s: %w", secret.Name, err)
+				}
+				continue
+			}
 			src := filepath.Join(c.config.SecretsPath, secret.Name)
+			if secretIsEncrypted(secret) {
+				// Encrypted secrets are decrypted to the run dir on every
+				// start and removed again on cleanup.
+				if err := os.MkdirAll(c.encryptedSecretsPath(), 0o700); err != nil {
+					return err
+				}
+				src = filepath.Join(c.encryptedSecretsPath(), secret.Name)
+				if err := c.extractSecretToCtrStorage(secret, src); err != nil {
+					return fmt.Errorf("decrypting secret %s: %w", secret.Name, err)
+				}
+			}
 			dest := filepath.Join(base, secretFileName)
 			c.state.BindMounts[dest] = src
 		}
@@ -2897,6 +2963,71 @@ func (c *Container) createSecretMountDir(runPath string) error {
 	return err
 }
 
+// rotatedSecretPath returns the path on the host of a secret mounted with the
+// rotate option, which is written to the dir mounted at /run/secrets.
+func (c *Container) rotatedSecretPath(secret *ContainerSecret) (string, error) {
+	runPath, err := c.getPlatformRunPath()
+	if err != nil {
+		return "", err
+	}
+	dir, ok := c.state.BindMounts[filepath.Join(runPath, "secrets")]
+	if !ok {
+		dir = filepath.Join(c.state.RunDir, "/run/secrets")
+	}
+	target := secret.Name
+	if secret.Target != "" {
+		target = secret.Target
+	}
+	return securejoin.SecureJoin(dir, target)
+}
+
+// removeRotatedSecrets removes the files of secrets mounted with the rotate
+// option, they are written again on the next start.
+func (c *Container) removeRotatedSecrets() {
+	for _, secret := range c.config.Secrets {
+		if !secret.Rotate {
+			continue
+		}
+		secretFile, err := c.rotatedSecretPath(secret)
+		if err == nil {
+			err = os.Remove(secretFile)
+		}
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			logrus.Errorf("Removing secret %s of container %s: %v", secret.Name, c.ID(), err)
+		}
+	}
+}
+
+// rotateSecret writes the current data of the secret to the secret files of
+// the running container which use the rotate option and sends the configured
+// signal. It returns true if a secret file was updated.
+func (c *Container) rotateSecret(secretName string) (bool, error) {
+	// stopped containers get the current data on the next start
+	if !c.ensureState(define.ContainerStateRunning, define.ContainerStatePaused) {
+		return false, nil
+	}
+	rotated := false
+	for _, secret := range c.config.Secrets {
+		if secret.Name != secretName || !secret.Rotate {
+			continue
+		}
+		secretFile, err := c.rotatedSecretPath(secret)
+		if err != nil {
+			return rotated, err
+		}
+		if err := c.extractSecretToCtrStorage(secret, secretFile); err != nil {
+			return rotated, fmt.Errorf("rotating secret %s: %w", secret.Name, err)
+		}
+		rotated = true
+		if secret.RotateSignal != 0 {
+			if err := c.ociRuntime.KillContainer(c, secret.RotateSignal, false); err != nil {
+				return rotated, fmt.Errorf("signaling rotation of secret %s: %w", secret.Name, err)
+			}
+		}
+	}
+	return rotated, nil
+}
+
 func hasIdmapOption(options []string) bool {
 	for _, o := range options {
 		if o == "idmap" || strings.HasPrefix(o, "idmap=") {
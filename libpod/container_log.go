@@ -3,25 +3,34 @@
 package libpod
 
 import (
+	"bufio"
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"os"
+	"os/exec"
+	"path/filepath"
+	"syscall"
+	"text/template"
 	"time"
 
 	"github.com/containers/podman/v5/libpod/define"
 	"github.com/containers/podman/v5/libpod/logs"
+	"github.com/containers/podman/v5/pkg/specgenutil"
 	systemdDefine "github.com/containers/podman/v5/pkg/systemd/define"
+	"github.com/containers/storage/pkg/fileutils"
 	"github.com/nxadm/tail"
 	"github.com/nxadm/tail/watch"
 	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
 )
 
 // logDrivers stores the currently available log drivers, do not modify
 var logDrivers []string
 
 func init() {
-	logDrivers = append(logDrivers, define.KubernetesLogging, define.NoLogging, define.PassthroughLogging)
+	logDrivers = append(logDrivers, define.KubernetesLogging, define.NoLogging, define.PassthroughLogging, define.SyslogLogging, define.GELFLogging, define.FluentdLogging)
 }
 
 // Log is a runtime function that can read one or more container logs.
@@ -51,13 +60,274 @@ func (c *Container) ReadLog(ctx context.Context, options *logs.LogOptions, logCh
 		// TODO provide a separate implementation of this when Conmon
 		// has support.
 		fallthrough
-	case define.KubernetesLogging, "":
+	case define.KubernetesLogging, define.SyslogLogging, define.GELFLogging, define.FluentdLogging, "":
+		// The forwarding log drivers keep a local copy of the log for podman logs.
 		return c.readFromLogFile(ctx, options, logChannel, colorID)
 	default:
 		return fmt.Errorf("unrecognized log driver %q, cannot read logs: %w", c.LogDriver(), define.ErrInternal)
 	}
 }
 
+const (
+	// logHelperStartTimeout is the time a log helper is given to follow
+	// the log file before starting the container fails.
+	logHelperStartTimeout = 10 * time.Second
+
+	// logHelperStopWait is the time the container cleanup waits for the
+	// log helpers to exit. Helpers still sending the last lines keep running
+	// on their own, they exit once they are done.
+	logHelperStopWait = 2 * time.Second
+
+	// logHelperPollInterval is the interval at which a log helper checks
+	// whether conmon exited when pidfds are not supported.
+	logHelperPollInterval = 100 * time.Millisecond
+)
+
+// logHelper is a log helper process started for a container.
+type logHelper struct {
+	name string
+	cmd  *exec.Cmd
+	// done is closed once the process exited.
+	done chan struct{}
+}
+
+// startLogHelpers starts the processes forwarding and rotating the log of the
+// container, as its log options require. They follow the log file before the
+// container is started and exit once conmon does, so that no line is lost.
+// The returned helpers must be killed with killLogHelpers() if the container
+// is not started.
+func (c *Container) startLogHelpers() ([]*logHelper, error) {
+	// Handle the helpers of a previous run which was not cleaned up.
+	c.waitLogHelpers()
+
+	var helpers []*logHelper
+	if define.IsForwardingLogDriver(c.LogDriver()) {
+		tag, err := c.logTag()
+		if err != nil {
+			return nil, fmt.Errorf("forwarding logs of container %s: %w", c.ID(), err)
+		}
+		helper, err := c.startLogHelper("logforward", "--tag="+tag)
+		if err != nil {
+			return nil, fmt.Errorf("forwarding logs of container %s: %w", c.ID(), err)
+		}
+		helpers = append(helpers, helper)
+	}
+	if c.config.LogMaxFile > 1 {
+		helper, err := c.startLogHelper("logrotate")
+		if err != nil {
+			c.killLogHelpers(helpers)
+			return nil, fmt.Errorf("rotating logs of container %s: %w", c.ID(), err)
+		}
+		helpers = append(helpers, helper)
+	}
+	return helpers, nil
+}
+
+// startLogHelper starts a podman process running the given container
+// subcommand for the container, to forward or rotate its log. It returns once
+// the process follows the log file, which it signals by writing a line to its
+// stdout.
+func (c *Container) startLogHelper(name string, args ...string) (*logHelper, error) {
+	command, err := specgenutil.CreatePodmanCommandArgs(c.runtime.storageConfig, c.runtime.config, true)
+	if err != nil {
+		return nil, fmt.Errorf("creating log helper command of container %s: %w", c.ID(), err)
+	}
+	command = append(command, "container", name)
+	command = append(command, args...)
+	command = append(command, c.ID())
+
+	logrus.Debugf("Starting log helper of container %s: %v", c.ID(), command)
+	cmd := exec.Command(command[0], command[1:]...)
+	// Detach from the terminal, the helper outlives podman run.
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Setsid: true,
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("starting log helper of container %s: %w", c.ID(), err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("starting log helper of container %s: %w", c.ID(), err)
+	}
+	helper := &logHelper{
+		name: name,
+		cmd:  cmd,
+		done: make(chan struct{}),
+	}
+	go func() {
+		if err := cmd.Wait(); err != nil {
+			logrus.Debugf("Log helper %s of container %s: %v", name, c.ID(), err)
+		}
+		close(helper.done)
+	}()
+
+	ready := make(chan error, 1)
+	go func() {
+		_, err := bufio.NewReader(stdout).ReadString('\n')
+		ready <- err
+	}()
+	timer := time.NewTimer(logHelperStartTimeout)
+	defer timer.Stop()
+	select {
+	case err = <-ready:
+		if err != nil {
+			err = fmt.Errorf("log helper %s of container %s exited before following the log", name, c.ID())
+		}
+	case <-timer.C:
+		err = fmt.Errorf("log helper %s of container %s did not follow the log within %s", name, c.ID(), logHelperStartTimeout)
+	}
+	if err != nil {
+		c.killLogHelpers([]*logHelper{helper})
+		return nil, err
+	}
+	return helper, nil
+}
+
+// killLogHelpers kills the log helpers of a container that was not started
+// and waits for them to exit.
+func (c *Container) killLogHelpers(helpers []*logHelper) {
+	for _, helper := range helpers {
+		if err := helper.cmd.Process.Kill(); err != nil && !errors.Is(err, os.ErrProcessDone) {
+			logrus.Debugf("Killing log helper %s of container %s: %v", helper.name, c.ID(), err)
+		}
+		<-helper.done
+		if err := os.Remove(c.logHelperLockPath(helper.name)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			logrus.Errorf("Removing log helper lock of container %s: %v", c.ID(), err)
+		}
+	}
+}
+
+// logHelperLockPath is the file the log helper name of the container keeps
+// locked while it runs. The helper removes it once it handled the whole log,
+// so a lock file that is not locked was left by a helper which died.
+func (c *Container) logHelperLockPath(name string) string {
+	return filepath.Join(c.config.StaticDir, name+".lock")
+}
+
+// runLogHelper runs the log helper name of the container in the helper
+// process. run is passed a context which is canceled once conmon exits, when
+// the container wrote the last line of its log.
+func (c *Container) runLogHelper(ctx context.Context, name string, run func(ctx context.Context) error) error {
+	if c.state.ConmonPID == 0 {
+		return fmt.Errorf("container %s has no conmon process: %w", c.ID(), define.ErrCtrStateInvalid)
+	}
+	conmonPidFd := c.getConmonPidFd()
+	if conmonPidFd > -1 {
+		defer unix.Close(conmonPidFd)
+	}
+
+	lock, err := lockLogHelper(c.logHelperLockPath(name))
+	if err != nil {
+		return fmt.Errorf("locking log helper %s of container %s: %w", name, c.ID(), err)
+	}
+	defer lock.Close()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		if err := waitForConmonExit(ctx, c.state.ConmonPID, conmonPidFd, logHelperPollInterval); err == nil {
+			cancel()
+		}
+	}()
+	if err := run(ctx); err != nil {
+		return err
+	}
+	return os.Remove(lock.Name())
+}
+
+// lockLogHelper creates and locks the log helper lock file at path. It waits
+// for a helper of a previous run which still handles the log.
+func lockLogHelper(path string) (*os.File, error) {
+	for {
+		f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o600)
+		if err != nil {
+			return nil, err
+		}
+		if err := unix.Flock(int(f.Fd()), unix.LOCK_EX); err != nil {
+			f.Close()
+			return nil, err
+		}
+		// The previous helper removes the file once it is done,
+		// make sure the locked file is still in place.
+		locked, err := f.Stat()
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+		current, err := os.Stat(path)
+		if err == nil && os.SameFile(locked, current) {
+			return f, nil
+		}
+		f.Close()
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+	}
+}
+
+// waitLogHelpers waits for the log helpers of the container to handle the
+// last lines of the log, they exit on their own once conmon exited. As it runs
+// with the container locked, it only waits logHelperStopWait for them. The
+// lines a forwarding helper which died did not forward are forwarded now.
+func (c *Container) waitLogHelpers() {
+	for _, name := range []string{"logforward", "logrotate"} {
+		path := c.logHelperLockPath(name)
+		f, err := os.Open(path)
+		if err != nil {
+			if !errors.Is(err, os.ErrNotExist) {
+				logrus.Errorf("Opening log helper lock of container %s: %v", c.ID(), err)
+			}
+			continue
+		}
+		deadline := time.Now().Add(logHelperStopWait)
+		for {
+			err = unix.Flock(int(f.Fd()), unix.LOCK_EX|unix.LOCK_NB)
+			if err != unix.EWOULDBLOCK || time.Now().After(deadline) {
+				break
+			}
+			time.Sleep(50 * time.Millisecond)
+		}
+		if err != nil {
+			f.Close()
+			if err == unix.EWOULDBLOCK {
+				logrus.Debugf("Log helper %s of container %s is still handling the log, not waiting for it", name, c.ID())
+			} else {
+				logrus.Errorf("Locking log helper lock of container %s: %v", c.ID(), err)
+			}
+			continue
+		}
+		if err := fileutils.Exists(path); err != nil {
+			// The helper handled the whole log.
+			f.Close()
+			continue
+		}
+		logrus.Errorf("Log helper %s of container %s exited before the container", name, c.ID())
+		if name == "logforward" {
+			if err := c.forwardMissedLogs(); err != nil {
+				logrus.Errorf("Forwarding logs of container %s: %v", c.ID(), err)
+			}
+		}
+		if err := os.Remove(path); err != nil {
+			logrus.Errorf("Removing log helper lock of container %s: %v", c.ID(), err)
+		}
+		f.Close()
+	}
+}
+
+// formatLogTag expands the log tag template of a container.
+func formatLogTag(logTag string, data *define.InspectContainerData) (string, error) {
+	tmpl, err := template.New("container").Parse(logTag)
+	if err != nil {
+		return "", fmt.Errorf("template parsing error %s: %w", logTag, err)
+	}
+	var b bytes.Buffer
+	err = tmpl.Execute(&b, data)
+	if err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
 func (c *Container) readFromLogFile(ctx context.Context, options *logs.LogOptions, logChannel chan *logs.LogLine, colorID int64) error {
 	t, tailLog, err := logs.GetLogFile(c.LogPath(), options)
 	if err != nil {
@@ -78,6 +348,22 @@ func (c *Container) readFromLogFile(ctx context.Context, options *logs.LogOption
 	}()
 
 	go func() {
+		// With a tail, GetLogFile already read the last lines backwards
+		// across the rotated log files.
+		if options.Tail < 0 {
+			// All lines were requested, start with the rotated log files.
+			err := logs.ReadRotatedLogFiles(c.LogPath(), func(nll *logs.LogLine) {
+				nll.CID = c.ID()
+				nll.CName = c.Name()
+				nll.ColorID = colorID
+				if nll.Since(options.Since) && nll.Until(options.Until) {
+					logChannel <- nll
+				}
+			})
+			if err != nil {
+				logrus.Errorf("Reading rotated log files of container %s: %v", c.ID(), err)
+			}
+		}
 		for _, nll := range tailLog {
 			nll.CID = c.ID()
 			nll.CName = c.Name()
@@ -0,0 +1,205 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/sirupsen/logrus"
+)
+
+// logFollower follows the log file of a container across the generations
+// conmon creates when the log reaches its maximum size. conmon writes the next
+// generation to a temporary file, which it then renames over the log file.
+// Each generation is opened as soon as conmon creates it and read to its end
+// from the offset reached before the next one, so that no line is lost when
+// conmon replaces the log file while the follower is behind.
+type logFollower struct {
+	path    string
+	tmpPath string
+	watcher *fsnotify.Watcher
+	// current is the generation being read, pending the next generation
+	// if it was opened when conmon created it.
+	current *os.File
+	pending *os.File
+	reader  *bufio.Reader
+	// partial is the start of a line conmon did not finish writing yet.
+	partial string
+	// line is called with each line of the log, if set.
+	line func(string)
+	// rotated is called with each generation once conmon replaced it and
+	// it was read to its end, if set.
+	rotated func(*os.File)
+}
+
+// newLogFollower starts watching the log file at path. Reading starts at the
+// end of the log file if end is set, at its beginning otherwise.
+func newLogFollower(path string, end bool, line func(string), rotated func(*os.File)) (*logFollower, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("watching log file %s: %w", path, err)
+	}
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		watcher.Close()
+		return nil, fmt.Errorf("watching log file %s: %w", path, err)
+	}
+	current, err := os.Open(path)
+	if err != nil {
+		watcher.Close()
+		return nil, fmt.Errorf("opening log file: %w", err)
+	}
+	if end {
+		if _, err := current.Seek(0, io.SeekEnd); err != nil {
+			current.Close()
+			watcher.Close()
+			return nil, fmt.Errorf("seeking log file %s: %w", path, err)
+		}
+	}
+	return &logFollower{
+		path:    path,
+		tmpPath: path + ".tmp",
+		watcher: watcher,
+		current: current,
+		reader:  bufio.NewReader(current),
+		line:    line,
+		rotated: rotated,
+	}, nil
+}
+
+// Close stops watching the log file.
+func (f *logFollower) Close() {
+	f.watcher.Close()
+	f.current.Close()
+	if f.pending != nil {
+		f.pending.Close()
+	}
+}
+
+// follow reads the log file until ctx is canceled, then reads it to its end
+// and returns.
+func (f *logFollower) follow(ctx context.Context) error {
+	// The log file may have been replaced before it was watched.
+	if err := f.next(); err != nil {
+		return err
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return f.next()
+		case event, ok := <-f.watcher.Events:
+			if !ok {
+				return fmt.Errorf("watching log file %s: watcher closed", f.path)
+			}
+			switch {
+			case event.Name == f.tmpPath && event.Has(fsnotify.Create):
+				if err := f.next(); err != nil {
+					return err
+				}
+				if f.pending != nil {
+					// The previous generation was never
+					// renamed to the log file.
+					f.pending.Close()
+					f.pending = nil
+				}
+				// conmon may already have renamed it, it is then
+				// opened as log file.
+				if pending, err := os.Open(f.tmpPath); err == nil {
+					f.pending = pending
+				}
+			case event.Name == f.path && (event.Has(fsnotify.Create) || f.line != nil):
+				if err := f.next(); err != nil {
+					return err
+				}
+			}
+		case err, ok := <-f.watcher.Errors:
+			if !ok {
+				return fmt.Errorf("watching log file %s: watcher closed", f.path)
+			}
+			// Events may have been lost, check the log file.
+			logrus.Warnf("Watching log file %s: %v", f.path, err)
+			if err := f.next(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// next reads the lines added to the current generation. If conmon replaced
+// it, it is read to its end before continuing with the next generation.
+func (f *logFollower) next() error {
+	for {
+		info, err := os.Stat(f.path)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				// The container was removed.
+				return f.read()
+			}
+			return err
+		}
+		currentInfo, err := f.current.Stat()
+		if err != nil {
+			return err
+		}
+		if os.SameFile(info, currentInfo) {
+			return f.read()
+		}
+
+		// conmon no longer writes to the current generation.
+		if err := f.read(); err != nil {
+			return err
+		}
+		if f.partial != "" {
+			f.line(f.partial)
+			f.partial = ""
+		}
+		// The pending generation may have been replaced as well, it is
+		// then read by the next iteration.
+		next := f.pending
+		f.pending = nil
+		if next == nil {
+			if next, err = os.Open(f.path); err != nil {
+				if errors.Is(err, os.ErrNotExist) {
+					// The container was removed.
+					return nil
+				}
+				return fmt.Errorf("opening log file: %w", err)
+			}
+		}
+		if f.rotated != nil {
+			f.rotated(f.current)
+		}
+		f.current.Close()
+		f.current = next
+		f.reader.Reset(next)
+	}
+}
+
+// read passes the complete lines added to the current generation to f.line.
+func (f *logFollower) read() error {
+	if f.line == nil {
+		return nil
+	}
+	for {
+		s, err := f.reader.ReadString('\n')
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				f.partial += s
+				return nil
+			}
+			return fmt.Errorf("reading log file %s: %w", f.path, err)
+		}
+		s = f.partial + strings.TrimSuffix(s, "\n")
+		f.partial = ""
+		if s != "" {
+			f.line(s)
+		}
+	}
+}
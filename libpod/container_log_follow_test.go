@@ -0,0 +1,102 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func appendTestLog(t *testing.T, path, content string) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o640)
+	require.NoError(t, err)
+	_, err = f.WriteString(content)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+}
+
+// replaceTestLog simulates conmon replacing the log file at path by a new
+// generation with content.
+func replaceTestLog(t *testing.T, path, content string) {
+	require.NoError(t, os.WriteFile(path+".tmp", []byte(content), 0o640))
+	require.NoError(t, os.Rename(path+".tmp", path))
+}
+
+func TestLogFollowerReadsReplacedGeneration(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ctr.log")
+	appendTestLog(t, path, "old\n")
+
+	var lines []string
+	f, err := newLogFollower(path, true, func(line string) { lines = append(lines, line) }, nil)
+	require.NoError(t, err)
+	defer f.Close()
+
+	appendTestLog(t, path, "line1\nli")
+	require.NoError(t, f.next())
+	assert.Equal(t, []string{"line1"}, lines)
+
+	// Lines written to the generation after it was last read are not
+	// lost when conmon replaces it.
+	appendTestLog(t, path, "ne2\nline3\n")
+	replaceTestLog(t, path, "line4\n")
+	require.NoError(t, f.next())
+	assert.Equal(t, []string{"line1", "line2", "line3", "line4"}, lines)
+}
+
+func TestLogFollowerFollowsRotations(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ctr.log")
+	appendTestLog(t, path, "")
+
+	var (
+		lock    sync.Mutex
+		lines   []string
+		rotated int
+	)
+	f, err := newLogFollower(path, true, func(line string) {
+		lock.Lock()
+		defer lock.Unlock()
+		lines = append(lines, line)
+	}, func(*os.File) {
+		lock.Lock()
+		defer lock.Unlock()
+		rotated++
+	})
+	require.NoError(t, err)
+	defer f.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- f.follow(ctx)
+	}()
+
+	var expected []string
+	for i := range 5 {
+		line := fmt.Sprintf("line%d", i)
+		expected = append(expected, line, line+"-late")
+		appendTestLog(t, path, line+"\n")
+		appendTestLog(t, path, line+"-late\n")
+		replaceTestLog(t, path, "")
+		assert.Eventually(t, func() bool {
+			lock.Lock()
+			defer lock.Unlock()
+			return rotated == i+1
+		}, 5*time.Second, 10*time.Millisecond)
+	}
+	appendTestLog(t, path, "last\n")
+	expected = append(expected, "last")
+	cancel()
+	require.NoError(t, <-done)
+
+	lock.Lock()
+	defer lock.Unlock()
+	assert.Equal(t, expected, lines)
+}
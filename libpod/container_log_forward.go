@@ -0,0 +1,206 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/libpod/logs"
+	"github.com/containers/podman/v5/pkg/logforward"
+	"github.com/containers/storage/pkg/ioutils"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// logForwardCloseTimeout is the time the log forwarder keeps trying to
+	// send buffered messages after the container exited.
+	logForwardCloseTimeout = 30 * time.Second
+
+	// logForwardCheckpointInterval is the interval at which the time of
+	// the last forwarded line is recorded.
+	logForwardCheckpointInterval = time.Second
+)
+
+// ForwardLogs sends the log lines the container writes from now on to the
+// collector configured by its log driver, tagged with tag. ready is called
+// once the log file is followed, so that the container can be started
+// without losing lines. It returns when conmon exits or ctx is canceled,
+// after forwarding the lines written until then and sending the buffered
+// messages.
+func (c *Container) ForwardLogs(ctx context.Context, tag string, ready func()) error {
+	return c.runLogHelper(ctx, "logforward", func(ctx context.Context) error {
+		f, err := c.newLogForward(tag, time.Time{})
+		if err != nil {
+			return err
+		}
+		follower, err := newLogFollower(c.LogPath(), true, f.text, nil)
+		if err != nil {
+			if closeErr := f.close(0); closeErr != nil {
+				logrus.Error(closeErr)
+			}
+			return fmt.Errorf("following log file of container %s: %w", c.ID(), err)
+		}
+		defer follower.Close()
+		ready()
+
+		if err := follower.follow(ctx); err != nil {
+			logrus.Errorf("Forwarding log of container %s: %v", c.ID(), err)
+		}
+		return f.close(logForwardCloseTimeout)
+	})
+}
+
+// forwardMissedLogs forwards the log lines of the exited container which its
+// log forwarding helper did not forward before it died.
+func (c *Container) forwardMissedLogs() error {
+	since := c.state.StartedTime
+	checkpoint, err := c.logForwardCheckpoint()
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		logrus.Warnf("Reading log forwarding checkpoint of container %s: %v", c.ID(), err)
+	}
+	if checkpoint.After(since) {
+		since = checkpoint
+	}
+
+	tag, err := c.logTag()
+	if err != nil {
+		return err
+	}
+	f, err := c.newLogForward(tag, since)
+	if err != nil {
+		return err
+	}
+	if err := logs.ReadRotatedLogFiles(c.LogPath(), f.logLine); err != nil {
+		logrus.Errorf("Reading rotated log files of container %s: %v", c.ID(), err)
+	}
+	follower, err := newLogFollower(c.LogPath(), false, f.text, nil)
+	if err == nil {
+		err = follower.next()
+		follower.Close()
+	}
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		logrus.Errorf("Reading log file of container %s: %v", c.ID(), err)
+	}
+	return f.close(logForwardCloseTimeout)
+}
+
+// logTag returns the tag of the log messages of the container.
+func (c *Container) logTag() (string, error) {
+	if c.config.LogTag == "" {
+		return c.Name(), nil
+	}
+	data, err := c.inspectLocked(false)
+	if err != nil {
+		return "", err
+	}
+	return formatLogTag(c.config.LogTag, data)
+}
+
+// logForwardCheckpointPath is the file recording the time of the last log
+// line of the container that was forwarded.
+func (c *Container) logForwardCheckpointPath() string {
+	return filepath.Join(c.config.StaticDir, "logforward-checkpoint")
+}
+
+func (c *Container) logForwardCheckpoint() (time.Time, error) {
+	content, err := os.ReadFile(c.logForwardCheckpointPath())
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Parse(time.RFC3339Nano, string(content))
+}
+
+// logForward passes the log lines of a container to its log forwarder,
+// joining the lines conmon split, and records how far the log was forwarded.
+type logForward struct {
+	c         *Container
+	forwarder *logforward.Forwarder
+	since     time.Time
+	partial   map[string]string
+	last      time.Time
+	recorded  time.Time
+}
+
+func (c *Container) newLogForward(tag string, since time.Time) (*logForward, error) {
+	if !define.IsForwardingLogDriver(c.LogDriver()) {
+		return nil, fmt.Errorf("container %s uses the %s log driver, which does not forward logs: %w", c.ID(), c.LogDriver(), define.ErrInvalidArg)
+	}
+	options, err := logforward.ParseOptions(c.LogDriver(), c.config.LogOptions)
+	if err != nil {
+		return nil, err
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		return nil, fmt.Errorf("getting hostname: %w", err)
+	}
+	forwarder := logforward.New(options, logforward.Info{
+		ContainerID:   c.ID(),
+		ContainerName: c.Name(),
+		ImageName:     c.config.RawImageName,
+		Hostname:      hostname,
+		Tag:           tag,
+	})
+	return &logForward{
+		c:         c,
+		forwarder: forwarder,
+		since:     since,
+		partial:   make(map[string]string),
+	}, nil
+}
+
+func (f *logForward) text(line string) {
+	nll, err := logs.NewLogLine(line)
+	if err != nil {
+		logrus.Errorf("Getting new log line: %v", err)
+		return
+	}
+	f.logLine(nll)
+}
+
+func (f *logForward) logLine(line *logs.LogLine) {
+	if !line.Since(f.since) {
+		return
+	}
+	// Lines longer than the buffer of conmon are split into partial lines,
+	// join them again before sending.
+	if line.Partial() {
+		f.partial[line.Device] += line.Msg
+		return
+	}
+	msg := &logforward.Message{
+		Time:   line.Time,
+		Stream: line.Device,
+		Line:   f.partial[line.Device] + line.Msg,
+	}
+	delete(f.partial, line.Device)
+	if err := f.forwarder.Write(msg); err != nil {
+		logrus.Errorf("Forwarding log of container %s: %v", f.c.ID(), err)
+	}
+	f.last = line.Time
+	if time.Since(f.recorded) >= logForwardCheckpointInterval {
+		f.checkpoint()
+	}
+}
+
+// checkpoint records the time of the last forwarded line, from which the
+// log is forwarded again should the forwarder die.
+func (f *logForward) checkpoint() {
+	if f.last.IsZero() {
+		return
+	}
+	if err := ioutils.AtomicWriteFile(f.c.logForwardCheckpointPath(), []byte(f.last.Format(time.RFC3339Nano)), 0o600); err != nil {
+		logrus.Errorf("Recording log forwarding checkpoint of container %s: %v", f.c.ID(), err)
+	}
+	f.recorded = time.Now()
+}
+
+func (f *logForward) close(timeout time.Duration) error {
+	f.checkpoint()
+	return f.forwarder.Close(timeout)
+}
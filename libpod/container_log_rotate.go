@@ -0,0 +1,39 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/libpod/logs"
+	"github.com/sirupsen/logrus"
+)
+
+// RotateLogs keeps the log file generations conmon replaces when the log
+// reaches its maximum size, as configured by the max-file and compress log
+// options. ready is called once the log file is watched, so that the
+// container can be started without missing a generation. It returns when
+// conmon exits or ctx is canceled, after keeping the generations replaced
+// until then.
+func (c *Container) RotateLogs(ctx context.Context, ready func()) error {
+	if c.config.LogMaxFile < 2 {
+		return fmt.Errorf("container %s does not keep rotated log files: %w", c.ID(), define.ErrInvalidArg)
+	}
+	return c.runLogHelper(ctx, "logrotate", func(ctx context.Context) error {
+		path := c.LogPath()
+		follower, err := newLogFollower(path, true, nil, func(generation *os.File) {
+			if err := logs.RotateLogFile(path, generation, int(c.config.LogMaxFile), c.config.LogCompress); err != nil {
+				logrus.Errorf("Rotating log file of container %s: %v", c.ID(), err)
+			}
+		})
+		if err != nil {
+			return fmt.Errorf("following log file of container %s: %w", c.ID(), err)
+		}
+		defer follower.Close()
+		ready()
+		return follower.follow(ctx)
+	})
+}
@@ -0,0 +1,49 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+)
+
+func TestWaitLogHelpersKilledHelper(t *testing.T) {
+	ctr := &Container{config: &ContainerConfig{ID: "ctr", ContainerRootFSConfig: ContainerRootFSConfig{StaticDir: t.TempDir()}}}
+	path := ctr.logHelperLockPath("logrotate")
+
+	// A helper which was killed leaves its lock file behind, unlocked.
+	lock, err := lockLogHelper(path)
+	require.NoError(t, err)
+	require.NoError(t, lock.Close())
+
+	ctr.waitLogHelpers()
+	assert.NoFileExists(t, path)
+
+	// The next helper does not wait for the killed one.
+	lock, err = lockLogHelper(path)
+	require.NoError(t, err)
+	require.NoError(t, lock.Close())
+}
+
+func TestWaitLogHelpersRunningHelper(t *testing.T) {
+	ctr := &Container{config: &ContainerConfig{ID: "ctr", ContainerRootFSConfig: ContainerRootFSConfig{StaticDir: t.TempDir()}}}
+	path := ctr.logHelperLockPath("logrotate")
+
+	lock, err := lockLogHelper(path)
+	require.NoError(t, err)
+	defer lock.Close()
+
+	// The lock file of a helper still handling the log is left to it.
+	ctr.waitLogHelpers()
+	assert.FileExists(t, path)
+
+	// Once it handled the whole log, the helper removes the lock file.
+	require.NoError(t, os.Remove(path))
+	require.NoError(t, unix.Flock(int(lock.Fd()), unix.LOCK_UN))
+	ctr.waitLogHelpers()
+	assert.NoFileExists(t, path)
+}
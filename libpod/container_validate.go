@@ -10,6 +10,7 @@ import (
 	"github.com/containers/image/v5/pkg/shortnames"
 	"github.com/containers/image/v5/transports/alltransports"
 	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/pkg/logforward"
 	spec "github.com/opencontainers/runtime-spec/specs-go"
 )
 
@@ -127,6 +128,12 @@ func (c *Container) validate() error {
 		}
 		destinations[vol.Dest] = true
 	}
+	for _, vol := range c.config.ArtifactVolumes {
+		if _, ok := destinations[vol.Dest]; ok {
+			return fmt.Errorf("two volumes found with destination %s: %w", vol.Dest, define.ErrInvalidArg)
+		}
+		destinations[vol.Dest] = true
+	}
 
 	// If User in the OCI spec is set, require that c.config.User is set for
 	// security reasons (a lot of our code relies on c.config.User).
@@ -152,7 +159,7 @@ func (c *Container) validate() error {
 		// TODO: we cannot reference pkg/autoupdate here due to
 		// circular dependencies.  It's worth considering moving the
 		// auto-update logic into the libpod package.
-		if value == "registry" || value == "image" {
+		if value == "registry" || value == "image" || strings.HasPrefix(value, "registry-semver:") {
 			if err := validateAutoUpdateImageReference(c.config.RawImageName); err != nil {
 				return err
 			}
@@ -176,6 +183,33 @@ func (c *Container) validate() error {
 		return fmt.Errorf("cannot set a startup healthcheck when there is no regular healthcheck: %w", define.ErrInvalidArg)
 	}
 
+	// The readiness probe is run by the healthcheck timer
+	if c.config.HealthCheckConfig == nil && c.config.ReadinessProbeConfig != nil {
+		return fmt.Errorf("cannot set a readiness probe when there is no regular healthcheck: %w", define.ErrInvalidArg)
+	}
+
+	// Log drivers forwarding logs need a valid collector configuration
+	if define.IsForwardingLogDriver(c.config.LogDriver) {
+		if _, err := logforward.ParseOptions(c.config.LogDriver, c.config.LogOptions); err != nil {
+			return err
+		}
+	}
+
+	// Rotated log files are only kept for log files written by conmon
+	if c.config.LogMaxFile > 1 || c.config.LogCompress {
+		switch c.config.LogDriver {
+		case define.KubernetesLogging, define.JSONLogging, define.SyslogLogging, define.GELFLogging, define.FluentdLogging:
+		default:
+			return fmt.Errorf("log options max-file and compress are not supported by the %s log driver: %w", c.config.LogDriver, define.ErrInvalidArg)
+		}
+		if c.config.LogSize <= 0 && c.runtime.config.Containers.LogSizeMax <= 0 && !define.IsForwardingLogDriver(c.config.LogDriver) {
+			return fmt.Errorf("log option max-file requires max-size to be set: %w", define.ErrInvalidArg)
+		}
+		if c.config.LogCompress && c.config.LogMaxFile < 2 {
+			return fmt.Errorf("log option compress requires max-file to be greater than 1: %w", define.ErrInvalidArg)
+		}
+	}
+
 	// Ensure all ports list a single protocol
 	for _, p := range c.config.PortMappings {
 		if strings.Contains(p.Protocol, ",") {
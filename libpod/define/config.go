@@ -41,6 +41,16 @@ const VolumeDriverLocal = "local"
 // uses volumes backed by an image.
 const VolumeDriverImage = "image"
 
+const (
+	// VolumeQuotaProject enforces the size and inodes of a local volume
+	// with project quotas of the file system containing the volume path,
+	// e.g. XFS or ext4 mounted with prjquota.
+	VolumeQuotaProject = "project"
+	// VolumeQuotaLoop enforces the size of a local volume by storing its
+	// data in a file system in a loopback-mounted image.
+	VolumeQuotaLoop = "loop"
+)
+
 const (
 	OCIManifestDir  = "oci-dir"
 	OCIArchive      = "oci-archive"
@@ -85,6 +95,30 @@ const PassthroughLogging = "passthrough"
 // PassthroughTTYLogging is the string conmon expects when specifying to use the passthrough driver even on a tty.
 const PassthroughTTYLogging = "passthrough-tty"
 
+// SyslogLogging is the log driver forwarding logs to a syslog collector
+const SyslogLogging = "syslog"
+
+// GELFLogging is the log driver forwarding logs to a GELF collector
+const GELFLogging = "gelf"
+
+// FluentdLogging is the log driver forwarding logs to a fluentd collector
+const FluentdLogging = "fluentd"
+
+// DefaultForwardingLogSizeMax is the default size of the local log of
+// containers using a forwarding log driver.
+const DefaultForwardingLogSizeMax = 10 * 1024 * 1024
+
+// IsForwardingLogDriver returns whether the given log driver forwards logs to
+// a collector over the network. Conmon writes the logs of such containers
+// to a size limited k8s-file log, which Podman forwards from.
+func IsForwardingLogDriver(driver string) bool {
+	switch driver {
+	case SyslogLogging, GELFLogging, FluentdLogging:
+		return true
+	}
+	return false
+}
+
 // DefaultRlimitValue is the value set by default for nofile and nproc
 const RLimitDefaultValue = uint64(1048576)
 
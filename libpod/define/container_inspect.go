@@ -61,6 +61,12 @@ type InspectContainerConfig struct {
 	StartupHealthCheck *StartupHealthCheck `json:"StartupHealthCheck,omitempty"`
 	// Configured healthcheck for the container
 	Healthcheck *manifest.Schema2HealthConfig `json:"Healthcheck,omitempty"`
+	// Configured readiness probe for the container
+	ReadinessProbe *ReadinessProbe `json:"ReadinessProbe,omitempty"`
+	// PostStartHook is the command run in the container after it started
+	PostStartHook []string `json:"PostStartHook,omitempty"`
+	// PreStopHook is the command run in the container before it is stopped
+	PreStopHook []string `json:"PreStopHook,omitempty"`
 	// HealthcheckOnFailureAction defines an action to take once the container turns unhealthy.
 	HealthcheckOnFailureAction string `json:"HealthcheckOnFailureAction,omitempty"`
 	// HealthLogDestination defines the destination where the log is stored
@@ -310,6 +316,7 @@ type InspectContainerState struct {
 	StartedAt      time.Time           `json:"StartedAt"`
 	FinishedAt     time.Time           `json:"FinishedAt"`
 	Health         *HealthCheckResults `json:"Health,omitempty"`
+	Ready          *bool               `json:"Ready,omitempty"`
 	Checkpointed   bool                `json:"Checkpointed,omitempty"`
 	CgroupPath     string              `json:"CgroupPath,omitempty"`
 	CheckpointedAt time.Time           `json:"CheckpointedAt,omitempty"`
@@ -319,6 +326,7 @@ type InspectContainerState struct {
 	RestoreLog     string              `json:"RestoreLog,omitempty"`
 	Restored       bool                `json:"Restored,omitempty"`
 	StoppedByUser  bool                `json:"StoppedByUser,omitempty"`
+	AutoUpdateTag  string              `json:"AutoUpdateTag,omitempty"`
 }
 
 // Healthcheck returns the HealthCheckResults. This is used for old podman compat
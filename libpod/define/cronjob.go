@@ -0,0 +1,28 @@
+package define
+
+// PodCronJob describes the schedule of a pod created from a Kubernetes
+// CronJob.  The containers of the pod are started on every activation of
+// the schedule, all runs reuse the same pod.
+type PodCronJob struct {
+	// Schedule is the cron expression of the CronJob.
+	Schedule string `json:"schedule"`
+	// Calendar holds the systemd calendar events equivalent to Schedule.
+	Calendar []string `json:"calendar,omitempty"`
+	// TimeZone the schedule is evaluated in.  Local time if empty.
+	TimeZone string `json:"timeZone,omitempty"`
+	// ConcurrencyPolicy decides what happens when the schedule fires
+	// while the pod is still running.  Either "Forbid" to skip the run
+	// or "Replace" to restart the pod.
+	ConcurrencyPolicy string `json:"concurrencyPolicy,omitempty"`
+	// Suspend prevents the schedule from starting the pod.
+	Suspend bool `json:"suspend,omitempty"`
+}
+
+const (
+	// CronJobConcurrencyForbid skips an activation of the schedule when
+	// the pod is still running.
+	CronJobConcurrencyForbid = "Forbid"
+	// CronJobConcurrencyReplace restarts a pod which is still running
+	// when the schedule fires.
+	CronJobConcurrencyReplace = "Replace"
+)
@@ -18,6 +18,10 @@ var (
 	// ErrNoSuchVolume indicates the requested volume does not exist
 	ErrNoSuchVolume = errors.New("no such volume")
 
+	// ErrNoSuchVolumeSnapshot indicates the requested volume snapshot does
+	// not exist
+	ErrNoSuchVolumeSnapshot = errors.New("no such snapshot")
+
 	// ErrNoSuchNetwork indicates the requested network does not exist
 	ErrNoSuchNetwork = types.ErrNoSuchNetwork
 
@@ -29,6 +33,14 @@ var (
 	// does not exist.
 	ErrNoSuchExitCode = errors.New("no such exit code")
 
+	// ErrNoSuchQuadlet indicates the requested Quadlet unit is not
+	// installed.
+	ErrNoSuchQuadlet = errors.New("no such quadlet")
+
+	// ErrNoSuchArtifact indicates the requested OCI artifact does not exist
+	// in the local artifact store.
+	ErrNoSuchArtifact = errors.New("no such artifact")
+
 	// ErrDepExists indicates that the current object has dependencies and
 	// cannot be removed before them.
 	ErrDepExists = errors.New("dependency exists")
@@ -50,12 +62,24 @@ var (
 	ErrImageExists = errors.New("image already exists")
 	// ErrVolumeExists indicates a volume with the same name already exists
 	ErrVolumeExists = errors.New("volume already exists")
+	// ErrVolumeSnapshotExists indicates a snapshot with the same name
+	// already exists for the volume
+	ErrVolumeSnapshotExists = errors.New("volume snapshot already exists")
 	// ErrExecSessionExists indicates an exec session with the same ID
 	// already exists.
 	ErrExecSessionExists = errors.New("exec session already exists")
 	// ErrNetworkExists indicates that a network with the given name already
 	// exists.
 	ErrNetworkExists = types.ErrNetworkExists
+	// ErrQuadletExists indicates that a Quadlet unit or one of its files
+	// with the given name is already installed.
+	ErrQuadletExists = errors.New("quadlet already exists")
+	// ErrArtifactExists indicates that an OCI artifact with the given name
+	// already exists.
+	ErrArtifactExists = errors.New("artifact already exists")
+	// ErrArtifactInUse indicates that an OCI artifact is mounted into a
+	// container.
+	ErrArtifactInUse = errors.New("artifact is in use by a container")
 
 	// ErrCtrStateInvalid indicates a container is in an improper state for
 	// the requested operation
@@ -132,6 +156,9 @@ var (
 	// ErrExecSessionRemoved indicates that the exec session has already
 	// been removed and no further operations can be performed on it.
 	ErrExecSessionRemoved = errors.New("exec session has already been removed")
+	// ErrExecTimeout indicates that an exec session did not complete in
+	// time and was stopped.
+	ErrExecTimeout = errors.New("exec session timed out")
 
 	// ErrDBClosed indicates that the connection to the state database has
 	// already been closed
@@ -20,6 +20,8 @@ const (
 	HealthCheckStarting string = "starting"
 	// HealthCheckReset describes reset of HealthCheck logs
 	HealthCheckReset string = "reset"
+	// ReadinessReady describes a container whose readiness probe passed
+	ReadinessReady string = "ready"
 )
 
 // HealthCheckStatus represents the current state of a container
@@ -158,6 +160,19 @@ type StartupHealthCheck struct {
 	Successes int `json:",omitempty"`
 }
 
+// ReadinessProbe is the configuration of a readiness probe.
+// Contrary to the regular healthcheck, a failing readiness probe does not
+// mark the container as unhealthy; it only flags it as not ready.
+// The probe has no timer of its own, it is run each time the regular
+// healthcheck is run, so its Interval is the one of the healthcheck.
+type ReadinessProbe struct {
+	manifest.Schema2HealthConfig
+	// Successes are the number of consecutive successes required to mark
+	// the container as ready.
+	// If set to 0, a single success will mark the container as ready.
+	Successes int `json:",omitempty"`
+}
+
 type UpdateHealthCheckConfig struct {
 	// HealthLogDestination set the destination of the HealthCheck log.
 	// Directory path, local or events_logger (local use container state file)
@@ -21,6 +21,8 @@ type InspectPodData struct {
 	CreateCommand []string `json:"CreateCommand,omitempty"`
 	// ExitPolicy of the pod.
 	ExitPolicy string `json:"ExitPolicy,omitempty"`
+	// CronJob is the schedule the pod is started on, if any.
+	CronJob *PodCronJob `json:"CronJob,omitempty"`
 	// State represents the current state of the pod.
 	State string `json:"State"`
 	// Hostname is the hostname that the pod will set.
@@ -153,4 +155,8 @@ type InspectPodContainerInfo struct {
 	Name string
 	// State is the current status of the container.
 	State string
+	// Ready indicates whether the container is ready. Containers with a
+	// readiness probe are ready once it passed, other containers as soon
+	// as they are running.
+	Ready bool
 }
@@ -63,6 +63,38 @@ type InspectVolumeData struct {
 	StorageID string `json:"StorageID,omitempty"`
 	// LockNumber is the number of the volume's Libpod lock.
 	LockNumber uint32
+	// Snapshots are the snapshots of the volume's data, oldest first.
+	Snapshots []InspectVolumeSnapshot `json:"Snapshots,omitempty"`
+	// Quota is the size quota enforced for the volume and its usage.
+	// Only set for local volumes created with a size or inodes option.
+	Quota *InspectVolumeQuota `json:"Quota,omitempty"`
+}
+
+// InspectVolumeQuota describes the quota of a volume and its usage as reported
+// by the quota backend, without walking the volume's data.
+type InspectVolumeQuota struct {
+	// Backend enforcing the quota, either "project" for project quotas of
+	// the file system or "loop" for a loopback-mounted image.
+	Backend string `json:"Backend"`
+	// Size is the maximum size of the volume in bytes, 0 if unlimited.
+	Size uint64 `json:"Size,omitempty"`
+	// Inodes is the maximum number of inodes of the volume, 0 if
+	// unlimited.
+	Inodes uint64 `json:"Inodes,omitempty"`
+	// UsageAvailable indicates that the backend reported the usage.
+	UsageAvailable bool `json:"UsageAvailable"`
+	// Used is the number of bytes used by the volume.
+	Used int64 `json:"Used"`
+	// InodesUsed is the number of inodes used by the volume.
+	InodesUsed int64 `json:"InodesUsed"`
+}
+
+// InspectVolumeSnapshot describes a snapshot of a volume's data.
+type InspectVolumeSnapshot struct {
+	// Name is the name of the snapshot.
+	Name string `json:"Name"`
+	// CreatedAt is when the snapshot was taken.
+	CreatedAt time.Time `json:"CreatedAt"`
 }
 
 type VolumeReload struct {
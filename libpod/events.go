@@ -114,6 +114,28 @@ func (c *Container) newContainerExitedEvent(exitCode int32) {
 	}
 }
 
+// NewAutoUpdateEvent creates a new auto-update event for the container.  The
+// attributes are recorded in addition to the labels of the container.
+func (c *Container) NewAutoUpdateEvent(attributes map[string]string) {
+	e := events.NewEvent(events.AutoUpdate)
+	e.ID = c.ID()
+	e.Name = c.Name()
+	e.Image = c.config.RootfsImageName
+	e.Type = events.Container
+
+	e.Details = events.Details{
+		PodID:      c.PodID(),
+		Attributes: c.Labels(),
+	}
+	for key, value := range attributes {
+		e.Attributes[key] = value
+	}
+
+	if err := c.runtime.eventer.Write(e); err != nil {
+		logrus.Errorf("Unable to write auto-update event: %q", err)
+	}
+}
+
 // newExecDiedEvent creates a new event for an exec session's death
 func (c *Container) newExecDiedEvent(sessionID string, exitCode int) {
 	e := events.NewEvent(events.ExecDied)
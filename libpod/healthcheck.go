@@ -8,6 +8,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"io/fs"
 	"os"
 	"path/filepath"
@@ -53,7 +54,6 @@ func (r *Runtime) HealthCheck(ctx context.Context, name string) (define.HealthCh
 
 func (c *Container) runHealthCheck(ctx context.Context, isStartup bool) (define.HealthCheckStatus, string, error) {
 	var (
-		newCommand    []string
 		returnCode    int
 		inStartPeriod bool
 	)
@@ -63,34 +63,13 @@ func (c *Container) runHealthCheck(ctx context.Context, isStartup bool) (define.
 		logrus.Debugf("Running startup healthcheck for container %s", c.ID())
 		hcCommand = c.config.StartupHealthCheckConfig.Test
 	}
-	if len(hcCommand) < 1 {
-		return define.HealthCheckNotDefined, "", fmt.Errorf("container %s has no defined healthcheck", c.ID())
-	}
-	switch hcCommand[0] {
-	case "", define.HealthConfigTestNone:
-		return define.HealthCheckNotDefined, "", fmt.Errorf("container %s has no defined healthcheck", c.ID())
-	case define.HealthConfigTestCmd:
-		newCommand = hcCommand[1:]
-	case define.HealthConfigTestCmdShell:
-		// TODO: SHELL command from image not available in Container - use Docker default
-		newCommand = []string{"/bin/sh", "-c", strings.Join(hcCommand[1:], " ")}
-	default:
-		// command supplied on command line - pass as-is
-		newCommand = hcCommand
-	}
-	if len(newCommand) < 1 || newCommand[0] == "" {
+	newCommand := healthCheckCommand(hcCommand)
+	if len(newCommand) < 1 {
 		return define.HealthCheckNotDefined, "", fmt.Errorf("container %s has no defined healthcheck", c.ID())
 	}
 
-	streams := new(define.AttachStreams)
 	output := &bytes.Buffer{}
-
-	streams.InputStream = bufio.NewReader(os.Stdin)
-	streams.OutputStream = output
-	streams.ErrorStream = output
-	streams.AttachOutput = true
-	streams.AttachError = true
-	streams.AttachInput = true
+	streams := healthCheckStreams(output)
 
 	logrus.Debugf("executing health check command %s for %s", strings.Join(newCommand, " "), c.ID())
 	timeStart := time.Now()
@@ -165,6 +144,136 @@ func (c *Container) runHealthCheck(ctx context.Context, isStartup bool) (define.
 	return hcResult, healthCheckResult.Status, hcErr
 }
 
+// healthCheckCommand converts the Test of a healthcheck configuration into
+// the command to execute in the container.  Returns nil if no command is
+// defined.
+func healthCheckCommand(hcCommand []string) []string {
+	var newCommand []string
+	if len(hcCommand) < 1 {
+		return nil
+	}
+	switch hcCommand[0] {
+	case "", define.HealthConfigTestNone:
+		return nil
+	case define.HealthConfigTestCmd:
+		newCommand = hcCommand[1:]
+	case define.HealthConfigTestCmdShell:
+		// TODO: SHELL command from image not available in Container - use Docker default
+		newCommand = []string{"/bin/sh", "-c", strings.Join(hcCommand[1:], " ")}
+	default:
+		// command supplied on command line - pass as-is
+		newCommand = hcCommand
+	}
+	if len(newCommand) < 1 || newCommand[0] == "" {
+		return nil
+	}
+	return newCommand
+}
+
+// healthCheckStreams returns the attach streams used by healthchecks and
+// probes, both stdout and stderr are written to output.
+func healthCheckStreams(output io.Writer) *define.AttachStreams {
+	streams := new(define.AttachStreams)
+	streams.InputStream = bufio.NewReader(os.Stdin)
+	streams.OutputStream = output
+	streams.ErrorStream = output
+	streams.AttachOutput = true
+	streams.AttachError = true
+	streams.AttachInput = true
+	return streams
+}
+
+// ReadinessProbe verifies that the container is running and has a readiness
+// probe and then runs the probe. It is run by the timer of the readiness probe,
+// independent of the healthcheck of the container.
+func (r *Runtime) ReadinessProbe(ctx context.Context, name string) error {
+	container, err := r.LookupContainer(name)
+	if err != nil {
+		return fmt.Errorf("unable to look up %s to run a readiness probe: %w", name, err)
+	}
+	state, err := container.State()
+	if err != nil {
+		return err
+	}
+	if state != define.ContainerStateRunning {
+		return fmt.Errorf("container %s is not running: %w", container.ID(), define.ErrCtrStateInvalid)
+	}
+	if !container.HasReadinessProbe() {
+		return fmt.Errorf("container %s has no defined readiness probe", container.ID())
+	}
+	return container.runReadinessProbe()
+}
+
+// runReadinessProbe executes the readiness probe of the container and updates
+// the readiness of the container accordingly.
+func (c *Container) runReadinessProbe() error {
+	probe := c.config.ReadinessProbeConfig
+	if probe == nil {
+		return nil
+	}
+	newCommand := healthCheckCommand(probe.Test)
+	if len(newCommand) < 1 {
+		return fmt.Errorf("container %s has no defined readiness probe", c.ID())
+	}
+
+	// Like Kubernetes, do not probe before the initial delay elapsed.
+	if probe.StartPeriod > 0 {
+		startedTime, err := c.StartedTime()
+		if err != nil {
+			return err
+		}
+		if time.Now().Before(startedTime.Add(probe.StartPeriod)) {
+			logrus.Debugf("Readiness probe of container %s skipped, still in initial delay", c.ID())
+			return nil
+		}
+	}
+
+	logrus.Debugf("executing readiness probe command %s for %s", strings.Join(newCommand, " "), c.ID())
+	config := new(ExecConfig)
+	config.Command = newCommand
+	exitCode, err := c.execWithTimeout(config, healthCheckStreams(io.Discard), probe.Timeout, true)
+	if errors.Is(err, define.ErrExecTimeout) {
+		logrus.Debugf("Readiness probe of container %s exceeded timeout of %s", c.ID(), probe.Timeout.String())
+	}
+	return c.updateReadiness(err == nil && exitCode == 0)
+}
+
+// updateReadiness records the result of a readiness probe and toggles the
+// readiness of the container once the success or failure threshold is met.
+func (c *Container) updateReadiness(passed bool) error {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return err
+		}
+	}
+
+	probe := c.config.ReadinessProbeConfig
+	if probe == nil || c.state.State != define.ContainerStateRunning {
+		return nil
+	}
+
+	if passed {
+		c.state.ReadinessFailureCount = 0
+		c.state.ReadinessSuccessCount++
+		if !c.state.Ready && (probe.Successes == 0 || c.state.ReadinessSuccessCount >= probe.Successes) {
+			logrus.Infof("Container %s is ready", c.ID())
+			c.state.Ready = true
+		}
+	} else {
+		c.state.ReadinessSuccessCount = 0
+		c.state.ReadinessFailureCount++
+		if c.state.Ready && (probe.Retries == 0 || c.state.ReadinessFailureCount >= probe.Retries) {
+			logrus.Infof("Container %s is not ready anymore, readiness probe failed %d times", c.ID(), c.state.ReadinessFailureCount)
+			c.state.Ready = false
+		}
+	}
+
+	return c.save()
+}
+
 func (c *Container) processHealthCheckStatus(status string) error {
 	if status != define.HealthCheckUnhealthy {
 		return nil
@@ -24,7 +24,42 @@ func (c *Container) createTimer(interval string, isStartup bool) error {
 	}
 
 	hcUnitName := c.hcUnitName(isStartup, false)
+	if err := createTransientTimer(hcUnitName, interval, "healthcheck", "run", c.ID()); err != nil {
+		return err
+	}
+
+	c.state.HCUnitName = hcUnitName
+	if err := c.save(); err != nil {
+		return fmt.Errorf("saving container %s healthcheck unit name: %w", c.ID(), err)
+	}
+
+	return nil
+}
+
+// createReadinessTimer creates the systemd timer running the readiness probe
+// of a container at the period of the probe, independent of the timers of
+// its healthchecks.
+func (c *Container) createReadinessTimer() error {
+	if c.disableReadinessSystemd() {
+		return nil
+	}
+
+	unitName := c.readinessUnitName()
+	if err := createTransientTimer(unitName, c.config.ReadinessProbeConfig.Interval.String(), "healthcheck", "readiness", c.ID()); err != nil {
+		return err
+	}
+
+	c.state.ReadinessUnitName = unitName
+	if err := c.save(); err != nil {
+		return fmt.Errorf("saving container %s readiness unit name: %w", c.ID(), err)
+	}
+
+	return nil
+}
 
+// createTransientTimer creates a transient systemd timer running podman with
+// the given arguments in the given interval after the last run finished.
+func createTransientTimer(unitName, interval string, args ...string) error {
 	podman, err := os.Executable()
 	if err != nil {
 		return fmt.Errorf("failed to get path for podman for a health check timer: %w", err)
@@ -39,13 +74,13 @@ func (c *Container) createTimer(interval string, isStartup bool) error {
 		cmd = append(cmd, "--setenv=PATH="+path)
 	}
 
-	cmd = append(cmd, "--unit", hcUnitName, fmt.Sprintf("--on-unit-inactive=%s", interval), "--timer-property=AccuracySec=1s", podman)
+	cmd = append(cmd, "--unit", unitName, fmt.Sprintf("--on-unit-inactive=%s", interval), "--timer-property=AccuracySec=1s", podman)
 
 	if logrus.IsLevelEnabled(logrus.DebugLevel) {
 		cmd = append(cmd, "--log-level=debug", "--syslog")
 	}
 
-	cmd = append(cmd, "healthcheck", "run", c.ID())
+	cmd = append(cmd, args...)
 
 	conn, err := systemd.ConnectToDBUS()
 	if err != nil {
@@ -57,12 +92,6 @@ func (c *Container) createTimer(interval string, isStartup bool) error {
 	if output, err := systemdRun.CombinedOutput(); err != nil {
 		return fmt.Errorf("%s", output)
 	}
-
-	c.state.HCUnitName = hcUnitName
-	if err := c.save(); err != nil {
-		return fmt.Errorf("saving container %s healthcheck unit name: %w", c.ID(), err)
-	}
-
 	return nil
 }
 
@@ -87,14 +116,27 @@ func (c *Container) startTimer(isStartup bool) error {
 	if hcUnitName == "" {
 		hcUnitName = c.hcUnitName(isStartup, true)
 	}
+	return startTransientTimer(hcUnitName)
+}
 
+// startReadinessTimer starts the systemd timer for the readiness probe
+func (c *Container) startReadinessTimer() error {
+	if c.disableReadinessSystemd() || c.state.ReadinessUnitName == "" {
+		return nil
+	}
+	return startTransientTimer(c.state.ReadinessUnitName)
+}
+
+// startTransientTimer runs the service of a timer created by
+// createTransientTimer, the timer then runs it again after each interval.
+func startTransientTimer(unitName string) error {
 	conn, err := systemd.ConnectToDBUS()
 	if err != nil {
 		return fmt.Errorf("unable to get systemd connection to start healthchecks: %w", err)
 	}
 	defer conn.Close()
 
-	startFile := fmt.Sprintf("%s.service", hcUnitName)
+	startFile := fmt.Sprintf("%s.service", unitName)
 	startChan := make(chan string)
 	if _, err := conn.RestartUnitContext(context.Background(), startFile, "fail", startChan); err != nil {
 		return err
@@ -112,6 +154,28 @@ func (c *Container) removeTransientFiles(ctx context.Context, isStartup bool, un
 	if c.disableHealthCheckSystemd(isStartup) {
 		return nil
 	}
+	if unitName == "" {
+		unitName = c.hcUnitName(isStartup, true)
+	}
+	return removeTransientTimer(ctx, unitName)
+}
+
+// removeReadinessTimer removes the systemd timer and unit files of the
+// readiness probe of the container
+func (c *Container) removeReadinessTimer(ctx context.Context) error {
+	if c.disableReadinessSystemd() || c.state.ReadinessUnitName == "" {
+		return nil
+	}
+	if err := removeTransientTimer(ctx, c.state.ReadinessUnitName); err != nil {
+		return err
+	}
+	c.state.ReadinessUnitName = ""
+	return nil
+}
+
+// removeTransientTimer stops and removes a timer created by
+// createTransientTimer and its service.
+func removeTransientTimer(ctx context.Context, unitName string) error {
 	conn, err := systemd.ConnectToDBUS()
 	if err != nil {
 		return fmt.Errorf("unable to get systemd connection to remove healthchecks: %w", err)
@@ -122,9 +186,6 @@ func (c *Container) removeTransientFiles(ctx context.Context, isStartup bool, un
 	// clean up as much as possible.
 	stopErrors := []error{}
 
-	if unitName == "" {
-		unitName = c.hcUnitName(isStartup, true)
-	}
 	// Stop the timer before the service to make sure the timer does not
 	// fire after the service is stopped.
 	timerChan := make(chan string)
@@ -171,6 +232,19 @@ func (c *Container) disableHealthCheckSystemd(isStartup bool) bool {
 	return false
 }
 
+func (c *Container) disableReadinessSystemd() bool {
+	if !systemdCommon.RunsOnSystemd() || os.Getenv("DISABLE_HC_SYSTEMD") == "true" {
+		return true
+	}
+	return c.config.ReadinessProbeConfig == nil || c.config.ReadinessProbeConfig.Interval <= 0
+}
+
+// Systemd unit name for the readiness probe systemd unit. Like for the
+// healthcheck units, a random suffix keeps the names unique from run to run.
+func (c *Container) readinessUnitName() string {
+	return fmt.Sprintf("%s-readiness-%x", c.ID(), rand.Int())
+}
+
 // Systemd unit name for the healthcheck systemd unit.
 // Bare indicates that a random suffix should not be applied to the name. This
 // was default behavior previously, and is used for backwards compatibility.
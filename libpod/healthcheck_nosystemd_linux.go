@@ -21,3 +21,19 @@ func (c *Container) startTimer(isStartup bool) error {
 func (c *Container) removeTransientFiles(ctx context.Context, isStartup bool, unitName string) error {
 	return nil
 }
+
+// createReadinessTimer creates the systemd timer for the readiness probe
+func (c *Container) createReadinessTimer() error {
+	return nil
+}
+
+// startReadinessTimer starts the systemd timer for the readiness probe
+func (c *Container) startReadinessTimer() error {
+	return nil
+}
+
+// removeReadinessTimer removes the systemd timer and unit files of the
+// readiness probe of the container
+func (c *Container) removeReadinessTimer(ctx context.Context) error {
+	return nil
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/containers/podman/v5/libpod/define"
 	"github.com/containers/podman/v5/pkg/domain/entities"
 	"github.com/containers/podman/v5/pkg/env"
+	v1apps "github.com/containers/podman/v5/pkg/k8s.io/api/apps/v1"
 	v1 "github.com/containers/podman/v5/pkg/k8s.io/api/core/v1"
 	"github.com/containers/podman/v5/pkg/k8s.io/apimachinery/pkg/api/resource"
 	v12 "github.com/containers/podman/v5/pkg/k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -288,6 +289,135 @@ func GenerateForKubeJob(ctx context.Context, pod *YAMLPod, options entities.Gene
 	return &job, nil
 }
 
+// GenerateForKubeStatefulSet returns a YAMLStatefulSet from a YAMLPod that is then used to create a kubernetes
+// StatefulSet kind YAML.  The persistent volume claims of the pod become volume claim templates so that every
+// replica gets its own volumes.
+func GenerateForKubeStatefulSet(ctx context.Context, pod *YAMLPod, options entities.GenerateKubeOptions) (*YAMLStatefulSet, error) {
+	// Restart policy for StatefulSets can only be set to Always
+	if !(pod.Spec.RestartPolicy == "" || pod.Spec.RestartPolicy == v1.RestartPolicyAlways) {
+		return nil, fmt.Errorf("k8s StatefulSets can only have restartPolicy set to Always")
+	}
+
+	// Create label map that will be added to podSpec and StatefulSet metadata
+	// The matching label lets the statefulset know which pods to manage
+	appKey := "app"
+	matchLabels := map[string]string{appKey: pod.Name}
+	// Add the key:value (app:pod-name) to the podSpec labels
+	if pod.Labels == nil {
+		pod.Labels = matchLabels
+	} else {
+		pod.Labels[appKey] = pod.Name
+	}
+
+	var claimTemplates []v1.PersistentVolumeClaim
+	volumes := make([]v1.Volume, 0, len(pod.Spec.Volumes))
+	for _, vol := range pod.Spec.Volumes {
+		if vol.PersistentVolumeClaim == nil {
+			volumes = append(volumes, vol)
+			continue
+		}
+		claimTemplates = append(claimTemplates, v1.PersistentVolumeClaim{
+			ObjectMeta: v12.ObjectMeta{
+				Name: vol.Name,
+			},
+			Spec: defaultPersistentVolumeClaimSpec(),
+		})
+	}
+	pod.Spec.Volumes = volumes
+
+	setSpec := YAMLStatefulSetSpec{
+		StatefulSetSpec: v1apps.StatefulSetSpec{
+			Selector: &v12.LabelSelector{
+				MatchLabels: matchLabels,
+			},
+			ServiceName:          pod.Name,
+			VolumeClaimTemplates: claimTemplates,
+		},
+		Template: &YAMLPodTemplateSpec{
+			PodTemplateSpec: v1.PodTemplateSpec{
+				ObjectMeta: pod.ObjectMeta,
+			},
+			Spec: pod.Spec,
+		},
+	}
+
+	// Add replicas count if user adds replica number with --replicas flag and is greater than 1
+	if options.Replicas > 1 {
+		setSpec.Replicas = &options.Replicas
+	}
+
+	// Create the StatefulSet object
+	set := YAMLStatefulSet{
+		StatefulSet: v1apps.StatefulSet{
+			ObjectMeta: v12.ObjectMeta{
+				Name:              pod.Name + "-statefulset",
+				CreationTimestamp: pod.CreationTimestamp,
+				Labels:            pod.Labels,
+			},
+			TypeMeta: v12.TypeMeta{
+				Kind:       "StatefulSet",
+				APIVersion: "apps/v1",
+			},
+		},
+		Spec: &setSpec,
+	}
+
+	return &set, nil
+}
+
+// GenerateForKubeCronJob returns a YAMLCronJob from a YAMLPod that is then used to create a kubernetes CronJob
+// kind YAML.  The pod is wrapped into the Job template of the CronJob.
+func GenerateForKubeCronJob(ctx context.Context, pod *YAMLPod, cronJob *define.PodCronJob, options entities.GenerateKubeOptions) (*YAMLCronJob, error) {
+	if cronJob == nil || cronJob.Schedule == "" {
+		return nil, fmt.Errorf("a schedule is required to generate a k8s CronJob")
+	}
+	// Restart policy for Jobs cannot be set to Always
+	if pod.Spec.RestartPolicy == v1.RestartPolicyAlways {
+		return nil, fmt.Errorf("k8s CronJobs can not have restartPolicy set to Always; only Never and OnFailure policies allowed")
+	}
+
+	job, err := GenerateForKubeJob(ctx, pod, options)
+	if err != nil {
+		return nil, err
+	}
+
+	cronJobSpec := YAMLCronJobSpec{
+		CronJobSpec: v1.CronJobSpec{
+			Schedule: cronJob.Schedule,
+		},
+		JobTemplate: &YAMLJobTemplateSpec{
+			Spec: job.Spec,
+		},
+	}
+	if cronJob.TimeZone != "" {
+		cronJobSpec.TimeZone = &cronJob.TimeZone
+	}
+	if cronJob.ConcurrencyPolicy != "" {
+		cronJobSpec.ConcurrencyPolicy = v1.ConcurrencyPolicy(cronJob.ConcurrencyPolicy)
+	}
+	if cronJob.Suspend {
+		cronJobSpec.Suspend = &cronJob.Suspend
+	}
+
+	// Create the CronJob object
+	yamlCronJob := YAMLCronJob{
+		CronJob: v1.CronJob{
+			ObjectMeta: v12.ObjectMeta{
+				Name:              pod.Name + "-cronjob",
+				CreationTimestamp: pod.CreationTimestamp,
+				Labels:            pod.Labels,
+			},
+			TypeMeta: v12.TypeMeta{
+				Kind:       "CronJob",
+				APIVersion: "batch/v1",
+			},
+		},
+		Spec: &cronJobSpec,
+	}
+
+	return &yamlCronJob, nil
+}
+
 // GenerateForKube generates a v1.PersistentVolumeClaim from a libpod volume.
 func (v *Volume) GenerateForKube() *v1.PersistentVolumeClaim {
 	annotations := make(map[string]string)
@@ -319,16 +449,22 @@ func (v *Volume) GenerateForKube() *v1.PersistentVolumeClaim {
 			Annotations:       annotations,
 			CreationTimestamp: v12.Now(),
 		},
-		Spec: v1.PersistentVolumeClaimSpec{
-			Resources: v1.ResourceRequirements{
-				Requests: map[v1.ResourceName]resource.Quantity{
-					v1.ResourceStorage: resource.MustParse("1Gi"),
-				},
-			},
-			AccessModes: []v1.PersistentVolumeAccessMode{
-				v1.ReadWriteOnce,
+		Spec: defaultPersistentVolumeClaimSpec(),
+	}
+}
+
+// defaultPersistentVolumeClaimSpec returns the claim spec used for
+// generated persistent volume claims.
+func defaultPersistentVolumeClaimSpec() v1.PersistentVolumeClaimSpec {
+	return v1.PersistentVolumeClaimSpec{
+		Resources: v1.ResourceRequirements{
+			Requests: map[v1.ResourceName]resource.Quantity{
+				v1.ResourceStorage: resource.MustParse("1Gi"),
 			},
 		},
+		AccessModes: []v1.PersistentVolumeAccessMode{
+			v1.ReadWriteOnce,
+		},
 	}
 }
 
@@ -392,6 +528,31 @@ type YAMLJobSpec struct {
 	Template *YAMLPodTemplateSpec `json:"template,omitempty"`
 }
 
+// YAMLStatefulSetSpec represents the same k8s API apps StatefulSetSpec with a small
+// change and that is having Template as a pointer to YAMLPodTemplateSpec and UpdateStrategy
+// as a pointer to k8s API apps StatefulSetUpdateStrategy.
+// Because Go doesn't omit empty struct and we want to omit UpdateStrategy and any fields in the Pod YAML
+// if it's empty.
+type YAMLStatefulSetSpec struct {
+	v1apps.StatefulSetSpec
+	Template       *YAMLPodTemplateSpec              `json:"template,omitempty"`
+	UpdateStrategy *v1apps.StatefulSetUpdateStrategy `json:"updateStrategy,omitempty"`
+}
+
+// YAMLJobTemplateSpec represents the same k8s API core JobTemplateSpec with a small
+// change and that is having Spec as a pointer to YAMLJobSpec.
+type YAMLJobTemplateSpec struct {
+	v1.JobTemplateSpec
+	Spec *YAMLJobSpec `json:"spec,omitempty"`
+}
+
+// YAMLCronJobSpec represents the same k8s API core CronJobSpec with a small
+// change and that is having JobTemplate as a pointer to YAMLJobTemplateSpec.
+type YAMLCronJobSpec struct {
+	v1.CronJobSpec
+	JobTemplate *YAMLJobTemplateSpec `json:"jobTemplate,omitempty"`
+}
+
 // YAMLDaemonSet represents the same k8s API core DaemonSet with a small change
 // and that is having Spec as a pointer to YAMLDaemonSetSpec and Status as a pointer to
 // k8s API core DaemonSetStatus.
@@ -420,6 +581,24 @@ type YAMLJob struct {
 	Status *v1.JobStatus `json:"status,omitempty"`
 }
 
+// YAMLStatefulSet represents the same k8s API apps StatefulSet with a small change
+// and that is having Spec as a pointer to YAMLStatefulSetSpec and Status as a pointer to
+// k8s API apps StatefulSetStatus.
+type YAMLStatefulSet struct {
+	v1apps.StatefulSet
+	Spec   *YAMLStatefulSetSpec      `json:"spec,omitempty"`
+	Status *v1apps.StatefulSetStatus `json:"status,omitempty"`
+}
+
+// YAMLCronJob represents the same k8s API core CronJob with a small change
+// and that is having Spec as a pointer to YAMLCronJobSpec and Status as a pointer to
+// k8s API core CronJobStatus.
+type YAMLCronJob struct {
+	v1.CronJob
+	Spec   *YAMLCronJobSpec  `json:"spec,omitempty"`
+	Status *v1.CronJobStatus `json:"status,omitempty"`
+}
+
 // YAMLService represents the same k8s API core Service struct with a small
 // change and that is having Status as a pointer to k8s API core ServiceStatus.
 // Because Go doesn't omit empty struct and we want to omit Status in YAML
@@ -83,13 +83,9 @@ func GetLogFile(path string, options *LogOptions) (*tail.Tail, []*LogLine, error
 	return t, logTail, err
 }
 
+// getTailLog returns the last tail lines of the log file at path, continuing
+// with the rotated generations when the log file has fewer lines.
 func getTailLog(path string, tail int) ([]*LogLine, error) {
-	var (
-		nllCounter int
-		leftover   string
-		tailLog    []*LogLine
-		eof        bool
-	)
 	f, err := os.Open(path)
 	if err != nil {
 		return nil, err
@@ -100,13 +96,62 @@ func getTailLog(path string, tail int) ([]*LogLine, error) {
 		return nil, err
 	}
 
-	first := true
+	t := &tailLogReader{tail: tail, first: true}
+	done, err := t.read(rr.Read)
+	if err != nil || done {
+		return reverseLog(t.lines), err
+	}
+
+	rotated, err := rotatedLogFiles(path)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range rotated {
+		content, err := readRotatedLogFile(file)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				// rotated away in the meantime
+				continue
+			}
+			return nil, err
+		}
+		read := func() (string, error) {
+			return string(content), io.EOF
+		}
+		done, err := t.read(read)
+		if err != nil {
+			return nil, err
+		}
+		if done {
+			break
+		}
+	}
+	// because we add lines in the inverse order we must invert the slice in the end
+	return reverseLog(t.lines), nil
+}
 
+// tailLogReader collects the last lines of log files, reading them backwards.
+type tailLogReader struct {
+	tail       int
+	nllCounter int
+	first      bool
+	// lines are the collected lines in inverse order.
+	lines []*LogLine
+}
+
+// read reads a log file backwards. readChunk returns the previous chunk of
+// the file, and io.EOF with the first one. It returns true when enough lines
+// were read.
+func (t *tailLogReader) read(readChunk func() (string, error)) (bool, error) {
+	var (
+		leftover string
+		eof      bool
+	)
 	for {
-		s, err := rr.Read()
+		s, err := readChunk()
 		if err != nil {
 			if !errors.Is(err, io.EOF) {
-				return nil, fmt.Errorf("reverse log read: %w", err)
+				return false, fmt.Errorf("reverse log read: %w", err)
 			}
 			eof = true
 		}
@@ -114,48 +159,48 @@ func getTailLog(path string, tail int) ([]*LogLine, error) {
 		lines := strings.Split(s+leftover, "\n")
 		// we read a chunk of data, so make sure to read the line in inverse order
 		for i := len(lines) - 1; i > 0; i-- {
-			// ignore empty lines
-			if lines[i] == "" {
-				continue
-			}
-			nll, err := NewLogLine(lines[i])
-			if err != nil {
-				return nil, err
-			}
-			if !nll.Partial() || first {
-				nllCounter++
-				// Even if the last line is partial we need to count it as it will be printed as line.
-				// Because we read backwards the first line we read is the last line in the log.
-				first = false
-			}
-			// We explicitly need to check for more lines than tail because we have
-			// to read to next full line and must keep all partial lines
-			// https://github.com/containers/podman/issues/19545
-			if nllCounter > tail {
-				// because we add lines in the inverse order we must invert the slice in the end
-				return reverseLog(tailLog), nil
+			done, err := t.add(lines[i])
+			if err != nil || done {
+				return done, err
 			}
-			// only append after the return here because we do not want to include the next full line
-			tailLog = append(tailLog, nll)
 		}
 		leftover = lines[0]
 
 		// eof was reached
 		if eof {
-			// when we have still a line and do not have enough tail lines already
-			if leftover != "" && nllCounter < tail {
-				nll, err := NewLogLine(leftover)
-				if err != nil {
-					return nil, err
-				}
-				tailLog = append(tailLog, nll)
-			}
-			// because we add lines in the inverse order we must invert the slice in the end
-			return reverseLog(tailLog), nil
+			// the first line of the file
+			return t.add(leftover)
 		}
 	}
 }
 
+// add adds a line read backwards, it returns true when enough lines were read.
+func (t *tailLogReader) add(line string) (bool, error) {
+	// ignore empty lines
+	if line == "" {
+		return false, nil
+	}
+	nll, err := NewLogLine(line)
+	if err != nil {
+		return false, err
+	}
+	if !nll.Partial() || t.first {
+		t.nllCounter++
+		// Even if the last line is partial we need to count it as it will be printed as line.
+		// Because we read backwards the first line we read is the last line in the log.
+		t.first = false
+	}
+	// We explicitly need to check for more lines than tail because we have
+	// to read to next full line and must keep all partial lines
+	// https://github.com/containers/podman/issues/19545
+	if t.nllCounter > t.tail {
+		return true, nil
+	}
+	// only append after the return here because we do not want to include the next full line
+	t.lines = append(t.lines, nll)
+	return false, nil
+}
+
 // reverseLog reverse the log line slice, needed for tail as we read lines backwards but still
 // need to print them in the correct order at the end  so use that helper for it.
 func reverseLog(s []*LogLine) []*LogLine {
@@ -0,0 +1,196 @@
+package logs
+
+import (
+	"bufio"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// compressedSuffix is the suffix of compressed rotated log files.
+const compressedSuffix = ".gz"
+
+// rotatedLogFile is a rotated generation of a log file. The log file at path
+// is rotated to path.1, path.1 to path.2 and so on, with compressedSuffix
+// appended to compressed generations.
+type rotatedLogFile struct {
+	path       string
+	generation int
+	compressed bool
+}
+
+// rotatedLogFiles returns the rotated generations of the log file at path,
+// newest first.
+func rotatedLogFiles(path string) ([]rotatedLogFile, error) {
+	matches, err := filepath.Glob(globEscape(path) + ".*")
+	if err != nil {
+		return nil, err
+	}
+	var files []rotatedLogFile
+	for _, match := range matches {
+		suffix := strings.TrimPrefix(match, path+".")
+		compressed := strings.HasSuffix(suffix, compressedSuffix)
+		generation, err := strconv.Atoi(strings.TrimSuffix(suffix, compressedSuffix))
+		if err != nil || generation < 1 {
+			continue
+		}
+		files = append(files, rotatedLogFile{path: match, generation: generation, compressed: compressed})
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].generation < files[j].generation
+	})
+	return files, nil
+}
+
+// globEscape escapes the glob metacharacters in path.
+func globEscape(path string) string {
+	var b strings.Builder
+	for _, r := range path {
+		if strings.ContainsRune(`*?[\`, r) {
+			b.WriteRune('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// rotatedLogFilePath returns the path of the given generation of the log
+// file at path.
+func rotatedLogFilePath(path string, generation int, compressed bool) string {
+	p := fmt.Sprintf("%s.%d", path, generation)
+	if compressed {
+		p += compressedSuffix
+	}
+	return p
+}
+
+// readRotatedLogFile returns the content of a rotated log file.
+func readRotatedLogFile(file rotatedLogFile) ([]byte, error) {
+	f, err := os.Open(file.path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	if !file.compressed {
+		return io.ReadAll(f)
+	}
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("reading compressed log file %s: %w", file.path, err)
+	}
+	defer gz.Close()
+	return io.ReadAll(gz)
+}
+
+// ReadRotatedLogFiles calls fn for each line of the rotated generations of
+// the log file at path, oldest first. Generations rotated away while reading
+// are skipped.
+func ReadRotatedLogFiles(path string, fn func(*LogLine)) error {
+	files, err := rotatedLogFiles(path)
+	if err != nil {
+		return err
+	}
+	for i := len(files) - 1; i >= 0; i-- {
+		f, err := os.Open(files[i].path)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return err
+		}
+		var reader io.Reader = f
+		if files[i].compressed {
+			gz, err := gzip.NewReader(f)
+			if err != nil {
+				f.Close()
+				return fmt.Errorf("reading compressed log file %s: %w", files[i].path, err)
+			}
+			reader = gz
+		}
+		scanner := bufio.NewScanner(reader)
+		scanner.Buffer(nil, 1024*1024)
+		for scanner.Scan() {
+			if scanner.Text() == "" {
+				continue
+			}
+			nll, err := NewLogLine(scanner.Text())
+			if err != nil {
+				f.Close()
+				return err
+			}
+			fn(nll)
+		}
+		f.Close()
+		if err := scanner.Err(); err != nil {
+			return fmt.Errorf("reading log file %s: %w", files[i].path, err)
+		}
+	}
+	return nil
+}
+
+// RotateLogFile stores the content of current, a log file generation which
+// was replaced at path, as the first rotated generation of the log file at
+// path. Older generations are shifted, keeping at most maxFiles log files
+// including the one at path.
+func RotateLogFile(path string, current *os.File, maxFiles int, compress bool) error {
+	files, err := rotatedLogFiles(path)
+	if err != nil {
+		return err
+	}
+	for i := len(files) - 1; i >= 0; i-- {
+		file := files[i]
+		if file.generation >= maxFiles-1 {
+			if err := os.Remove(file.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+			continue
+		}
+		if err := os.Rename(file.path, rotatedLogFilePath(path, file.generation+1, file.compressed)); err != nil {
+			return err
+		}
+	}
+	if maxFiles < 2 {
+		return nil
+	}
+
+	target := rotatedLogFilePath(path, 1, compress)
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(target)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if err := tmp.Chmod(0o640); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := current.Seek(0, io.SeekStart); err != nil {
+		tmp.Close()
+		return err
+	}
+	var w io.Writer = tmp
+	var gz *gzip.Writer
+	if compress {
+		gz = gzip.NewWriter(tmp)
+		w = gz
+	}
+	if _, err := io.Copy(w, current); err != nil {
+		tmp.Close()
+		return err
+	}
+	if gz != nil {
+		if err := gz.Close(); err != nil {
+			tmp.Close()
+			return err
+		}
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), target)
+}
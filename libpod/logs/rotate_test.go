@@ -0,0 +1,121 @@
+package logs
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testLogContent(from, to int) string {
+	var content string
+	for i := from; i <= to; i++ {
+		content += fmt.Sprintf("2023-08-07T19:56:34.223758260-06:00 stdout F line%d\n", i)
+	}
+	return content
+}
+
+func testLogLines(from, to int) []*LogLine {
+	var lines []*LogLine
+	for i := from; i <= to; i++ {
+		lines = append(lines, makeTestLogLine("F", fmt.Sprintf("line%d", i)))
+	}
+	return lines
+}
+
+// rotateTestLog simulates conmon replacing the log file at path by a new one
+// with content, and rotates the replaced file.
+func rotateTestLog(t *testing.T, path, content string, maxFiles int, compress bool) {
+	current, err := os.Open(path)
+	require.NoError(t, err)
+	defer current.Close()
+	require.NoError(t, os.WriteFile(path+".tmp", []byte(content), 0o640))
+	require.NoError(t, os.Rename(path+".tmp", path))
+	require.NoError(t, RotateLogFile(path, current, maxFiles, compress))
+}
+
+func readGzip(t *testing.T, path string) string {
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	require.NoError(t, err)
+	content, err := io.ReadAll(gz)
+	require.NoError(t, err)
+	return string(content)
+}
+
+func TestRotateLogFile(t *testing.T) {
+	for _, compress := range []bool{false, true} {
+		t.Run(fmt.Sprintf("compress=%t", compress), func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, "ctr.log")
+			require.NoError(t, os.WriteFile(path, []byte(testLogContent(1, 3)), 0o640))
+
+			rotateTestLog(t, path, testLogContent(4, 6), 3, compress)
+			rotateTestLog(t, path, testLogContent(7, 9), 3, compress)
+			rotateTestLog(t, path, testLogContent(10, 12), 3, compress)
+
+			suffix := ""
+			if compress {
+				suffix = compressedSuffix
+			}
+			entries, err := os.ReadDir(dir)
+			require.NoError(t, err)
+			var names []string
+			for _, entry := range entries {
+				names = append(names, entry.Name())
+			}
+			assert.ElementsMatch(t, []string{"ctr.log", "ctr.log.1" + suffix, "ctr.log.2" + suffix}, names)
+
+			if compress {
+				assert.Equal(t, testLogContent(7, 9), readGzip(t, path+".1.gz"))
+			} else {
+				content, err := os.ReadFile(path + ".1")
+				require.NoError(t, err)
+				assert.Equal(t, testLogContent(7, 9), string(content))
+			}
+
+			var lines []*LogLine
+			require.NoError(t, ReadRotatedLogFiles(path, func(nll *LogLine) {
+				lines = append(lines, nll)
+			}))
+			assert.Equal(t, testLogLines(4, 9), lines)
+
+			got, err := getTailLog(path, 5)
+			require.NoError(t, err)
+			assert.Equal(t, testLogLines(8, 12), got)
+
+			got, err = getTailLog(path, 100)
+			require.NoError(t, err)
+			assert.Equal(t, testLogLines(4, 12), got)
+
+			// Right after a rotation, the lines are all read from
+			// the rotated log files.
+			rotateTestLog(t, path, "", 3, compress)
+			got, err = getTailLog(path, 5)
+			require.NoError(t, err)
+			assert.Equal(t, testLogLines(8, 12), got)
+		})
+	}
+}
+
+func TestRotatedLogFiles(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "ctr[1].log")
+	for _, name := range []string{"ctr[1].log", "ctr[1].log.10.gz", "ctr[1].log.2", "ctr[1].log.1", "ctr[1].log.tmp", "ctr[1].log.1.gz.tmp123", "ctr.log.3"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), nil, 0o640))
+	}
+	files, err := rotatedLogFiles(path)
+	require.NoError(t, err)
+	assert.Equal(t, []rotatedLogFile{
+		{path: path + ".1", generation: 1},
+		{path: path + ".2", generation: 2},
+		{path: path + ".10.gz", generation: 10, compressed: true},
+	}, files)
+}
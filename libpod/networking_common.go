@@ -74,6 +74,12 @@ func (c *Container) getNetworkOptions(networkOpts map[string]types.PerNetworkOpt
 // setUpNetwork will set up the networks, on error it will also tear down the cni
 // networks. If rootless it will join/create the rootless network namespace.
 func (r *Runtime) setUpNetwork(ns string, opts types.NetworkOptions) (map[string]types.StatusBlock, error) {
+	lock, err := r.getNetworkUpdateLock()
+	if err != nil {
+		return nil, err
+	}
+	lock.RLock()
+	defer lock.Unlock()
 	return r.network.Setup(ns, types.SetupOptions{NetworkOptions: opts})
 }
 
@@ -92,6 +98,12 @@ func getNetworkPodName(c *Container) string {
 // Tear down a container's network configuration and joins the
 // rootless net ns as rootless user
 func (r *Runtime) teardownNetworkBackend(ns string, opts types.NetworkOptions) error {
+	lock, err := r.getNetworkUpdateLock()
+	if err != nil {
+		return err
+	}
+	lock.RLock()
+	defer lock.Unlock()
 	return r.network.Teardown(ns, types.TeardownOptions{NetworkOptions: opts})
 }
 
@@ -18,6 +18,9 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+// netavarkConfigDir is the config directory for the rootful network files
+const netavarkConfigDir = "/usr/local/etc/containers/networks"
+
 type Netstat struct {
 	Statistics NetstatInterface `json:"statistics"`
 }
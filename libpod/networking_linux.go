@@ -16,6 +16,9 @@ import (
 	"github.com/vishvananda/netlink"
 )
 
+// netavarkConfigDir is the config directory for the rootful network files
+const netavarkConfigDir = "/etc/containers/networks"
+
 // Create and configure a new network namespace for a container
 func (r *Runtime) configureNetNS(ctr *Container, ctrNS string) (status map[string]types.StatusBlock, rerr error) {
 	if err := r.exposeMachinePorts(ctr.config.PortMappings); err != nil {
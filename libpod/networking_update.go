@@ -0,0 +1,824 @@
+//go:build !remote && (linux || freebsd)
+
+package libpod
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"maps"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+
+	"github.com/containers/common/libnetwork/etchosts"
+	"github.com/containers/common/libnetwork/types"
+	netutil "github.com/containers/common/libnetwork/util"
+	"github.com/containers/common/pkg/config"
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/pkg/rootless"
+	"github.com/containers/storage/pkg/ioutils"
+	"github.com/containers/storage/pkg/lockfile"
+	"github.com/sirupsen/logrus"
+)
+
+// netavarkRootLockPath is the lock of the netavark backend for rootful
+// network changes.
+const netavarkRootLockPath = "/run/lock/netavark.lock"
+
+// NetworkUpdateOptions describes the changes of a network update. The DNS
+// server changes of the embedded options are applied by the network backend,
+// all other changes are applied by libpod.
+type NetworkUpdateOptions struct {
+	types.NetworkUpdateOptions
+	// AddSubnets are added to the network. The gateway and lease range
+	// of each subnet are optional.
+	AddSubnets []types.Subnet
+	// RemoveSubnets are removed from the network.
+	RemoveSubnets []types.IPNet
+	// AddIPRanges set the lease range of the subnet which contains the range.
+	AddIPRanges []types.LeaseRange
+	// RemoveIPRanges unset the matching lease range of a subnet.
+	RemoveIPRanges []types.LeaseRange
+	// AddLabels are set on the network, existing keys are overwritten.
+	AddLabels map[string]string
+	// RemoveLabels are the label keys to remove from the network.
+	RemoveLabels []string
+	// AddOptions are driver options set on the network, e.g. mtu,
+	// metric or isolate. Existing keys are overwritten.
+	AddOptions map[string]string
+	// RemoveOptions are the driver option keys to remove from the network.
+	RemoveOptions []string
+	// Internal changes whether the network is internal when set.
+	Internal *bool
+	// DNSEnabled changes whether name resolution is enabled when set.
+	DNSEnabled *bool
+}
+
+// changesConfig returns true if the update touches more than the DNS servers
+// which the network backend can update on its own.
+func (o *NetworkUpdateOptions) changesConfig() bool {
+	return o.changesContainerSetup() ||
+		len(o.AddIPRanges) > 0 || len(o.RemoveIPRanges) > 0 ||
+		len(o.AddLabels) > 0 || len(o.RemoveLabels) > 0
+}
+
+// changesContainerSetup returns true if the update changes the network
+// setup of containers and not only the network config.
+func (o *NetworkUpdateOptions) changesContainerSetup() bool {
+	return len(o.AddSubnets) > 0 || len(o.RemoveSubnets) > 0 ||
+		len(o.AddOptions) > 0 || len(o.RemoveOptions) > 0 ||
+		o.Internal != nil || o.DNSEnabled != nil
+}
+
+// getNetworkUpdateLock returns the lock which serializes network updates
+// against setting up and tearing down container networks. Updates take it
+// exclusively, everything else only needs a read lock.
+func (r *Runtime) getNetworkUpdateLock() (*lockfile.LockFile, error) {
+	return lockfile.GetLockFile(filepath.Join(r.config.Engine.TmpDir, "network-update.lck"))
+}
+
+// networkConfigDir returns the directory of the netavark network config
+// files, it must match the directory used by the network backend.
+func (r *Runtime) networkConfigDir() string {
+	if r.config.Network.NetworkConfigDir != "" {
+		return r.config.Network.NetworkConfigDir
+	}
+	if !rootless.IsRootless() {
+		return netavarkConfigDir
+	}
+	return filepath.Join(r.store.GraphRoot(), "networks")
+}
+
+// getNetworkBackendLock returns the lock the netavark backend holds while it
+// reads or writes the network configs, e.g. when a network is created. The
+// network backend must not be used while holding it, the lock is not
+// reentrant within the process.
+func (r *Runtime) getNetworkBackendLock() (*lockfile.LockFile, error) {
+	// root uses a global lock as there is only one host netns
+	lockPath := netavarkRootLockPath
+	if rootless.IsRootless() {
+		lockPath = filepath.Join(r.networkConfigDir(), "netavark.lock")
+	}
+	return lockfile.GetLockFile(lockPath)
+}
+
+// NetworkUpdate updates the config of the given network. Changes which only
+// touch the DNS servers are handed to the network backend directly. All other
+// changes are validated first and are then written to the network config.
+// Changes to subnets, options, internal and dns are applied to the running
+// containers attached to the network by tearing down their network with the
+// old config and setting it up again with the new one. The names of the
+// containers which could not be reconfigured are returned, they need a
+// restart to pick up the changes. They are also returned together with an
+// error when the update failed after containers were torn down.
+func (r *Runtime) NetworkUpdate(nameOrID string, options NetworkUpdateOptions) ([]string, error) {
+	netName, _, err := r.normalizeNetworkName(nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	if !options.changesConfig() {
+		// The backend rewrites the whole config, do not lose the changes
+		// of a concurrent update.
+		lock, err := r.getNetworkUpdateLock()
+		if err != nil {
+			return nil, err
+		}
+		lock.Lock()
+		defer lock.Unlock()
+		return nil, r.network.NetworkUpdate(netName, options.NetworkUpdateOptions)
+	}
+	if r.config.Network.NetworkBackend != string(types.Netavark) {
+		return nil, fmt.Errorf("updating more than the dns servers of a network is only supported with the %s network backend: %w", types.Netavark, define.ErrInvalidArg)
+	}
+
+	// Lock the attached containers before the network update lock, starting
+	// containers take the network update lock while holding their own lock.
+	var attached []*Container
+	if options.changesContainerSetup() {
+		ctrs, err := r.GetAllContainers()
+		if err != nil {
+			return nil, err
+		}
+		for _, ctr := range ctrs {
+			networks, err := ctr.networks()
+			if err != nil {
+				if errors.Is(err, define.ErrNoSuchCtr) || errors.Is(err, define.ErrCtrRemoved) {
+					continue
+				}
+				return nil, err
+			}
+			if _, ok := networks[netName]; !ok {
+				continue
+			}
+			ctr.lock.Lock()
+			defer ctr.lock.Unlock()
+			attached = append(attached, ctr)
+		}
+	}
+
+	lock, err := r.getNetworkUpdateLock()
+	if err != nil {
+		return nil, err
+	}
+	lock.Lock()
+	defer lock.Unlock()
+
+	network, err := r.network.NetworkInspect(netName)
+	if err != nil {
+		return nil, err
+	}
+	newNetwork, err := r.updateNetworkConfig(&network, &options)
+	if err != nil {
+		return nil, err
+	}
+
+	needRestart := []string{}
+	reconnect := make([]*Container, 0, len(attached))
+	if !reflect.DeepEqual(&network, newNetwork) {
+		// The containers must be torn down with the old config, otherwise
+		// the firewall rules of removed subnets would be left behind.
+		for _, ctr := range attached {
+			isSetUp, err := ctr.networkUpdateTeardown(netName)
+			if err != nil {
+				if errors.Is(err, define.ErrNoSuchCtr) || errors.Is(err, define.ErrCtrRemoved) {
+					continue
+				}
+				if errors.Is(err, define.ErrCtrStateInvalid) {
+					needRestart = append(needRestart, ctr.Name())
+					continue
+				}
+				logrus.Errorf("Tearing down network %s of container %s: %v", netName, ctr.ID(), err)
+			}
+			if isSetUp {
+				reconnect = append(reconnect, ctr)
+			}
+		}
+
+		// Always set up the containers again, if writing the config
+		// failed they simply use the old config.
+		commitErr := r.commitNetworkConfig(newNetwork, addedSubnets(&network, newNetwork))
+		for _, ctr := range reconnect {
+			if err := ctr.networkUpdateSetup(netName); err != nil {
+				logrus.Errorf("Setting up network %s of container %s: %v", netName, ctr.ID(), err)
+				needRestart = append(needRestart, ctr.Name())
+			}
+		}
+		if commitErr != nil {
+			return needRestart, commitErr
+		}
+	}
+
+	if len(options.AddDNSServers) > 0 || len(options.RemoveDNSServers) > 0 {
+		if err := r.network.NetworkUpdate(netName, options.NetworkUpdateOptions); err != nil {
+			return needRestart, err
+		}
+	}
+	return needRestart, nil
+}
+
+// updateNetworkConfig returns a copy of the network with all changes from the
+// update options applied. All changes are validated so that the new config
+// can be written without touching any container first.
+func (r *Runtime) updateNetworkConfig(network *types.Network, options *NetworkUpdateOptions) (*types.Network, error) {
+	newNetwork := copyNetwork(network)
+	for key, value := range options.AddLabels {
+		newNetwork.Labels[key] = value
+	}
+	for _, key := range options.RemoveLabels {
+		delete(newNetwork.Labels, key)
+	}
+	for key, value := range options.AddOptions {
+		newNetwork.Options[key] = value
+	}
+	for _, key := range options.RemoveOptions {
+		delete(newNetwork.Options, key)
+	}
+	if options.Internal != nil {
+		newNetwork.Internal = *options.Internal
+	}
+	if options.DNSEnabled != nil {
+		if *options.DNSEnabled && newNetwork.Driver != types.BridgeNetworkDriver {
+			return nil, fmt.Errorf("dns can only be enabled for %s networks: %w", types.BridgeNetworkDriver, define.ErrInvalidArg)
+		}
+		newNetwork.DNSEnabled = *options.DNSEnabled
+	}
+
+	subnetsChanged := len(options.AddSubnets) > 0 || len(options.RemoveSubnets) > 0 ||
+		len(options.AddIPRanges) > 0 || len(options.RemoveIPRanges) > 0
+	if subnetsChanged {
+		if newNetwork.IPAMOptions[types.Driver] != types.HostLocalIPAMDriver {
+			return nil, fmt.Errorf("subnets can only be changed with the %s ipam driver: %w", types.HostLocalIPAMDriver, define.ErrInvalidArg)
+		}
+		var usedNetworks []*net.IPNet
+		if len(options.AddSubnets) > 0 {
+			var err error
+			usedNetworks, err = r.usedSubnets()
+			if err != nil {
+				return nil, err
+			}
+		}
+		if err := updateNetworkSubnets(newNetwork, options, usedNetworks); err != nil {
+			return nil, err
+		}
+	}
+
+	var err error
+	switch newNetwork.Driver {
+	case types.BridgeNetworkDriver:
+		err = validateBridgeOptions(newNetwork)
+	case types.MacVLANNetworkDriver, types.IPVLANNetworkDriver:
+		err = validateIpvlanOrMacvlanOptions(newNetwork)
+	default:
+		if len(options.AddOptions) > 0 || len(options.RemoveOptions) > 0 || subnetsChanged {
+			err = fmt.Errorf("updating options or subnets of %s networks is not supported", newNetwork.Driver)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", err, define.ErrInvalidArg)
+	}
+
+	// The DNS servers are changed by the network backend after the config
+	// was written, make sure it will accept the result.
+	dnsServers := slices.DeleteFunc(slices.Clone(newNetwork.NetworkDNSServers), func(server string) bool {
+		return slices.Contains(options.RemoveDNSServers, server)
+	})
+	for _, server := range options.AddDNSServers {
+		if net.ParseIP(server) == nil {
+			return nil, fmt.Errorf("unable to parse ip %s specified in AddDNSServer: %w", server, define.ErrInvalidArg)
+		}
+		dnsServers = append(dnsServers, server)
+	}
+	if len(dnsServers) > 0 && !newNetwork.DNSEnabled {
+		return nil, fmt.Errorf("cannot set NetworkDNSServers if DNS is not enabled for the network: %w", define.ErrInvalidArg)
+	}
+
+	// add gateway when not internal or dns enabled
+	addGateway := !newNetwork.Internal || newNetwork.DNSEnabled
+	for i := range newNetwork.Subnets {
+		if err := validateSubnet(&newNetwork.Subnets[i], addGateway, nil); err != nil {
+			return nil, fmt.Errorf("%v: %w", err, define.ErrInvalidArg)
+		}
+	}
+	return newNetwork, nil
+}
+
+// updateNetworkSubnets applies the subnet and ip range changes to the network.
+// Added subnets must not overlap with the given used networks.
+func updateNetworkSubnets(network *types.Network, options *NetworkUpdateOptions, usedNetworks []*net.IPNet) error {
+	for _, subnet := range options.RemoveSubnets {
+		idx := slices.IndexFunc(network.Subnets, func(s types.Subnet) bool {
+			return s.Subnet.String() == subnet.String()
+		})
+		if idx < 0 {
+			return fmt.Errorf("subnet %s is not part of network %s: %w", subnet.String(), network.Name, define.ErrInvalidArg)
+		}
+		network.Subnets = slices.Delete(network.Subnets, idx, idx+1)
+	}
+	for _, subnet := range options.AddSubnets {
+		if err := validateSubnet(&subnet, false, usedNetworks); err != nil {
+			return fmt.Errorf("%v: %w", err, define.ErrInvalidArg)
+		}
+		usedNetworks = append(usedNetworks, &subnet.Subnet.IPNet)
+		network.Subnets = append(network.Subnets, subnet)
+	}
+	for _, ipRange := range options.RemoveIPRanges {
+		idx := slices.IndexFunc(network.Subnets, func(s types.Subnet) bool {
+			return s.LeaseRange != nil && s.LeaseRange.StartIP.Equal(ipRange.StartIP) && s.LeaseRange.EndIP.Equal(ipRange.EndIP)
+		})
+		if idx < 0 {
+			return fmt.Errorf("ip range %s-%s is not set on network %s: %w", ipRange.StartIP, ipRange.EndIP, network.Name, define.ErrInvalidArg)
+		}
+		network.Subnets[idx].LeaseRange = nil
+	}
+	for _, ipRange := range options.AddIPRanges {
+		idx := slices.IndexFunc(network.Subnets, func(s types.Subnet) bool {
+			return s.Subnet.Contains(ipRange.StartIP) && s.Subnet.Contains(ipRange.EndIP)
+		})
+		if idx < 0 {
+			return fmt.Errorf("ip range %s-%s is not part of a subnet of network %s: %w", ipRange.StartIP, ipRange.EndIP, network.Name, define.ErrInvalidArg)
+		}
+		network.Subnets[idx].LeaseRange = &types.LeaseRange{
+			StartIP: ipRange.StartIP,
+			EndIP:   ipRange.EndIP,
+		}
+	}
+	if len(network.Subnets) == 0 {
+		return fmt.Errorf("network %s needs at least one subnet with the %s ipam driver: %w", network.Name, types.HostLocalIPAMDriver, define.ErrInvalidArg)
+	}
+	network.IPv6Enabled = slices.ContainsFunc(network.Subnets, func(s types.Subnet) bool {
+		return netutil.IsIPv6(s.Subnet.IP)
+	})
+	return nil
+}
+
+// usedSubnets returns the subnets of all network configs and of the
+// interfaces on the host.
+func (r *Runtime) usedSubnets() ([]*net.IPNet, error) {
+	networks, err := r.network.NetworkList()
+	if err != nil {
+		return nil, err
+	}
+	subnets := []*net.IPNet{}
+	for _, network := range networks {
+		for _, subnet := range network.Subnets {
+			subnets = append(subnets, &subnet.Subnet.IPNet)
+		}
+	}
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return nil, err
+	}
+	for _, addr := range addrs {
+		if ipNet, ok := addr.(*net.IPNet); ok {
+			subnets = append(subnets, ipNet)
+		}
+	}
+	return subnets, nil
+}
+
+// validateSubnet makes sure that the gateway and lease range are part of the
+// subnet and that the subnet does not overlap with the used networks. When
+// addGateway is set a missing gateway is set to the first ip of the subnet.
+func validateSubnet(s *types.Subnet, addGateway bool, usedNetworks []*net.IPNet) error {
+	if s.Subnet.IP == nil {
+		return errors.New("subnet ip is nil")
+	}
+	_, n, err := net.ParseCIDR(s.Subnet.String())
+	if err != nil {
+		return fmt.Errorf("subnet invalid: %w", err)
+	}
+	for _, used := range usedNetworks {
+		if used.Contains(n.IP) || n.Contains(used.IP) {
+			return fmt.Errorf("subnet %s is already used on the host or by another config", n.String())
+		}
+	}
+
+	s.Subnet = types.IPNet{IPNet: *n}
+	if s.Gateway != nil {
+		if !s.Subnet.Contains(s.Gateway) {
+			return fmt.Errorf("gateway %s not in subnet %s", s.Gateway, &s.Subnet)
+		}
+		netutil.NormalizeIP(&s.Gateway)
+	} else if addGateway {
+		ip, err := netutil.FirstIPInSubnet(n)
+		if err != nil {
+			return err
+		}
+		s.Gateway = ip
+	}
+
+	if s.LeaseRange != nil {
+		if s.LeaseRange.StartIP != nil {
+			if !s.Subnet.Contains(s.LeaseRange.StartIP) {
+				return fmt.Errorf("lease range start ip %s not in subnet %s", s.LeaseRange.StartIP, &s.Subnet)
+			}
+			netutil.NormalizeIP(&s.LeaseRange.StartIP)
+		}
+		if s.LeaseRange.EndIP != nil {
+			if !s.Subnet.Contains(s.LeaseRange.EndIP) {
+				return fmt.Errorf("lease range end ip %s not in subnet %s", s.LeaseRange.EndIP, &s.Subnet)
+			}
+			netutil.NormalizeIP(&s.LeaseRange.EndIP)
+		}
+	}
+	return nil
+}
+
+// validateBridgeOptions validates the options of a bridge network the same
+// way the network backend does when the network is created.
+func validateBridgeOptions(network *types.Network) error {
+	for key, value := range network.Options {
+		switch key {
+		case types.MTUOption:
+			if err := validateMTU(value); err != nil {
+				return err
+			}
+		case types.VLANOption:
+			if value == "" {
+				continue
+			}
+			v, err := strconv.Atoi(value)
+			if err != nil {
+				return err
+			}
+			if v < 0 || v > 4094 {
+				return fmt.Errorf("vlan ID %d must be between 0 and 4094", v)
+			}
+		case types.MetricOption:
+			if _, err := strconv.ParseUint(value, 10, 32); err != nil {
+				return err
+			}
+		case types.IsolateOption:
+			if value == "" {
+				network.Options[key] = "false"
+				continue
+			}
+			if value == "strict" {
+				continue
+			}
+			val, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("failed to parse isolate option: %w", err)
+			}
+			// rust only support "true" or "false" while go can parse 1 and 0 as well so we need to change it
+			network.Options[key] = strconv.FormatBool(val)
+		case types.NoDefaultRoute:
+			val, err := strconv.ParseBool(value)
+			if err != nil {
+				return err
+			}
+			network.Options[key] = strconv.FormatBool(val)
+		case types.VRFOption:
+			if len(value) == 0 {
+				return errors.New("invalid vrf name")
+			}
+		case types.ModeOption:
+			if !slices.Contains(types.ValidBridgeModes, value) {
+				return fmt.Errorf("unknown bridge mode %q", value)
+			}
+		default:
+			return fmt.Errorf("unsupported bridge network option %s", key)
+		}
+	}
+	return nil
+}
+
+// validateIpvlanOrMacvlanOptions validates the options of a macvlan or ipvlan
+// network the same way the network backend does when the network is created.
+func validateIpvlanOrMacvlanOptions(network *types.Network) error {
+	isMacVlan := network.Driver == types.MacVLANNetworkDriver
+	for key, value := range network.Options {
+		switch key {
+		case types.ModeOption:
+			validModes := types.ValidIPVLANModes
+			if isMacVlan {
+				validModes = types.ValidMacVLANModes
+			}
+			if !slices.Contains(validModes, value) {
+				return fmt.Errorf("unknown %s mode %q", network.Driver, value)
+			}
+		case types.MTUOption:
+			if err := validateMTU(value); err != nil {
+				return err
+			}
+		case types.MetricOption:
+			if _, err := strconv.ParseUint(value, 10, 32); err != nil {
+				return err
+			}
+		case types.NoDefaultRoute:
+			val, err := strconv.ParseBool(value)
+			if err != nil {
+				return err
+			}
+			network.Options[key] = strconv.FormatBool(val)
+		case types.BclimOption:
+			if !isMacVlan {
+				return fmt.Errorf("unsupported %s network option %s", network.Driver, key)
+			}
+			if _, err := strconv.ParseInt(value, 10, 32); err != nil {
+				return fmt.Errorf("failed to parse %q option: %w", key, err)
+			}
+		default:
+			return fmt.Errorf("unsupported %s network option %s", network.Driver, key)
+		}
+	}
+	return nil
+}
+
+// validateMTU validates the mtu option, an empty value means the default.
+func validateMTU(mtu string) error {
+	if mtu == "" {
+		return nil
+	}
+	m, err := strconv.Atoi(mtu)
+	if err != nil {
+		return err
+	}
+	if m < 0 {
+		return fmt.Errorf("mtu %d is less than zero", m)
+	}
+	return nil
+}
+
+// copyNetwork returns a copy of the network which can be modified
+// without changing the original one.
+func copyNetwork(network *types.Network) *types.Network {
+	newNetwork := *network
+	newNetwork.Subnets = slices.Clone(network.Subnets)
+	newNetwork.Routes = slices.Clone(network.Routes)
+	newNetwork.NetworkDNSServers = slices.Clone(network.NetworkDNSServers)
+	newNetwork.Labels = maps.Clone(network.Labels)
+	if newNetwork.Labels == nil {
+		newNetwork.Labels = map[string]string{}
+	}
+	newNetwork.Options = maps.Clone(network.Options)
+	if newNetwork.Options == nil {
+		newNetwork.Options = map[string]string{}
+	}
+	newNetwork.IPAMOptions = maps.Clone(network.IPAMOptions)
+	return &newNetwork
+}
+
+// addedSubnets returns the subnets of the new network config which are not
+// part of the old one.
+func addedSubnets(network, newNetwork *types.Network) []types.Subnet {
+	var added []types.Subnet
+	for _, subnet := range newNetwork.Subnets {
+		if !slices.ContainsFunc(network.Subnets, func(s types.Subnet) bool {
+			return s.Subnet.String() == subnet.Subnet.String()
+		}) {
+			added = append(added, subnet)
+		}
+	}
+	return added
+}
+
+// commitNetworkConfig writes the network config file under the lock of the
+// network backend. The added subnets are checked against the configs of all
+// other networks again while holding the lock, a network created after the
+// update was validated must not get an overlapping subnet. The file is
+// replaced atomically which also changes the modification time of the config
+// directory, so the network backend reloads the config on its next use.
+func (r *Runtime) commitNetworkConfig(network *types.Network, added []types.Subnet) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetIndent("", "     ")
+	if err := enc.Encode(network); err != nil {
+		return err
+	}
+
+	lock, err := r.getNetworkBackendLock()
+	if err != nil {
+		return err
+	}
+	lock.Lock()
+	defer lock.Unlock()
+
+	if len(added) > 0 {
+		configured, err := r.configuredSubnets(network.Name)
+		if err != nil {
+			return err
+		}
+		for _, subnet := range added {
+			for name, used := range configured {
+				if slices.ContainsFunc(used, func(u *net.IPNet) bool {
+					return u.Contains(subnet.Subnet.IP) || subnet.Subnet.Contains(u.IP)
+				}) {
+					return fmt.Errorf("subnet %s is already used by network %s: %w", subnet.Subnet.String(), name, define.ErrInvalidArg)
+				}
+			}
+		}
+	}
+
+	confPath := filepath.Join(r.networkConfigDir(), network.Name+".json")
+	if err := ioutils.AtomicWriteFile(confPath, buf.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("writing network config %s: %w", confPath, err)
+	}
+	return nil
+}
+
+// configuredSubnets reads the subnets of all network configs except the
+// given network from the config directory. It is used while holding the lock
+// of the network backend which therefore cannot be asked for the configs.
+func (r *Runtime) configuredSubnets(exclude string) (map[string][]*net.IPNet, error) {
+	dir := r.networkConfigDir()
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	subnets := make(map[string][]*net.IPNet, len(files))
+	for _, f := range files {
+		if f.IsDir() || filepath.Ext(f.Name()) != ".json" {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(dir, f.Name()))
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+		var network types.Network
+		if err := json.Unmarshal(content, &network); err != nil {
+			// the network backend ignores invalid configs as well
+			logrus.Debugf("Skipping network config %s: %v", f.Name(), err)
+			continue
+		}
+		if network.Name == exclude {
+			continue
+		}
+		for _, subnet := range network.Subnets {
+			subnets[network.Name] = append(subnets[network.Name], &subnet.Subnet.IPNet)
+		}
+	}
+	return subnets, nil
+}
+
+// networkUpdateTeardown tears down the given network of the container with the
+// current network config. It returns true when the network was configured and
+// must be set up again with networkUpdateSetup(). The container and the network
+// update lock must be held by the caller.
+func (c *Container) networkUpdateTeardown(netName string) (bool, error) {
+	if err := c.syncContainer(); err != nil {
+		return false, err
+	}
+	networks, err := c.networks()
+	if err != nil {
+		return false, err
+	}
+	perNetOpts, ok := networks[netName]
+	if !ok || c.state.NetNS == "" {
+		return false, nil
+	}
+	if !c.ensureState(define.ContainerStateRunning, define.ContainerStateCreated) {
+		return false, fmt.Errorf("cannot update network of container %s in state %s: %w", c.ID(), c.state.State, define.ErrCtrStateInvalid)
+	}
+
+	opts := types.NetworkOptions{
+		ContainerID:   c.config.ID,
+		ContainerName: getNetworkPodName(c),
+	}
+	opts.PortMappings = c.convertPortMappings()
+	opts.Networks = map[string]types.PerNetworkOptions{
+		netName: perNetOpts,
+	}
+	return true, c.runtime.network.Teardown(c.state.NetNS, types.TeardownOptions{NetworkOptions: opts})
+}
+
+// networkUpdateSetup sets up the given network of the container again after
+// it was torn down by networkUpdateTeardown(). The MAC address and all IP
+// addresses which are still part of the network are kept. The container and
+// the network update lock must be held by the caller.
+func (c *Container) networkUpdateSetup(netName string) error {
+	networks, err := c.networks()
+	if err != nil {
+		return err
+	}
+	perNetOpts := networks[netName]
+
+	network, err := c.runtime.network.NetworkInspect(netName)
+	if err != nil {
+		return err
+	}
+	networkStatus := c.getNetworkStatus()
+	oldStatus := networkStatus[netName]
+	for _, netInt := range oldStatus.Interfaces {
+		if perNetOpts.StaticMAC == nil {
+			perNetOpts.StaticMAC = netInt.MacAddress
+		}
+		if len(perNetOpts.StaticIPs) == 0 {
+			for _, address := range netInt.Subnets {
+				if slices.ContainsFunc(network.Subnets, func(s types.Subnet) bool {
+					return s.Subnet.Contains(address.IPNet.IP)
+				}) {
+					perNetOpts.StaticIPs = append(perNetOpts.StaticIPs, address.IPNet.IP)
+				}
+			}
+		}
+		// Normally interfaces have a length of 1, see reloadContainerNetwork().
+		break
+	}
+
+	opts := types.NetworkOptions{
+		ContainerID:   c.config.ID,
+		ContainerName: getNetworkPodName(c),
+	}
+	opts.PortMappings = c.convertPortMappings()
+	opts.Networks = map[string]types.PerNetworkOptions{
+		netName: perNetOpts,
+	}
+	results, err := c.runtime.network.Setup(c.state.NetNS, types.SetupOptions{NetworkOptions: opts})
+	if err != nil {
+		delete(networkStatus, netName)
+		c.state.NetworkStatus = networkStatus
+		if saveErr := c.save(); saveErr != nil {
+			logrus.Errorf("Saving container %s state: %v", c.ID(), saveErr)
+		}
+		return err
+	}
+
+	hostNames := []string{c.Hostname(), c.config.Name}
+	oldHostEntries := etchosts.GetNetworkHostEntries(networkStatus, hostNames...)
+	networkStatus[netName] = results[netName]
+	c.state.NetworkStatus = networkStatus
+	if err := c.save(); err != nil {
+		return err
+	}
+
+	if rootless.IsRootless() {
+		if err := c.reloadRootlessRLKPortMapping(); err != nil {
+			return err
+		}
+	}
+
+	// Update resolv.conf, the DNS servers change when dns is toggled
+	oldDNS := make([]string, 0, len(oldStatus.DNSServerIPs))
+	for _, ip := range oldStatus.DNSServerIPs {
+		oldDNS = append(oldDNS, ip.String())
+	}
+	ipv6 := c.checkForIPv6(networkStatus)
+	newDNS := make([]string, 0, len(results[netName].DNSServerIPs))
+	for _, ip := range results[netName].DNSServerIPs {
+		if (ip.To4() == nil) && !ipv6 {
+			continue
+		}
+		newDNS = append(newDNS, ip.String())
+	}
+	if !slices.Equal(oldDNS, newDNS) {
+		if len(oldDNS) > 0 {
+			logrus.Debugf("Removing DNS Servers %v from resolv.conf", oldDNS)
+			if err := c.removeNameserver(oldDNS); err != nil {
+				return err
+			}
+		}
+		if len(newDNS) > 0 {
+			logrus.Debugf("Adding DNS Servers %v to resolv.conf", newDNS)
+			if err := c.addNameserver(newDNS); err != nil {
+				return err
+			}
+		}
+	}
+
+	// update /etc/hosts file, the ips change when a subnet was removed or added
+	if file, ok := c.state.BindMounts[config.DefaultHostsFile]; ok {
+		oldEntries := etchosts.GetNetworkHostEntries(map[string]types.StatusBlock{netName: oldStatus}, hostNames...)
+		newEntries := etchosts.GetNetworkHostEntries(results, hostNames...)
+		add := hostEntriesDifference(newEntries, oldEntries)
+		rm := hostEntriesDifference(oldEntries, newEntries)
+		if len(add) == 0 && len(rm) == 0 {
+			return nil
+		}
+		lock, err := lockfile.GetLockFile(file)
+		if err != nil {
+			return fmt.Errorf("failed to lock hosts file: %w", err)
+		}
+		lock.Lock()
+		defer lock.Unlock()
+		if len(add) > 0 {
+			logrus.Debugf("Add /etc/hosts entries %v", add)
+			if err := etchosts.AddIfExists(file, oldHostEntries, add); err != nil {
+				return err
+			}
+		}
+		if len(rm) > 0 {
+			logrus.Debugf("Remove /etc/hosts entries %v", rm)
+			if err := etchosts.Remove(file, rm); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// hostEntriesDifference returns the entries of a whose ip is not in b.
+func hostEntriesDifference(a, b etchosts.HostEntries) etchosts.HostEntries {
+	return slices.DeleteFunc(slices.Clone(a), func(entry etchosts.HostEntry) bool {
+		return slices.ContainsFunc(b, func(e etchosts.HostEntry) bool {
+			return e.IP == entry.IP
+		})
+	})
+}
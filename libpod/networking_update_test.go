@@ -0,0 +1,95 @@
+//go:build !remote && (linux || freebsd)
+
+package libpod
+
+import (
+	"maps"
+	"net"
+	"testing"
+
+	"github.com/containers/common/libnetwork/netavark"
+	"github.com/containers/common/libnetwork/types"
+	"github.com/containers/common/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNetworkUpdateOptionsMatchBackend makes sure that network update accepts
+// and normalizes the same driver options as the netavark backend does when a
+// network is created.
+func TestNetworkUpdateOptionsMatchBackend(t *testing.T) {
+	backend, err := netavark.NewNetworkInterface(&netavark.InitConfig{
+		NetworkConfigDir: t.TempDir(),
+		NetworkRunDir:    t.TempDir(),
+		NetavarkBinary:   "netavark",
+		Config:           &config.Config{},
+	})
+	require.NoError(t, err)
+	if _, err := backend.NetworkList(); err != nil {
+		t.Skipf("netavark backend cannot be used: %v", err)
+	}
+
+	tests := []struct {
+		driver  string
+		options map[string]string
+	}{
+		{types.BridgeNetworkDriver, map[string]string{types.MTUOption: "1400"}},
+		{types.BridgeNetworkDriver, map[string]string{types.MTUOption: ""}},
+		{types.BridgeNetworkDriver, map[string]string{types.MTUOption: "-1"}},
+		{types.BridgeNetworkDriver, map[string]string{types.VLANOption: "4094"}},
+		{types.BridgeNetworkDriver, map[string]string{types.VLANOption: "4095"}},
+		{types.BridgeNetworkDriver, map[string]string{types.MetricOption: "100"}},
+		{types.BridgeNetworkDriver, map[string]string{types.MetricOption: "-1"}},
+		{types.BridgeNetworkDriver, map[string]string{types.IsolateOption: "1"}},
+		{types.BridgeNetworkDriver, map[string]string{types.IsolateOption: "strict"}},
+		{types.BridgeNetworkDriver, map[string]string{types.IsolateOption: ""}},
+		{types.BridgeNetworkDriver, map[string]string{types.IsolateOption: "maybe"}},
+		{types.BridgeNetworkDriver, map[string]string{types.NoDefaultRoute: "0"}},
+		{types.BridgeNetworkDriver, map[string]string{types.VRFOption: ""}},
+		{types.BridgeNetworkDriver, map[string]string{types.ModeOption: "unmanaged"}},
+		{types.BridgeNetworkDriver, map[string]string{types.ModeOption: "l2"}},
+		{types.BridgeNetworkDriver, map[string]string{types.BclimOption: "1"}},
+		{types.BridgeNetworkDriver, map[string]string{"foo": "bar"}},
+		{types.MacVLANNetworkDriver, map[string]string{types.ModeOption: "bridge"}},
+		{types.MacVLANNetworkDriver, map[string]string{types.ModeOption: "l2"}},
+		{types.MacVLANNetworkDriver, map[string]string{types.BclimOption: "-1"}},
+		{types.MacVLANNetworkDriver, map[string]string{types.BclimOption: "x"}},
+		{types.MacVLANNetworkDriver, map[string]string{types.NoDefaultRoute: "1"}},
+		{types.MacVLANNetworkDriver, map[string]string{types.VLANOption: "1"}},
+		{types.IPVLANNetworkDriver, map[string]string{types.ModeOption: "l3s"}},
+		{types.IPVLANNetworkDriver, map[string]string{types.ModeOption: "bridge"}},
+		{types.IPVLANNetworkDriver, map[string]string{types.BclimOption: "1"}},
+		{types.IPVLANNetworkDriver, map[string]string{types.MTUOption: "x"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.driver+" "+fmtOptions(tt.options), func(t *testing.T) {
+			created, createErr := backend.NetworkCreate(types.Network{
+				Driver:  tt.driver,
+				Subnets: []types.Subnet{{Subnet: types.IPNet{IPNet: net.IPNet{IP: net.ParseIP("10.251.0.0"), Mask: net.CIDRMask(24, 32)}}}},
+				Options: maps.Clone(tt.options),
+			}, nil)
+
+			network := &types.Network{Driver: tt.driver, Options: maps.Clone(tt.options)}
+			var err error
+			if tt.driver == types.BridgeNetworkDriver {
+				err = validateBridgeOptions(network)
+			} else {
+				err = validateIpvlanOrMacvlanOptions(network)
+			}
+			if createErr != nil {
+				assert.Error(t, err, "backend rejects the options: %v", createErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, created.Options, network.Options)
+			require.NoError(t, backend.NetworkRemove(created.Name))
+		})
+	}
+}
+
+func fmtOptions(options map[string]string) string {
+	for k, v := range options {
+		return k + "=" + v
+	}
+	return ""
+}
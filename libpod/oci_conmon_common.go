@@ -18,7 +18,6 @@ import (
 	"strings"
 	"sync"
 	"syscall"
-	"text/template"
 	"time"
 
 	"github.com/containers/common/pkg/config"
@@ -30,6 +29,7 @@ import (
 	"github.com/containers/podman/v5/libpod/logs"
 	"github.com/containers/podman/v5/pkg/checkpoint/crutils"
 	"github.com/containers/podman/v5/pkg/errorhandling"
+	"github.com/containers/podman/v5/pkg/logforward"
 	"github.com/containers/podman/v5/pkg/rootless"
 	"github.com/containers/podman/v5/pkg/specgenutil"
 	"github.com/containers/podman/v5/pkg/util"
@@ -924,16 +924,7 @@ func (r *ConmonOCIRuntime) getLogTag(ctr *Container) (string, error) {
 		// FIXME: this error should probably be returned
 		return "", nil //nolint: nilerr
 	}
-	tmpl, err := template.New("container").Parse(logTag)
-	if err != nil {
-		return "", fmt.Errorf("template parsing error %s: %w", logTag, err)
-	}
-	var b bytes.Buffer
-	err = tmpl.Execute(&b, data)
-	if err != nil {
-		return "", err
-	}
-	return b.String(), nil
+	return formatLogTag(logTag, data)
 }
 
 func getPreserveFdExtraFiles(preserveFD []uint, preserveFDs uint) (uint, []*os.File, []*os.File, error) {
@@ -1326,6 +1317,10 @@ func (r *ConmonOCIRuntime) sharedConmonArgs(ctr *Container, cuuid, bundlePath, p
 		fallthrough
 	case define.JSONLogging:
 		fallthrough
+	case define.SyslogLogging, define.GELFLogging, define.FluentdLogging:
+		// The log forwarder reads the log file written by conmon,
+		// which also serves podman logs.
+		fallthrough
 	case define.KubernetesLogging:
 		logDriverArg = fmt.Sprintf("%s:%s", define.KubernetesLogging, logPath)
 	}
@@ -1341,6 +1336,12 @@ func (r *ConmonOCIRuntime) sharedConmonArgs(ctr *Container, cuuid, bundlePath, p
 	if ctr.config.LogSize > 0 {
 		size = ctr.config.LogSize
 	}
+	if size <= 0 && define.IsForwardingLogDriver(logDriver) && ctr.config.LogOptions["mode"] != logforward.ModeBlocking {
+		// Forwarded logs are only kept locally as ring buffer. In
+		// blocking mode, the log file keeps the messages which the
+		// collector did not receive yet and must not be rotated.
+		size = define.DefaultForwardingLogSizeMax
+	}
 	if size > 0 {
 		args = append(args, "--log-size-max", strconv.FormatInt(size, 10))
 	}
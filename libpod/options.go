@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"slices"
 	"strings"
 	"syscall"
 	"time"
@@ -552,6 +553,31 @@ func WithMaxLogSize(limit int64) CtrCreateOption {
 	}
 }
 
+// WithLogMaxFile sets the number of log files kept when the container log is
+// rotated, including the current one.
+func WithLogMaxFile(maxFile uint) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+		ctr.config.LogMaxFile = maxFile
+
+		return nil
+	}
+}
+
+// WithLogCompress compresses the rotated log files of the container.
+func WithLogCompress() CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+		ctr.config.LogCompress = true
+
+		return nil
+	}
+}
+
 // WithShmDir sets the directory that should be mounted on /dev/shm.
 func WithShmDir(dir string) CtrCreateOption {
 	return func(ctr *Container) error {
@@ -1121,7 +1147,8 @@ func WithLogDriver(driver string) CtrCreateOption {
 		switch driver {
 		case "":
 			return fmt.Errorf("log driver must be set: %w", define.ErrInvalidArg)
-		case define.JournaldLogging, define.KubernetesLogging, define.JSONLogging, define.NoLogging, define.PassthroughLogging, define.PassthroughTTYLogging:
+		case define.JournaldLogging, define.KubernetesLogging, define.JSONLogging, define.NoLogging, define.PassthroughLogging, define.PassthroughTTYLogging,
+			define.SyslogLogging, define.GELFLogging, define.FluentdLogging:
 			break
 		default:
 			return fmt.Errorf("invalid log driver: %w", define.ErrInvalidArg)
@@ -1133,6 +1160,23 @@ func WithLogDriver(driver string) CtrCreateOption {
 	}
 }
 
+// WithLogOptions sets the options of log drivers forwarding logs to a
+// collector. They are validated when the container is created.
+func WithLogOptions(options map[string]string) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+
+		ctr.config.LogOptions = make(map[string]string, len(options))
+		for key, value := range options {
+			ctr.config.LogOptions[key] = value
+		}
+
+		return nil
+	}
+}
+
 // WithLogPath sets the path to the log file.
 func WithLogPath(path string) CtrCreateOption {
 	return func(ctr *Container) error {
@@ -1502,6 +1546,26 @@ func WithImageVolumes(volumes []*ContainerImageVolume) CtrCreateOption {
 	}
 }
 
+// WithArtifactVolumes adds the given artifact volumes to the container.
+func WithArtifactVolumes(volumes []*ContainerArtifactVolume) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+
+		for _, vol := range volumes {
+			ctr.config.ArtifactVolumes = append(ctr.config.ArtifactVolumes, &ContainerArtifactVolume{
+				Dest:   vol.Dest,
+				Source: vol.Source,
+				Title:  vol.Title,
+				Digest: vol.Digest,
+			})
+		}
+
+		return nil
+	}
+}
+
 // WithHealthCheck adds the healthcheck to the container config
 func WithHealthCheck(healthCheck *manifest.Schema2HealthConfig) CtrCreateOption {
 	return func(ctr *Container) error {
@@ -1999,6 +2063,45 @@ func WithStartupHealthcheck(startupHC *define.StartupHealthCheck) CtrCreateOptio
 	}
 }
 
+// WithReadinessProbe sets a readiness probe for the container.
+// Requires that a healthcheck must be set.
+func WithReadinessProbe(probe *define.ReadinessProbe) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+		ctr.config.ReadinessProbeConfig = new(define.ReadinessProbe)
+		if err := JSONDeepCopy(probe, ctr.config.ReadinessProbeConfig); err != nil {
+			return fmt.Errorf("error copying readiness probe into container: %w", err)
+		}
+		return nil
+	}
+}
+
+// WithPostStartHook sets a command to execute in the container right after it
+// has been started.
+func WithPostStartHook(command []string) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+		ctr.config.PostStartHook = slices.Clone(command)
+		return nil
+	}
+}
+
+// WithPreStopHook sets a command to execute in the container before it is
+// stopped.
+func WithPreStopHook(command []string) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+		ctr.config.PreStopHook = slices.Clone(command)
+		return nil
+	}
+}
+
 // Pod Creation Options
 
 // WithPodCreateCommand adds the full command plus arguments of the current
@@ -2068,6 +2171,25 @@ func WithPodRestartPolicy(policy string) PodCreateOption {
 	}
 }
 
+// WithPodCronJob sets the schedule the pod is started on.
+func WithPodCronJob(cronJob *define.PodCronJob) PodCreateOption {
+	return func(pod *Pod) error {
+		if pod.valid {
+			return define.ErrPodFinalized
+		}
+
+		switch cronJob.ConcurrencyPolicy {
+		case "", define.CronJobConcurrencyForbid, define.CronJobConcurrencyReplace:
+		default:
+			return fmt.Errorf("%q is not a valid cron job concurrency policy: %w", cronJob.ConcurrencyPolicy, define.ErrInvalidArg)
+		}
+
+		pod.config.CronJob = cronJob
+
+		return nil
+	}
+}
+
 // WithPodRestartRetries sets the number of retries to use when restarting a
 // container with the "on-failure" restart policy.
 // 0 is an allowed value, and indicates infinite retries.
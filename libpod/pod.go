@@ -5,6 +5,7 @@ package libpod
 import (
 	"errors"
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -94,6 +95,10 @@ type PodConfig struct {
 
 	// ResourceLimits hold the pod level resource limits
 	ResourceLimits specs.LinuxResources
+
+	// CronJob is the schedule the pod is started on, if it was created
+	// from a Kubernetes CronJob.
+	CronJob *define.PodCronJob `json:"cronJob,omitempty"`
 }
 
 // podState represents a pod's state
@@ -115,6 +120,17 @@ func (p *Pod) Name() string {
 	return p.config.Name
 }
 
+// CronJob returns the schedule the pod is started on, or nil if the pod
+// is not scheduled.
+func (p *Pod) CronJob() *define.PodCronJob {
+	if p.config.CronJob == nil {
+		return nil
+	}
+	cronJob := *p.config.CronJob
+	cronJob.Calendar = slices.Clone(p.config.CronJob.Calendar)
+	return &cronJob
+}
+
 // MountLabel returns the SELinux label associated with the pod
 func (p *Pod) MountLabel() (string, error) {
 	if !p.HasInfraContainer() {
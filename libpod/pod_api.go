@@ -6,6 +6,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/containers/common/pkg/cgroups"
 	"github.com/containers/podman/v5/libpod/define"
@@ -570,6 +571,189 @@ func (p *Pod) Kill(ctx context.Context, signal uint) (map[string]error, error) {
 	return nil, nil
 }
 
+// Update updates the resource limits of the pod and persists them in the pod
+// configuration.  The new limits are applied to the pod cgroup right away.
+// If restartPolicy is set, it becomes the restart policy of the pod and of all
+// its containers.  If healthCheckOnFailure is set, it becomes the healthcheck
+// on-failure action of all containers of the pod.  The infra container and
+// init containers are left untouched.
+func (p *Pod) Update(ctx context.Context, resources *specs.LinuxResources, restartPolicy *string, restartRetries *uint, healthCheckOnFailure *string) error {
+	if resources == nil && restartPolicy == nil && healthCheckOnFailure == nil {
+		return fmt.Errorf("must provide at least one of resources, restart policy and healthcheck on-failure action to update a pod: %w", define.ErrInvalidArg)
+	}
+	if restartPolicy != nil {
+		if err := define.ValidateRestartPolicy(*restartPolicy); err != nil {
+			return err
+		}
+		if restartRetries != nil && *restartPolicy != define.RestartPolicyOnFailure {
+			return fmt.Errorf("cannot set restart policy retries unless policy is on-failure: %w", define.ErrInvalidArg)
+		}
+	} else if restartRetries != nil {
+		return fmt.Errorf("must provide restart policy if updating restart retries: %w", define.ErrInvalidArg)
+	}
+	if healthCheckOnFailure != nil {
+		if _, err := define.ParseHealthCheckOnFailureAction(*healthCheckOnFailure); err != nil {
+			return err
+		}
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if !p.valid {
+		return define.ErrPodRemoved
+	}
+
+	if err := p.updatePod(); err != nil {
+		return err
+	}
+
+	if resources != nil && !p.config.UsePodCgroup {
+		return fmt.Errorf("pod %s does not have a cgroup, cannot update its resource limits: %w", p.ID(), define.ErrInvalidArg)
+	}
+
+	// Validate the members before anything is changed.
+	var members []*Container
+	if restartPolicy != nil || healthCheckOnFailure != nil {
+		var err error
+		members, err = p.membersToUpdate(restartPolicy)
+		if err != nil {
+			return err
+		}
+	}
+
+	var oldConfig *PodConfig
+	if resources != nil || restartPolicy != nil {
+		oldConfig = new(PodConfig)
+		if err := JSONDeepCopy(p.config, oldConfig); err != nil {
+			return err
+		}
+		if err := p.updateConfig(resources, restartPolicy, restartRetries); err != nil {
+			return err
+		}
+	}
+	// restoreConfig restores the pod config and resource limits if a
+	// later step of the update fails.
+	restoreConfig := func() {
+		if oldConfig == nil {
+			return
+		}
+		p.config = oldConfig
+		if err := p.runtime.state.RewritePodConfig(p, p.config); err != nil {
+			logrus.Errorf("Restoring config of pod %s: %v", p.ID(), err)
+		}
+		if resources != nil {
+			if err := p.platformUpdateResources(); err != nil {
+				logrus.Errorf("Restoring resource limits of pod %s: %v", p.ID(), err)
+			}
+		}
+	}
+
+	if resources != nil {
+		if err := p.platformUpdateResources(); err != nil {
+			restoreConfig()
+			return err
+		}
+	}
+
+	if restartPolicy != nil || healthCheckOnFailure != nil {
+		if err := p.updateMembers(members, restartPolicy, restartRetries, healthCheckOnFailure); err != nil {
+			restoreConfig()
+			return err
+		}
+	}
+
+	p.newPodEvent(events.Update)
+	return nil
+}
+
+// podMemberUpdate holds the settings of a pod member before the update, so
+// the update can be rolled back.
+type podMemberUpdate struct {
+	ctr       *Container
+	policy    string
+	retries   *uint
+	onFailure string
+}
+
+// membersToUpdate returns the pod members whose restart policy and
+// healthcheck on-failure action are updated, after checking that the update
+// can be applied to all of them.
+func (p *Pod) membersToUpdate(restartPolicy *string) ([]*Container, error) {
+	ctrs, err := p.runtime.state.PodContainers(p)
+	if err != nil {
+		return nil, err
+	}
+	members := make([]*Container, 0, len(ctrs))
+	for _, ctr := range ctrs {
+		if ctr.IsInfra() || ctr.config.InitContainerType != "" {
+			continue
+		}
+		state, err := ctr.State()
+		if err != nil {
+			return nil, err
+		}
+		if state == define.ContainerStateRemoving {
+			return nil, fmt.Errorf("container %s of pod %s is being removed, cannot update: %w", ctr.ID(), p.ID(), define.ErrCtrStateInvalid)
+		}
+		if restartPolicy != nil {
+			if err := ctr.validateSecretsRestartPolicy(*restartPolicy); err != nil {
+				return nil, fmt.Errorf("updating container %s of pod %s: %w", ctr.ID(), p.ID(), err)
+			}
+		}
+		members = append(members, ctr)
+	}
+	return members, nil
+}
+
+// updateMembers updates the restart policy and healthcheck on-failure action
+// of the given pod members. If updating a member fails, the members already
+// updated are rolled back, and the error names those which could not be
+// rolled back.
+func (p *Pod) updateMembers(members []*Container, restartPolicy *string, restartRetries *uint, healthCheckOnFailure *string) error {
+	healthCheckConfig := define.UpdateHealthCheckConfig{HealthOnFailure: healthCheckOnFailure}
+	updated := make([]podMemberUpdate, 0, len(members))
+	for _, ctr := range members {
+		old := podMemberUpdate{
+			ctr:       ctr,
+			policy:    ctr.RestartPolicy(),
+			onFailure: ctr.config.HealthCheckOnFailureAction.String(),
+		}
+		if old.policy == define.RestartPolicyOnFailure {
+			retries := ctr.RestartRetries()
+			old.retries = &retries
+		}
+		policy, retries := restartPolicy, restartRetries
+		if policy == nil {
+			// Keep the restart policy of the container as is.
+			policy, retries = &old.policy, old.retries
+		}
+		if err := ctr.Update(nil, policy, retries, &healthCheckConfig); err != nil {
+			return p.rollbackMembers(updated, fmt.Errorf("updating container %s of pod %s: %w", ctr.ID(), p.ID(), err))
+		}
+		updated = append(updated, old)
+	}
+	return nil
+}
+
+// rollbackMembers restores the settings of the updated pod members after
+// updateErr. The returned error names the containers which keep the update.
+func (p *Pod) rollbackMembers(updated []podMemberUpdate, updateErr error) error {
+	var changed []string
+	for i := len(updated) - 1; i >= 0; i-- {
+		old := updated[i]
+		healthCheckConfig := define.UpdateHealthCheckConfig{HealthOnFailure: &old.onFailure}
+		if err := old.ctr.Update(nil, &old.policy, old.retries, &healthCheckConfig); err != nil {
+			logrus.Errorf("Rolling back update of container %s of pod %s: %v", old.ctr.ID(), p.ID(), err)
+			changed = append(changed, old.ctr.ID())
+		}
+	}
+	if len(changed) > 0 {
+		return fmt.Errorf("%w: containers %s of the pod were updated and could not be rolled back", updateErr, strings.Join(changed, ", "))
+	}
+	return updateErr
+}
+
 // Status gets the status of all containers in the pod.
 // Returns a map of Container ID to Container Status.
 func (p *Pod) Status() (map[string]define.ContainerStatus, error) {
@@ -630,10 +814,14 @@ func (p *Pod) Inspect() (*define.InspectPodData, error) {
 		if err == nil {
 			containerStatus = containerState.String()
 		}
+		// Same as above, not being able to determine the readiness
+		// simply reports the container as not ready.
+		ready, _ := c.IsReady()
 		ctrs = append(ctrs, define.InspectPodContainerInfo{
 			ID:    c.ID(),
 			Name:  c.Name(),
 			State: containerStatus,
+			Ready: ready,
 		})
 		// Do not add init containers fdr status
 		if len(c.config.InitContainerType) < 1 {
@@ -735,6 +923,7 @@ func (p *Pod) Inspect() (*define.InspectPodData, error) {
 		Created:             p.CreatedTime(),
 		CreateCommand:       p.config.CreateCommand,
 		ExitPolicy:          string(p.config.ExitPolicy),
+		CronJob:             p.CronJob(),
 		State:               podState,
 		Hostname:            p.config.Hostname,
 		Labels:              p.Labels(),
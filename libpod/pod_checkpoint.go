@@ -0,0 +1,232 @@
+//go:build !remote && (linux || freebsd)
+
+package libpod
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	metadata "github.com/checkpoint-restore/checkpointctl/lib"
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/libpod/events"
+	"github.com/containers/podman/v5/pkg/checkpoint/crutils"
+	"github.com/containers/storage/pkg/archive"
+	"github.com/sirupsen/logrus"
+)
+
+// Checkpoint checkpoints all containers of the pod and writes them, together
+// with the configuration of the pod and of its infra container, into the
+// single archive options.TargetFile.
+// All containers are paused before the first one is checkpointed, so the
+// checkpoint reflects one consistent point in time of the whole pod.
+// The infra container itself is not checkpointed, it is recreated from its
+// configuration on restore. Init containers are not part of the checkpoint.
+func (p *Pod) Checkpoint(ctx context.Context, options ContainerCheckpointOptions) (retErr error) {
+	if options.TargetFile == "" {
+		return fmt.Errorf("must provide a target file to checkpoint a pod: %w", define.ErrInvalidArg)
+	}
+	if options.PreCheckPoint || options.WithPrevious || options.CreateImage != "" {
+		return fmt.Errorf("pre-checkpoints and checkpoint images are not supported for pods: %w", define.ErrInvalidArg)
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if !p.valid {
+		return define.ErrPodRemoved
+	}
+
+	if err := p.updatePod(); err != nil {
+		return err
+	}
+
+	allCtrs, err := p.runtime.state.PodContainers(p)
+	if err != nil {
+		return err
+	}
+
+	var infra *Container
+	ctrs := make([]*Container, 0, len(allCtrs))
+	for _, ctr := range allCtrs {
+		switch {
+		case ctr.IsInfra():
+			infra = ctr
+		case ctr.config.InitContainerType != "":
+			continue
+		default:
+			ctrs = append(ctrs, ctr)
+		}
+	}
+	if len(ctrs) == 0 {
+		return fmt.Errorf("pod %s has no containers to checkpoint: %w", p.ID(), define.ErrNoSuchCtr)
+	}
+
+	dir, err := os.MkdirTemp("", "pod-checkpoint")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := os.RemoveAll(dir); err != nil {
+			logrus.Errorf("Could not recursively remove %s: %q", dir, err)
+		}
+	}()
+
+	if _, err := metadata.WriteJSONFile(p.config, dir, metadata.PodDumpFile); err != nil {
+		return err
+	}
+	if infra != nil {
+		if err := infra.writePodCheckpointConfig(filepath.Join(dir, crutils.PodCheckpointInfraDirectory)); err != nil {
+			return fmt.Errorf("saving infra container configuration of pod %s: %w", p.ID(), err)
+		}
+	}
+
+	// Freeze all containers first, nothing in the pod may change while
+	// the containers are checkpointed one after another.
+	var paused []*Container
+	defer func() {
+		if retErr == nil {
+			return
+		}
+		for _, ctr := range paused {
+			if err := ctr.unpauseAfterPodCheckpoint(); err != nil {
+				logrus.Errorf("Unpausing container %s of pod %s: %v", ctr.ID(), p.ID(), err)
+			}
+		}
+	}()
+	for _, ctr := range ctrs {
+		if err := ctr.pauseForPodCheckpoint(); err != nil {
+			return err
+		}
+		paused = append(paused, ctr)
+	}
+
+	ctrsDir := filepath.Join(dir, crutils.PodCheckpointContainersDirectory)
+	if err := os.MkdirAll(ctrsDir, 0700); err != nil {
+		return err
+	}
+	for _, ctr := range ctrs {
+		ctrOptions := options
+		ctrOptions.TargetFile = filepath.Join(ctrsDir, ctr.ID()+".tar")
+		ctrOptions.Compression = archive.Uncompressed
+		ctrOptions.PrintStats = false
+		if err := ctr.checkpointForPod(ctx, ctrOptions); err != nil {
+			return fmt.Errorf("checkpointing container %s of pod %s: %w", ctr.ID(), p.ID(), err)
+		}
+	}
+
+	// Containers that are not kept running are stopped by now.
+	for _, ctr := range ctrs {
+		if err := ctr.unpauseAfterPodCheckpoint(); err != nil {
+			return fmt.Errorf("unpausing container %s of pod %s: %w", ctr.ID(), p.ID(), err)
+		}
+	}
+
+	input, err := archive.TarWithOptions(dir, &archive.TarOptions{
+		Compression:      options.Compression,
+		IncludeSourceDir: true,
+	})
+	if err != nil {
+		return fmt.Errorf("reading pod checkpoint directory %q: %w", dir, err)
+	}
+
+	outFile, err := os.Create(options.TargetFile)
+	if err != nil {
+		return fmt.Errorf("creating pod checkpoint export file %q: %w", options.TargetFile, err)
+	}
+	defer outFile.Close()
+
+	if err := os.Chmod(options.TargetFile, 0600); err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(outFile, input); err != nil {
+		return err
+	}
+
+	p.newPodEvent(events.Checkpoint)
+	return nil
+}
+
+// writePodCheckpointConfig writes the configuration and the spec of an infra
+// container into dir, the infra container is recreated from them when the
+// pod checkpoint is restored.
+func (c *Container) writePodCheckpointConfig(dir string) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if err := c.syncContainer(); err != nil {
+		return err
+	}
+
+	config := new(ContainerConfig)
+	if err := JSONDeepCopy(c.config, config); err != nil {
+		return err
+	}
+	networks, err := c.checkpointNetworks()
+	if err != nil {
+		return err
+	}
+	config.Networks = networks
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	if _, err := metadata.WriteJSONFile(config, dir, metadata.ConfigDumpFile); err != nil {
+		return err
+	}
+	if _, err := metadata.WriteJSONFile(c.config.Spec, dir, metadata.SpecDumpFile); err != nil {
+		return err
+	}
+	return nil
+}
+
+// pauseForPodCheckpoint pauses a running container of a pod that is about to
+// be checkpointed.
+func (c *Container) pauseForPodCheckpoint() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if err := c.syncContainer(); err != nil {
+		return err
+	}
+
+	if c.state.State != define.ContainerStateRunning {
+		return fmt.Errorf("container %s is %s, all containers of pod %s must be running to checkpoint it: %w", c.ID(), c.state.State, c.config.Pod, define.ErrCtrStateInvalid)
+	}
+	return c.pause()
+}
+
+// unpauseAfterPodCheckpoint unpauses a container of a pod after the pod has
+// been checkpointed. Containers which are not paused anymore are ignored.
+func (c *Container) unpauseAfterPodCheckpoint() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if err := c.syncContainer(); err != nil {
+		return err
+	}
+
+	if c.state.State != define.ContainerStatePaused {
+		return nil
+	}
+	return c.unpause()
+}
+
+// checkpointForPod exports the checkpoint of a paused container of a pod.
+func (c *Container) checkpointForPod(ctx context.Context, options ContainerCheckpointOptions) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if err := c.syncContainer(); err != nil {
+		return err
+	}
+
+	if err := c.prepareCheckpointExport(); err != nil {
+		return err
+	}
+	_, _, err := c.checkpoint(ctx, options)
+	return err
+}
@@ -0,0 +1,40 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"fmt"
+
+	"github.com/containers/podman/v5/libpod/define"
+)
+
+// ScheduleCronJob creates the systemd timer starting the pod on the
+// schedule of its cron job and returns the name of the timer unit.
+// An error wrapping define.ErrNotImplemented is returned if systemd timers
+// are not available; the schedule is then run by the Podman API service.
+func (p *Pod) ScheduleCronJob() (string, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if !p.valid {
+		return "", define.ErrPodRemoved
+	}
+
+	if err := p.updatePod(); err != nil {
+		return "", err
+	}
+
+	if p.config.CronJob == nil {
+		return "", fmt.Errorf("pod %s has no cron job schedule: %w", p.ID(), define.ErrInvalidArg)
+	}
+	if p.config.CronJob.Suspend {
+		return "", fmt.Errorf("cron job of pod %s is suspended: %w", p.ID(), define.ErrInvalidArg)
+	}
+
+	return p.createCronJobTimer()
+}
+
+// Systemd unit name for the cron job of the pod.
+func (p *Pod) cronJobUnitName() string {
+	return p.ID() + "-cronjob"
+}
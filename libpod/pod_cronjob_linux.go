@@ -0,0 +1,125 @@
+//go:build !remote && systemd
+
+package libpod
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	systemdCommon "github.com/containers/common/pkg/systemd"
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/pkg/errorhandling"
+	"github.com/containers/podman/v5/pkg/rootless"
+	"github.com/containers/podman/v5/pkg/systemd"
+	"github.com/sirupsen/logrus"
+)
+
+// CronJobTimersSupported returns an error wrapping define.ErrNotImplemented
+// if pods cannot be scheduled with systemd timers.
+func CronJobTimersSupported() error {
+	if !systemdCommon.RunsOnSystemd() {
+		return fmt.Errorf("systemd is not available: %w", define.ErrNotImplemented)
+	}
+	return nil
+}
+
+// createCronJobTimer creates a transient systemd timer starting the pod on
+// the calendar events of its cron job.
+func (p *Pod) createCronJobTimer() (string, error) {
+	if err := CronJobTimersSupported(); err != nil {
+		return "", fmt.Errorf("scheduling pod %s: %w", p.ID(), err)
+	}
+
+	unitName := p.cronJobUnitName()
+
+	podman, err := os.Executable()
+	if err != nil {
+		return "", fmt.Errorf("failed to get path for podman for a cron job timer: %w", err)
+	}
+
+	var cmd = []string{"--property", "LogLevelMax=notice"}
+	if rootless.IsRootless() {
+		cmd = append(cmd, "--user")
+	}
+	path := os.Getenv("PATH")
+	if path != "" {
+		cmd = append(cmd, "--setenv=PATH="+path)
+	}
+
+	cmd = append(cmd, "--unit", unitName)
+	for _, event := range p.config.CronJob.Calendar {
+		cmd = append(cmd, "--on-calendar="+event)
+	}
+	cmd = append(cmd, "--timer-property=AccuracySec=1s", podman)
+
+	if logrus.IsLevelEnabled(logrus.DebugLevel) {
+		cmd = append(cmd, "--log-level=debug", "--syslog")
+	}
+
+	// Starting a running pod is a no-op which is exactly what the
+	// Forbid policy asks for.
+	verb := "start"
+	if p.config.CronJob.ConcurrencyPolicy == define.CronJobConcurrencyReplace {
+		verb = "restart"
+	}
+	cmd = append(cmd, "pod", verb, p.ID())
+
+	conn, err := systemd.ConnectToDBUS()
+	if err != nil {
+		return "", fmt.Errorf("unable to get systemd connection to add cron job timer: %w", err)
+	}
+	conn.Close()
+	logrus.Debugf("creating systemd-transient files: %s %s", "systemd-run", cmd)
+	systemdRun := exec.Command("systemd-run", cmd...)
+	if output, err := systemdRun.CombinedOutput(); err != nil {
+		return "", fmt.Errorf("%s", output)
+	}
+
+	return unitName, nil
+}
+
+// removeCronJobTimer stops and removes the systemd timer and service of the
+// pod's cron job.
+func (p *Pod) removeCronJobTimer(ctx context.Context) error {
+	if !systemdCommon.RunsOnSystemd() {
+		return nil
+	}
+	conn, err := systemd.ConnectToDBUS()
+	if err != nil {
+		return fmt.Errorf("unable to get systemd connection to remove cron job timer: %w", err)
+	}
+	defer conn.Close()
+
+	stopErrors := []error{}
+	unitName := p.cronJobUnitName()
+
+	// Stop the timer before the service to make sure the timer does not
+	// fire after the service is stopped.
+	timerChan := make(chan string)
+	timerFile := fmt.Sprintf("%s.timer", unitName)
+	if _, err := conn.StopUnitContext(ctx, timerFile, "ignore-dependencies", timerChan); err != nil {
+		if !strings.HasSuffix(err.Error(), ".timer not loaded.") {
+			stopErrors = append(stopErrors, fmt.Errorf("removing cron job timer %q: %w", timerFile, err))
+		}
+	} else if err := systemdOpSuccessful(timerChan); err != nil {
+		stopErrors = append(stopErrors, fmt.Errorf("stopping systemd cron job timer %q: %w", timerFile, err))
+	}
+
+	serviceChan := make(chan string)
+	serviceFile := fmt.Sprintf("%s.service", unitName)
+	if _, err := conn.StopUnitContext(ctx, serviceFile, "ignore-dependencies", serviceChan); err != nil {
+		if !strings.HasSuffix(err.Error(), ".service not loaded.") {
+			stopErrors = append(stopErrors, fmt.Errorf("removing cron job service %q: %w", serviceFile, err))
+		}
+	} else if err := systemdOpSuccessful(serviceChan); err != nil {
+		stopErrors = append(stopErrors, fmt.Errorf("stopping systemd cron job service %q: %w", serviceFile, err))
+	}
+	if err := conn.ResetFailedUnitContext(ctx, serviceFile); err != nil {
+		logrus.Debugf("Failed to reset unit file: %q", err)
+	}
+
+	return errorhandling.JoinErrors(stopErrors)
+}
@@ -0,0 +1,28 @@
+//go:build !remote && !linux
+
+package libpod
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/containers/podman/v5/libpod/define"
+)
+
+// CronJobTimersSupported returns an error wrapping define.ErrNotImplemented
+// if pods cannot be scheduled with systemd timers.
+func CronJobTimersSupported() error {
+	return fmt.Errorf("systemd timers are not supported: %w", define.ErrNotImplemented)
+}
+
+// createCronJobTimer creates a transient systemd timer starting the pod on
+// the calendar events of its cron job.
+func (p *Pod) createCronJobTimer() (string, error) {
+	return "", fmt.Errorf("scheduling pod %s: %w", p.ID(), CronJobTimersSupported())
+}
+
+// removeCronJobTimer stops and removes the systemd timer and service of the
+// pod's cron job.
+func (p *Pod) removeCronJobTimer(ctx context.Context) error {
+	return nil
+}
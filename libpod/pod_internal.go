@@ -8,6 +8,7 @@ import (
 
 	"github.com/containers/podman/v5/libpod/define"
 	"github.com/containers/storage/pkg/stringid"
+	"github.com/opencontainers/runtime-spec/specs-go"
 )
 
 // Creates a new, empty pod
@@ -42,6 +43,37 @@ func (p *Pod) save() error {
 	return nil
 }
 
+// updateConfig merges the given resource limits into the resource limits of
+// the pod, sets the restart policy if given and persists the configuration.
+// Must be called with the pod lock held.
+func (p *Pod) updateConfig(resources *specs.LinuxResources, restartPolicy *string, restartRetries *uint) error {
+	oldConfig := new(PodConfig)
+	if err := JSONDeepCopy(p.config, oldConfig); err != nil {
+		return err
+	}
+
+	if resources != nil {
+		resourcesToUpdate, err := json.Marshal(resources)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(resourcesToUpdate, &p.config.ResourceLimits); err != nil {
+			return err
+		}
+	}
+	if restartPolicy != nil {
+		p.config.RestartPolicy = *restartPolicy
+		p.config.RestartRetries = restartRetries
+	}
+
+	if err := p.runtime.state.RewritePodConfig(p, p.config); err != nil {
+		// Assume DB write failed, revert to the old config
+		p.config = oldConfig
+		return err
+	}
+	return nil
+}
+
 // Refresh a pod's state after restart
 // This cannot lock any other pod, but may lock individual containers, as those
 // will have refreshed by the time pod refresh runs.
@@ -5,3 +5,7 @@ package libpod
 func (p *Pod) platformRefresh() error {
 	return nil
 }
+
+func (p *Pod) platformUpdateResources() error {
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"path/filepath"
 
+	"github.com/containers/common/pkg/cgroups"
 	"github.com/containers/common/pkg/config"
 	"github.com/containers/podman/v5/libpod/define"
 	"github.com/containers/podman/v5/pkg/rootless"
@@ -34,3 +35,28 @@ func (p *Pod) platformRefresh() error {
 	}
 	return nil
 }
+
+// platformUpdateResources applies the resource limits of the pod to its
+// cgroup.  A pod cgroup that does not exist (yet) is created with the new
+// limits when the next container of the pod starts.
+func (p *Pod) platformUpdateResources() error {
+	if p.state.CgroupPath == "" || !cgroupExist(p.state.CgroupPath) {
+		return nil
+	}
+
+	res, err := GetLimits(&p.config.ResourceLimits)
+	if err != nil {
+		return err
+	}
+	res.SkipDevices = true
+
+	cgc, err := cgroups.Load(p.state.CgroupPath)
+	if err != nil {
+		return fmt.Errorf("loading cgroup of pod %s: %w", p.ID(), err)
+	}
+	if err := cgc.Update(&res); err != nil {
+		return fmt.Errorf("updating cgroup of pod %s: %w", p.ID(), err)
+	}
+	logrus.Debugf("Updated cgroup %s of pod %s", p.state.CgroupPath, p.ID())
+	return nil
+}
@@ -21,7 +21,6 @@ import (
 	nettypes "github.com/containers/common/libnetwork/types"
 	"github.com/containers/common/pkg/cgroups"
 	"github.com/containers/common/pkg/config"
-	"github.com/containers/common/pkg/secrets"
 	systemdCommon "github.com/containers/common/pkg/systemd"
 	"github.com/containers/image/v5/pkg/sysregistriesv2"
 	is "github.com/containers/image/v5/storage"
@@ -32,7 +31,9 @@ import (
 	"github.com/containers/podman/v5/libpod/plugin"
 	"github.com/containers/podman/v5/libpod/shutdown"
 	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/containers/podman/v5/pkg/libartifact/store"
 	"github.com/containers/podman/v5/pkg/rootless"
+	"github.com/containers/podman/v5/pkg/secretstore"
 	"github.com/containers/podman/v5/pkg/systemd"
 	"github.com/containers/podman/v5/pkg/util"
 	"github.com/containers/storage"
@@ -116,7 +117,7 @@ type Runtime struct {
 	eventer events.Eventer
 
 	// secretsManager manages secrets
-	secretsManager *secrets.SecretsManager
+	secretsManager *secretstore.Manager
 }
 
 // SetXdgDirs ensures the XDG_RUNTIME_DIR env and XDG_CONFIG_HOME variables are set.
@@ -299,12 +300,7 @@ func getDBState(runtime *Runtime) (State, error) {
 		return nil, err
 	}
 
-	// get default boltdb path
-	baseDir := runtime.config.Engine.StaticDir
-	if runtime.storageConfig.TransientStore {
-		baseDir = runtime.config.Engine.TmpDir
-	}
-	boltDBPath := filepath.Join(baseDir, "bolt_state.db")
+	boltDBPath := boltStatePath(runtime)
 
 	switch backend {
 	case config.DBBackendDefault:
@@ -331,6 +327,15 @@ func getDBState(runtime *Runtime) (State, error) {
 	}
 }
 
+// boltStatePath returns the path of the BoltDB database of the runtime.
+func boltStatePath(runtime *Runtime) string {
+	baseDir := runtime.config.Engine.StaticDir
+	if runtime.storageConfig.TransientStore {
+		baseDir = runtime.config.Engine.TmpDir
+	}
+	return filepath.Join(baseDir, "bolt_state.db")
+}
+
 // Make a new runtime based on the given configuration
 // Sets up containers/storage, state store, OCI runtime
 func makeRuntime(ctx context.Context, runtime *Runtime) (retErr error) {
@@ -977,6 +982,12 @@ func (r *Runtime) StorageConfig() storage.StoreOptions {
 	return r.storageConfig
 }
 
+// ArtifactStore returns the store of OCI artifacts, which is kept next to the
+// image store in the storage graph root.
+func (r *Runtime) ArtifactStore() (*store.ArtifactStore, error) {
+	return store.NewArtifactStore(filepath.Join(r.storageConfig.GraphRoot, "artifacts"), r.SystemContext())
+}
+
 func (r *Runtime) GarbageCollect() error {
 	return r.store.GarbageCollect()
 }
@@ -1137,9 +1148,9 @@ func (r *Runtime) GetSecretsStorageDir() string {
 }
 
 // SecretsManager returns the directory that the secrets manager should take
-func (r *Runtime) SecretsManager() (*secrets.SecretsManager, error) {
+func (r *Runtime) SecretsManager() (*secretstore.Manager, error) {
 	if r.secretsManager == nil {
-		manager, err := secrets.NewManager(r.GetSecretsStorageDir())
+		manager, err := secretstore.NewManager(r.GetSecretsStorageDir())
 		if err != nil {
 			return nil, err
 		}
@@ -1264,9 +1275,24 @@ func (r *Runtime) LockConflicts() (map[uint32][]string, []uint32, error) {
 	return toReturn, locksHeld, nil
 }
 
-// SystemCheck checks our storage for consistency, and depending on the options
-// specified, will attempt to remove anything which fails consistency checks.
+// SystemCheck checks our storage and our database for consistency, and
+// depending on the options specified, will attempt to repair or remove
+// anything which fails consistency checks.
 func (r *Runtime) SystemCheck(ctx context.Context, options entities.SystemCheckOptions) (entities.SystemCheckReport, error) {
+	report, err := r.checkStorage(options)
+	if err != nil {
+		return report, err
+	}
+	if err := r.checkState(ctx, options, &report); err != nil {
+		return report, err
+	}
+	return report, nil
+}
+
+// checkStorage checks our storage for consistency, and depending on the
+// options specified, will attempt to remove anything which fails consistency
+// checks.
+func (r *Runtime) checkStorage(options entities.SystemCheckOptions) (entities.SystemCheckReport, error) {
 	what := storage.CheckEverything()
 	if options.Quick {
 		what = storage.CheckMost()
@@ -0,0 +1,345 @@
+//go:build !remote && (linux || freebsd)
+
+package libpod
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/containers/storage"
+	"github.com/hashicorp/go-multierror"
+	"github.com/sirupsen/logrus"
+)
+
+// checkState checks that the libpod database agrees with storage and with
+// itself, and adds what it finds to report. Inconsistencies that can be
+// fixed without losing data are repaired if options.Repair is set. Containers
+// and pods that cannot be fixed are removed if options.RepairLossy is set, too.
+func (r *Runtime) checkState(ctx context.Context, options entities.SystemCheckOptions, report *entities.SystemCheckReport) error {
+	var merr *multierror.Error
+
+	ctrs, err := r.state.AllContainers(false)
+	if err != nil {
+		return fmt.Errorf("retrieving containers from the database: %w", err)
+	}
+	for _, ctr := range ctrs {
+		if err := r.checkContainerState(ctx, ctr, options, report); err != nil {
+			merr = multierror.Append(merr, err)
+		}
+	}
+
+	pods, err := r.state.AllPods()
+	if err != nil {
+		return fmt.Errorf("retrieving pods from the database: %w", err)
+	}
+	for _, pod := range pods {
+		if err := r.checkPodState(ctx, pod, options, report); err != nil {
+			merr = multierror.Append(merr, err)
+		}
+	}
+
+	volumes, err := r.state.AllVolumes()
+	if err != nil {
+		return fmt.Errorf("retrieving volumes from the database: %w", err)
+	}
+	for _, vol := range volumes {
+		if err := checkVolumeState(vol, options, report); err != nil {
+			merr = multierror.Append(merr, err)
+		}
+	}
+
+	return merr.ErrorOrNil()
+}
+
+// checkContainerState checks that the storage and the pod of a container
+// still exist, and that the exec sessions of the container are not stale.
+func (r *Runtime) checkContainerState(ctx context.Context, ctr *Container, options entities.SystemCheckOptions, report *entities.SystemCheckReport) error {
+	var problems []string
+
+	// Containers with a user-supplied root filesystem have no storage.
+	if ctr.config.Rootfs == "" {
+		if _, err := r.store.Container(ctr.ID()); err != nil {
+			if !errors.Is(err, storage.ErrContainerUnknown) {
+				return fmt.Errorf("looking up storage of container %s: %w", ctr.ID(), err)
+			}
+			problems = append(problems, "storage container does not exist")
+		}
+	}
+
+	podMissing := false
+	if ctr.config.Pod != "" {
+		if _, err := r.state.Pod(ctr.config.Pod); err != nil {
+			if !errors.Is(err, define.ErrNoSuchPod) {
+				return fmt.Errorf("looking up pod of container %s: %w", ctr.ID(), err)
+			}
+			podMissing = true
+			problems = append(problems, fmt.Sprintf("pod %s does not exist", ctr.config.Pod))
+		}
+	}
+
+	if len(problems) > 0 {
+		addCheckProblems(&report.DBContainers, ctr.ID(), problems)
+		report.Errors = true
+		if !options.Repair {
+			return nil
+		}
+		if !options.RepairLossy {
+			return fmt.Errorf("container %s can only be repaired by removing it, use --force to remove it: %s", ctr.ID(), strings.Join(problems, ", "))
+		}
+		var err error
+		if podMissing {
+			err = r.removeOrphanedContainer(ctr)
+		} else {
+			err = r.RemoveContainer(ctx, ctr, true, false, nil)
+		}
+		if err != nil {
+			return fmt.Errorf("removing container %s with missing storage or pod: %w", ctr.ID(), err)
+		}
+		if report.RemovedContainers == nil {
+			report.RemovedContainers = make(map[string]string)
+		}
+		report.RemovedContainers[ctr.ID()] = ctr.config.Name
+		return nil
+	}
+
+	return ctr.checkExecSessions(options.Repair, report)
+}
+
+// checkExecSessions looks for exec sessions of a container which are
+// registered in the database but unknown to the container, and for exec
+// sessions which are marked as running although their process is gone.
+func (c *Container) checkExecSessions(repair bool, report *entities.SystemCheckReport) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if err := c.syncContainer(); err != nil {
+		if errors.Is(err, define.ErrCtrRemoved) || errors.Is(err, define.ErrNoSuchCtr) {
+			return nil
+		}
+		return err
+	}
+
+	dbSessions, err := c.runtime.state.GetContainerExecSessions(c)
+	if err != nil {
+		return fmt.Errorf("retrieving exec sessions of container %s: %w", c.ID(), err)
+	}
+	for _, id := range dbSessions {
+		if _, ok := c.state.ExecSessions[id]; ok {
+			continue
+		}
+		if _, ok := c.state.LegacyExecSessions[id]; ok {
+			continue
+		}
+		addCheckProblems(&report.ExecSessions, id, []string{fmt.Sprintf("exec session is registered for container %s but the container does not know it", c.ID())})
+		report.Errors = true
+		if !repair {
+			continue
+		}
+		if err := c.runtime.state.RemoveExecSession(&ExecSession{Id: id, ContainerId: c.ID()}); err != nil {
+			return fmt.Errorf("removing exec session %s of container %s from the database: %w", id, c.ID(), err)
+		}
+		report.RemovedExecSessions = append(report.RemovedExecSessions, id)
+	}
+
+	ctrRunning := c.ensureState(define.ContainerStateRunning, define.ContainerStatePaused)
+	var stale []string
+	for _, id := range c.getKnownExecSessions() {
+		if ctrRunning {
+			alive, err := c.ociRuntime.ExecUpdateStatus(c, id)
+			if err != nil {
+				return fmt.Errorf("checking exec session %s of container %s: %w", id, c.ID(), err)
+			}
+			if alive {
+				continue
+			}
+		}
+		stale = append(stale, id)
+		addCheckProblems(&report.ExecSessions, id, []string{fmt.Sprintf("exec session of container %s is marked as running but its process is gone", c.ID())})
+		report.Errors = true
+	}
+	if !repair || len(stale) == 0 {
+		return nil
+	}
+
+	if ctrRunning {
+		// Reaping the exec sessions of a running container marks the
+		// dead sessions as stopped.
+		if _, err := c.getActiveExecSessions(); err != nil {
+			return fmt.Errorf("reaping exec sessions of container %s: %w", c.ID(), err)
+		}
+		return nil
+	}
+	for _, id := range stale {
+		if session, ok := c.state.ExecSessions[id]; ok {
+			session.State = define.ExecStateStopped
+			session.PID = 0
+		} else {
+			delete(c.state.LegacyExecSessions, id)
+		}
+		if err := c.cleanupExecBundle(id); err != nil {
+			logrus.Errorf("Cleaning up exec session %s of container %s: %v", id, c.ID(), err)
+		}
+	}
+	return c.save()
+}
+
+// removeOrphanedContainer removes a container whose pod does not exist
+// anymore. The regular removal path cannot be used, it expects the pod of a
+// container to exist.
+func (r *Runtime) removeOrphanedContainer(c *Container) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if err := c.syncContainer(); err != nil {
+		return err
+	}
+	if c.ensureState(define.ContainerStateRunning, define.ContainerStatePaused) {
+		if err := c.stop(c.StopTimeout()); err != nil {
+			return err
+		}
+	}
+	if err := c.removeAllExecSessions(); err != nil {
+		logrus.Errorf("Removing exec sessions of container %s: %v", c.ID(), err)
+	}
+	if err := c.teardownStorage(); err != nil {
+		return err
+	}
+
+	// The database only removes containers without a pod on their own.
+	c.config.Pod = ""
+	if err := r.state.RemoveContainer(c); err != nil {
+		return err
+	}
+	c.valid = false
+	if err := c.lock.Free(); err != nil {
+		logrus.Errorf("Freeing lock of container %s: %v", c.ID(), err)
+	}
+	return nil
+}
+
+// checkPodState checks that the infra container and the containers of a pod
+// exist.
+func (r *Runtime) checkPodState(ctx context.Context, pod *Pod, options entities.SystemCheckOptions, report *entities.SystemCheckReport) error {
+	if err := r.checkPodContainers(pod, options, report); err != nil {
+		return err
+	}
+
+	infraID, err := pod.InfraContainerID()
+	if err != nil {
+		if errors.Is(err, define.ErrNoSuchPod) || errors.Is(err, define.ErrPodRemoved) {
+			return nil
+		}
+		return err
+	}
+	if infraID == "" {
+		return nil
+	}
+	exists, err := r.state.HasContainer(infraID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return nil
+	}
+
+	problem := fmt.Sprintf("infra container %s does not exist", infraID)
+	addCheckProblems(&report.Pods, pod.ID(), []string{problem})
+	report.Errors = true
+	if !options.Repair {
+		return nil
+	}
+	if !options.RepairLossy {
+		return fmt.Errorf("pod %s can only be repaired by removing it, use --force to remove it: %s", pod.ID(), problem)
+	}
+	if _, err := r.RemovePod(ctx, pod, true, true, nil); err != nil {
+		return fmt.Errorf("removing pod %s with missing infra container: %w", pod.ID(), err)
+	}
+	if report.RemovedPods == nil {
+		report.RemovedPods = make(map[string]string)
+	}
+	report.RemovedPods[pod.ID()] = pod.Name()
+	return nil
+}
+
+// checkPodContainers looks for containers which are members of a pod in the
+// database but do not exist, and removes them from the pod when repairing.
+func (r *Runtime) checkPodContainers(pod *Pod, options entities.SystemCheckOptions, report *entities.SystemCheckReport) error {
+	ctrIDs, err := r.state.PodContainersByID(pod)
+	if err != nil {
+		if errors.Is(err, define.ErrNoSuchPod) || errors.Is(err, define.ErrPodRemoved) {
+			return nil
+		}
+		return fmt.Errorf("retrieving containers of pod %s: %w", pod.ID(), err)
+	}
+	repaired := false
+	for _, id := range ctrIDs {
+		exists, err := r.state.HasContainer(id)
+		if err != nil {
+			return err
+		}
+		if exists {
+			continue
+		}
+		addCheckProblems(&report.Pods, pod.ID(), []string{fmt.Sprintf("container %s of the pod does not exist", id)})
+		report.Errors = true
+		if !options.Repair {
+			continue
+		}
+		if err := r.state.RemoveDanglingPodContainer(pod, id); err != nil {
+			return fmt.Errorf("removing container %s from pod %s: %w", id, pod.ID(), err)
+		}
+		repaired = true
+	}
+	if repaired {
+		report.RepairedPods = append(report.RepairedPods, pod.ID())
+	}
+	return nil
+}
+
+// checkVolumeState checks that the mountpoint of a volume which is not
+// managed by a volume plugin exists, and recreates it when repairing.
+func checkVolumeState(vol *Volume, options entities.SystemCheckOptions, report *entities.SystemCheckReport) error {
+	if vol.UsesVolumeDriver() || vol.config.Driver == define.VolumeDriverImage || vol.config.MountPoint == "" {
+		return nil
+	}
+
+	if _, err := os.Stat(vol.config.MountPoint); err == nil {
+		return nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("checking mountpoint of volume %s: %w", vol.Name(), err)
+	}
+
+	addCheckProblems(&report.Volumes, vol.Name(), []string{fmt.Sprintf("mountpoint %s does not exist", vol.config.MountPoint)})
+	report.Errors = true
+	if !options.Repair {
+		return nil
+	}
+
+	vol.lock.Lock()
+	defer vol.lock.Unlock()
+	if _, err := makeVolumeDirectories(filepath.Dir(vol.config.MountPoint), vol.config); err != nil {
+		return fmt.Errorf("recreating mountpoint of volume %s: %w", vol.Name(), err)
+	}
+	report.RepairedVolumes = append(report.RepairedVolumes, vol.Name())
+	return nil
+}
+
+// addCheckProblems records problems found for the object with the given ID in
+// one of the maps of a SystemCheckReport.
+func addCheckProblems(m *map[string][]string, id string, problems []string) {
+	if *m == nil {
+		*m = make(map[string][]string)
+	}
+	for _, problem := range problems {
+		if !slices.Contains((*m)[id], problem) {
+			(*m)[id] = append((*m)[id], problem)
+		}
+	}
+}
@@ -457,13 +457,21 @@ func (r *Runtime) setupContainer(ctx context.Context, ctr *Container) (_ *Contai
 		}
 	}()
 
+	if err := ctr.validateSecretsRestartPolicy(ctr.config.RestartPolicy); err != nil {
+		return nil, err
+	}
+
 	ctr.config.SecretsPath = filepath.Join(ctr.config.StaticDir, "secrets")
 	err = os.MkdirAll(ctr.config.SecretsPath, 0755)
 	if err != nil {
 		return nil, err
 	}
 	for _, secr := range ctr.config.Secrets {
-		err = ctr.extractSecretToCtrStorage(secr)
+		// encrypted and rotated secrets are written when the container starts
+		if secretSkipsStaticDir(secr) {
+			continue
+		}
+		err = ctr.extractSecretToCtrStorage(secr, filepath.Join(ctr.config.SecretsPath, secr.Name))
 		if err != nil {
 			return nil, err
 		}
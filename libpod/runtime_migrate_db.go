@@ -0,0 +1,528 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/containers/common/pkg/config"
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/storage/pkg/fileutils"
+	"github.com/containers/storage/pkg/homedir"
+	"github.com/containers/storage/pkg/ioutils"
+	"github.com/sirupsen/logrus"
+)
+
+// databaseBackendConf is the name of the containers.conf drop-in which sets
+// database_backend after a migration.
+const databaseBackendConf = "podman-database-backend.conf"
+
+// databaseMigration describes the migration of the database to a new backend.
+type databaseMigration struct {
+	backend    config.DBBackend
+	oldPath    string
+	newPath    string
+	tmpPath    string
+	backupPath string
+	// confPath is the containers.conf drop-in which configures the new
+	// backend, if database_backend is set in containers.conf.
+	confPath string
+}
+
+// newDatabaseMigration validates the migration of the database to the given
+// backend, so that errors are reported before anything is changed.
+func (r *Runtime) newDatabaseMigration(backend string) (*databaseMigration, error) {
+	newBackend, err := config.ParseDBBackend(backend)
+	if err != nil {
+		return nil, err
+	}
+	if newBackend == config.DBBackendDefault {
+		return nil, fmt.Errorf("a database backend to migrate to must be given: %w", define.ErrInvalidArg)
+	}
+	oldBackend, err := config.ParseDBBackend(r.config.Engine.DBBackend)
+	if err != nil {
+		return nil, err
+	}
+	if oldBackend == newBackend {
+		return nil, fmt.Errorf("already using the %s database backend: %w", newBackend.String(), define.ErrInvalidArg)
+	}
+	if r.storageConfig.TransientStore {
+		return nil, fmt.Errorf("cannot migrate the database of a transient store: %w", define.ErrInvalidArg)
+	}
+
+	oldPath := r.databasePath(oldBackend)
+	newPath := r.databasePath(newBackend)
+	m := &databaseMigration{
+		backend:    newBackend,
+		oldPath:    oldPath,
+		newPath:    newPath,
+		backupPath: oldPath + ".bak",
+	}
+	// A BoltDB database is used as soon as it exists, it is only moved
+	// in place once complete.
+	if newBackend == config.DBBackendBoltDB {
+		m.tmpPath = newPath + ".tmp"
+	}
+	// Without database_backend, the backend is chosen per store by the
+	// database found in it. A configured backend must be switched along
+	// with the database.
+	conf, err := config.New(nil)
+	if err != nil {
+		return nil, err
+	}
+	if conf.Engine.DBBackend != "" {
+		m.confPath, err = databaseBackendConfPath()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := m.checkPaths(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// databaseBackendConfPath returns the path of the containers.conf drop-in
+// which sets database_backend. The drop-ins of the user are read after the
+// configuration of the system.
+func databaseBackendConfPath() (string, error) {
+	for _, env := range []string{"CONTAINERS_CONF", "CONTAINERS_CONF_OVERRIDE"} {
+		if os.Getenv(env) != "" {
+			return "", fmt.Errorf("database_backend is set in containers.conf and %s is set, the configured backend cannot be switched: %w", env, define.ErrInvalidArg)
+		}
+	}
+	configHome, err := homedir.GetConfigHome()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configHome, "containers", "containers.conf.d", databaseBackendConf), nil
+}
+
+// checkPaths makes sure that neither the new database nor the backup of the
+// old one exist.
+func (m *databaseMigration) checkPaths() error {
+	for _, path := range []string{m.newPath, m.tmpPath, m.backupPath} {
+		if path == "" {
+			continue
+		}
+		if err := fileutils.Exists(path); err == nil {
+			return fmt.Errorf("%s already exists, move it out of the way to migrate the database: %w", path, define.ErrInvalidArg)
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateDatabase copies all containers, pods, volumes, exec sessions, exit
+// codes and network attachments from the database in use into a new database.
+// Once the copy has been verified, the old database is moved to a backup, so
+// that the store uses the new database from then on. If database_backend is
+// set in containers.conf, a drop-in setting it to the new backend is written
+// as well, and the old database is restored if that fails.
+// The caller must hold the alive lock, so nothing changes the database while
+// it is copied, and must have stopped all containers.
+func (r *Runtime) migrateDatabase(m *databaseMigration) (retErr error) {
+	if err := m.checkPaths(); err != nil {
+		return err
+	}
+	running, err := r.GetRunningContainers()
+	if err != nil {
+		return err
+	}
+	if len(running) > 0 {
+		return fmt.Errorf("container %s is running, all containers must be stopped to migrate the database: %w", running[0].ID(), define.ErrCtrStateInvalid)
+	}
+
+	var newState State
+	if m.backend == config.DBBackendBoltDB {
+		newState, err = newBoltState(m.tmpPath, r, true)
+	} else {
+		newState, err = NewSqliteState(r)
+	}
+	if err != nil {
+		return fmt.Errorf("creating %s database: %w", m.backend.String(), err)
+	}
+	stateClosed := false
+	defer func() {
+		if retErr == nil {
+			return
+		}
+		if !stateClosed {
+			if err := newState.Close(); err != nil {
+				logrus.Errorf("Closing %s database: %v", m.backend.String(), err)
+			}
+		}
+		incompletePath := m.newPath
+		if m.tmpPath != "" {
+			incompletePath = m.tmpPath
+		}
+		if err := os.Remove(incompletePath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			logrus.Errorf("Removing incomplete %s database: %v", m.backend.String(), err)
+		}
+	}()
+
+	if err := newState.ValidateDBConfig(r); err != nil {
+		return err
+	}
+	if err := r.copyDatabase(newState); err != nil {
+		return fmt.Errorf("copying the database: %w", err)
+	}
+	if err := r.verifyDatabaseCopy(newState); err != nil {
+		return fmt.Errorf("verifying the copy of the database: %w", err)
+	}
+	stateClosed = true
+	if err := newState.Close(); err != nil {
+		return fmt.Errorf("closing %s database: %w", m.backend.String(), err)
+	}
+
+	// A BoltDB database is preferred over an SQLite one, so the store
+	// switches to BoltDB once it is in place and to SQLite once the
+	// BoltDB database is moved away.
+	if m.tmpPath != "" {
+		if err := os.Rename(m.tmpPath, m.newPath); err != nil {
+			return fmt.Errorf("moving %s database in place: %w", m.backend.String(), err)
+		}
+	}
+	if err := os.Rename(m.oldPath, m.backupPath); err != nil {
+		if m.tmpPath == "" {
+			return fmt.Errorf("backing up the old database %s: %w", m.oldPath, err)
+		}
+		return fmt.Errorf("switched to the %s database backend but could not back up the old database %s: %w", m.backend.String(), m.oldPath, err)
+	}
+	if m.confPath != "" {
+		if err := m.writeConf(); err != nil {
+			if restoreErr := m.restoreOldDatabase(); restoreErr != nil {
+				logrus.Errorf("Restoring the old database: %v", restoreErr)
+			}
+			return fmt.Errorf("configuring the %s database backend: %w", m.backend.String(), err)
+		}
+		logrus.Warnf("Set database_backend to %s in %s, it applies to all stores, migrate the databases of other stores with --db-backend set to their old backend", m.backend.String(), m.confPath)
+	}
+	logrus.Infof("Migrated the database to %s, the old database was moved to %s", m.backend.String(), m.backupPath)
+
+	return nil
+}
+
+// writeConf writes the containers.conf drop-in which sets database_backend to
+// the new backend and checks that the configuration now uses it.
+func (m *databaseMigration) writeConf() (retErr error) {
+	oldConf, err := os.ReadFile(m.confPath)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(m.confPath), 0o755); err != nil {
+		return err
+	}
+	content := fmt.Sprintf("# Written by podman system migrate --database\n[engine]\ndatabase_backend = %q\n", m.backend.String())
+	if err := ioutils.AtomicWriteFile(m.confPath, []byte(content), 0o644); err != nil {
+		return err
+	}
+	defer func() {
+		if retErr == nil {
+			return
+		}
+		var err error
+		if oldConf != nil {
+			err = ioutils.AtomicWriteFile(m.confPath, oldConf, 0o644)
+		} else {
+			err = os.Remove(m.confPath)
+		}
+		if err != nil {
+			logrus.Errorf("Restoring %s: %v", m.confPath, err)
+		}
+	}()
+
+	conf, err := config.New(nil)
+	if err != nil {
+		return err
+	}
+	if conf.Engine.DBBackend != m.backend.String() {
+		return fmt.Errorf("database_backend is set to %q by a containers.conf file read after %s: %w", conf.Engine.DBBackend, m.confPath, define.ErrInvalidArg)
+	}
+	return nil
+}
+
+// restoreOldDatabase moves the old database back in place and removes the new
+// one after the database has been switched.
+func (m *databaseMigration) restoreOldDatabase() error {
+	if err := os.Rename(m.backupPath, m.oldPath); err != nil {
+		return err
+	}
+	return os.Remove(m.newPath)
+}
+
+// databasePath returns the path of the database file of the given backend.
+func (r *Runtime) databasePath(backend config.DBBackend) string {
+	if backend == config.DBBackendBoltDB {
+		return boltStatePath(r)
+	}
+	return filepath.Join(sqliteStateDir(r), sqliteDBFile)
+}
+
+// copyDatabase copies the content of the database in use into newState.
+func (r *Runtime) copyDatabase(newState State) error {
+	volumes, err := r.state.AllVolumes()
+	if err != nil {
+		return err
+	}
+	for _, vol := range volumes {
+		if err := r.state.UpdateVolume(vol); err != nil {
+			return fmt.Errorf("retrieving state of volume %s: %w", vol.Name(), err)
+		}
+		newVol := &Volume{config: vol.config, state: vol.state, valid: true, runtime: r}
+		if err := newState.AddVolume(newVol); err != nil {
+			return fmt.Errorf("copying volume %s: %w", vol.Name(), err)
+		}
+	}
+
+	pods, err := r.state.AllPods()
+	if err != nil {
+		return err
+	}
+	newPods := make(map[string]*Pod, len(pods))
+	for _, pod := range pods {
+		if err := r.state.UpdatePod(pod); err != nil {
+			return fmt.Errorf("retrieving state of pod %s: %w", pod.ID(), err)
+		}
+		// The infra container is not in the new database yet, it is
+		// set once all containers have been copied.
+		state := new(podState)
+		if err := JSONDeepCopy(pod.state, state); err != nil {
+			return err
+		}
+		state.InfraContainerID = ""
+		newPod := &Pod{config: pod.config, state: state, valid: true, runtime: r}
+		if err := newState.AddPod(newPod); err != nil {
+			return fmt.Errorf("copying pod %s: %w", pod.ID(), err)
+		}
+		newPods[pod.ID()] = newPod
+	}
+
+	ctrs, err := r.state.AllContainers(true)
+	if err != nil {
+		return err
+	}
+	// Containers can only be added once all of their dependencies are.
+	copied := make(map[string]bool, len(ctrs))
+	for len(copied) < len(ctrs) {
+		progress := false
+		for _, ctr := range ctrs {
+			if copied[ctr.ID()] || slices.ContainsFunc(ctr.Dependencies(), func(dep string) bool { return !copied[dep] }) {
+				continue
+			}
+			if err := r.copyContainerToState(newState, ctr, newPods[ctr.config.Pod]); err != nil {
+				return fmt.Errorf("copying container %s: %w", ctr.ID(), err)
+			}
+			copied[ctr.ID()] = true
+			progress = true
+		}
+		if !progress {
+			return fmt.Errorf("%d containers depend on containers that are not in the database: %w", len(ctrs)-len(copied), define.ErrInternal)
+		}
+	}
+
+	for _, pod := range pods {
+		if pod.state.InfraContainerID == "" {
+			continue
+		}
+		newPod := newPods[pod.ID()]
+		newPod.state.InfraContainerID = pod.state.InfraContainerID
+		if err := newState.SavePod(newPod); err != nil {
+			return fmt.Errorf("setting infra container of pod %s: %w", pod.ID(), err)
+		}
+	}
+
+	// Exit codes are kept for a while after their container is removed.
+	// Their time stamps restart with the migration, so they are pruned at
+	// most five minutes later than before.
+	exitCodes, err := r.state.AllContainerExitCodes()
+	if err != nil {
+		return err
+	}
+	for id, exitCode := range exitCodes {
+		if err := newState.AddContainerExitCode(id, exitCode); err != nil {
+			return fmt.Errorf("copying exit code of container %s: %w", id, err)
+		}
+	}
+
+	return nil
+}
+
+// copyContainerToState copies a container, including its network attachments
+// and exec sessions, from the database in use into newState.
+func (r *Runtime) copyContainerToState(newState State, ctr *Container, pod *Pod) error {
+	// The BoltDB backend keeps networks out of the stored config.
+	networks, err := r.state.GetNetworks(ctr)
+	if err != nil {
+		return err
+	}
+	ctrConfig := new(ContainerConfig)
+	if err := JSONDeepCopy(ctr.config, ctrConfig); err != nil {
+		return err
+	}
+	ctrConfig.Networks = networks
+
+	newCtr := &Container{config: ctrConfig, state: ctr.state, valid: true, runtime: r}
+	if ctrConfig.Pod == "" {
+		err = newState.AddContainer(newCtr)
+	} else {
+		if pod == nil {
+			return fmt.Errorf("pod %s of container is not in the database: %w", ctrConfig.Pod, define.ErrNoSuchPod)
+		}
+		err = newState.AddContainerToPod(pod, newCtr)
+	}
+	if err != nil {
+		return err
+	}
+
+	// The database only associates the exec sessions with the container,
+	// the sessions themselves are part of the copied container state.
+	sessions, err := r.state.GetContainerExecSessions(ctr)
+	if err != nil {
+		return err
+	}
+	for _, id := range sessions {
+		session, ok := ctr.state.ExecSessions[id]
+		if !ok {
+			return fmt.Errorf("exec session %s is not in the state of the container, it would be lost: %w", id, define.ErrInternal)
+		}
+		if err := newState.AddExecSession(newCtr, session); err != nil {
+			return fmt.Errorf("copying exec session %s: %w", id, err)
+		}
+	}
+	return nil
+}
+
+// verifyDatabaseCopy checks that newState holds the same containers, pods,
+// volumes and exit codes as the database in use.
+func (r *Runtime) verifyDatabaseCopy(newState State) error {
+	oldExitCodes, err := r.state.AllContainerExitCodes()
+	if err != nil {
+		return err
+	}
+	newExitCodes, err := newState.AllContainerExitCodes()
+	if err != nil {
+		return err
+	}
+	if !maps.Equal(oldExitCodes, newExitCodes) {
+		return fmt.Errorf("exit codes differ: %w", define.ErrInternal)
+	}
+
+	oldVolumes, err := r.state.AllVolumes()
+	if err != nil {
+		return err
+	}
+	newVolumes, err := newState.AllVolumes()
+	if err != nil {
+		return err
+	}
+	err = compareStateObjects(r.state, newState, "volume", oldVolumes, newVolumes, (*Volume).Name,
+		func(state State, vol *Volume) (any, error) {
+			if err := state.UpdateVolume(vol); err != nil {
+				return nil, err
+			}
+			return []any{vol.config, vol.state}, nil
+		})
+	if err != nil {
+		return err
+	}
+
+	oldPods, err := r.state.AllPods()
+	if err != nil {
+		return err
+	}
+	newPods, err := newState.AllPods()
+	if err != nil {
+		return err
+	}
+	err = compareStateObjects(r.state, newState, "pod", oldPods, newPods, (*Pod).ID,
+		func(state State, pod *Pod) (any, error) {
+			if err := state.UpdatePod(pod); err != nil {
+				return nil, err
+			}
+			return []any{pod.config, pod.state}, nil
+		})
+	if err != nil {
+		return err
+	}
+
+	oldCtrs, err := r.state.AllContainers(true)
+	if err != nil {
+		return err
+	}
+	newCtrs, err := newState.AllContainers(true)
+	if err != nil {
+		return err
+	}
+	return compareStateObjects(r.state, newState, "container", oldCtrs, newCtrs, (*Container).ID,
+		func(state State, ctr *Container) (any, error) {
+			ctrConfig := new(ContainerConfig)
+			if err := JSONDeepCopy(ctr.config, ctrConfig); err != nil {
+				return nil, err
+			}
+			networks, err := state.GetNetworks(ctr)
+			if err != nil {
+				return nil, err
+			}
+			ctrConfig.Networks = nil
+			if len(networks) > 0 {
+				ctrConfig.Networks = networks
+			}
+			sessions, err := state.GetContainerExecSessions(ctr)
+			if err != nil {
+				return nil, err
+			}
+			if len(sessions) == 0 {
+				sessions = nil
+			}
+			slices.Sort(sessions)
+			return []any{ctrConfig, ctr.state, sessions}, nil
+		})
+}
+
+// compareStateObjects checks that oldState and newState hold the same objects
+// of one kind. Objects are matched by key and compared by the JSON encoding of
+// what data returns for them.
+func compareStateObjects[T any](oldState, newState State, kind string, oldObjs, newObjs []T, key func(T) string, data func(State, T) (any, error)) error {
+	if len(oldObjs) != len(newObjs) {
+		return fmt.Errorf("found %d %ss instead of %d: %w", len(newObjs), kind, len(oldObjs), define.ErrInternal)
+	}
+	newByKey := make(map[string]T, len(newObjs))
+	for _, obj := range newObjs {
+		newByKey[key(obj)] = obj
+	}
+	for _, oldObj := range oldObjs {
+		newObj, ok := newByKey[key(oldObj)]
+		if !ok {
+			return fmt.Errorf("%s %s is missing: %w", kind, key(oldObj), define.ErrInternal)
+		}
+		oldData, err := data(oldState, oldObj)
+		if err != nil {
+			return err
+		}
+		newData, err := data(newState, newObj)
+		if err != nil {
+			return err
+		}
+		oldJSON, err := json.Marshal(oldData)
+		if err != nil {
+			return err
+		}
+		newJSON, err := json.Marshal(newData)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(oldJSON, newJSON) {
+			return fmt.Errorf("%s %s differs: %w", kind, key(oldObj), define.ErrInternal)
+		}
+	}
+	return nil
+}
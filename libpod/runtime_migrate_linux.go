@@ -44,8 +44,17 @@ func (r *Runtime) stopPauseProcess() error {
 
 // Migrate stops the rootless pause process and performs any necessary database
 // migrations that are required. It can also migrate all containers to a new OCI
-// runtime, if requested.
-func (r *Runtime) Migrate(newRuntime string) error {
+// runtime and the database to a new backend, if requested.
+func (r *Runtime) Migrate(newRuntime, newDBBackend string) error {
+	var dbMigration *databaseMigration
+	if newDBBackend != "" {
+		var err error
+		dbMigration, err = r.newDatabaseMigration(newDBBackend)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Acquire the alive lock and hold it.
 	// Ensures that we don't let other Podman commands run while we are
 	// rewriting things in the DB.
@@ -116,5 +125,11 @@ func (r *Runtime) Migrate(newRuntime string) error {
 		}
 	}
 
+	if dbMigration != nil {
+		if err := r.migrateDatabase(dbMigration); err != nil {
+			return err
+		}
+	}
+
 	return r.stopPauseProcess()
 }
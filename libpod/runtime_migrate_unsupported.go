@@ -10,6 +10,6 @@ func (r *Runtime) stopPauseProcess() error {
 	return errors.New("not implemented (*Runtime) stopPauseProcess")
 }
 
-func (r *Runtime) Migrate(newRuntime string) error {
+func (r *Runtime) Migrate(newRuntime, newDBBackend string) error {
 	return errors.New("not implemented (*Runtime) migrate")
 }
@@ -6,10 +6,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/containers/podman/v5/libpod/define"
 	"github.com/containers/podman/v5/libpod/events"
 	"github.com/containers/podman/v5/pkg/specgen"
+	"github.com/containers/storage/pkg/stringid"
 	"github.com/hashicorp/go-multierror"
 	"github.com/sirupsen/logrus"
 )
@@ -97,6 +99,61 @@ func (r *Runtime) NewPod(ctx context.Context, p specgen.PodSpecGenerator, option
 	return pod, nil
 }
 
+// RestorePod re-creates a pod from the configuration stored in a pod
+// checkpoint. If the ID of the configuration is empty a new name for the
+// restored pod was requested and a new ID is generated.
+// The infra container has to be restored and added with AddInfra()
+// afterwards.
+func (r *Runtime) RestorePod(ctx context.Context, config *PodConfig) (_ *Pod, deferredErr error) {
+	if !r.valid {
+		return nil, define.ErrRuntimeStopped
+	}
+
+	pod := newPod(r)
+	if err := JSONDeepCopy(config, pod.config); err != nil {
+		return nil, fmt.Errorf("copying pod config for restore: %w", err)
+	}
+	if pod.config.ID == "" {
+		pod.config.ID = stringid.GenerateRandomID()
+	}
+	pod.config.CreatedTime = time.Now()
+	// The service container of the checkpointed pod is not restored.
+	pod.config.ServiceContainerID = ""
+	// Use the default cgroup parent of this host, the pod might have been
+	// checkpointed on a host with a different cgroup manager.
+	switch pod.config.CgroupParent {
+	case CgroupfsDefaultCgroupParent, SystemdDefaultCgroupParent, SystemdDefaultRootlessCgroupParent:
+		pod.config.CgroupParent = ""
+	}
+
+	lock, err := r.lockManager.AllocateLock()
+	if err != nil {
+		return nil, fmt.Errorf("allocating lock for restored pod: %w", err)
+	}
+	pod.lock = lock
+	pod.config.LockID = pod.lock.ID()
+
+	defer func() {
+		if deferredErr != nil {
+			if err := pod.lock.Free(); err != nil {
+				logrus.Errorf("Freeing pod lock after failed restore: %v", err)
+			}
+		}
+	}()
+
+	pod.valid = true
+
+	if _, err := r.platformMakePod(pod, &pod.config.ResourceLimits); err != nil {
+		return nil, err
+	}
+
+	if err := r.state.AddPod(pod); err != nil {
+		return nil, fmt.Errorf("adding pod to state: %w", err)
+	}
+
+	return pod, nil
+}
+
 // AddInfra adds the created infra container to the pod state
 func (r *Runtime) AddInfra(ctx context.Context, pod *Pod, infraCtr *Container) (*Pod, error) {
 	if !r.valid {
@@ -280,6 +337,12 @@ func (r *Runtime) removePod(ctx context.Context, p *Pod, removeCtrs, force bool,
 		}
 	}
 
+	if p.config.CronJob != nil {
+		if err := p.removeCronJobTimer(ctx); err != nil {
+			logrus.Errorf("Removing cron job timer of pod %s: %v", p.ID(), err)
+		}
+	}
+
 	if err := p.maybeRemoveServiceContainer(); err != nil {
 		return removedCtrs, err
 	}
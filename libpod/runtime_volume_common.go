@@ -162,20 +162,8 @@ func (r *Runtime) newVolume(ctx context.Context, noCreatePluginVolume bool, opti
 	} else {
 		// Create the mountpoint of this volume
 		volPathRoot := filepath.Join(r.config.Engine.VolumePath, volume.config.Name)
-		if err := os.MkdirAll(volPathRoot, 0700); err != nil {
-			return nil, fmt.Errorf("creating volume directory %q: %w", volPathRoot, err)
-		}
-		if err := idtools.SafeChown(volPathRoot, volume.config.UID, volume.config.GID); err != nil {
-			return nil, fmt.Errorf("chowning volume directory %q to %d:%d: %w", volPathRoot, volume.config.UID, volume.config.GID, err)
-		}
-		fullVolPath := filepath.Join(volPathRoot, "_data")
-		if err := os.MkdirAll(fullVolPath, 0755); err != nil {
-			return nil, fmt.Errorf("creating volume directory %q: %w", fullVolPath, err)
-		}
-		if err := idtools.SafeChown(fullVolPath, volume.config.UID, volume.config.GID); err != nil {
-			return nil, fmt.Errorf("chowning volume directory %q to %d:%d: %w", fullVolPath, volume.config.UID, volume.config.GID, err)
-		}
-		if err := LabelVolumePath(fullVolPath, volume.config.MountLabel); err != nil {
+		fullVolPath, err := makeVolumeDirectories(volPathRoot, volume.config)
+		if err != nil {
 			return nil, err
 		}
 		switch {
@@ -189,13 +177,19 @@ func (r *Runtime) newVolume(ctx context.Context, noCreatePluginVolume bool, opti
 				return nil, errors.New("volume option inodes not supported on tmpfs filesystem")
 			}
 		case volume.config.Inodes > 0 || volume.config.Size > 0:
-			projectQuotaSupported := false
 			q, err := quota.NewControl(r.config.Engine.VolumePath)
-			if err == nil {
-				projectQuotaSupported = true
-			}
-			if !projectQuotaSupported {
-				return nil, errors.New("volume options size and inodes not supported. Filesystem does not support Project Quota")
+			if err != nil {
+				// Without project quotas, the size can still be
+				// enforced by storing the data in a loopback image.
+				if volume.config.Size == 0 {
+					return nil, errors.New("volume options size and inodes not supported. Filesystem does not support Project Quota")
+				}
+				logrus.Debugf("Project quota not supported for volume %s, using a loopback image: %v", volume.config.Name, err)
+				if err := createVolumeLoopImage(volPathRoot, volume.config); err != nil {
+					return nil, fmt.Errorf("volume option size not supported. Filesystem does not support Project Quota and creating a loopback image failed: %w", err)
+				}
+				volume.config.QuotaBackend = define.VolumeQuotaLoop
+				break
 			}
 			quota := quota.Quota{
 				Inodes: volume.config.Inodes,
@@ -208,6 +202,7 @@ func (r *Runtime) newVolume(ctx context.Context, noCreatePluginVolume bool, opti
 			if err := q.SetQuota(volPathRoot, quota); err != nil {
 				return nil, fmt.Errorf("failed to set size quota size=%d inodes=%d for volume directory %q: %w", volume.config.Size, volume.config.Inodes, fullVolPath, err)
 			}
+			volume.config.QuotaBackend = define.VolumeQuotaProject
 		}
 
 		volume.config.MountPoint = fullVolPath
@@ -497,3 +492,26 @@ func (r *Runtime) removeVolume(ctx context.Context, v *Volume, force bool, timeo
 	logrus.Debugf("Removed volume %s", v.Name())
 	return removalErr
 }
+
+// makeVolumeDirectories creates the directory of a volume that is not managed
+// by a volume plugin, and the _data directory in it that is mounted into
+// containers. The path of the _data directory is returned.
+func makeVolumeDirectories(volPathRoot string, config *VolumeConfig) (string, error) {
+	if err := os.MkdirAll(volPathRoot, 0700); err != nil {
+		return "", fmt.Errorf("creating volume directory %q: %w", volPathRoot, err)
+	}
+	if err := idtools.SafeChown(volPathRoot, config.UID, config.GID); err != nil {
+		return "", fmt.Errorf("chowning volume directory %q to %d:%d: %w", volPathRoot, config.UID, config.GID, err)
+	}
+	fullVolPath := filepath.Join(volPathRoot, "_data")
+	if err := os.MkdirAll(fullVolPath, 0755); err != nil {
+		return "", fmt.Errorf("creating volume directory %q: %w", fullVolPath, err)
+	}
+	if err := idtools.SafeChown(fullVolPath, config.UID, config.GID); err != nil {
+		return "", fmt.Errorf("chowning volume directory %q to %d:%d: %w", fullVolPath, config.UID, config.GID, err)
+	}
+	if err := LabelVolumePath(fullVolPath, config.MountLabel); err != nil {
+		return "", err
+	}
+	return fullVolPath, nil
+}
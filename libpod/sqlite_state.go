@@ -40,8 +40,11 @@ const (
 	// Make sure that transactions happen exclusively.
 	sqliteOptionTXLock = "&_txlock=exclusive"
 
+	// Name of the database file.
+	sqliteDBFile = "db.sql"
+
 	// Assembled sqlite options used when opening the database.
-	sqliteOptions = "db.sql?" +
+	sqliteOptions = sqliteDBFile + "?" +
 		sqliteOptionLocation +
 		sqliteOptionSynchronous +
 		sqliteOptionForeignKeys +
@@ -53,12 +56,7 @@ func NewSqliteState(runtime *Runtime) (_ State, defErr error) {
 	logrus.Info("Using sqlite as database backend")
 	state := new(SQLiteState)
 
-	basePath := runtime.storageConfig.GraphRoot
-	if runtime.storageConfig.TransientStore {
-		basePath = runtime.storageConfig.RunRoot
-	} else if !runtime.storageSet.StaticDirSet {
-		basePath = runtime.config.Engine.StaticDir
-	}
+	basePath := sqliteStateDir(runtime)
 
 	// c/storage is set up *after* the DB - so even though we use the c/s
 	// root (or, for transient, runroot) dir, we need to make the dir
@@ -101,6 +99,18 @@ func NewSqliteState(runtime *Runtime) (_ State, defErr error) {
 	return state, nil
 }
 
+// sqliteStateDir returns the directory holding the SQLite database of the
+// runtime.
+func sqliteStateDir(runtime *Runtime) string {
+	if runtime.storageConfig.TransientStore {
+		return runtime.storageConfig.RunRoot
+	}
+	if !runtime.storageSet.StaticDirSet {
+		return runtime.config.Engine.StaticDir
+	}
+	return runtime.storageConfig.GraphRoot
+}
+
 // Close closes the state and prevents further use
 func (s *SQLiteState) Close() error {
 	if err := s.conn.Close(); err != nil {
@@ -979,6 +989,36 @@ func (s *SQLiteState) GetContainerExitCode(id string) (int32, error) {
 	return exitCode, nil
 }
 
+// AllContainerExitCodes returns the exit codes of all containers, including
+// removed ones, by container ID.
+func (s *SQLiteState) AllContainerExitCodes() (map[string]int32, error) {
+	if !s.valid {
+		return nil, define.ErrDBClosed
+	}
+
+	rows, err := s.conn.Query("SELECT ID, ExitCode FROM ContainerExitCode;")
+	if err != nil {
+		return nil, fmt.Errorf("querying container exit codes: %w", err)
+	}
+	defer rows.Close()
+
+	exitCodes := make(map[string]int32)
+	for rows.Next() {
+		var (
+			id       string
+			exitCode int32
+		)
+		if err := rows.Scan(&id, &exitCode); err != nil {
+			return nil, fmt.Errorf("scanning container exit code: %w", err)
+		}
+		exitCodes[id] = exitCode
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return exitCodes, nil
+}
+
 // GetContainerExitCodeTimeStamp returns the time stamp when the exit code of
 // the specified container was added to the database.
 func (s *SQLiteState) GetContainerExitCodeTimeStamp(id string) (*time.Time, error) {
@@ -1464,6 +1504,20 @@ func (s *SQLiteState) PodHasContainer(pod *Pod, id string) (bool, error) {
 	return true, nil
 }
 
+// RemoveDanglingPodContainer removes the ID of a container which does not
+// exist in the state from the containers of a pod.  The containers of a pod
+// are looked up by their configuration, so there is nothing to remove.
+func (s *SQLiteState) RemoveDanglingPodContainer(pod *Pod, ctrID string) error {
+	exists, err := s.PodHasContainer(pod, ctrID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("container %s of pod %s exists: %w", ctrID, pod.ID(), define.ErrCtrExists)
+	}
+	return nil
+}
+
 // PodContainersByID returns the IDs of all containers present in the given pod
 func (s *SQLiteState) PodContainersByID(pod *Pod) ([]string, error) {
 	if !s.valid {
@@ -113,6 +113,9 @@ type State interface { //nolint:interfacebloat
 	AddContainerExitCode(id string, exitCode int32) error
 	// Return the exit code for the specified container.
 	GetContainerExitCode(id string) (int32, error)
+	// Return the exit codes of all containers, including removed ones, by
+	// container ID.
+	AllContainerExitCodes() (map[string]int32, error)
 	// Remove exit codes older than 5 minutes.
 	PruneContainerExitCodes() error
 
@@ -241,6 +244,11 @@ type State interface { //nolint:interfacebloat
 	// The container must be in the given pod, and the pod must be in the
 	// set namespace.
 	RemoveContainerFromPod(pod *Pod, ctr *Container) error
+	// RemoveDanglingPodContainer removes the ID of a container which does
+	// not exist in the state from the containers of a pod.
+	// It is used to repair inconsistent databases.
+	// The pod must be in the set namespace.
+	RemoveDanglingPodContainer(pod *Pod, ctrID string) error
 	// UpdatePod updates a pod's state from the database.
 	// The pod must be in the set namespace.
 	UpdatePod(pod *Pod) error
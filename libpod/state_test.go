@@ -1671,6 +1671,33 @@ func TestPodContainersByIDOneContainer(t *testing.T) {
 	})
 }
 
+func TestRemoveDanglingPodContainer(t *testing.T) {
+	runForAllStates(t, func(t *testing.T, state State, manager lock.Manager) {
+		testPod, err := getTestPod1(manager)
+		assert.NoError(t, err)
+
+		testCtr, err := getTestCtr2(manager)
+		assert.NoError(t, err)
+		testCtr.config.Pod = testPod.ID()
+
+		err = state.AddPod(testPod)
+		assert.NoError(t, err)
+
+		err = state.AddContainerToPod(testPod, testCtr)
+		assert.NoError(t, err)
+
+		err = state.RemoveDanglingPodContainer(testPod, testCtr.ID())
+		assert.ErrorIs(t, err, define.ErrCtrExists)
+
+		err = state.RemoveDanglingPodContainer(testPod, strings.Repeat("3", 32))
+		assert.NoError(t, err)
+
+		ctrs, err := state.PodContainersByID(testPod)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{testCtr.ID()}, ctrs)
+	})
+}
+
 func TestPodContainersByIDMultipleContainers(t *testing.T) {
 	runForAllStates(t, func(t *testing.T, state State, manager lock.Manager) {
 		testPod, err := getTestPod1(manager)
@@ -2594,6 +2621,29 @@ func TestGetContainerConfigNonExistentIDFails(t *testing.T) {
 }
 
 // Test that the state will convert the ports to the new format
+func TestAllContainerExitCodes(t *testing.T) {
+	runForAllStates(t, func(t *testing.T, state State, manager lock.Manager) {
+		exitCodes, err := state.AllContainerExitCodes()
+		assert.NoError(t, err)
+		assert.Empty(t, exitCodes)
+
+		testCtr, err := getTestCtr1(manager)
+		assert.NoError(t, err)
+		err = state.AddContainer(testCtr)
+		assert.NoError(t, err)
+
+		// exit codes are kept for removed containers as well
+		err = state.AddContainerExitCode(testCtr.ID(), 1)
+		assert.NoError(t, err)
+		err = state.AddContainerExitCode("removed", 127)
+		assert.NoError(t, err)
+
+		exitCodes, err = state.AllContainerExitCodes()
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]int32{testCtr.ID(): 1, "removed": 127}, exitCodes)
+	})
+}
+
 func TestConvertPortMapping(t *testing.T) {
 	runForAllStates(t, func(t *testing.T, state State, manager lock.Manager) {
 		testCtr, err := getTestCtr1(manager)
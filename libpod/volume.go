@@ -58,6 +58,10 @@ type VolumeConfig struct {
 	// DisableQuota indicates that the volume should completely disable using any
 	// quota tracking.
 	DisableQuota bool `json:"disableQuota,omitempty"`
+	// QuotaBackend is the backend enforcing Size and Inodes, one of
+	// define.VolumeQuotaProject or define.VolumeQuotaLoop. Volumes created
+	// before it was recorded use project quotas if Size or Inodes is set.
+	QuotaBackend string `json:"quotaBackend,omitempty"`
 	// Timeout allows users to override the default driver timeout of 5 seconds
 	Timeout *uint `json:"timeout,omitempty"`
 	// StorageName is the name of the volume in c/storage. Only used for
@@ -106,6 +110,10 @@ type VolumeState struct {
 	UIDChowned int `json:"uidChowned,omitempty"`
 	// GIDChowned is the GID the volume was chowned to.
 	GIDChowned int `json:"gidChowned,omitempty"`
+	// Snapshots are the snapshots of the volume's data, oldest first.
+	// Only volumes using the local driver without mount options can
+	// have snapshots.
+	Snapshots []VolumeSnapshot `json:"snapshots,omitempty"`
 }
 
 // Name retrieves the volume's name
@@ -119,6 +127,19 @@ func (v *Volume) Size() (uint64, error) {
 	return uint64(size), err
 }
 
+// QuotaUsage returns the quota enforced for the volume and its usage as
+// reported by the quota backend, which is much cheaper than walking the
+// volume's data. It returns nil if no quota is enforced for the volume.
+func (v *Volume) QuotaUsage() (*define.InspectVolumeQuota, error) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if err := v.update(); err != nil {
+		return nil, err
+	}
+	return v.quotaUsage(), nil
+}
+
 // Driver retrieves the volume's driver.
 func (v *Volume) Driver() string {
 	return v.config.Driver
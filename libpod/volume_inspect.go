@@ -68,6 +68,10 @@ func (v *Volume) Inspect() (*define.InspectVolumeData, error) {
 	data.NeedsChown = v.state.NeedsChown
 	data.StorageID = v.config.StorageID
 	data.LockNumber = v.lock.ID()
+	for _, snapshot := range v.state.Snapshots {
+		data.Snapshots = append(data.Snapshots, snapshot.inspect())
+	}
+	data.Quota = v.quotaUsage()
 
 	if v.config.Timeout != nil {
 		data.Timeout = *v.config.Timeout
@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 
 	"github.com/containers/podman/v5/libpod/define"
+	"github.com/sirupsen/logrus"
 )
 
 // Creates a new volume
@@ -29,6 +30,9 @@ func (v *Volume) teardownStorage() error {
 		return nil
 	}
 
+	if err := os.RemoveAll(v.snapshotsDir()); err != nil {
+		return err
+	}
 	// TODO: Should this be converted to use v.config.MountPoint?
 	return os.RemoveAll(filepath.Join(v.runtime.config.Engine.VolumePath, v.Name()))
 }
@@ -46,6 +50,11 @@ func (v *Volume) needsMount() bool {
 		return true
 	}
 
+	// The data of volumes with a loopback quota is in the image.
+	if v.config.QuotaBackend == define.VolumeQuotaLoop {
+		return true
+	}
+
 	// Commit 28138dafcc added the UID and GID options to this map
 	// However we should only mount when options other than uid and gid are set.
 	// see https://github.com/containers/podman/issues/10620
@@ -59,6 +68,9 @@ func (v *Volume) needsMount() bool {
 	if _, ok := v.config.Options["SIZE"]; ok {
 		index++
 	}
+	if _, ok := v.config.Options["INODES"]; ok {
+		index++
+	}
 	if _, ok := v.config.Options["NOQUOTA"]; ok {
 		index++
 	}
@@ -112,3 +124,62 @@ func resetVolumeState(state *VolumeState) {
 	state.MountPoint = ""
 	state.CopiedUp = false
 }
+
+const (
+	// volumeLoopImage is the name of the image holding the data of volumes
+	// with a loopback quota, next to the _data directory it is mounted on.
+	volumeLoopImage = "volume.img"
+	// volumeLoopFSType is the file system created in the image.
+	volumeLoopFSType = "ext4"
+)
+
+func (v *Volume) loopImagePath() string {
+	return filepath.Join(v.runtime.config.Engine.VolumePath, v.Name(), volumeLoopImage)
+}
+
+// quotaBackend returns the backend enforcing the size and inodes of the
+// volume, or an empty string if they are not enforced.
+func (v *Volume) quotaBackend() string {
+	if v.config.QuotaBackend != "" {
+		return v.config.QuotaBackend
+	}
+	// Volumes created before the backend was recorded could only use
+	// project quotas.
+	if v.UsesVolumeDriver() || v.config.Driver == define.VolumeDriverImage || v.config.DisableQuota || v.config.Options["type"] == define.TypeTmpfs {
+		return ""
+	}
+	if v.config.Size > 0 || v.config.Inodes > 0 {
+		return define.VolumeQuotaProject
+	}
+	return ""
+}
+
+// quotaUsage returns the quota of the volume and, if the backend can report
+// it, its usage. It returns nil if no quota is enforced for the volume.
+// Must be called with the volume locked.
+func (v *Volume) quotaUsage() *define.InspectVolumeQuota {
+	backend := v.quotaBackend()
+	if backend == "" {
+		return nil
+	}
+	usage := &define.InspectVolumeQuota{
+		Backend: backend,
+		Size:    v.config.Size,
+		Inodes:  v.config.Inodes,
+	}
+	var err error
+	switch backend {
+	case define.VolumeQuotaProject:
+		err = v.projectQuotaUsage(usage)
+	case define.VolumeQuotaLoop:
+		err = v.loopQuotaUsage(usage)
+	default:
+		err = fmt.Errorf("unknown quota backend %q", backend)
+	}
+	if err != nil {
+		logrus.Debugf("Getting quota usage of volume %s: %v", v.Name(), err)
+		return usage
+	}
+	usage.UsageAvailable = true
+	return usage
+}
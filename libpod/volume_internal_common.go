@@ -79,6 +79,14 @@ func (v *Volume) mount() error {
 	volDevice := v.config.Options["device"]
 	volType := v.config.Options["type"]
 	volOptions := v.config.Options["o"]
+	if v.config.QuotaBackend == define.VolumeQuotaLoop {
+		// The size and inodes are already enforced by the file system in
+		// the image, the other options in "o" are not mount options.
+		// Discarding releases the space of deleted data from the image.
+		volDevice = v.loopImagePath()
+		volType = volumeLoopFSType
+		volOptions = "loop,discard"
+	}
 
 	// Some filesystems (tmpfs) don't have a device, but we still need to
 	// give the kernel something.
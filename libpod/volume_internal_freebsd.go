@@ -3,9 +3,20 @@
 package libpod
 
 import (
+	"errors"
+
+	"github.com/containers/podman/v5/libpod/define"
 	"golang.org/x/sys/unix"
 )
 
 func detachUnmount(mountPoint string) error {
 	return unix.Unmount(mountPoint, unix.MNT_FORCE)
 }
+
+func createVolumeLoopImage(volPathRoot string, config *VolumeConfig) error {
+	return errors.New("loopback images are not supported on FreeBSD")
+}
+
+func (v *Volume) loopQuotaUsage(usage *define.InspectVolumeQuota) error {
+	return errors.New("loopback images are not supported on FreeBSD")
+}
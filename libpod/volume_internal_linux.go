@@ -3,9 +3,85 @@
 package libpod
 
 import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/pkg/rootless"
 	"golang.org/x/sys/unix"
 )
 
 func detachUnmount(mountPoint string) error {
 	return unix.Unmount(mountPoint, unix.MNT_DETACH)
 }
+
+// createVolumeLoopImage creates the image holding the data of a volume whose
+// size cannot be enforced with project quotas. The image is mounted on the
+// volume's _data directory when the volume is mounted.
+func createVolumeLoopImage(volPathRoot string, config *VolumeConfig) error {
+	if rootless.IsRootless() {
+		return errors.New("loopback images cannot be mounted by rootless users")
+	}
+	mkfsPath, err := exec.LookPath("mkfs." + volumeLoopFSType)
+	if err != nil {
+		return fmt.Errorf("locating 'mkfs.%s' binary: %w", volumeLoopFSType, err)
+	}
+
+	image := filepath.Join(volPathRoot, volumeLoopImage)
+	f, err := os.OpenFile(image, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return err
+	}
+	// The image is sparse, space is only allocated as data is written.
+	err = f.Truncate(int64(config.Size))
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		// No blocks are reserved for root, the volume size is the usable size.
+		args := []string{"-q", "-F", "-m", "0", "-E", fmt.Sprintf("root_owner=%d:%d", config.UID, config.GID)}
+		if config.Inodes > 0 {
+			args = append(args, "-N", strconv.FormatUint(config.Inodes, 10))
+		}
+		args = append(args, image)
+		if output, mkfsErr := exec.Command(mkfsPath, args...).CombinedOutput(); mkfsErr != nil {
+			err = fmt.Errorf("creating file system in %s: %s: %w", image, strings.TrimSpace(string(output)), mkfsErr)
+		}
+	}
+	if err != nil {
+		if rmErr := os.Remove(image); rmErr != nil {
+			return fmt.Errorf("%w (removing %s: %v)", err, image, rmErr)
+		}
+		return err
+	}
+	return nil
+}
+
+// loopQuotaUsage fills in the usage of a volume with a loopback quota. The
+// usage is the space allocated for the sparse image whether or not the volume
+// is mounted, so it does not change when the volume is mounted or unmounted.
+func (v *Volume) loopQuotaUsage(usage *define.InspectVolumeQuota) error {
+	if v.state.MountCount > 0 {
+		// Write back the file system so its data is allocated in the image.
+		fd, err := unix.Open(v.config.MountPoint, unix.O_RDONLY|unix.O_DIRECTORY|unix.O_CLOEXEC, 0)
+		if err != nil {
+			return err
+		}
+		err = unix.Syncfs(fd)
+		unix.Close(fd)
+		if err != nil {
+			return fmt.Errorf("syncing volume %s: %w", v.Name(), err)
+		}
+	}
+	var st unix.Stat_t
+	if err := unix.Stat(v.loopImagePath(), &st); err != nil {
+		return err
+	}
+	usage.Used = st.Blocks * 512
+	return nil
+}
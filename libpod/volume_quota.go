@@ -0,0 +1,26 @@
+//go:build !remote && linux && !exclude_disk_quota && cgo
+
+package libpod
+
+import (
+	"path/filepath"
+
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/storage/drivers/quota"
+	"github.com/containers/storage/pkg/directory"
+)
+
+// projectQuotaUsage fills in the usage of a volume with an XFS project quota.
+func (v *Volume) projectQuotaUsage(usage *define.InspectVolumeQuota) error {
+	q, err := quota.NewControl(v.runtime.config.Engine.VolumePath)
+	if err != nil {
+		return err
+	}
+	var diskUsage directory.DiskUsage
+	if err := q.GetDiskUsage(filepath.Join(v.runtime.config.Engine.VolumePath, v.Name()), &diskUsage); err != nil {
+		return err
+	}
+	usage.Used = diskUsage.Size
+	usage.InodesUsed = diskUsage.InodeCount
+	return nil
+}
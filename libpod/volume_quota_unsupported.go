@@ -0,0 +1,15 @@
+//go:build !remote && (!linux || exclude_disk_quota || !cgo)
+
+package libpod
+
+import (
+	"errors"
+
+	"github.com/containers/podman/v5/libpod/define"
+)
+
+// projectQuotaUsage is not supported without project quota support in
+// c/storage.
+func (v *Volume) projectQuotaUsage(usage *define.InspectVolumeQuota) error {
+	return errors.New("project quotas are not supported on this platform")
+}
@@ -0,0 +1,322 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/storage/drivers/copy"
+	"github.com/sirupsen/logrus"
+)
+
+// volumeSnapshotsDir is the directory in the volume path which contains the
+// snapshots of the volumes, one directory per volume. The snapshots are kept
+// out of the directory of the volume, so they do not count against its
+// project quota. Volume names cannot start with a dot.
+const volumeSnapshotsDir = ".snapshots"
+
+// VolumeSnapshot is a point in time copy of the data of a volume using the
+// local driver. The data is copied with reflinks if the filesystem supports
+// them, so snapshots of large volumes are cheap on Btrfs or XFS.
+type VolumeSnapshot struct {
+	// Name of the snapshot, unique for the volume.
+	Name string `json:"name"`
+	// CreatedTime is when the snapshot was taken.
+	CreatedTime time.Time `json:"createdAt"`
+}
+
+func (s VolumeSnapshot) inspect() define.InspectVolumeSnapshot {
+	return define.InspectVolumeSnapshot{
+		Name:      s.Name,
+		CreatedAt: s.CreatedTime,
+	}
+}
+
+// volumePathRoot returns the directory of the volume in the volume path,
+// which contains the _data directory.
+func (v *Volume) volumePathRoot() string {
+	return filepath.Join(v.runtime.config.Engine.VolumePath, v.Name())
+}
+
+// snapshotsDir returns the directory which contains the snapshots of the
+// volume.
+func (v *Volume) snapshotsDir() string {
+	return filepath.Join(v.runtime.config.Engine.VolumePath, volumeSnapshotsDir, v.Name())
+}
+
+func (v *Volume) snapshotPath(name string) string {
+	return filepath.Join(v.snapshotsDir(), name)
+}
+
+// supportsSnapshots returns an error if the volume data cannot be snapshotted.
+func (v *Volume) supportsSnapshots() error {
+	if v.config.Driver != define.VolumeDriverLocal && v.config.Driver != "" {
+		return fmt.Errorf("volume %s uses the %s driver, snapshots are only supported with the local driver: %w", v.Name(), v.config.Driver, define.ErrNotImplemented)
+	}
+	if v.config.QuotaBackend == define.VolumeQuotaLoop {
+		return fmt.Errorf("volume %s is stored in a loopback image, snapshots are only supported for volumes without it: %w", v.Name(), define.ErrNotImplemented)
+	}
+	if v.needsMount() {
+		return fmt.Errorf("volume %s has mount options, snapshots are only supported for volumes without them: %w", v.Name(), define.ErrNotImplemented)
+	}
+	return nil
+}
+
+func (v *Volume) snapshotIndex(name string) int {
+	return slices.IndexFunc(v.state.Snapshots, func(s VolumeSnapshot) bool {
+		return s.Name == name
+	})
+}
+
+// copyVolumeData copies the directory src to dst, which must not exist
+// yet. On failure the partial copy is removed.
+func copyVolumeData(src, dst string) error {
+	if err := os.Mkdir(dst, 0o700); err != nil {
+		return err
+	}
+	// DirCopy clones the files with reflinks if possible and falls back to
+	// copy_file_range and regular copies otherwise.
+	if err := copy.DirCopy(src, dst, copy.Content, true); err != nil {
+		if rmErr := os.RemoveAll(dst); rmErr != nil {
+			logrus.Errorf("Removing partial copy %s: %v", dst, rmErr)
+		}
+		return err
+	}
+	return nil
+}
+
+// CreateSnapshot takes a snapshot of the volume's data with the given name.
+// If name is empty, a name based on the current time is used.
+func (v *Volume) CreateSnapshot(name string) (*VolumeSnapshot, error) {
+	snapshot := VolumeSnapshot{
+		Name:        name,
+		CreatedTime: time.Now(),
+	}
+	if snapshot.Name == "" {
+		snapshot.Name = snapshot.CreatedTime.UTC().Format("20060102T150405Z")
+	}
+	if !define.NameRegex.MatchString(snapshot.Name) {
+		return nil, define.RegexError
+	}
+
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if err := v.update(); err != nil {
+		return nil, err
+	}
+	if err := v.supportsSnapshots(); err != nil {
+		return nil, err
+	}
+	if v.snapshotIndex(snapshot.Name) >= 0 {
+		return nil, fmt.Errorf("snapshot %s of volume %s: %w", snapshot.Name, v.Name(), define.ErrVolumeSnapshotExists)
+	}
+
+	if err := os.MkdirAll(v.snapshotsDir(), 0o700); err != nil {
+		return nil, err
+	}
+	// The data is copied to a temporary directory first, so a failed or
+	// interrupted copy never looks like a complete snapshot.
+	tmpPath := v.snapshotPath(".tmp-" + snapshot.Name)
+	if err := os.RemoveAll(tmpPath); err != nil {
+		return nil, err
+	}
+	if err := copyVolumeData(v.config.MountPoint, tmpPath); err != nil {
+		return nil, fmt.Errorf("copying data of volume %s: %w", v.Name(), err)
+	}
+	if err := os.Rename(tmpPath, v.snapshotPath(snapshot.Name)); err != nil {
+		return nil, err
+	}
+
+	v.state.Snapshots = append(v.state.Snapshots, snapshot)
+	if err := v.save(); err != nil {
+		return nil, err
+	}
+	return &snapshot, nil
+}
+
+// Snapshots returns the snapshots of the volume, oldest first.
+func (v *Volume) Snapshots() ([]VolumeSnapshot, error) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if err := v.update(); err != nil {
+		return nil, err
+	}
+	return slices.Clone(v.state.Snapshots), nil
+}
+
+// RemoveSnapshot removes the snapshot with the given name.
+func (v *Volume) RemoveSnapshot(name string) error {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if err := v.update(); err != nil {
+		return err
+	}
+	index := v.snapshotIndex(name)
+	if index < 0 {
+		return fmt.Errorf("snapshot %s of volume %s: %w", name, v.Name(), define.ErrNoSuchVolumeSnapshot)
+	}
+	if err := os.RemoveAll(v.snapshotPath(name)); err != nil {
+		return fmt.Errorf("removing snapshot %s of volume %s: %w", name, v.Name(), err)
+	}
+	v.state.Snapshots = slices.Delete(v.state.Snapshots, index, index+1)
+	return v.save()
+}
+
+// RestoreSnapshot replaces the volume's data with the data of the snapshot.
+// The volume must not be used by running containers.
+func (v *Volume) RestoreSnapshot(name string) error {
+	// Containers must be locked before the volume to prevent ABBA
+	// deadlocks, so check them before locking the volume.
+	if err := v.runtime.volumeNotInUseByRunningContainers(v); err != nil {
+		return err
+	}
+
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if err := v.update(); err != nil {
+		return err
+	}
+	if err := v.supportsSnapshots(); err != nil {
+		return err
+	}
+	if v.snapshotIndex(name) < 0 {
+		return fmt.Errorf("snapshot %s of volume %s: %w", name, v.Name(), define.ErrNoSuchVolumeSnapshot)
+	}
+
+	restorePath := filepath.Join(v.volumePathRoot(), "_data.restore")
+	oldPath := filepath.Join(v.volumePathRoot(), "_data.old")
+	for _, path := range []string{restorePath, oldPath} {
+		if err := os.RemoveAll(path); err != nil {
+			return err
+		}
+	}
+	if err := copyVolumeData(v.snapshotPath(name), restorePath); err != nil {
+		return fmt.Errorf("copying snapshot %s of volume %s: %w", name, v.Name(), err)
+	}
+	if err := os.Rename(v.config.MountPoint, oldPath); err != nil {
+		return err
+	}
+	if err := os.Rename(restorePath, v.config.MountPoint); err != nil {
+		if rollbackErr := os.Rename(oldPath, v.config.MountPoint); rollbackErr != nil {
+			logrus.Errorf("Moving back data of volume %s: %v", v.Name(), rollbackErr)
+		}
+		return err
+	}
+	if err := os.RemoveAll(oldPath); err != nil {
+		logrus.Errorf("Removing old data of volume %s: %v", v.Name(), err)
+	}
+	return LabelVolumePath(v.config.MountPoint, v.config.MountLabel)
+}
+
+// volumeNotInUseByRunningContainers returns ErrVolumeBeingUsed if a running or
+// paused container uses the volume.
+func (r *Runtime) volumeNotInUseByRunningContainers(v *Volume) error {
+	deps, err := r.state.VolumeInUse(v)
+	if err != nil {
+		return err
+	}
+	for _, dep := range deps {
+		ctr, err := r.state.Container(dep)
+		if err != nil {
+			if errors.Is(err, define.ErrNoSuchCtr) {
+				continue
+			}
+			return err
+		}
+		state, err := ctr.State()
+		if err != nil {
+			return err
+		}
+		if state == define.ContainerStateRunning || state == define.ContainerStatePaused {
+			return fmt.Errorf("volume %s is being used by the running container %s: %w", v.Name(), ctr.ID(), define.ErrVolumeBeingUsed)
+		}
+	}
+	return nil
+}
+
+// CloneVolume creates a new volume with the given name and the configuration
+// of the source volume and copies the data of the source volume, or of one of
+// its snapshots, into it.
+func (r *Runtime) CloneVolume(ctx context.Context, source *Volume, name, snapshot string) (_ *Volume, retErr error) {
+	if !r.valid {
+		return nil, define.ErrRuntimeStopped
+	}
+
+	source.lock.Lock()
+	defer source.lock.Unlock()
+
+	if err := source.update(); err != nil {
+		return nil, err
+	}
+	if err := source.supportsSnapshots(); err != nil {
+		return nil, err
+	}
+	srcPath := source.config.MountPoint
+	if snapshot != "" {
+		if source.snapshotIndex(snapshot) < 0 {
+			return nil, fmt.Errorf("snapshot %s of volume %s: %w", snapshot, source.Name(), define.ErrNoSuchVolumeSnapshot)
+		}
+		srcPath = source.snapshotPath(snapshot)
+	}
+
+	options := []VolumeCreateOption{
+		WithVolumeLabels(source.config.Labels),
+		WithVolumeOptions(source.config.Options),
+		WithVolumeUID(source.config.UID),
+		WithVolumeGID(source.config.GID),
+		WithVolumeMountLabel(source.config.MountLabel),
+	}
+	if name != "" {
+		options = append(options, WithVolumeName(name))
+	}
+	if source.config.Size > 0 {
+		options = append(options, WithVolumeSize(source.config.Size))
+	}
+	if source.config.Inodes > 0 {
+		options = append(options, WithVolumeInodes(source.config.Inodes))
+	}
+	if source.config.DisableQuota {
+		options = append(options, WithVolumeDisableQuota())
+	}
+	volume, err := r.newVolume(ctx, false, options...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if retErr != nil {
+			if err := r.removeVolume(ctx, volume, true, nil, false); err != nil {
+				logrus.Errorf("Removing volume %s after failed clone: %v", volume.Name(), err)
+			}
+		}
+	}()
+
+	volume.lock.Lock()
+	defer volume.lock.Unlock()
+
+	if err := copy.DirCopy(srcPath, volume.config.MountPoint, copy.Content, true); err != nil {
+		return nil, fmt.Errorf("copying data of volume %s: %w", source.Name(), err)
+	}
+	if err := LabelVolumePath(volume.config.MountPoint, volume.config.MountLabel); err != nil {
+		return nil, err
+	}
+	// The data was already copied up and chowned for the source volume.
+	volume.state.NeedsCopyUp = source.state.NeedsCopyUp
+	volume.state.NeedsChown = source.state.NeedsChown
+	volume.state.UIDChowned = source.state.UIDChowned
+	volume.state.GIDChowned = source.state.GIDChowned
+	if err := volume.save(); err != nil {
+		return nil, err
+	}
+	return volume, nil
+}
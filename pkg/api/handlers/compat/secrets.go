@@ -40,18 +40,26 @@ func ListSecrets(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	// Docker compat expects a version field that increments when the secret is updated
-	// We currently can't update a secret, so we default the version to 1
 	compatReports := make([]entities.SecretInfoReportCompat, 0, len(reports))
 	for _, report := range reports {
 		compatRep := entities.SecretInfoReportCompat{
 			SecretInfoReport: *report,
-			Version:          entities.SecretVersion{Index: 1},
+			Version:          secretVersion(report),
 		}
 		compatReports = append(compatReports, compatRep)
 	}
 	utils.WriteResponse(w, http.StatusOK, compatReports)
 }
 
+// secretVersion returns the current version of the secret data as docker
+// compatible version.
+func secretVersion(report *entities.SecretInfoReport) entities.SecretVersion {
+	if len(report.Versions) == 0 {
+		return entities.SecretVersion{Index: 1}
+	}
+	return entities.SecretVersion{Index: report.Versions[len(report.Versions)-1].Version}
+}
+
 func InspectSecret(w http.ResponseWriter, r *http.Request) {
 	decoder := utils.GetDecoder(r)
 	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
@@ -89,10 +97,9 @@ func InspectSecret(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	// Docker compat expects a version field that increments when the secret is updated
-	// We currently can't update a secret, so we default the version to 1
 	compatReport := entities.SecretInfoReportCompat{
 		SecretInfoReport: *reports[0],
-		Version:          entities.SecretVersion{Index: 1},
+		Version:          secretVersion(reports[0]),
 	}
 	utils.WriteResponse(w, http.StatusOK, compatReport)
 }
@@ -148,7 +148,7 @@ func CreateVolume(w http.ResponseWriter, r *http.Request) {
 		volumeOptions = append(volumeOptions, libpod.WithVolumeLabels(input.Labels))
 	}
 	if len(input.DriverOpts) > 0 {
-		parsedOptions, err := parse.VolumeOptions(input.DriverOpts)
+		parsedOptions, err := parse.VolumeOptions(parse.LocalVolumeOptions(input.Driver, input.DriverOpts))
 		if err != nil {
 			utils.InternalServerError(w, err)
 			return
@@ -0,0 +1,349 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"archive/tar"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/containers/image/v5/types"
+	"github.com/containers/podman/v5/libpod"
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/pkg/api/handlers/utils"
+	api "github.com/containers/podman/v5/pkg/api/types"
+	"github.com/containers/podman/v5/pkg/auth"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/containers/podman/v5/pkg/domain/infra/abi"
+	"github.com/gorilla/schema"
+	"github.com/sirupsen/logrus"
+)
+
+// artifactError writes err with the status code matching its cause
+func artifactError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, define.ErrNoSuchArtifact):
+		utils.Error(w, http.StatusNotFound, err)
+	case errors.Is(err, define.ErrArtifactExists), errors.Is(err, define.ErrArtifactInUse):
+		utils.Error(w, http.StatusConflict, err)
+	default:
+		utils.InternalServerError(w, err)
+	}
+}
+
+func ListArtifacts(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	ir := abi.ImageEngine{Libpod: runtime}
+	reports, err := ir.ArtifactList(r.Context(), entities.ArtifactListOptions{})
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, reports)
+}
+
+func InspectArtifact(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	ir := abi.ImageEngine{Libpod: runtime}
+	report, err := ir.ArtifactInspect(r.Context(), utils.GetName(r), entities.ArtifactInspectOptions{})
+	if err != nil {
+		artifactError(w, err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, report)
+}
+
+func AddArtifact(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	decoder := r.Context().Value(api.DecoderKey).(*schema.Decoder)
+	query := struct {
+		Name         string            `schema:"name"`
+		FileName     string            `schema:"fileName"`
+		ArtifactType string            `schema:"artifactType"`
+		FileType     string            `schema:"fileType"`
+		Annotations  map[string]string `schema:"annotations"`
+		Append       bool              `schema:"append"`
+	}{}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+	if query.Name == "" {
+		utils.Error(w, http.StatusBadRequest, errors.New("name parameter cannot be empty"))
+		return
+	}
+	// A tar archive holds several files which are added all at once,
+	// otherwise the body is the content of a single file
+	isArchive := r.Header.Get("Content-Type") == "application/x-tar"
+	if !isArchive && !validArtifactFileName(query.FileName) {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("invalid file name %q", query.FileName))
+		return
+	}
+
+	// The files are stored under their own names, which become their titles
+	tmpDir, err := os.MkdirTemp("", "libpod_artifact")
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	defer func() {
+		if err := os.RemoveAll(tmpDir); err != nil {
+			logrus.Warn(fmt.Errorf("failed to remove libpod_artifact tmp directory %q: %w", tmpDir, err))
+		}
+	}()
+	var paths []string
+	if isArchive {
+		paths, err = receiveArtifactArchive(r.Body, tmpDir)
+		if err != nil {
+			if errors.Is(err, define.ErrInvalidArg) {
+				utils.Error(w, http.StatusBadRequest, err)
+			} else {
+				utils.InternalServerError(w, fmt.Errorf("receiving artifact files: %w", err))
+			}
+			return
+		}
+	} else {
+		path := filepath.Join(tmpDir, query.FileName)
+		if err := receiveArtifactFile(r.Body, path); err != nil {
+			utils.InternalServerError(w, fmt.Errorf("receiving artifact file: %w", err))
+			return
+		}
+		paths = []string{path}
+	}
+
+	options := entities.ArtifactAddOptions{
+		Annotations:  query.Annotations,
+		ArtifactType: query.ArtifactType,
+		FileType:     query.FileType,
+		Append:       query.Append,
+	}
+	ir := abi.ImageEngine{Libpod: runtime}
+	report, err := ir.ArtifactAdd(r.Context(), query.Name, paths, options)
+	if err != nil {
+		artifactError(w, err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusCreated, report)
+}
+
+// validArtifactFileName returns whether name can be used as the name of a
+// file in the temporary directory of AddArtifact.
+func validArtifactFileName(name string) bool {
+	return name != "" && name != "." && name != ".." && filepath.Base(name) == name
+}
+
+// receiveArtifactFile writes the content of a file to path.
+func receiveArtifactFile(r io.Reader, path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(f, r)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// receiveArtifactArchive writes the regular files of a tar archive to dir and
+// returns their paths in the order of the archive.
+func receiveArtifactArchive(r io.Reader, dir string) ([]string, error) {
+	var paths []string
+	seen := make(map[string]bool)
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			return nil, fmt.Errorf("%s is not a regular file: %w", hdr.Name, define.ErrInvalidArg)
+		}
+		if !validArtifactFileName(hdr.Name) {
+			return nil, fmt.Errorf("invalid file name %q: %w", hdr.Name, define.ErrInvalidArg)
+		}
+		if seen[hdr.Name] {
+			return nil, fmt.Errorf("file %s is in the archive twice: %w", hdr.Name, define.ErrInvalidArg)
+		}
+		seen[hdr.Name] = true
+		path := filepath.Join(dir, hdr.Name)
+		if err := receiveArtifactFile(tr, path); err != nil {
+			return nil, err
+		}
+		paths = append(paths, path)
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("the archive contains no files: %w", define.ErrInvalidArg)
+	}
+	return paths, nil
+}
+
+func PullArtifact(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	decoder := r.Context().Value(api.DecoderKey).(*schema.Decoder)
+	query := struct {
+		Name       string `schema:"name"`
+		Retry      uint   `schema:"retry"`
+		RetryDelay string `schema:"retryDelay"`
+		TLSVerify  bool   `schema:"tlsVerify"`
+	}{
+		TLSVerify: true,
+	}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+	if query.Name == "" {
+		utils.Error(w, http.StatusBadRequest, errors.New("name parameter cannot be empty"))
+		return
+	}
+
+	authConf, authfile, err := auth.GetCredentials(r)
+	if err != nil {
+		utils.Error(w, http.StatusBadRequest, err)
+		return
+	}
+	defer auth.RemoveAuthfile(authfile)
+
+	options := entities.ArtifactPullOptions{
+		Authfile:   authfile,
+		Quiet:      true,
+		RetryDelay: query.RetryDelay,
+	}
+	if authConf != nil {
+		options.Username = authConf.Username
+		options.Password = authConf.Password
+	}
+	if _, found := r.URL.Query()["tlsVerify"]; found {
+		options.SkipTLSVerify = types.NewOptionalBool(!query.TLSVerify)
+	}
+	if _, found := r.URL.Query()["retry"]; found {
+		options.Retry = &query.Retry
+	}
+
+	ir := abi.ImageEngine{Libpod: runtime}
+	report, err := ir.ArtifactPull(r.Context(), query.Name, options)
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, report)
+}
+
+func PushArtifact(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	decoder := r.Context().Value(api.DecoderKey).(*schema.Decoder)
+	query := struct {
+		Destination string `schema:"destination"`
+		Retry       uint   `schema:"retry"`
+		RetryDelay  string `schema:"retryDelay"`
+		TLSVerify   bool   `schema:"tlsVerify"`
+	}{
+		TLSVerify: true,
+	}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+
+	authConf, authfile, err := auth.GetCredentials(r)
+	if err != nil {
+		utils.Error(w, http.StatusBadRequest, err)
+		return
+	}
+	defer auth.RemoveAuthfile(authfile)
+
+	options := entities.ArtifactPushOptions{
+		Authfile:   authfile,
+		Quiet:      true,
+		RetryDelay: query.RetryDelay,
+	}
+	if authConf != nil {
+		options.Username = authConf.Username
+		options.Password = authConf.Password
+	}
+	if _, found := r.URL.Query()["tlsVerify"]; found {
+		options.SkipTLSVerify = types.NewOptionalBool(!query.TLSVerify)
+	}
+	if _, found := r.URL.Query()["retry"]; found {
+		options.Retry = &query.Retry
+	}
+
+	ir := abi.ImageEngine{Libpod: runtime}
+	report, err := ir.ArtifactPush(r.Context(), utils.GetName(r), query.Destination, options)
+	if err != nil {
+		artifactError(w, err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, report)
+}
+
+func RemoveArtifacts(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	decoder := r.Context().Value(api.DecoderKey).(*schema.Decoder)
+	query := struct {
+		Artifacts []string `schema:"artifacts"`
+		All       bool     `schema:"all"`
+		Force     bool     `schema:"force"`
+	}{}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+	if (len(query.Artifacts) > 0) == query.All {
+		utils.Error(w, http.StatusBadRequest, errors.New("either artifacts or all must be given"))
+		return
+	}
+
+	ir := abi.ImageEngine{Libpod: runtime}
+	report, err := ir.ArtifactRm(r.Context(), query.Artifacts, entities.ArtifactRemoveOptions{All: query.All, Force: query.Force})
+	if err != nil {
+		artifactError(w, err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, report)
+}
+
+func ExtractArtifact(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	decoder := r.Context().Value(api.DecoderKey).(*schema.Decoder)
+	query := struct {
+		Digest string `schema:"digest"`
+		Title  string `schema:"title"`
+	}{}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+
+	name := utils.GetName(r)
+	options := entities.ArtifactExtractOptions{
+		Digest: query.Digest,
+		Title:  query.Title,
+	}
+	ir := abi.ImageEngine{Libpod: runtime}
+	// Check the artifact before sending the headers, to be able to report
+	// errors with the right status code
+	artifact, err := ir.ArtifactInspect(r.Context(), name, entities.ArtifactInspectOptions{})
+	if err != nil {
+		artifactError(w, err)
+		return
+	}
+	if _, err := artifact.SelectFiles(&options); err != nil {
+		utils.Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/x-tar")
+	w.WriteHeader(http.StatusOK)
+	if err := ir.ArtifactExtractTarStream(r.Context(), w, name, options); err != nil {
+		logrus.Errorf("Unable to send artifact %s: %v", name, err)
+	}
+}
@@ -18,7 +18,9 @@ import (
 	api "github.com/containers/podman/v5/pkg/api/types"
 	"github.com/containers/podman/v5/pkg/domain/entities"
 	"github.com/containers/podman/v5/pkg/domain/infra/abi"
+	"github.com/containers/podman/v5/pkg/specgenutil"
 	"github.com/containers/podman/v5/pkg/util"
+	"github.com/containers/storage"
 	"github.com/gorilla/schema"
 	"github.com/sirupsen/logrus"
 )
@@ -455,6 +457,104 @@ func UpdateContainer(w http.ResponseWriter, r *http.Request) {
 	utils.WriteResponse(w, http.StatusCreated, ctr.ID())
 }
 
+func CloneContainer(w http.ResponseWriter, r *http.Request) {
+	name := utils.GetName(r)
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	decoder := utils.GetDecoder(r)
+	query := struct {
+		Destroy bool   `schema:"destroy"`
+		Force   bool   `schema:"force"`
+		Image   string `schema:"image"`
+		Name    string `schema:"name"`
+		Pod     string `schema:"pod"`
+		Run     bool   `schema:"run"`
+	}{
+		// override any golang type defaults
+	}
+
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+	if query.Force && !query.Destroy {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("cannot set force without destroy: %w", define.ErrInvalidArg))
+		return
+	}
+
+	ctr, err := runtime.LookupContainer(name)
+	if err != nil {
+		utils.ContainerNotFound(w, name, err)
+		return
+	}
+	if query.Pod != "" {
+		if _, err := runtime.LookupPod(query.Pod); err != nil {
+			utils.PodNotFound(w, query.Pod, err)
+			return
+		}
+	}
+
+	resources := handlers.CloneResources{}
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&resources); err != nil {
+			utils.Error(w, http.StatusBadRequest, fmt.Errorf("decode(): %w", err))
+			return
+		}
+	}
+
+	rtc, err := runtime.GetConfigNoCopy()
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	createOpts := entities.ContainerCreateOptions{
+		BlkIOWeight:       resources.BlkIOWeight,
+		BlkIOWeightDevice: resources.BlkIOWeightDevice,
+		CPUPeriod:         resources.CPUPeriod,
+		CPUQuota:          resources.CPUQuota,
+		CPURTPeriod:       resources.CPURTPeriod,
+		CPURTRuntime:      resources.CPURTRuntime,
+		CPUShares:         resources.CPUShares,
+		CPUS:              resources.CPUS,
+		CPUSetCPUs:        resources.CPUSetCPUs,
+		CPUSetMems:        resources.CPUSetMems,
+		DeviceReadBPs:     resources.DeviceReadBPs,
+		DeviceWriteBPs:    resources.DeviceWriteBPs,
+		IsClone:           true,
+		Memory:            resources.Memory,
+		MemoryReservation: resources.MemoryReservation,
+		MemorySwap:        resources.MemorySwap,
+		Name:              query.Name,
+		Pod:               query.Pod,
+	}
+	// Use the same defaults as podman container clone.
+	specgenutil.DefineCreateDefaults(&createOpts, rtc, false)
+	if resources.MemorySwappiness != nil {
+		createOpts.MemorySwappiness = *resources.MemorySwappiness
+	}
+
+	containerEngine := abi.ContainerEngine{Libpod: runtime}
+	report, err := containerEngine.ContainerClone(r.Context(), entities.ContainerCloneOptions{
+		ID:         ctr.ID(),
+		Destroy:    query.Destroy,
+		CreateOpts: createOpts,
+		Image:      query.Image,
+		Run:        query.Run,
+		Force:      query.Force,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, define.ErrInvalidArg):
+			utils.Error(w, http.StatusBadRequest, err)
+		case errors.Is(err, storage.ErrDuplicateName), errors.Is(err, define.ErrCtrExists), errors.Is(err, define.ErrCtrStateInvalid):
+			utils.Error(w, http.StatusConflict, err)
+		default:
+			utils.InternalServerError(w, err)
+		}
+		return
+	}
+	utils.WriteResponse(w, http.StatusCreated, entities.ContainerCreateResponse{ID: report.Id, Warnings: []string{}})
+}
+
 func ShouldRestart(w http.ResponseWriter, r *http.Request) {
 	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
 	// Now use the ABI implementation to prevent us from having duplicate
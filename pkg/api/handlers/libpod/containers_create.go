@@ -9,10 +9,12 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/containers/common/libimage"
 	"github.com/containers/podman/v5/libpod"
 	"github.com/containers/podman/v5/libpod/define"
 	"github.com/containers/podman/v5/pkg/api/handlers/utils"
 	api "github.com/containers/podman/v5/pkg/api/types"
+	"github.com/containers/podman/v5/pkg/auth"
 	"github.com/containers/podman/v5/pkg/domain/entities"
 	"github.com/containers/podman/v5/pkg/specgen"
 	"github.com/containers/podman/v5/pkg/specgen/generate"
@@ -57,6 +59,24 @@ func CreateContainer(w http.ResponseWriter, r *http.Request) {
 		sg.Passwd = &t
 	}
 
+	// Artifacts of artifact volumes are pulled with the credentials of
+	// the request.
+	if len(sg.ArtifactVolumes) > 0 {
+		authConf, authfile, err := auth.GetCredentials(r)
+		if err != nil {
+			utils.Error(w, http.StatusBadRequest, err)
+			return
+		}
+		defer auth.RemoveAuthfile(authfile)
+
+		pullOptions := libimage.CopyOptions{AuthFilePath: authfile}
+		if authConf != nil {
+			pullOptions.Username = authConf.Username
+			pullOptions.Password = authConf.Password
+		}
+		sg.SetArtifactPullOptions(pullOptions)
+	}
+
 	// need to check for memory limit to adjust swap
 	if sg.ResourceLimits != nil && sg.ResourceLimits.Memory != nil {
 		s := ""
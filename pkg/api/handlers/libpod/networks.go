@@ -67,13 +67,20 @@ func UpdateNetwork(w http.ResponseWriter, r *http.Request) {
 
 	name := utils.GetName(r)
 
-	err := ic.NetworkUpdate(r.Context(), name, networkUpdateOptions)
+	report, err := ic.NetworkUpdate(r.Context(), name, networkUpdateOptions)
 	if err != nil {
-		utils.Error(w, http.StatusInternalServerError, err)
+		switch {
+		case errors.Is(err, define.ErrNoSuchNetwork):
+			utils.NetworkNotFound(w, name, err)
+		case errors.Is(err, types.ErrInvalidArg):
+			utils.Error(w, http.StatusBadRequest, err)
+		default:
+			utils.Error(w, http.StatusInternalServerError, err)
+		}
 		return
 	}
 
-	utils.WriteResponse(w, http.StatusNoContent, nil)
+	utils.WriteResponse(w, http.StatusOK, report)
 }
 
 func ListNetworks(w http.ResponseWriter, r *http.Request) {
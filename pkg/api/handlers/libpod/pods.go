@@ -6,13 +6,16 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"os"
 	"strings"
 	"time"
 
 	"github.com/containers/podman/v5/libpod"
 	"github.com/containers/podman/v5/libpod/define"
 	"github.com/containers/podman/v5/pkg/api/handlers"
+	"github.com/containers/podman/v5/pkg/api/handlers/compat"
 	"github.com/containers/podman/v5/pkg/api/handlers/utils"
 	api "github.com/containers/podman/v5/pkg/api/types"
 	"github.com/containers/podman/v5/pkg/domain/entities"
@@ -21,8 +24,10 @@ import (
 	"github.com/containers/podman/v5/pkg/specgen/generate"
 	"github.com/containers/podman/v5/pkg/specgenutil"
 	"github.com/containers/podman/v5/pkg/util"
+	"github.com/containers/storage/pkg/archive"
 	"github.com/gorilla/schema"
 	"github.com/hashicorp/go-multierror"
+	"github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/sirupsen/logrus"
 )
 
@@ -311,6 +316,198 @@ func PodRestart(w http.ResponseWriter, r *http.Request) {
 	utils.WriteResponse(w, code, report)
 }
 
+func PodUpdate(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	decoder := utils.GetDecoder(r)
+	query := struct {
+		RestartPolicy   string `schema:"restartPolicy"`
+		RestartRetries  *uint  `schema:"restartRetries"`
+		HealthOnFailure string `schema:"healthOnFailure"`
+	}{
+		// override any golang type defaults
+	}
+
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+	if query.RestartRetries != nil && query.RestartPolicy != define.RestartPolicyOnFailure {
+		utils.Error(w, http.StatusBadRequest, errors.New("cannot set restart retries unless restart policy is on-failure"))
+		return
+	}
+
+	resources := new(specs.LinuxResources)
+	if err := json.NewDecoder(r.Body).Decode(resources); err != nil && !errors.Is(err, io.EOF) {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("decode(): %w", err))
+		return
+	}
+
+	s := new(specgen.SpecGenerator)
+	s.ResourceLimits = resources
+	s.RestartPolicy = query.RestartPolicy
+	s.RestartRetries = query.RestartRetries
+	options := &entities.PodUpdateOptions{
+		NameOrID: utils.GetName(r),
+		Specgen:  s,
+	}
+	if _, found := r.URL.Query()["healthOnFailure"]; found {
+		options.HealthOnFailure = &query.HealthOnFailure
+	}
+
+	// Now use the ABI implementation to prevent us from having duplicate
+	// code.
+	containerEngine := abi.ContainerEngine{Libpod: runtime}
+	id, err := containerEngine.PodUpdate(r.Context(), options)
+	if err != nil {
+		switch {
+		case errors.Is(err, define.ErrNoSuchPod):
+			utils.PodNotFound(w, options.NameOrID, err)
+		case errors.Is(err, define.ErrInvalidArg):
+			utils.Error(w, http.StatusBadRequest, err)
+		default:
+			utils.InternalServerError(w, err)
+		}
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, id)
+}
+
+func PodCheckpoint(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	decoder := utils.GetDecoder(r)
+	query := struct {
+		Keep           bool   `schema:"keep"`
+		LeaveRunning   bool   `schema:"leaveRunning"`
+		TCPEstablished bool   `schema:"tcpEstablished"`
+		IgnoreRootFS   bool   `schema:"ignoreRootFS"`
+		IgnoreVolumes  bool   `schema:"ignoreVolumes"`
+		FileLocks      bool   `schema:"fileLocks"`
+		Compress       string `schema:"compress"`
+	}{
+		Compress: "zstd",
+	}
+
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+
+	options := entities.PodCheckpointOptions{
+		Keep:           query.Keep,
+		LeaveRunning:   query.LeaveRunning,
+		TCPEstablished: query.TCPEstablished,
+		IgnoreRootFS:   query.IgnoreRootFS,
+		IgnoreVolumes:  query.IgnoreVolumes,
+		FileLocks:      query.FileLocks,
+	}
+	switch strings.ToLower(query.Compress) {
+	case "none":
+		options.Compression = archive.Uncompressed
+	case "gzip":
+		options.Compression = archive.Gzip
+	case "zstd":
+		options.Compression = archive.Zstd
+	default:
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("selected compression algorithm (%q) not supported. Please select one from: gzip, none, zstd", query.Compress))
+		return
+	}
+
+	name := utils.GetName(r)
+	if _, err := runtime.LookupPod(name); err != nil {
+		utils.PodNotFound(w, name, err)
+		return
+	}
+
+	f, err := os.CreateTemp("", "pod-checkpoint")
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	defer os.Remove(f.Name())
+	if err := f.Close(); err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	options.Export = f.Name()
+
+	containerEngine := abi.ContainerEngine{Libpod: runtime}
+	if _, err := containerEngine.PodCheckpoint(r.Context(), name, options); err != nil {
+		if errors.Is(err, define.ErrCtrStateInvalid) || errors.Is(err, define.ErrNoSuchCtr) {
+			utils.Error(w, http.StatusConflict, err)
+			return
+		}
+		utils.InternalServerError(w, err)
+		return
+	}
+
+	f, err = os.Open(options.Export)
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	defer f.Close()
+	utils.WriteResponse(w, http.StatusOK, f)
+}
+
+func PodRestore(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	decoder := utils.GetDecoder(r)
+	query := struct {
+		Keep            bool   `schema:"keep"`
+		TCPEstablished  bool   `schema:"tcpEstablished"`
+		Name            string `schema:"name"`
+		IgnoreRootFS    bool   `schema:"ignoreRootFS"`
+		IgnoreVolumes   bool   `schema:"ignoreVolumes"`
+		IgnoreStaticIP  bool   `schema:"ignoreStaticIP"`
+		IgnoreStaticMAC bool   `schema:"ignoreStaticMAC"`
+		FileLocks       bool   `schema:"fileLocks"`
+		PublishPorts    string `schema:"publishPorts"`
+	}{
+		// override any golang type defaults
+	}
+
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+
+	options := entities.PodRestoreOptions{
+		Name:            query.Name,
+		Keep:            query.Keep,
+		TCPEstablished:  query.TCPEstablished,
+		IgnoreRootFS:    query.IgnoreRootFS,
+		IgnoreVolumes:   query.IgnoreVolumes,
+		IgnoreStaticIP:  query.IgnoreStaticIP,
+		IgnoreStaticMAC: query.IgnoreStaticMAC,
+		FileLocks:       query.FileLocks,
+		PublishPorts:    strings.Fields(query.PublishPorts),
+	}
+
+	t, err := os.CreateTemp("", "pod-restore")
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	defer os.Remove(t.Name())
+	if err := compat.SaveFromBody(t, r); err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	options.Import = t.Name()
+
+	containerEngine := abi.ContainerEngine{Libpod: runtime}
+	report, err := containerEngine.PodRestore(r.Context(), options)
+	if err != nil {
+		if errors.Is(err, define.ErrPodExists) {
+			utils.Error(w, http.StatusConflict, err)
+			return
+		}
+		utils.InternalServerError(w, err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, report)
+}
+
 func PodPrune(w http.ResponseWriter, r *http.Request) {
 	reports, err := PodPruneHelper(r)
 	if err != nil {
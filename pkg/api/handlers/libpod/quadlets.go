@@ -0,0 +1,149 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/containers/podman/v5/libpod"
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/pkg/api/handlers/utils"
+	api "github.com/containers/podman/v5/pkg/api/types"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/containers/podman/v5/pkg/domain/infra/abi"
+	"github.com/containers/storage/pkg/archive"
+	"github.com/gorilla/schema"
+	"github.com/sirupsen/logrus"
+)
+
+// quadletError writes err with the status code matching its cause
+func quadletError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, define.ErrNoSuchQuadlet):
+		utils.Error(w, http.StatusNotFound, err)
+	case errors.Is(err, define.ErrQuadletExists):
+		utils.Error(w, http.StatusConflict, err)
+	case errors.Is(err, define.ErrInvalidArg):
+		utils.Error(w, http.StatusBadRequest, err)
+	default:
+		utils.InternalServerError(w, err)
+	}
+}
+
+func InstallQuadlets(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	decoder := r.Context().Value(api.DecoderKey).(*schema.Decoder)
+	query := struct {
+		Replace       bool `schema:"replace"`
+		ReloadSystemd bool `schema:"reloadSystemd"`
+	}{
+		ReloadSystemd: true,
+	}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+	if contentType := r.Header.Get("Content-Type"); contentType != "application/x-tar" {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("Content-Type: %s is not supported. Should be \"application/x-tar\"", contentType))
+		return
+	}
+
+	contextDirectory, err := os.MkdirTemp("", "libpod_quadlet")
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	defer func() {
+		if err := os.RemoveAll(contextDirectory); err != nil {
+			logrus.Warn(fmt.Errorf("failed to remove libpod_quadlet tmp directory %q: %w", contextDirectory, err))
+		}
+	}()
+	if err := archive.Untar(r.Body, contextDirectory, nil); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("extracting Quadlet files: %w", err))
+		return
+	}
+
+	options := entities.QuadletInstallOptions{
+		Replace:       query.Replace,
+		ReloadSystemd: query.ReloadSystemd,
+	}
+	ic := abi.ContainerEngine{Libpod: runtime}
+	report, err := ic.QuadletInstall(r.Context(), []string{contextDirectory}, options)
+	if err != nil {
+		quadletError(w, err)
+		return
+	}
+	// The temporary paths of the uploaded files are meaningless to the client
+	installed := make(map[string]string, len(report.InstalledQuadlets))
+	for src, dst := range report.InstalledQuadlets {
+		installed[filepath.Base(src)] = dst
+	}
+	report.InstalledQuadlets = installed
+	utils.WriteResponse(w, http.StatusOK, report)
+}
+
+func ListQuadlets(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	ic := abi.ContainerEngine{Libpod: runtime}
+	reports, err := ic.QuadletList(r.Context(), entities.QuadletListOptions{})
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, reports)
+}
+
+func GetQuadletFile(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	ic := abi.ContainerEngine{Libpod: runtime}
+	content, err := ic.QuadletPrint(r.Context(), utils.GetName(r))
+	if err != nil {
+		quadletError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write([]byte(content)); err != nil {
+		logrus.Errorf("Unable to send Quadlet file: %q", err)
+	}
+}
+
+func RemoveQuadlets(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	decoder := r.Context().Value(api.DecoderKey).(*schema.Decoder)
+	query := struct {
+		Quadlets      []string `schema:"quadlets"`
+		All           bool     `schema:"all"`
+		Force         bool     `schema:"force"`
+		Ignore        bool     `schema:"ignore"`
+		ReloadSystemd bool     `schema:"reloadSystemd"`
+	}{
+		ReloadSystemd: true,
+	}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+	if (len(query.Quadlets) > 0) == query.All {
+		utils.Error(w, http.StatusBadRequest, errors.New("either quadlets or all must be given"))
+		return
+	}
+
+	options := entities.QuadletRemoveOptions{
+		All:           query.All,
+		Force:         query.Force,
+		Ignore:        query.Ignore,
+		ReloadSystemd: query.ReloadSystemd,
+	}
+	ic := abi.ContainerEngine{Libpod: runtime}
+	report, err := ic.QuadletRemove(r.Context(), query.Quadlets, options)
+	if err != nil {
+		quadletError(w, err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, report)
+}
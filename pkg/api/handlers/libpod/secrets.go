@@ -3,6 +3,7 @@
 package libpod
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -50,6 +51,57 @@ func CreateSecret(w http.ResponseWriter, r *http.Request) {
 	utils.WriteResponse(w, http.StatusOK, report)
 }
 
+func RekeySecret(w http.ResponseWriter, r *http.Request) {
+	var (
+		runtime = r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+		decoder = r.Context().Value(api.DecoderKey).(*schema.Decoder)
+	)
+
+	query := struct {
+		DriverOpts map[string]string `schema:"driveropts"`
+	}{
+		// override any golang type defaults
+	}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+
+	name := utils.GetName(r)
+	ic := abi.ContainerEngine{Libpod: runtime}
+	reports, err := ic.SecretRekey(r.Context(), []string{name}, entities.SecretRekeyOptions{DriverOpts: query.DriverOpts})
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	if err := reports[0].Err; err != nil {
+		if errors.Is(err, secrets.ErrNoSuchSecret) {
+			utils.SecretNotFound(w, name, err)
+			return
+		}
+		utils.InternalServerError(w, err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, reports[0])
+}
+
+func UpdateSecret(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	name := utils.GetName(r)
+	ic := abi.ContainerEngine{Libpod: runtime}
+
+	report, err := ic.SecretUpdate(r.Context(), name, r.Body)
+	if err != nil {
+		if errors.Is(err, secrets.ErrNoSuchSecret) {
+			utils.SecretNotFound(w, name, err)
+			return
+		}
+		utils.InternalServerError(w, err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, report)
+}
+
 func SecretExists(w http.ResponseWriter, r *http.Request) {
 	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
 	name := utils.GetName(r)
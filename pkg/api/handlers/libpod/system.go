@@ -3,17 +3,23 @@
 package libpod
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"time"
 
+	"github.com/containers/image/v5/types"
 	"github.com/containers/podman/v5/libpod"
 	"github.com/containers/podman/v5/pkg/api/handlers/utils"
 	api "github.com/containers/podman/v5/pkg/api/types"
+	"github.com/containers/podman/v5/pkg/auth"
+	"github.com/containers/podman/v5/pkg/channel"
 	"github.com/containers/podman/v5/pkg/domain/entities"
 	"github.com/containers/podman/v5/pkg/domain/infra/abi"
 	"github.com/containers/podman/v5/pkg/util"
 	"github.com/gorilla/schema"
+	"github.com/sirupsen/logrus"
 )
 
 // SystemPrune removes unused data
@@ -111,3 +117,126 @@ func SystemCheck(w http.ResponseWriter, r *http.Request) {
 
 	utils.WriteResponse(w, http.StatusOK, report)
 }
+
+// SystemAutoUpdate auto-updates containers according to their auto-update
+// policy and streams the progress.
+func SystemAutoUpdate(w http.ResponseWriter, r *http.Request) {
+	decoder := r.Context().Value(api.DecoderKey).(*schema.Decoder)
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+
+	query := struct {
+		DryRun            bool   `schema:"dryRun"`
+		Recreate          bool   `schema:"recreate"`
+		RequireSignatures bool   `schema:"requireSignatures"`
+		Rollback          bool   `schema:"rollback"`
+		SoakPeriod        string `schema:"soakPeriod"`
+		TLSVerify         bool   `schema:"tlsVerify"`
+	}{
+		Rollback:  true,
+		TLSVerify: true,
+	}
+
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest,
+			fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+
+	var soakPeriod time.Duration
+	if query.SoakPeriod != "" {
+		duration, err := time.ParseDuration(query.SoakPeriod)
+		if err != nil {
+			utils.Error(w, http.StatusBadRequest, err)
+			return
+		}
+		soakPeriod = duration
+	}
+
+	// Registry credentials of the client are used for all containers
+	// which have not been created with the authfile label.
+	_, authfile, err := auth.GetCredentials(r)
+	if err != nil {
+		utils.Error(w, http.StatusBadRequest, err)
+		return
+	}
+	defer auth.RemoveAuthfile(authfile)
+
+	writer := channel.NewWriter(make(chan []byte))
+	defer writer.Close()
+
+	options := entities.AutoUpdateOptions{
+		Authfile:          authfile,
+		DryRun:            query.DryRun,
+		Recreate:          query.Recreate,
+		Rollback:          query.Rollback,
+		ProgressWriter:    writer,
+		RequireSignatures: query.RequireSignatures,
+		SoakPeriod:        soakPeriod,
+	}
+	if _, found := r.URL.Query()["tlsVerify"]; found {
+		options.InsecureSkipTLSVerify = types.NewOptionalBool(!query.TLSVerify)
+	}
+
+	containerEngine := abi.ContainerEngine{Libpod: runtime}
+
+	// The updates must not be interrupted when the client goes away, a
+	// container may be stopped already. The results are passed back over a
+	// channel once all updates are done.
+	type autoUpdateResult struct {
+		reports []*entities.AutoUpdateReport
+		errs    []error
+	}
+	done := make(chan autoUpdateResult, 1)
+	go func() {
+		reports, updateErrs := containerEngine.AutoUpdate(context.WithoutCancel(r.Context()), options)
+		done <- autoUpdateResult{reports: reports, errs: updateErrs}
+	}()
+
+	flush := func() {
+		if flusher, ok := w.(http.Flusher); ok {
+			flusher.Flush()
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	flush()
+
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(true)
+	// Keep draining the progress until the updates are done even if the
+	// client has closed the connection, the writer would block otherwise.
+	clientGone := r.Context().Done()
+	connected := true
+	for {
+		var report entities.AutoUpdateStreamReport
+		select {
+		case s := <-writer.Chan():
+			if !connected {
+				continue
+			}
+			report.Stream = string(s)
+			if err := enc.Encode(report); err != nil {
+				logrus.Warnf("Failed to encode json: %v", err)
+			}
+			flush()
+		case result := <-done:
+			if !connected {
+				return
+			}
+			report.Reports = result.reports
+			for _, err := range result.errs {
+				report.Errors = append(report.Errors, err.Error())
+			}
+			if err := enc.Encode(report); err != nil {
+				logrus.Warnf("Failed to encode json: %v", err)
+			}
+			flush()
+			return
+		case <-clientGone:
+			logrus.Infof("Client closed connection, continuing auto-update")
+			connected = false
+			clientGone = nil
+		}
+	}
+}
@@ -65,7 +65,7 @@ func CreateVolume(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if len(input.Options) > 0 {
-		parsedOptions, err := parse.VolumeOptions(input.Options)
+		parsedOptions, err := parse.VolumeOptions(parse.LocalVolumeOptions(input.Driver, input.Options))
 		if err != nil {
 			utils.InternalServerError(w, err)
 			return
@@ -215,3 +215,131 @@ func ExistsVolume(w http.ResponseWriter, r *http.Request) {
 	}
 	utils.WriteResponse(w, http.StatusNoContent, "")
 }
+
+// volumeSnapshotError writes the error of a volume snapshot or clone operation
+// with a matching status code.
+func volumeSnapshotError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, define.ErrNoSuchVolumeSnapshot):
+		utils.Error(w, http.StatusNotFound, err)
+	case errors.Is(err, define.ErrVolumeSnapshotExists), errors.Is(err, define.ErrVolumeExists), errors.Is(err, define.ErrVolumeBeingUsed):
+		utils.Error(w, http.StatusConflict, err)
+	case errors.Is(err, define.ErrNotImplemented), errors.Is(err, define.RegexError):
+		utils.Error(w, http.StatusBadRequest, err)
+	default:
+		utils.InternalServerError(w, err)
+	}
+}
+
+func CloneVolume(w http.ResponseWriter, r *http.Request) {
+	var (
+		runtime = r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+		decoder = r.Context().Value(api.DecoderKey).(*schema.Decoder)
+	)
+	query := struct {
+		Name     string `schema:"name"`
+		Snapshot string `schema:"snapshot"`
+	}{
+		// override any golang type defaults
+	}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest,
+			fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+	name := utils.GetName(r)
+	vol, err := runtime.LookupVolume(name)
+	if err != nil {
+		utils.VolumeNotFound(w, name, err)
+		return
+	}
+	clone, err := runtime.CloneVolume(r.Context(), vol, query.Name, query.Snapshot)
+	if err != nil {
+		volumeSnapshotError(w, err)
+		return
+	}
+	inspectOut, err := clone.Inspect()
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	volResponse := entities.VolumeConfigResponse{
+		InspectVolumeData: *inspectOut,
+	}
+	utils.WriteResponse(w, http.StatusCreated, volResponse)
+}
+
+func CreateVolumeSnapshot(w http.ResponseWriter, r *http.Request) {
+	var (
+		runtime = r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+		decoder = r.Context().Value(api.DecoderKey).(*schema.Decoder)
+	)
+	query := struct {
+		Name string `schema:"name"`
+	}{
+		// override any golang type defaults
+	}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest,
+			fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+	name := utils.GetName(r)
+	if _, err := runtime.LookupVolume(name); err != nil {
+		utils.VolumeNotFound(w, name, err)
+		return
+	}
+	ic := abi.ContainerEngine{Libpod: runtime}
+	report, err := ic.VolumeSnapshotCreate(r.Context(), name, query.Name)
+	if err != nil {
+		volumeSnapshotError(w, err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusCreated, report)
+}
+
+func ListVolumeSnapshots(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	name := utils.GetName(r)
+	if _, err := runtime.LookupVolume(name); err != nil {
+		utils.VolumeNotFound(w, name, err)
+		return
+	}
+	ic := abi.ContainerEngine{Libpod: runtime}
+	reports, err := ic.VolumeSnapshotList(r.Context(), name)
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, reports)
+}
+
+func RestoreVolumeSnapshot(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	name := utils.GetName(r)
+	vol, err := runtime.LookupVolume(name)
+	if err != nil {
+		utils.VolumeNotFound(w, name, err)
+		return
+	}
+	if err := vol.RestoreSnapshot(utils.GetVar(r, "snapshot")); err != nil {
+		volumeSnapshotError(w, err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusNoContent, "")
+}
+
+func RemoveVolumeSnapshot(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	name := utils.GetName(r)
+	vol, err := runtime.LookupVolume(name)
+	if err != nil {
+		utils.VolumeNotFound(w, name, err)
+		return
+	}
+	if err := vol.RemoveSnapshot(utils.GetVar(r, "snapshot")); err != nil {
+		volumeSnapshotError(w, err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusNoContent, "")
+}
@@ -65,6 +65,20 @@ type manifestNotFound struct {
 	Body errorhandling.ErrorModel
 }
 
+// No such artifact
+// swagger:response
+type artifactNotFound struct {
+	// in:body
+	Body errorhandling.ErrorModel
+}
+
+// No such quadlet
+// swagger:response
+type quadletNotFound struct {
+	// in:body
+	Body errorhandling.ErrorModel
+}
+
 // Internal server error
 // swagger:response
 type internalError struct {
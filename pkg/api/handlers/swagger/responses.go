@@ -198,6 +198,13 @@ type systemCheckResponse struct {
 	Body entities.SystemCheckReport
 }
 
+// Auto-update
+// swagger:response
+type systemAutoUpdateResponse struct {
+	// in:body
+	Body entities.AutoUpdateStreamReport
+}
+
 // Disk usage
 // swagger:response
 type systemDiskUsage struct {
@@ -275,6 +282,83 @@ type volumeListLibpod struct {
 	Body []entities.VolumeConfigResponse
 }
 
+// Volume snapshot
+// swagger:response
+type volumeSnapshotLibpod struct {
+	// in:body
+	Body entities.VolumeSnapshotReport
+}
+
+// Volume snapshot list
+// swagger:response
+type volumeSnapshotListLibpod struct {
+	// in:body
+	Body []entities.VolumeSnapshotReport
+}
+
+// Artifact add
+// swagger:response
+type artifactAddLibpod struct {
+	// in:body
+	Body entities.ArtifactAddReport
+}
+
+// Artifact inspect
+// swagger:response
+type artifactInspectLibpod struct {
+	// in:body
+	Body entities.ArtifactInspectReport
+}
+
+// Artifact list
+// swagger:response
+type artifactListLibpod struct {
+	// in:body
+	Body []entities.ArtifactListReport
+}
+
+// Artifact pull
+// swagger:response
+type artifactPullLibpod struct {
+	// in:body
+	Body entities.ArtifactPullReport
+}
+
+// Artifact push
+// swagger:response
+type artifactPushLibpod struct {
+	// in:body
+	Body entities.ArtifactPushReport
+}
+
+// Artifact remove
+// swagger:response
+type artifactRemoveLibpod struct {
+	// in:body
+	Body entities.ArtifactRemoveReport
+}
+
+// Quadlet list
+// swagger:response
+type quadletListLibpod struct {
+	// in:body
+	Body []entities.ListQuadlet
+}
+
+// Quadlet install
+// swagger:response
+type quadletInstallLibpod struct {
+	// in:body
+	Body entities.QuadletInstallReport
+}
+
+// Quadlet remove
+// swagger:response
+type quadletRemoveLibpod struct {
+	// in:body
+	Body entities.QuadletRemoveReport
+}
+
 // Image Prune
 // swagger:response
 type imagesPruneLibpod struct {
@@ -405,6 +489,20 @@ type podRestartResponse struct {
 	Body entities.PodRestartReport
 }
 
+// Update pod
+// swagger:response
+type podUpdateResponse struct {
+	// in:body
+	ID string
+}
+
+// Restore pod
+// swagger:response
+type podRestoreResponse struct {
+	// in:body
+	Body entities.PodRestoreReport
+}
+
 // Start pod
 // swagger:response
 type podStartResponse struct {
@@ -468,6 +566,13 @@ type networkCreateResponse struct {
 	Body types.Network
 }
 
+// Network update
+// swagger:response
+type networkUpdateResponse struct {
+	// in:body
+	Body entities.NetworkUpdateReport
+}
+
 // Network prune
 // swagger:response
 type networkPruneResponse struct {
@@ -78,6 +78,29 @@ type UpdateEntities struct {
 	define.UpdateHealthCheckConfig
 }
 
+// CloneResources are the resource limits to change when cloning a container.
+// Values are in the format of the podman container clone options, unset
+// values keep the limits of the original container.
+// swagger:model
+type CloneResources struct {
+	BlkIOWeight       string   `json:"blkio_weight,omitempty"`
+	BlkIOWeightDevice []string `json:"blkio_weight_device,omitempty"`
+	CPUPeriod         uint64   `json:"cpu_period,omitempty"`
+	CPUQuota          int64    `json:"cpu_quota,omitempty"`
+	CPURTPeriod       uint64   `json:"cpu_rt_period,omitempty"`
+	CPURTRuntime      int64    `json:"cpu_rt_runtime,omitempty"`
+	CPUShares         uint64   `json:"cpu_shares,omitempty"`
+	CPUS              float64  `json:"cpus,omitempty"`
+	CPUSetCPUs        string   `json:"cpuset_cpus,omitempty"`
+	CPUSetMems        string   `json:"cpuset_mems,omitempty"`
+	DeviceReadBPs     []string `json:"device_read_bps,omitempty"`
+	DeviceWriteBPs    []string `json:"device_write_bps,omitempty"`
+	Memory            string   `json:"memory,omitempty"`
+	MemoryReservation string   `json:"memory_reservation,omitempty"`
+	MemorySwap        string   `json:"memory_swap,omitempty"`
+	MemorySwappiness  *int64   `json:"memory_swappiness,omitempty"`
+}
+
 type Info struct {
 	system.Info
 	BuildahVersion     string
@@ -0,0 +1,84 @@
+//go:build !remote
+
+package server
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/containers/podman/v5/libpod"
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/pkg/specgen/generate/kube"
+	"github.com/sirupsen/logrus"
+)
+
+// setupCronJobs runs the schedules of the pods created from Kubernetes
+// CronJobs in the service when systemd timers are not available to run them.
+func (s *APIServer) setupCronJobs() {
+	if err := libpod.CronJobTimersSupported(); err == nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cronJobCancel = cancel
+	go s.runCronJobs(ctx)
+}
+
+// runCronJobs starts the pods of CronJobs on their schedules until ctx is
+// canceled.  The pods are looked up every minute, so the schedules of pods
+// played or removed by other Podman processes are picked up as well.
+// Activations which passed while the service was not running are skipped.
+func (s *APIServer) runCronJobs(ctx context.Context) {
+	last := time.Now()
+	for {
+		timer := time.NewTimer(time.Until(last.Truncate(time.Minute).Add(time.Minute)))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		now := time.Now()
+		pods, err := s.Runtime.GetAllPods()
+		if err != nil {
+			if errors.Is(err, define.ErrRuntimeStopped) {
+				return
+			}
+			logrus.Errorf("Looking up the pods of CronJobs: %v", err)
+		}
+		for _, pod := range pods {
+			cronJob := pod.CronJob()
+			if cronJob == nil || cronJob.Suspend {
+				continue
+			}
+			schedule, err := kube.ParseCronSchedule(cronJob.Schedule, cronJob.TimeZone)
+			if err != nil {
+				logrus.Errorf("Schedule of pod %s: %v", pod.Name(), err)
+				continue
+			}
+			if next := schedule.Next(last); next.IsZero() || next.After(now) {
+				continue
+			}
+			go startCronJobPod(ctx, pod, cronJob.ConcurrencyPolicy)
+		}
+		last = now
+	}
+}
+
+// startCronJobPod starts the pod of a CronJob for an activation of its
+// schedule, following the concurrency policy when the pod is still running.
+func startCronJobPod(ctx context.Context, pod *libpod.Pod, concurrencyPolicy string) {
+	logrus.Debugf("Starting pod %s on schedule", pod.Name())
+	var err error
+	if concurrencyPolicy == define.CronJobConcurrencyReplace {
+		_, err = pod.Restart(ctx)
+	} else {
+		// Starting a running pod is a no-op which is exactly what the
+		// Forbid policy asks for.
+		_, err = pod.Start(ctx)
+	}
+	if err != nil && !errors.Is(err, define.ErrNoSuchPod) && !errors.Is(err, define.ErrPodRemoved) && !errors.Is(err, define.ErrRuntimeStopped) {
+		logrus.Errorf("Starting pod %s on schedule: %v", pod.Name(), err)
+	}
+}
@@ -75,3 +75,54 @@ func loggingHandler() mux.MiddlewareFunc {
 		})
 	}
 }
+
+// statusResponseWriter records the status code of the response
+type statusResponseWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (l *statusResponseWriter) WriteHeader(statusCode int) {
+	if l.status == 0 {
+		l.status = statusCode
+	}
+	l.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (l *statusResponseWriter) Write(b []byte) (int, error) {
+	if l.status == 0 {
+		l.status = http.StatusOK
+	}
+	return l.ResponseWriter.Write(b)
+}
+
+func (l *statusResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if wrapped, ok := l.ResponseWriter.(http.Hijacker); ok {
+		if l.status == 0 {
+			l.status = http.StatusSwitchingProtocols
+		}
+		return wrapped.Hijack()
+	}
+
+	return nil, nil, errors.New("ResponseWriter does not support hijacking")
+}
+
+func (l *statusResponseWriter) Flush() {
+	if wrapped, ok := l.ResponseWriter.(http.Flusher); ok {
+		wrapped.Flush()
+	}
+}
+
+// metricsHandler counts the handled requests by method, route and status code
+func metricsHandler(m *apiMetrics) mux.MiddlewareFunc {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			sw := &statusResponseWriter{ResponseWriter: w}
+			h.ServeHTTP(sw, r)
+			if sw.status == 0 {
+				sw.status = http.StatusOK
+			}
+			m.observeRequest(r, sw.status)
+		})
+	}
+}
@@ -0,0 +1,129 @@
+//go:build !remote
+
+package server
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/expfmt"
+	"github.com/sirupsen/logrus"
+)
+
+// metricsNamespace prefixes the names of all metrics exposed by the service.
+const metricsNamespace = "podman"
+
+// metricsTimeout bounds the time to read a scrape request and to write the
+// metrics, and how long idle connections of scrapers are kept open.
+const metricsTimeout = 30 * time.Second
+
+// apiMetrics holds the metrics exposed on the /metrics endpoint
+type apiMetrics struct {
+	registry *prometheus.Registry
+	requests *prometheus.CounterVec
+}
+
+// newAPIMetrics creates the metrics registry for the service, with the API
+// request counters and the metrics of collector.
+func newAPIMetrics(collector prometheus.Collector) (*apiMetrics, error) {
+	m := &apiMetrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: "api",
+			Name:      "requests_total",
+			Help:      "Number of API requests handled by the service.",
+		}, []string{"method", "path", "code"}),
+	}
+	if err := m.registry.Register(m.requests); err != nil {
+		return nil, err
+	}
+	if err := m.registry.Register(collector); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// observeRequest counts a handled API request. The path is the template of
+// the matched route without the version prefix, to keep the number of time
+// series bounded.
+func (m *apiMetrics) observeRequest(r *http.Request, code int) {
+	path := "unknown"
+	if route := mux.CurrentRoute(r); route != nil {
+		if tpl, err := route.GetPathTemplate(); err == nil {
+			path = strings.TrimPrefix(tpl, VersionedPath(""))
+		}
+	}
+	m.requests.WithLabelValues(r.Method, path, strconv.Itoa(code)).Inc()
+}
+
+// ServeHTTP writes the gathered metrics in the format negotiated with the
+// client, the Prometheus text format by default.
+func (m *apiMetrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	families, err := m.registry.Gather()
+	if err != nil {
+		// Serve the metrics which could be gathered rather than failing the scrape
+		logrus.Errorf("Gathering metrics: %v", err)
+	}
+
+	format := expfmt.Negotiate(r.Header)
+	w.Header().Set("Content-Type", string(format))
+	w.WriteHeader(http.StatusOK)
+	enc := expfmt.NewEncoder(w, format)
+	for _, family := range families {
+		if err := enc.Encode(family); err != nil {
+			logrus.Errorf("Encoding metrics: %v", err)
+			return
+		}
+	}
+}
+
+// metricsListenAddr returns the address the metrics service binds for the
+// --metrics-address value addr. Without a host, only localhost is bound. As
+// anyone able to connect can read the metrics, binding any other address is
+// warned about.
+func metricsListenAddr(addr string) (string, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "", fmt.Errorf("invalid metrics address %q: %w", addr, err)
+	}
+	if host == "" {
+		return net.JoinHostPort("localhost", port), nil
+	}
+	if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+		logrus.Warnf("Metrics address %q is not a loopback address, the metrics reveal the names, images and IDs of containers, pods, images and volumes to anyone able to connect to it", addr)
+	}
+	return addr, nil
+}
+
+// setupMetrics serves the /metrics endpoint on its own address, when requested
+func (s *APIServer) setupMetrics() {
+	if s.MetricsAddr == "" || s.metrics == nil {
+		return
+	}
+
+	logrus.Infof("metrics service listening on %q", s.MetricsAddr)
+	router := mux.NewRouter()
+	router.Handle("/metrics", s.metrics).Methods(http.MethodGet)
+	s.metricsServer = &http.Server{
+		Addr:              s.MetricsAddr,
+		Handler:           router,
+		ErrorLog:          s.Server.ErrorLog,
+		ReadHeaderTimeout: metricsTimeout,
+		ReadTimeout:       metricsTimeout,
+		WriteTimeout:      metricsTimeout,
+		IdleTimeout:       metricsTimeout,
+	}
+	go func() {
+		err := s.metricsServer.ListenAndServe()
+		if err != nil && err != http.ErrServerClosed {
+			logrus.Warnf("metrics service failed: %v", err)
+		}
+	}()
+}
@@ -0,0 +1,281 @@
+//go:build !remote
+
+package server
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/containers/podman/v5/libpod"
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/containers/podman/v5/pkg/domain/infra/abi"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+)
+
+// diskUsageCacheDuration is how long the disk usage of images and volumes is
+// reused between scrapes, computing it walks the storage.
+const diskUsageCacheDuration = time.Minute
+
+func newMetricsDesc(name, help string, labels ...string) *prometheus.Desc {
+	return prometheus.NewDesc(prometheus.BuildFQName(metricsNamespace, "", name), help, labels, nil)
+}
+
+var (
+	containerLabels = []string{"id", "name", "pod_id"}
+	podLabels       = []string{"id", "name"}
+	imageLabels     = []string{"id", "repository", "tag"}
+	volumeLabels    = []string{"name"}
+
+	containerInfoDesc     = newMetricsDesc("container_info", "Information about the container, always 1.", "id", "name", "image", "pod_id", "state")
+	containerRestartsDesc = newMetricsDesc("container_restarts_total", "Number of times the container was restarted by its restart policy.", containerLabels...)
+	containerHealthDesc   = newMetricsDesc("container_health", "Healthcheck status of the container, 1 for the current status.", append(containerLabels, "status")...)
+	containerCPUDesc      = newMetricsDesc("container_cpu_seconds_total", "CPU time consumed by the container in seconds.", containerLabels...)
+	containerMemUsageDesc = newMetricsDesc("container_memory_usage_bytes", "Memory used by the container in bytes.", containerLabels...)
+	containerMemLimitDesc = newMetricsDesc("container_memory_limit_bytes", "Memory limit of the container in bytes.", containerLabels...)
+	containerNetRxDesc    = newMetricsDesc("container_network_receive_bytes_total", "Bytes received by the container on a network interface.", append(containerLabels, "interface")...)
+	containerNetTxDesc    = newMetricsDesc("container_network_transmit_bytes_total", "Bytes transmitted by the container on a network interface.", append(containerLabels, "interface")...)
+	containerBlockInDesc  = newMetricsDesc("container_block_input_bytes_total", "Bytes read from block devices by the container.", containerLabels...)
+	containerBlockOutDesc = newMetricsDesc("container_block_output_bytes_total", "Bytes written to block devices by the container.", containerLabels...)
+	containerPIDsDesc     = newMetricsDesc("container_pids", "Number of processes in the container.", containerLabels...)
+
+	podInfoDesc       = newMetricsDesc("pod_info", "Information about the pod, always 1.", "id", "name", "state")
+	podContainersDesc = newMetricsDesc("pod_containers", "Number of containers in the pod.", podLabels...)
+	podCPUDesc        = newMetricsDesc("pod_cpu_seconds_total", "CPU time consumed by the running containers of the pod in seconds.", podLabels...)
+	podMemUsageDesc   = newMetricsDesc("pod_memory_usage_bytes", "Memory used by the running containers of the pod in bytes.", podLabels...)
+	podNetRxDesc      = newMetricsDesc("pod_network_receive_bytes_total", "Bytes received by the running containers of the pod.", podLabels...)
+	podNetTxDesc      = newMetricsDesc("pod_network_transmit_bytes_total", "Bytes transmitted by the running containers of the pod.", podLabels...)
+	podBlockInDesc    = newMetricsDesc("pod_block_input_bytes_total", "Bytes read from block devices by the running containers of the pod.", podLabels...)
+	podBlockOutDesc   = newMetricsDesc("pod_block_output_bytes_total", "Bytes written to block devices by the running containers of the pod.", podLabels...)
+	podPIDsDesc       = newMetricsDesc("pod_pids", "Number of processes in the running containers of the pod.", podLabels...)
+
+	imagesSizeDesc        = newMetricsDesc("images_size_bytes", "Disk space used by all images in bytes.")
+	imageSizeDesc         = newMetricsDesc("image_size_bytes", "Size of the image in bytes.", imageLabels...)
+	imageUniqueSizeDesc   = newMetricsDesc("image_unique_size_bytes", "Size of the image not shared with other images in bytes.", imageLabels...)
+	imageContainersDesc   = newMetricsDesc("image_containers", "Number of containers using the image.", imageLabels...)
+	volumeSizeDesc        = newMetricsDesc("volume_size_bytes", "Disk space used by the volume in bytes.", volumeLabels...)
+	volumeReclaimableDesc = newMetricsDesc("volume_reclaimable_size_bytes", "Disk space of the volume which can be reclaimed as no container uses it in bytes.", volumeLabels...)
+	volumeLinksDesc       = newMetricsDesc("volume_links", "Number of containers using the volume.", volumeLabels...)
+)
+
+// podUsage sums up the stats of the running containers of a pod
+type podUsage struct {
+	containers int
+	cpuNano    uint64
+	memUsage   uint64
+	netRx      uint64
+	netTx      uint64
+	blockIn    uint64
+	blockOut   uint64
+	pids       uint64
+}
+
+// libpodCollector collects the container, pod and disk usage metrics from
+// the runtime on each scrape.
+type libpodCollector struct {
+	runtime *libpod.Runtime
+
+	lock          sync.Mutex
+	diskUsage     *entities.SystemDfReport
+	diskUsageTime time.Time
+}
+
+func newLibpodCollector(runtime *libpod.Runtime) *libpodCollector {
+	return &libpodCollector{runtime: runtime}
+}
+
+// Describe implements prometheus.Collector
+func (c *libpodCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range []*prometheus.Desc{
+		containerInfoDesc, containerRestartsDesc, containerHealthDesc, containerCPUDesc,
+		containerMemUsageDesc, containerMemLimitDesc, containerNetRxDesc, containerNetTxDesc,
+		containerBlockInDesc, containerBlockOutDesc, containerPIDsDesc,
+		podInfoDesc, podContainersDesc, podCPUDesc, podMemUsageDesc, podNetRxDesc,
+		podNetTxDesc, podBlockInDesc, podBlockOutDesc, podPIDsDesc,
+		imagesSizeDesc, imageSizeDesc, imageUniqueSizeDesc, imageContainersDesc,
+		volumeSizeDesc, volumeReclaimableDesc, volumeLinksDesc,
+	} {
+		ch <- desc
+	}
+}
+
+// Collect implements prometheus.Collector. Failures to collect the metrics
+// of a single container or pod are logged and do not fail the scrape.
+func (c *libpodCollector) Collect(ch chan<- prometheus.Metric) {
+	pods := c.collectContainers(ch)
+	c.collectPods(ch, pods)
+	c.collectDiskUsage(ch)
+}
+
+// collectContainers collects the metrics of all containers and returns the
+// usage of their pods.
+func (c *libpodCollector) collectContainers(ch chan<- prometheus.Metric) map[string]*podUsage {
+	pods := make(map[string]*podUsage)
+	ctrs, err := c.runtime.GetAllContainers()
+	if err != nil {
+		logrus.Errorf("Collecting container metrics: %v", err)
+		return pods
+	}
+	for _, ctr := range ctrs {
+		state, err := ctr.State()
+		if err != nil {
+			if !errors.Is(err, define.ErrNoSuchCtr) && !errors.Is(err, define.ErrCtrRemoved) {
+				logrus.Errorf("Collecting metrics of container %s: %v", ctr.ID(), err)
+			}
+			continue
+		}
+		labels := []string{ctr.ID(), ctr.Name(), ctr.PodID()}
+		ch <- prometheus.MustNewConstMetric(containerInfoDesc, prometheus.GaugeValue, 1,
+			ctr.ID(), ctr.Name(), ctr.RawImageName(), ctr.PodID(), state.String())
+
+		if restarts, err := ctr.RestartCount(); err == nil {
+			ch <- prometheus.MustNewConstMetric(containerRestartsDesc, prometheus.CounterValue, float64(restarts), labels...)
+		}
+
+		if ctr.HasHealthCheck() {
+			status, err := ctr.HealthCheckStatus()
+			if err != nil {
+				logrus.Debugf("Collecting healthcheck status of container %s: %v", ctr.ID(), err)
+			} else {
+				for _, s := range []string{define.HealthCheckStarting, define.HealthCheckHealthy, define.HealthCheckUnhealthy} {
+					value := 0.0
+					if s == status {
+						value = 1
+					}
+					ch <- prometheus.MustNewConstMetric(containerHealthDesc, prometheus.GaugeValue, value, append(labels, s)...)
+				}
+			}
+		}
+
+		var usage *podUsage
+		if ctr.PodID() != "" {
+			usage = pods[ctr.PodID()]
+			if usage == nil {
+				usage = new(podUsage)
+				pods[ctr.PodID()] = usage
+			}
+			usage.containers++
+		}
+
+		if state != define.ContainerStateRunning && state != define.ContainerStatePaused {
+			continue
+		}
+		stats, err := ctr.GetContainerStats(nil)
+		if err != nil {
+			// Containers without cgroup or exiting while collecting
+			logrus.Debugf("Collecting stats of container %s: %v", ctr.ID(), err)
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(containerCPUDesc, prometheus.CounterValue, float64(stats.CPUNano)/float64(time.Second), labels...)
+		ch <- prometheus.MustNewConstMetric(containerMemUsageDesc, prometheus.GaugeValue, float64(stats.MemUsage), labels...)
+		ch <- prometheus.MustNewConstMetric(containerMemLimitDesc, prometheus.GaugeValue, float64(stats.MemLimit), labels...)
+		ch <- prometheus.MustNewConstMetric(containerBlockInDesc, prometheus.CounterValue, float64(stats.BlockInput), labels...)
+		ch <- prometheus.MustNewConstMetric(containerBlockOutDesc, prometheus.CounterValue, float64(stats.BlockOutput), labels...)
+		ch <- prometheus.MustNewConstMetric(containerPIDsDesc, prometheus.GaugeValue, float64(stats.PIDs), labels...)
+		for iface, net := range stats.Network {
+			ch <- prometheus.MustNewConstMetric(containerNetRxDesc, prometheus.CounterValue, float64(net.RxBytes), append(labels, iface)...)
+			ch <- prometheus.MustNewConstMetric(containerNetTxDesc, prometheus.CounterValue, float64(net.TxBytes), append(labels, iface)...)
+		}
+
+		if usage == nil {
+			continue
+		}
+		usage.cpuNano += stats.CPUNano
+		usage.memUsage += stats.MemUsage
+		usage.blockIn += stats.BlockInput
+		usage.blockOut += stats.BlockOutput
+		usage.pids += stats.PIDs
+		// Containers joining the network namespace of another container,
+		// usually the infra container, report the same interfaces.
+		if ctr.ConfigNoCopy().NetNsCtr == "" {
+			for _, net := range stats.Network {
+				usage.netRx += net.RxBytes
+				usage.netTx += net.TxBytes
+			}
+		}
+	}
+	return pods
+}
+
+// collectPods collects the metrics of all pods from the usage of their
+// containers.
+func (c *libpodCollector) collectPods(ch chan<- prometheus.Metric, usages map[string]*podUsage) {
+	pods, err := c.runtime.GetAllPods()
+	if err != nil {
+		logrus.Errorf("Collecting pod metrics: %v", err)
+		return
+	}
+	for _, pod := range pods {
+		state, err := pod.GetPodStatus()
+		if err != nil {
+			if !errors.Is(err, define.ErrNoSuchPod) && !errors.Is(err, define.ErrPodRemoved) {
+				logrus.Errorf("Collecting metrics of pod %s: %v", pod.ID(), err)
+			}
+			continue
+		}
+		usage := usages[pod.ID()]
+		if usage == nil {
+			usage = new(podUsage)
+		}
+		labels := []string{pod.ID(), pod.Name()}
+		ch <- prometheus.MustNewConstMetric(podInfoDesc, prometheus.GaugeValue, 1, pod.ID(), pod.Name(), state)
+		ch <- prometheus.MustNewConstMetric(podContainersDesc, prometheus.GaugeValue, float64(usage.containers), labels...)
+		ch <- prometheus.MustNewConstMetric(podCPUDesc, prometheus.CounterValue, float64(usage.cpuNano)/float64(time.Second), labels...)
+		ch <- prometheus.MustNewConstMetric(podMemUsageDesc, prometheus.GaugeValue, float64(usage.memUsage), labels...)
+		ch <- prometheus.MustNewConstMetric(podNetRxDesc, prometheus.CounterValue, float64(usage.netRx), labels...)
+		ch <- prometheus.MustNewConstMetric(podNetTxDesc, prometheus.CounterValue, float64(usage.netTx), labels...)
+		ch <- prometheus.MustNewConstMetric(podBlockInDesc, prometheus.CounterValue, float64(usage.blockIn), labels...)
+		ch <- prometheus.MustNewConstMetric(podBlockOutDesc, prometheus.CounterValue, float64(usage.blockOut), labels...)
+		ch <- prometheus.MustNewConstMetric(podPIDsDesc, prometheus.GaugeValue, float64(usage.pids), labels...)
+	}
+}
+
+// collectDiskUsage collects the disk usage of images and volumes as reported
+// by SystemDf.
+func (c *libpodCollector) collectDiskUsage(ch chan<- prometheus.Metric) {
+	df, err := c.getDiskUsage()
+	if err != nil {
+		logrus.Errorf("Collecting disk usage metrics: %v", err)
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(imagesSizeDesc, prometheus.GaugeValue, float64(df.ImagesSize))
+
+	// An image is listed once per tag, untagged images share <none>.
+	seen := make(map[[3]string]bool)
+	for _, img := range df.Images {
+		labels := [3]string{img.ImageID, img.Repository, img.Tag}
+		if seen[labels] {
+			continue
+		}
+		seen[labels] = true
+		ch <- prometheus.MustNewConstMetric(imageSizeDesc, prometheus.GaugeValue, float64(img.Size), labels[:]...)
+		ch <- prometheus.MustNewConstMetric(imageUniqueSizeDesc, prometheus.GaugeValue, float64(img.UniqueSize), labels[:]...)
+		ch <- prometheus.MustNewConstMetric(imageContainersDesc, prometheus.GaugeValue, float64(img.Containers), labels[:]...)
+	}
+	for _, vol := range df.Volumes {
+		ch <- prometheus.MustNewConstMetric(volumeSizeDesc, prometheus.GaugeValue, float64(vol.Size), vol.VolumeName)
+		ch <- prometheus.MustNewConstMetric(volumeReclaimableDesc, prometheus.GaugeValue, float64(vol.ReclaimableSize), vol.VolumeName)
+		ch <- prometheus.MustNewConstMetric(volumeLinksDesc, prometheus.GaugeValue, float64(vol.Links), vol.VolumeName)
+	}
+}
+
+// getDiskUsage returns the SystemDf report, computing it at most once per
+// diskUsageCacheDuration.
+func (c *libpodCollector) getDiskUsage() (*entities.SystemDfReport, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.diskUsage != nil && time.Since(c.diskUsageTime) < diskUsageCacheDuration {
+		return c.diskUsage, nil
+	}
+	ic := abi.ContainerEngine{Libpod: c.runtime}
+	df, err := ic.SystemDf(context.Background(), entities.SystemDfOptions{})
+	if err != nil {
+		return nil, err
+	}
+	c.diskUsage = df
+	c.diskUsageTime = time.Now()
+	return df, nil
+}
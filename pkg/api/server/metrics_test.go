@@ -0,0 +1,78 @@
+//go:build !remote
+
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricsHandler(t *testing.T) {
+	gauge := prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "test_value",
+		Help:      "Test value.",
+	})
+	gauge.Set(42)
+	metrics, err := newAPIMetrics(gauge)
+	require.NoError(t, err)
+
+	router := mux.NewRouter().UseEncodedPath()
+	router.Use(metricsHandler(metrics))
+	router.HandleFunc(VersionedPath("/libpod/containers/{name}/json"), func(w http.ResponseWriter, r *http.Request) {
+		if mux.Vars(r)["name"] == "missing" {
+			http.Error(w, "no such container", http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte("{}"))
+	})
+	router.Handle("/metrics", metrics).Methods(http.MethodGet)
+
+	for _, path := range []string{"/v5.0.0/libpod/containers/foo/json", "/v5.0.0/libpod/containers/bar/json", "/v4.0.0/libpod/containers/missing/json"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.True(t, strings.HasPrefix(rec.Header().Get("Content-Type"), "text/plain; version=0.0.4"))
+
+	body := rec.Body.String()
+	assert.Contains(t, body, "# TYPE podman_api_requests_total counter\n")
+	assert.Contains(t, body, `podman_api_requests_total{code="200",method="GET",path="/libpod/containers/{name}/json"} 2`+"\n")
+	assert.Contains(t, body, `podman_api_requests_total{code="404",method="GET",path="/libpod/containers/{name}/json"} 1`+"\n")
+	assert.Contains(t, body, "podman_test_value 42\n")
+}
+
+func TestMetricsListenAddr(t *testing.T) {
+	tests := []struct {
+		addr     string
+		expected string
+		err      bool
+	}{
+		{addr: ":9882", expected: "localhost:9882"},
+		{addr: "localhost:9882", expected: "localhost:9882"},
+		{addr: "127.0.0.1:9882", expected: "127.0.0.1:9882"},
+		{addr: "[::1]:9882", expected: "[::1]:9882"},
+		{addr: "0.0.0.0:9882", expected: "0.0.0.0:9882"},
+		{addr: "9882", err: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			addr, err := metricsListenAddr(tt.addr)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, addr)
+		})
+	}
+}
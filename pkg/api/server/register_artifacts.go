@@ -0,0 +1,263 @@
+//go:build !remote
+
+package server
+
+import (
+	"net/http"
+
+	"github.com/containers/podman/v5/pkg/api/handlers/libpod"
+	"github.com/gorilla/mux"
+)
+
+func (s *APIServer) registerArtifactHandlers(r *mux.Router) error {
+	// swagger:operation GET /libpod/artifacts/json libpod ArtifactListLibpod
+	// ---
+	// tags:
+	//  - artifacts
+	// summary: List artifacts
+	// description: List the OCI artifacts in the local artifact store.
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: "#/responses/artifactListLibpod"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.Handle(VersionedPath("/libpod/artifacts/json"), s.APIHandler(libpod.ListArtifacts)).Methods(http.MethodGet)
+	// swagger:operation POST /libpod/artifacts/add libpod ArtifactAddLibpod
+	// ---
+	// tags:
+	//  - artifacts
+	// summary: Add files to an artifact
+	// description: |
+	//   Create an artifact from the file in the request body, or add the file to an existing artifact.
+	//   With the application/x-tar content type, the body is a tar archive of regular files which are all added at once, stored under their names.
+	// consumes:
+	// - application/octet-stream
+	// - application/x-tar
+	// parameters:
+	//  - in: query
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name of the artifact
+	//  - in: query
+	//    name: fileName
+	//    type: string
+	//    description: the name of the file, stored as its title. Required unless the body is a tar archive.
+	//  - in: query
+	//    name: artifactType
+	//    type: string
+	//    description: the artifact type of a new artifact
+	//  - in: query
+	//    name: fileType
+	//    type: string
+	//    description: the media type of the file
+	//  - in: query
+	//    name: annotations
+	//    type: string
+	//    description: JSON encoded map of annotations of the artifact
+	//  - in: query
+	//    name: append
+	//    type: boolean
+	//    default: false
+	//    description: add the file to the artifact if it already exists
+	//  - in: body
+	//    name: request
+	//    description: content of the file, or a tar archive of the files
+	//    schema:
+	//      type: string
+	//      format: binary
+	// produces:
+	// - application/json
+	// responses:
+	//   201:
+	//     $ref: "#/responses/artifactAddLibpod"
+	//   400:
+	//     $ref: "#/responses/badParamError"
+	//   409:
+	//     $ref: "#/responses/conflictError"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.Handle(VersionedPath("/libpod/artifacts/add"), s.APIHandler(libpod.AddArtifact)).Methods(http.MethodPost)
+	// swagger:operation POST /libpod/artifacts/pull libpod ArtifactPullLibpod
+	// ---
+	// tags:
+	//  - artifacts
+	// summary: Pull an artifact
+	// description: Pull an artifact from a registry into the local artifact store.
+	// parameters:
+	//  - in: query
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the reference of the artifact to pull
+	//  - in: query
+	//    name: retry
+	//    type: integer
+	//    description: number of times to retry in case of failure
+	//  - in: query
+	//    name: retryDelay
+	//    type: string
+	//    description: delay between retries in case of failure
+	//  - in: query
+	//    name: tlsVerify
+	//    type: boolean
+	//    default: true
+	//    description: Require TLS verification.
+	//  - in: header
+	//    name: X-Registry-Auth
+	//    type: string
+	//    description: "base-64 encoded auth config. Must include the following four values: username, password, email and server address OR simply just an identity token."
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: "#/responses/artifactPullLibpod"
+	//   400:
+	//     $ref: "#/responses/badParamError"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.Handle(VersionedPath("/libpod/artifacts/pull"), s.APIHandler(libpod.PullArtifact)).Methods(http.MethodPost)
+	// swagger:operation DELETE /libpod/artifacts/remove libpod ArtifactRemoveLibpod
+	// ---
+	// tags:
+	//  - artifacts
+	// summary: Remove artifacts
+	// description: Remove artifacts from the local artifact store.
+	// parameters:
+	//  - in: query
+	//    name: artifacts
+	//    type: array
+	//    items:
+	//      type: string
+	//    description: names or digests of the artifacts to remove
+	//  - in: query
+	//    name: all
+	//    type: boolean
+	//    default: false
+	//    description: remove all artifacts
+	//  - in: query
+	//    name: force
+	//    type: boolean
+	//    default: false
+	//    description: remove the containers using the artifacts
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: "#/responses/artifactRemoveLibpod"
+	//   400:
+	//     $ref: "#/responses/badParamError"
+	//   404:
+	//     $ref: "#/responses/artifactNotFound"
+	//   409:
+	//     $ref: "#/responses/conflictError"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.Handle(VersionedPath("/libpod/artifacts/remove"), s.APIHandler(libpod.RemoveArtifacts)).Methods(http.MethodDelete)
+	// swagger:operation GET /libpod/artifacts/{name}/json libpod ArtifactInspectLibpod
+	// ---
+	// tags:
+	//  - artifacts
+	// summary: Inspect an artifact
+	// description: Return the manifest and digest of an artifact.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or digest of the artifact
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: "#/responses/artifactInspectLibpod"
+	//   404:
+	//     $ref: "#/responses/artifactNotFound"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.Handle(VersionedPath("/libpod/artifacts/{name:.*}/json"), s.APIHandler(libpod.InspectArtifact)).Methods(http.MethodGet)
+	// swagger:operation POST /libpod/artifacts/{name}/push libpod ArtifactPushLibpod
+	// ---
+	// tags:
+	//  - artifacts
+	// summary: Push an artifact
+	// description: Push an artifact from the local artifact store to a registry.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or digest of the artifact
+	//  - in: query
+	//    name: destination
+	//    type: string
+	//    description: the reference to push to, defaults to the name of the artifact
+	//  - in: query
+	//    name: retry
+	//    type: integer
+	//    description: number of times to retry in case of failure
+	//  - in: query
+	//    name: retryDelay
+	//    type: string
+	//    description: delay between retries in case of failure
+	//  - in: query
+	//    name: tlsVerify
+	//    type: boolean
+	//    default: true
+	//    description: Require TLS verification.
+	//  - in: header
+	//    name: X-Registry-Auth
+	//    type: string
+	//    description: "base-64 encoded auth config. Must include the following four values: username, password, email and server address OR simply just an identity token."
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: "#/responses/artifactPushLibpod"
+	//   400:
+	//     $ref: "#/responses/badParamError"
+	//   404:
+	//     $ref: "#/responses/artifactNotFound"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.Handle(VersionedPath("/libpod/artifacts/{name:.*}/push"), s.APIHandler(libpod.PushArtifact)).Methods(http.MethodPost)
+	// swagger:operation GET /libpod/artifacts/{name}/extract libpod ArtifactExtractLibpod
+	// ---
+	// tags:
+	//  - artifacts
+	// summary: Extract an artifact
+	// description: |
+	//   Return the files of an artifact as a tar archive, with each file stored under its title.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or digest of the artifact
+	//  - in: query
+	//    name: digest
+	//    type: string
+	//    description: only extract the file with this digest
+	//  - in: query
+	//    name: title
+	//    type: string
+	//    description: only extract the file with this title
+	// produces:
+	// - application/x-tar
+	// responses:
+	//   200:
+	//     description: tar archive of the files of the artifact
+	//     schema:
+	//       type: string
+	//       format: binary
+	//   400:
+	//     $ref: "#/responses/badParamError"
+	//   404:
+	//     $ref: "#/responses/artifactNotFound"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.Handle(VersionedPath("/libpod/artifacts/{name:.*}/extract"), s.APIHandler(libpod.ExtractArtifact)).Methods(http.MethodGet)
+	return nil
+}
@@ -1814,5 +1814,63 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//   500:
 	//     $ref: "#/responses/internalError"
 	r.HandleFunc(VersionedPath("/libpod/containers/{name}/update"), s.APIHandler(libpod.UpdateContainer)).Methods(http.MethodPost)
+	// swagger:operation POST /libpod/containers/{name}/clone libpod ContainerCloneLibpod
+	// ---
+	// tags:
+	//   - containers
+	// summary: Clone a container
+	// description: Creates a copy of an existing container, optionally with different resource limits.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: Full or partial ID or full name of the container to clone
+	//  - in: query
+	//    name: name
+	//    type: string
+	//    description: Name of the new container. Defaults to the name of the original container with a "-clone" suffix.
+	//  - in: query
+	//    name: image
+	//    type: string
+	//    description: Image of the new container. The image must be present in the local storage. Defaults to the image of the original container.
+	//  - in: query
+	//    name: pod
+	//    type: string
+	//    description: Pod to create the new container in
+	//  - in: query
+	//    name: destroy
+	//    type: boolean
+	//    default: false
+	//    description: Remove the original container
+	//  - in: query
+	//    name: force
+	//    type: boolean
+	//    default: false
+	//    description: Force the removal of the original container. Only allowed if destroy is set.
+	//  - in: query
+	//    name: run
+	//    type: boolean
+	//    default: false
+	//    description: Start the new container
+	//  - in: body
+	//    name: resources
+	//    description: resource limits of the new container
+	//    schema:
+	//      $ref: "#/definitions/CloneResources"
+	// produces:
+	// - application/json
+	// responses:
+	//   201:
+	//     $ref: "#/responses/containerCreateResponse"
+	//   400:
+	//     $ref: "#/responses/badParamError"
+	//   404:
+	//     $ref: "#/responses/containerNotFound"
+	//   409:
+	//     $ref: "#/responses/conflictError"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.HandleFunc(VersionedPath("/libpod/containers/{name}/clone"), s.APIHandler(libpod.CloneContainer)).Methods(http.MethodPost)
 	return nil
 }
@@ -209,7 +209,11 @@ func (s *APIServer) registerKubeHandlers(r *mux.Router) error {
 	//    type: integer
 	//    format: int32
 	//    default: 0
-	//    description: Set the replica number for Deployment kind.
+	//    description: Set the replica number for Deployment and StatefulSet kinds.
+	//  - in: query
+	//    name: schedule
+	//    type: string
+	//    description: Set the cron schedule for CronJob kind. Defaults to the schedule of a pod created from a CronJob.
 	//  - in: query
 	//    name: noTrunc
 	//    type: boolean
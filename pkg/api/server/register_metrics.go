@@ -0,0 +1,32 @@
+//go:build !remote
+
+package server
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+func (s *APIServer) registerMetricsHandlers(r *mux.Router) error {
+	if s.metrics == nil {
+		return nil
+	}
+	// swagger:operation GET /metrics libpod SystemMetrics
+	// ---
+	//   summary: Prometheus metrics
+	//   description: |
+	//     Return metrics of containers, pods, images, volumes and of the API service in the Prometheus text exposition format.
+	//     The endpoint is only available when the service is started with `--metrics`, and is not versioned.
+	//   tags:
+	//   - system
+	//   produces:
+	//   - text/plain
+	//   responses:
+	//     200:
+	//       description: Metrics in Prometheus text format
+	//       schema:
+	//         type: string
+	r.Handle("/metrics", s.APIHandler(s.metrics.ServeHTTP)).Methods(http.MethodGet)
+	return nil
+}
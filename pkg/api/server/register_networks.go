@@ -243,7 +243,11 @@ func (s *APIServer) registerNetworkHandlers(r *mux.Router) error {
 	// tags:
 	//  - networks
 	// summary: Update existing podman network
-	// description: Update existing podman network
+	// description: |
+	//   Update existing podman network.
+	//   Changes to subnets, driver options, internal and dns_enabled are applied to running
+	//   containers by setting up their network again. The response lists the containers
+	//   which could not be reconfigured and need a restart.
 	// produces:
 	// - application/json
 	// parameters:
@@ -259,9 +263,11 @@ func (s *APIServer) registerNetworkHandlers(r *mux.Router) error {
 	//      $ref: "#/definitions/networkUpdateRequestLibpod"
 	// responses:
 	//   200:
-	//     description: OK
+	//     $ref: "#/responses/networkUpdateResponse"
 	//   400:
 	//     $ref: "#/responses/badParamError"
+	//   404:
+	//     $ref: "#/responses/networkNotFound"
 	//   500:
 	//     $ref: "#/responses/internalError"
 	r.HandleFunc(VersionedPath("/libpod/networks/{name}/update"), s.APIHandler(libpod.UpdateNetwork)).Methods(http.MethodPost)
@@ -214,6 +214,164 @@ func (s *APIServer) registerPodsHandlers(r *mux.Router) error {
 	//   500:
 	//     $ref: "#/responses/internalError"
 	r.Handle(VersionedPath("/libpod/pods/{name}/restart"), s.APIHandler(libpod.PodRestart)).Methods(http.MethodPost)
+	// swagger:operation POST /libpod/pods/{name}/update pods PodUpdateLibpod
+	// ---
+	// summary: Update a pod
+	// description: Update the resource limits of a pod and optionally the restart policy and healthcheck on-failure action of its containers.
+	// produces:
+	// - application/json
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the pod
+	//  - in: query
+	//    name: restartPolicy
+	//    type: string
+	//    required: false
+	//    description: New restart policy for the pod and its containers.
+	//  - in: query
+	//    name: restartRetries
+	//    type: integer
+	//    required: false
+	//    description: New amount of retries for the restart policy. Only allowed if restartPolicy is set to on-failure
+	//  - in: query
+	//    name: healthOnFailure
+	//    type: string
+	//    required: false
+	//    description: New healthcheck on-failure action for the containers of the pod.
+	//  - in: body
+	//    name: resources
+	//    description: resource limits of the pod, only the fields set are updated
+	//    schema:
+	//      $ref: "#/definitions/LinuxResources"
+	// responses:
+	//   200:
+	//     $ref: '#/responses/podUpdateResponse'
+	//   400:
+	//     $ref: "#/responses/badParamError"
+	//   404:
+	//     $ref: "#/responses/podNotFound"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.Handle(VersionedPath("/libpod/pods/{name}/update"), s.APIHandler(libpod.PodUpdate)).Methods(http.MethodPost)
+	// swagger:operation POST /libpod/pods/{name}/checkpoint pods PodCheckpointLibpod
+	// ---
+	// summary: Checkpoint a pod
+	// description: Checkpoint all containers of a pod at one consistent point and export them together with the pod configuration as one archive.
+	// produces:
+	// - application/x-tar
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the pod
+	//  - in: query
+	//    name: keep
+	//    type: boolean
+	//    description: keep all temporary checkpoint files
+	//  - in: query
+	//    name: leaveRunning
+	//    type: boolean
+	//    description: leave the containers of the pod running after writing the checkpoint
+	//  - in: query
+	//    name: tcpEstablished
+	//    type: boolean
+	//    description: checkpoint containers with established TCP connections
+	//  - in: query
+	//    name: ignoreRootFS
+	//    type: boolean
+	//    description: do not include root file-system changes in the archive
+	//  - in: query
+	//    name: ignoreVolumes
+	//    type: boolean
+	//    description: do not include the content of volumes in the archive
+	//  - in: query
+	//    name: fileLocks
+	//    type: boolean
+	//    description: checkpoint containers with file locks
+	//  - in: query
+	//    name: compress
+	//    type: string
+	//    default: zstd
+	//    description: compression algorithm of the archive (gzip, none, zstd)
+	// responses:
+	//   200:
+	//     description: tarball of the pod checkpoint
+	//   400:
+	//     $ref: "#/responses/badParamError"
+	//   404:
+	//     $ref: "#/responses/podNotFound"
+	//   409:
+	//     $ref: "#/responses/conflictError"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.Handle(VersionedPath("/libpod/pods/{name}/checkpoint"), s.APIHandler(libpod.PodCheckpoint)).Methods(http.MethodPost)
+	// swagger:operation POST /libpod/pods/restore pods PodRestoreLibpod
+	// ---
+	// summary: Restore a pod
+	// description: Recreate a pod and restore all of its containers from a pod checkpoint archive sent in the request body.
+	// consumes:
+	// - application/x-tar
+	// produces:
+	// - application/json
+	// parameters:
+	//  - in: query
+	//    name: name
+	//    type: string
+	//    description: restore the pod under a new name
+	//  - in: query
+	//    name: keep
+	//    type: boolean
+	//    description: keep all temporary checkpoint files
+	//  - in: query
+	//    name: tcpEstablished
+	//    type: boolean
+	//    description: restore containers with established TCP connections
+	//  - in: query
+	//    name: ignoreRootFS
+	//    type: boolean
+	//    description: do not restore root file-system changes from the archive
+	//  - in: query
+	//    name: ignoreVolumes
+	//    type: boolean
+	//    description: do not restore the content of volumes from the archive
+	//  - in: query
+	//    name: ignoreStaticIP
+	//    type: boolean
+	//    description: ignore IP addresses of the pod saved in the archive
+	//  - in: query
+	//    name: ignoreStaticMAC
+	//    type: boolean
+	//    description: ignore MAC addresses of the pod saved in the archive
+	//  - in: query
+	//    name: fileLocks
+	//    type: boolean
+	//    description: restore containers with file locks
+	//  - in: query
+	//    name: publishPorts
+	//    type: array
+	//    items:
+	//      type: string
+	//    description: publish the ports of the pod to the host, replacing the ports saved in the archive
+	//  - in: body
+	//    name: request
+	//    description: tarball of the pod checkpoint
+	//    schema:
+	//      type: string
+	//      format: binary
+	// responses:
+	//   200:
+	//     $ref: "#/responses/podRestoreResponse"
+	//   400:
+	//     $ref: "#/responses/badParamError"
+	//   409:
+	//     $ref: "#/responses/conflictError"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.Handle(VersionedPath("/libpod/pods/restore"), s.APIHandler(libpod.PodRestore)).Methods(http.MethodPost)
 	// swagger:operation POST /libpod/pods/{name}/start pods PodStartLibpod
 	// ---
 	// summary: Start a pod
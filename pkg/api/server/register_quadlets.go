@@ -0,0 +1,136 @@
+//go:build !remote
+
+package server
+
+import (
+	"net/http"
+
+	"github.com/containers/podman/v5/pkg/api/handlers/libpod"
+	"github.com/gorilla/mux"
+)
+
+func (s *APIServer) registerQuadletHandlers(r *mux.Router) error {
+	// swagger:operation POST /libpod/quadlets libpod QuadletInstallLibpod
+	// ---
+	// tags:
+	//  - quadlets
+	// summary: Install Quadlets
+	// description: |
+	//   Install the Quadlet files at the root of the tar archive in the request body, with the files they
+	//   reference, into the Quadlet unit directory of the user running the service.
+	// consumes:
+	// - application/x-tar
+	// parameters:
+	//  - in: query
+	//    name: replace
+	//    type: boolean
+	//    default: false
+	//    description: Replace Quadlets and files which are already installed
+	//  - in: query
+	//    name: reloadSystemd
+	//    type: boolean
+	//    default: true
+	//    description: Reload systemd after installing the Quadlets
+	//  - in: body
+	//    name: request
+	//    description: tar archive of the Quadlet files and the files they reference
+	//    schema:
+	//      type: string
+	//      format: binary
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: "#/responses/quadletInstallLibpod"
+	//   400:
+	//     $ref: "#/responses/badParamError"
+	//   409:
+	//     $ref: "#/responses/conflictError"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.Handle(VersionedPath("/libpod/quadlets"), s.APIHandler(libpod.InstallQuadlets)).Methods(http.MethodPost)
+	// swagger:operation GET /libpod/quadlets/json libpod QuadletListLibpod
+	// ---
+	// tags:
+	//  - quadlets
+	// summary: List Quadlets
+	// description: List the installed Quadlets, with the name and state of their systemd service.
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: "#/responses/quadletListLibpod"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.Handle(VersionedPath("/libpod/quadlets/json"), s.APIHandler(libpod.ListQuadlets)).Methods(http.MethodGet)
+	// swagger:operation GET /libpod/quadlets/{name}/file libpod QuadletFileLibpod
+	// ---
+	// tags:
+	//  - quadlets
+	// summary: Get Quadlet file
+	// description: Get the content of an installed Quadlet file.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name of the Quadlet file
+	// produces:
+	// - text/plain
+	// responses:
+	//   200:
+	//     description: the content of the Quadlet file
+	//     schema:
+	//       type: string
+	//   400:
+	//     $ref: "#/responses/badParamError"
+	//   404:
+	//     $ref: "#/responses/quadletNotFound"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.Handle(VersionedPath("/libpod/quadlets/{name}/file"), s.APIHandler(libpod.GetQuadletFile)).Methods(http.MethodGet)
+	// swagger:operation DELETE /libpod/quadlets libpod QuadletDeleteLibpod
+	// ---
+	// tags:
+	//  - quadlets
+	// summary: Remove Quadlets
+	// description: Remove installed Quadlets and the files installed alongside them.
+	// parameters:
+	//  - in: query
+	//    name: quadlets
+	//    type: array
+	//    items:
+	//      type: string
+	//    description: names of the Quadlet files to remove
+	//  - in: query
+	//    name: all
+	//    type: boolean
+	//    default: false
+	//    description: Remove all installed Quadlets
+	//  - in: query
+	//    name: force
+	//    type: boolean
+	//    default: false
+	//    description: Stop the systemd services of the Quadlets before removing them
+	//  - in: query
+	//    name: ignore
+	//    type: boolean
+	//    default: false
+	//    description: Ignore Quadlets which are not installed
+	//  - in: query
+	//    name: reloadSystemd
+	//    type: boolean
+	//    default: true
+	//    description: Reload systemd after removing the Quadlets
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: "#/responses/quadletRemoveLibpod"
+	//   400:
+	//     $ref: "#/responses/badParamError"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.Handle(VersionedPath("/libpod/quadlets"), s.APIHandler(libpod.RemoveQuadlets)).Methods(http.MethodDelete)
+	return nil
+}
@@ -117,6 +117,63 @@ func (s *APIServer) registerSecretHandlers(r *mux.Router) error {
 	//   '500':
 	//     "$ref": "#/responses/internalError"
 	r.Handle(VersionedPath("/libpod/secrets/{name}/exists"), s.APIHandler(libpod.SecretExists)).Methods(http.MethodGet)
+	// swagger:operation POST /libpod/secrets/{name}/rekey libpod SecretRekeyLibpod
+	// ---
+	// tags:
+	//  - secrets
+	// summary: Rekey secret
+	// description: |
+	//   Encrypt the data of a secret stored with the file driver again. The given driver options are
+	//   merged into the existing ones, an empty value removes the option. The secret keeps its ID.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the secret
+	//  - in: query
+	//    name: driveropts
+	//    type: string
+	//    description: Secret driver options, e.g. encrypt, keyfile and keyenv
+	// produces:
+	// - application/json
+	// responses:
+	//   '200':
+	//     "$ref": "#/responses/SecretRekeyResponse"
+	//   '404':
+	//     "$ref": "#/responses/NoSuchSecret"
+	//   '500':
+	//     "$ref": "#/responses/internalError"
+	r.Handle(VersionedPath("/libpod/secrets/{name}/rekey"), s.APIHandler(libpod.RekeySecret)).Methods(http.MethodPost)
+	// swagger:operation POST /libpod/secrets/{name}/update libpod SecretUpdateLibpod
+	// ---
+	// tags:
+	//  - secrets
+	// summary: Update secret
+	// description: |
+	//   Store new data for a secret as a new version. The secret keeps its ID. Running containers
+	//   which mounted the secret with the rotate option get the new data and the configured signal.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the secret
+	//  - in: body
+	//    name: request
+	//    description: Secret
+	//    schema:
+	//      type: string
+	// produces:
+	// - application/json
+	// responses:
+	//   '200':
+	//     "$ref": "#/responses/SecretUpdateResponse"
+	//   '404':
+	//     "$ref": "#/responses/NoSuchSecret"
+	//   '500':
+	//     "$ref": "#/responses/internalError"
+	r.Handle(VersionedPath("/libpod/secrets/{name}/update"), s.APIHandler(libpod.UpdateSecret)).Methods(http.MethodPost)
 	// swagger:operation DELETE /libpod/secrets/{name} libpod SecretDeleteLibpod
 	// ---
 	// tags:
@@ -89,5 +89,55 @@ func (s *APIServer) registerSystemHandlers(r *mux.Router) error {
 	//   500:
 	//     $ref: "#/responses/internalError"
 	r.Handle(VersionedPath("/libpod/system/df"), s.APIHandler(libpod.DiskUsage)).Methods(http.MethodGet)
+	// swagger:operation POST /libpod/system/auto-update libpod SystemAutoUpdateLibpod
+	// ---
+	// tags:
+	//   - system
+	// summary: Auto-update containers
+	// description: |
+	//   Auto-update containers according to their auto-update policy and restart their systemd units.
+	//   The progress is streamed as JSON objects with a stream field, the last object contains the reports and errors.
+	// parameters:
+	//   - in: query
+	//     name: dryRun
+	//     type: boolean
+	//     description: Only check for pending updates
+	//   - in: query
+	//     name: recreate
+	//     type: boolean
+	//     default: false
+	//     description: Recreate containers which do not run in a systemd unit with the updated image
+	//   - in: query
+	//     name: requireSignatures
+	//     type: boolean
+	//     description: Refuse updates to images which are not signed according to the signature policy
+	//   - in: query
+	//     name: rollback
+	//     type: boolean
+	//     default: true
+	//     description: Roll back to the previous image if restarting a systemd unit with the new image fails
+	//   - in: query
+	//     name: soakPeriod
+	//     type: string
+	//     description: Update one unit or container per image first and the others once it stayed healthy for the soak period (e.g. "10m")
+	//   - in: query
+	//     name: tlsVerify
+	//     type: boolean
+	//     default: true
+	//     description: Require HTTPS and verify certificates when contacting registries.
+	//   - in: header
+	//     name: X-Registry-Auth
+	//     type: string
+	//     description: A base64-encoded auth configuration.
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: '#/responses/systemAutoUpdateResponse'
+	//   400:
+	//     $ref: "#/responses/badParamError"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.Handle(VersionedPath("/libpod/system/auto-update"), s.APIHandler(libpod.SystemAutoUpdate)).Methods(http.MethodPost)
 	return nil
 }
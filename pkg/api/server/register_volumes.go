@@ -147,6 +147,147 @@ func (s *APIServer) registerVolumeHandlers(r *mux.Router) error {
 	//   500:
 	//     $ref: "#/responses/internalError"
 	r.Handle(VersionedPath("/libpod/volumes/{name}"), s.APIHandler(libpod.RemoveVolume)).Methods(http.MethodDelete)
+	// swagger:operation POST /libpod/volumes/{name}/clone libpod VolumeCloneLibpod
+	// ---
+	// tags:
+	//  - volumes
+	// summary: Clone volume
+	// description: Create a new volume with the configuration and a copy of the data of a volume using the local driver, or of one of its snapshots.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the volume to clone
+	//  - in: query
+	//    name: name
+	//    type: string
+	//    description: the name of the new volume
+	//  - in: query
+	//    name: snapshot
+	//    type: string
+	//    description: clone the data of this snapshot instead of the current data of the volume
+	// produces:
+	// - application/json
+	// responses:
+	//   '201':
+	//     $ref: "#/responses/volumeCreateResponse"
+	//   400:
+	//     $ref: "#/responses/badParamError"
+	//   404:
+	//     $ref: "#/responses/volumeNotFound"
+	//   409:
+	//     $ref: "#/responses/conflictError"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.Handle(VersionedPath("/libpod/volumes/{name}/clone"), s.APIHandler(libpod.CloneVolume)).Methods(http.MethodPost)
+	// swagger:operation POST /libpod/volumes/{name}/snapshots libpod VolumeSnapshotCreateLibpod
+	// ---
+	// tags:
+	//  - volumes
+	// summary: Create volume snapshot
+	// description: Take a snapshot of the data of a volume using the local driver.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the volume
+	//  - in: query
+	//    name: name
+	//    type: string
+	//    description: the name of the snapshot, defaults to a name based on the current time
+	// produces:
+	// - application/json
+	// responses:
+	//   '201':
+	//     $ref: "#/responses/volumeSnapshotLibpod"
+	//   400:
+	//     $ref: "#/responses/badParamError"
+	//   404:
+	//     $ref: "#/responses/volumeNotFound"
+	//   409:
+	//     $ref: "#/responses/conflictError"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.Handle(VersionedPath("/libpod/volumes/{name}/snapshots"), s.APIHandler(libpod.CreateVolumeSnapshot)).Methods(http.MethodPost)
+	// swagger:operation GET /libpod/volumes/{name}/snapshots libpod VolumeSnapshotListLibpod
+	// ---
+	// tags:
+	//  - volumes
+	// summary: List volume snapshots
+	// description: List the snapshots of a volume, oldest first.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the volume
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: "#/responses/volumeSnapshotListLibpod"
+	//   404:
+	//     $ref: "#/responses/volumeNotFound"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.Handle(VersionedPath("/libpod/volumes/{name}/snapshots"), s.APIHandler(libpod.ListVolumeSnapshots)).Methods(http.MethodGet)
+	// swagger:operation POST /libpod/volumes/{name}/snapshots/{snapshot}/restore libpod VolumeSnapshotRestoreLibpod
+	// ---
+	// tags:
+	//  - volumes
+	// summary: Restore volume snapshot
+	// description: Replace the data of a volume with the data of one of its snapshots. The volume must not be used by a running container.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the volume
+	//  - in: path
+	//    name: snapshot
+	//    type: string
+	//    required: true
+	//    description: the name of the snapshot
+	// produces:
+	// - application/json
+	// responses:
+	//   204:
+	//     description: no error
+	//   404:
+	//     $ref: "#/responses/volumeNotFound"
+	//   409:
+	//     description: Volume is used by a running container
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.Handle(VersionedPath("/libpod/volumes/{name}/snapshots/{snapshot}/restore"), s.APIHandler(libpod.RestoreVolumeSnapshot)).Methods(http.MethodPost)
+	// swagger:operation DELETE /libpod/volumes/{name}/snapshots/{snapshot} libpod VolumeSnapshotDeleteLibpod
+	// ---
+	// tags:
+	//  - volumes
+	// summary: Remove volume snapshot
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the volume
+	//  - in: path
+	//    name: snapshot
+	//    type: string
+	//    required: true
+	//    description: the name of the snapshot
+	// produces:
+	// - application/json
+	// responses:
+	//   204:
+	//     description: no error
+	//   404:
+	//     $ref: "#/responses/volumeNotFound"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.Handle(VersionedPath("/libpod/volumes/{name}/snapshots/{snapshot}"), s.APIHandler(libpod.RemoveVolumeSnapshot)).Methods(http.MethodDelete)
 
 	/*
 	 * Docker compatibility endpoints
@@ -37,7 +37,11 @@ type APIServer struct {
 	context.Context                  // Context to carry objects to handlers
 	CorsHeaders        string        // Inject Cross-Origin Resource Sharing (CORS) headers
 	PProfAddr          string        // Binding network address for pprof profiles
+	MetricsAddr        string        // Binding network address for Prometheus metrics
 	idleTracker        *idle.Tracker // Track connections to support idle shutdown
+	metrics            *apiMetrics   // Prometheus metrics, nil unless enabled
+	metricsServer      *http.Server  // Serves metrics on MetricsAddr, nil unless enabled
+	cronJobCancel      func()        // Stops the CronJob schedules, nil unless run by the service
 }
 
 // Number of seconds to wait for next request, if exceeded shutdown server
@@ -87,9 +91,25 @@ func newServer(runtime *libpod.Runtime, listener net.Listener, opts entities.Ser
 		CorsHeaders: opts.CorsHeaders,
 		Listener:    listener,
 		PProfAddr:   opts.PProfAddr,
+		MetricsAddr: opts.MetricsAddr,
 		idleTracker: tracker,
 	}
 
+	if opts.MetricsAddr != "" {
+		addr, err := metricsListenAddr(opts.MetricsAddr)
+		if err != nil {
+			return nil, err
+		}
+		server.MetricsAddr = addr
+	}
+	if opts.Metrics || opts.MetricsAddr != "" {
+		metrics, err := newAPIMetrics(newLibpodCollector(runtime))
+		if err != nil {
+			return nil, err
+		}
+		server.metrics = metrics
+	}
+
 	server.BaseContext = func(l net.Listener) context.Context {
 		ctx := context.WithValue(context.Background(), types.DecoderKey, handlers.NewAPIDecoder())
 		ctx = context.WithValue(ctx, types.CompatDecoderKey, handlers.NewCompatAPIDecoder())
@@ -101,6 +121,9 @@ func newServer(runtime *libpod.Runtime, listener net.Listener, opts entities.Ser
 	// Capture panics and print stack traces for diagnostics,
 	// additionally process X-Reference-Id Header to support event correlation
 	router.Use(panicHandler(), referenceIDHandler())
+	if server.metrics != nil {
+		router.Use(metricsHandler(server.metrics))
+	}
 	router.NotFoundHandler = http.HandlerFunc(
 		func(w http.ResponseWriter, r *http.Request) {
 			// We can track user errors...
@@ -120,6 +143,7 @@ func newServer(runtime *libpod.Runtime, listener net.Listener, opts entities.Ser
 	for _, fn := range []func(*mux.Router) error{
 		server.registerAuthHandlers,
 		server.registerArchiveHandlers,
+		server.registerArtifactHandlers,
 		server.registerContainersHandlers,
 		server.registerDistributionHandlers,
 		server.registerEventsHandlers,
@@ -129,12 +153,14 @@ func newServer(runtime *libpod.Runtime, listener net.Listener, opts entities.Ser
 		server.registerImagesHandlers,
 		server.registerInfoHandlers,
 		server.registerManifestHandlers,
+		server.registerMetricsHandlers,
 		server.registerMonitorHandlers,
 		server.registerNetworkHandlers,
 		server.registerPingHandlers,
 		server.registerKubeHandlers,
 		server.registerPluginsHandlers,
 		server.registerPodsHandlers,
+		server.registerQuadletHandlers,
 		server.registerSecretHandlers,
 		server.registerSwaggerHandlers,
 		server.registerSwarmHandlers,
@@ -195,6 +221,8 @@ func (s *APIServer) setupSystemd() {
 // Serve starts responding to HTTP requests.
 func (s *APIServer) Serve() error {
 	s.setupPprof()
+	s.setupMetrics()
+	s.setupCronJobs()
 
 	if err := shutdown.Register("service", func(sig os.Signal) error {
 		err := s.Shutdown(true)
@@ -284,10 +312,18 @@ func (s *APIServer) Shutdown(halt bool) error {
 		if s.idleTracker.Duration > 0 {
 			deadline = s.idleTracker.Duration
 		}
+		if s.cronJobCancel != nil {
+			s.cronJobCancel()
+		}
 		ctx, cancel := context.WithTimeout(context.Background(), deadline)
 		go func() {
 			defer cancel()
 
+			if s.metricsServer != nil {
+				if err := s.metricsServer.Shutdown(ctx); err != nil && err != context.Canceled && err != http.ErrServerClosed {
+					logrus.Error("Failed to cleanly shutdown metrics service: " + err.Error())
+				}
+			}
 			err := s.Server.Shutdown(ctx)
 			if err != nil && err != context.Canceled && err != http.ErrServerClosed {
 				logrus.Error("Failed to cleanly shutdown API service: " + err.Error())
@@ -300,5 +336,13 @@ func (s *APIServer) Shutdown(halt bool) error {
 
 // Close immediately stops responding to clients and exits
 func (s *APIServer) Close() error {
+	if s.cronJobCancel != nil {
+		s.cronJobCancel()
+	}
+	if s.metricsServer != nil {
+		if err := s.metricsServer.Close(); err != nil {
+			logrus.Errorf("Failed to close metrics service: %v", err)
+		}
+	}
 	return s.Server.Close()
 }
@@ -8,10 +8,13 @@ import (
 	"fmt"
 	"os"
 	"sort"
+	"strings"
 
+	"github.com/blang/semver/v4"
 	"github.com/containers/common/libimage"
 	"github.com/containers/common/pkg/config"
 	"github.com/containers/image/v5/docker"
+	"github.com/containers/image/v5/types"
 	"github.com/containers/podman/v5/libpod"
 	"github.com/containers/podman/v5/libpod/define"
 	"github.com/containers/podman/v5/libpod/events"
@@ -19,6 +22,7 @@ import (
 	"github.com/containers/podman/v5/pkg/systemd"
 	systemdDefine "github.com/containers/podman/v5/pkg/systemd/define"
 	"github.com/coreos/go-systemd/v22/dbus"
+	"github.com/opencontainers/go-digest"
 	"github.com/sirupsen/logrus"
 )
 
@@ -32,6 +36,10 @@ const (
 	PolicyRegistryImage = "registry"
 	// PolicyLocalImage is the policy to run auto-update based on a local image
 	PolicyLocalImage = "local"
+	// PolicyRegistrySemver is the policy to update to the image of the
+	// highest tag on the registry matching a version constraint, which is
+	// appended to the policy (e.g., "registry-semver:~1.4").
+	PolicyRegistrySemver = "registry-semver"
 )
 
 // Map for easy lookups of supported policies.
@@ -48,6 +56,7 @@ type updater struct {
 	conn             *dbus.Conn                  // DBUS connection
 	options          *entities.AutoUpdateOptions // User-specified options
 	unitToTasks      map[string][]*task          // Keeps track of tasks per unit
+	recreateTasks    []*task                     // Tasks of containers not running in a systemd unit
 	updatedRawImages map[string]bool             // Keeps track of updated images
 	runtime          *libpod.Runtime             // The libpod runtime
 }
@@ -62,14 +71,19 @@ const (
 
 // task includes data and state for updating a container
 type task struct {
-	authfile     string            // Container-specific authfile
-	auto         *updater          // Reverse pointer to the updater
-	container    *libpod.Container // Container to update
-	policy       Policy            // Update policy
-	image        *libimage.Image   // Original image before the update
-	rawImageName string            // The container's raw image name
-	status       string            // Auto-update status
-	unit         string            // Name of the systemd unit
+	authfile         string            // Container-specific authfile
+	auto             *updater          // Reverse pointer to the updater
+	container        *libpod.Container // Container to update
+	policy           Policy            // Update policy
+	restarted        bool              // The unit or container has been restarted with the update
+	image            *libimage.Image   // Original image before the update
+	rawImageName     string            // The container's raw image name
+	semverConstraint semver.Range      // Version constraint of PolicyRegistrySemver
+	stage            string            // Stage of a staged rollout
+	status           string            // Auto-update status
+	unit             string            // Name of the systemd unit
+	updateImageName  string            // Image chosen by PolicyRegistrySemver
+	verifiedDigest   digest.Digest     // Digest of the image with verified signatures
 }
 
 // LookupPolicy looks up the corresponding Policy for the specified
@@ -82,6 +96,12 @@ func LookupPolicy(s string) (Policy, error) {
 	if exists {
 		return policy, nil
 	}
+	if constraint, ok := strings.CutPrefix(s, PolicyRegistrySemver+":"); ok {
+		if _, err := parseSemverConstraint(constraint); err != nil {
+			return "", fmt.Errorf("invalid version constraint of auto-update policy %q: %w", s, err)
+		}
+		return PolicyRegistrySemver, nil
+	}
 
 	// Sort the keys first as maps are non-deterministic.
 	keys := []string{}
@@ -90,6 +110,7 @@ func LookupPolicy(s string) (Policy, error) {
 			keys = append(keys, k)
 		}
 	}
+	keys = append(keys, PolicyRegistrySemver+":CONSTRAINT")
 	sort.Strings(keys)
 
 	return "", fmt.Errorf("invalid auto-update policy %q: valid policies are %+q", s, keys)
@@ -107,6 +128,15 @@ func LookupPolicy(s string) (Policy, error) {
 // of a running container is different than the local one. If the image digests
 // differ, it restarts the systemd unit with the new image.
 //
+// If options.Recreate is set, containers not running in a systemd unit are
+// recreated with the new image instead, keeping their configuration.  If the
+// recreated container fails to start or to pass its healthcheck, the previous
+// container is restored.  Otherwise, such containers cannot be updated.
+//
+// If a soak period is set, the update is rolled out in stages: one unit or
+// container per image is updated first and the others follow once it stayed
+// healthy for the soak period.
+//
 // It returns a slice of successfully restarted systemd units and a slice of
 // errors encountered during auto update.
 func AutoUpdate(ctx context.Context, runtime *libpod.Runtime, options entities.AutoUpdateOptions) ([]*entities.AutoUpdateReport, []error) {
@@ -124,31 +154,45 @@ func AutoUpdate(ctx context.Context, runtime *libpod.Runtime, options entities.A
 	allErrors := auto.assembleTasks(ctx)
 
 	// Nothing to do.
-	if len(auto.unitToTasks) == 0 {
+	if len(auto.unitToTasks) == 0 && len(auto.recreateTasks) == 0 {
 		return nil, allErrors
 	}
 
 	// Connect to DBUS.
-	conn, err := systemd.ConnectToDBUS()
-	if err != nil {
-		logrus.Error(err.Error())
-		allErrors = append(allErrors, err)
-		return nil, allErrors
+	if len(auto.unitToTasks) > 0 {
+		conn, err := systemd.ConnectToDBUS()
+		if err != nil {
+			logrus.Error(err.Error())
+			allErrors = append(allErrors, err)
+			return nil, allErrors
+		}
+		defer conn.Close()
+		auto.conn = conn
 	}
-	defer conn.Close()
-	auto.conn = conn
 
 	runtime.NewSystemEvent(events.AutoUpdate)
 
 	// Update all images/container according to their auto-update policy.
+	if options.SoakPeriod > 0 && !options.DryRun {
+		allErrors = append(allErrors, auto.stagedUpdate(ctx)...)
+	} else {
+		for unit, tasks := range auto.unitToTasks {
+			allErrors = append(allErrors, auto.updateUnit(ctx, unit, tasks)...)
+		}
+		for _, task := range auto.recreateTasks {
+			allErrors = append(allErrors, auto.updateContainer(ctx, task)...)
+		}
+	}
+
 	var allReports []*entities.AutoUpdateReport
-	for unit, tasks := range auto.unitToTasks {
-		unitErrors := auto.updateUnit(ctx, unit, tasks)
-		allErrors = append(allErrors, unitErrors...)
+	for _, tasks := range auto.unitToTasks {
 		for _, task := range tasks {
 			allReports = append(allReports, task.report())
 		}
 	}
+	for _, task := range auto.recreateTasks {
+		allReports = append(allReports, task.report())
+	}
 
 	return allReports, allErrors
 }
@@ -158,6 +202,7 @@ func (u *updater) updateUnit(ctx context.Context, unit string, tasks []*task) []
 	var errors []error
 	tasksUpdated := false
 
+	u.progressf("Checking %s for updates", unit)
 	for _, task := range tasks {
 		err := func() error { // Use an anonymous function to avoid spaghetti continue's
 			updateAvailable, err := task.updateAvailable(ctx)
@@ -168,10 +213,18 @@ func (u *updater) updateUnit(ctx context.Context, unit string, tasks []*task) []
 
 			if !updateAvailable {
 				task.status = statusNotUpdated
-				return nil
+				return task.recordTag(task.container)
+			}
+
+			if u.options.RequireSignatures {
+				if err := task.verifySignatures(ctx); err != nil {
+					task.status = statusFailed
+					return fmt.Errorf("verifying image update for container %s: %w", task.container.ID(), err)
+				}
 			}
 
 			if u.options.DryRun {
+				u.progressf("Update of container %s (%s) in %s%s is pending", task.container.ID(), task.container.Name(), unit, task.updateImageSuffix())
 				task.status = statusPending
 				return nil
 			}
@@ -195,8 +248,10 @@ func (u *updater) updateUnit(ctx context.Context, unit string, tasks []*task) []
 		return errors
 	}
 
+	u.progressf("Restarting %s", unit)
 	updateError := u.restartSystemdUnit(ctx, unit)
 	for _, task := range tasks {
+		task.restarted = true
 		if updateError == nil {
 			task.status = statusUpdated
 		} else {
@@ -208,11 +263,22 @@ func (u *updater) updateUnit(ctx context.Context, unit string, tasks []*task) []
 	if updateError == nil || !u.options.Rollback {
 		if updateError != nil {
 			errors = append(errors, fmt.Errorf("restarting unit %s during update: %w", unit, updateError))
+		} else {
+			u.progressf("Updated %s", unit)
+			errors = append(errors, u.recordUnitTags(unit, tasks)...)
 		}
 		return errors
 	}
 
 	// The update has failed and rollbacks are enabled.
+	u.progressf("Restarting %s failed, rolling back", unit)
+	return append(errors, u.rollbackUnit(ctx, unit, tasks)...)
+}
+
+// rollbackUnit rolls back the images of the tasks in the specified systemd
+// unit and restarts the unit.
+func (u *updater) rollbackUnit(ctx context.Context, unit string, tasks []*task) []error {
+	var errors []error
 	for _, task := range tasks {
 		if err := task.rollbackImage(); err != nil {
 			err = fmt.Errorf("rolling back image for container %s in unit %s: %w", task.container.ID(), unit, err)
@@ -232,20 +298,52 @@ func (u *updater) updateUnit(ctx context.Context, unit string, tasks []*task) []
 	for _, task := range tasks {
 		task.status = statusRolledBack
 	}
+	u.progressf("Rolled back %s", unit)
 
 	return errors
 }
 
+// progressf writes a progress message to the user-specified progress writer,
+// if any.
+func (u *updater) progressf(format string, args ...any) {
+	if u.options.ProgressWriter == nil {
+		return
+	}
+	fmt.Fprintf(u.options.ProgressWriter, format+"\n", args...)
+}
+
 // report creates an auto-update report for the task.
 func (t *task) report() *entities.AutoUpdateReport {
-	return &entities.AutoUpdateReport{
+	report := &entities.AutoUpdateReport{
 		ContainerID:   t.container.ID(),
 		ContainerName: t.container.Name(),
 		ImageName:     t.container.RawImageName(),
 		Policy:        string(t.policy),
 		SystemdUnit:   t.unit,
 		Updated:       t.status,
+		Stage:         t.stage,
+	}
+	if t.updateImageName != t.rawImageName {
+		report.NewImageName = t.updateImageName
 	}
+	return report
+}
+
+// imageName returns the name of the image to update the container to.
+func (t *task) imageName() string {
+	if t.updateImageName != "" {
+		return t.updateImageName
+	}
+	return t.rawImageName
+}
+
+// updateImageSuffix returns a suffix for progress messages naming the image
+// chosen by PolicyRegistrySemver, if it differs from the container's image.
+func (t *task) updateImageSuffix() string {
+	if t.updateImageName == "" || t.updateImageName == t.rawImageName {
+		return ""
+	}
+	return " to " + t.updateImageName
 }
 
 // updateAvailable returns whether an update for the task is available.
@@ -253,6 +351,8 @@ func (t *task) updateAvailable(ctx context.Context) (bool, error) {
 	switch t.policy {
 	case PolicyRegistryImage:
 		return t.registryUpdateAvailable(ctx)
+	case PolicyRegistrySemver:
+		return t.semverUpdateAvailable(ctx)
 	case PolicyLocalImage:
 		return t.localUpdateAvailable()
 	default:
@@ -265,6 +365,8 @@ func (t *task) update(ctx context.Context) error {
 	switch t.policy {
 	case PolicyRegistryImage:
 		return t.registryUpdate(ctx)
+	case PolicyRegistrySemver:
+		return t.semverUpdate(ctx)
 	case PolicyLocalImage:
 		// Nothing to do as the image is already available in the local storage.
 		return nil
@@ -292,25 +394,60 @@ func (t *task) registryUpdateAvailable(ctx context.Context) (bool, error) {
 	return t.image.HasDifferentDigest(ctx, remoteRef, options)
 }
 
-// registryUpdate pulls down the image from the registry.
+// registryUpdate pulls down the image from the registry.  If its signatures
+// were verified, the verified digest is pulled and tagged with the image name.
 func (t *task) registryUpdate(ctx context.Context) error {
+	imageName := t.imageName()
 	// The newer image has already been pulled for another task.
-	if _, exists := t.auto.updatedRawImages[t.rawImageName]; exists {
+	if _, exists := t.auto.updatedRawImages[imageName]; exists {
 		return nil
 	}
 
 	pullOptions := &libimage.PullOptions{}
 	pullOptions.AuthFilePath = t.authfile
 	pullOptions.Writer = os.Stderr
+	if t.auto.options.ProgressWriter != nil {
+		pullOptions.Writer = t.auto.options.ProgressWriter
+	}
 	pullOptions.InsecureSkipTLSVerify = t.auto.options.InsecureSkipTLSVerify
-	if _, err := t.auto.runtime.LibimageRuntime().Pull(ctx, t.rawImageName, config.PullPolicyAlways, pullOptions); err != nil {
-		return err
+	if t.verifiedDigest == "" {
+		if _, err := t.auto.runtime.LibimageRuntime().Pull(ctx, imageName, config.PullPolicyAlways, pullOptions); err != nil {
+			return err
+		}
+	} else {
+		verifiedName, err := t.verifiedImageName()
+		if err != nil {
+			return err
+		}
+		pulled, err := t.auto.runtime.LibimageRuntime().Pull(ctx, verifiedName, config.PullPolicyAlways, pullOptions)
+		if err != nil {
+			return err
+		}
+		if len(pulled) != 1 {
+			return fmt.Errorf("pulling %s: expected one image but got %d", verifiedName, len(pulled))
+		}
+		if err := pulled[0].Tag(imageName); err != nil {
+			return err
+		}
 	}
 
-	t.auto.updatedRawImages[t.rawImageName] = true
+	t.auto.updatedRawImages[imageName] = true
 	return nil
 }
 
+// systemContext returns the system context for accessing the registry of the
+// task's image.
+func (t *task) systemContext() *types.SystemContext {
+	sys := t.auto.runtime.LibimageRuntime().SystemContext()
+	if t.authfile != "" {
+		sys.AuthFilePath = t.authfile
+	}
+	if s := t.auto.options.InsecureSkipTLSVerify; s != types.OptionalBoolUndefined {
+		sys.DockerInsecureSkipTLSVerify = s
+	}
+	return sys
+}
+
 // localUpdateAvailable returns whether a new image in the local storage is available.
 func (t *task) localUpdateAvailable() (bool, error) {
 	localImg, _, err := t.auto.runtime.LibimageRuntime().LookupImage(t.rawImageName, nil)
@@ -399,15 +536,21 @@ func (u *updater) assembleTasks(ctx context.Context) []error {
 		if policy == PolicyDefault {
 			continue
 		}
+		var constraint semver.Range
+		if policy == PolicyRegistrySemver {
+			// The constraint has already been validated by LookupPolicy.
+			constraint, _ = parseSemverConstraint(strings.TrimPrefix(value, PolicyRegistrySemver+":"))
+		}
 
-		// Make sure the container runs in a systemd unit which is
-		// stored as a label at container creation.
-		unit, exists, err := u.systemdUnitForContainer(ctr, labels)
+		// Check if the container runs in a systemd unit which is
+		// stored as a label at container creation.  Containers which
+		// do not are only recreated if requested.
+		unit, hasUnit, err := u.systemdUnitForContainer(ctr, labels)
 		if err != nil {
 			errs = append(errs, err)
 			continue
 		}
-		if !exists {
+		if !hasUnit && !u.options.Recreate {
 			errs = append(errs, fmt.Errorf("auto-updating container %q: no %s label found", ctr.ID(), systemdDefine.EnvVariable))
 			continue
 		}
@@ -441,9 +584,15 @@ func (u *updater) assembleTasks(ctx context.Context) []error {
 			unit:         unit,
 			rawImageName: rawImageName,
 			status:       statusFailed, // must be updated later on
+
+			semverConstraint: constraint,
 		}
 
 		// Add the task to the unit.
+		if !hasUnit {
+			u.recreateTasks = append(u.recreateTasks, &t)
+			continue
+		}
 		u.unitToTasks[unit] = append(u.unitToTasks[unit], &t)
 	}
 
@@ -0,0 +1,260 @@
+//go:build !remote
+
+package autoupdate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/containers/podman/v5/libpod"
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/pkg/specgen"
+	"github.com/containers/podman/v5/pkg/specgen/generate"
+	"github.com/sirupsen/logrus"
+)
+
+// updateContainer auto updates the task of a container which does not run in
+// a systemd unit by recreating the container with the updated image.
+func (u *updater) updateContainer(ctx context.Context, task *task) []error {
+	updateAvailable, err := task.updateAvailable(ctx)
+	if err != nil {
+		task.status = statusFailed
+		return []error{fmt.Errorf("checking image updates for container %s: %w", task.container.ID(), err)}
+	}
+
+	if !updateAvailable {
+		task.status = statusNotUpdated
+		if err := task.recordTag(task.container); err != nil {
+			return []error{err}
+		}
+		return nil
+	}
+
+	if u.options.RequireSignatures {
+		if err := task.verifySignatures(ctx); err != nil {
+			task.status = statusFailed
+			return []error{fmt.Errorf("verifying image update for container %s: %w", task.container.ID(), err)}
+		}
+	}
+
+	if u.options.DryRun {
+		u.progressf("Update of container %s (%s)%s is pending", task.container.ID(), task.container.Name(), task.updateImageSuffix())
+		task.status = statusPending
+		return nil
+	}
+
+	if err := task.update(ctx); err != nil {
+		task.status = statusFailed
+		return []error{fmt.Errorf("updating image for container %s: %w", task.container.ID(), err)}
+	}
+
+	name := task.container.Name()
+	u.progressf("Recreating container %s (%s)", task.container.ID(), name)
+	task.restarted = true
+	newCtr, previous, updateError := task.recreateContainer(ctx)
+	if updateError == nil {
+		task.status = statusUpdated
+		u.progressf("Updated container %s", name)
+		return nil
+	}
+
+	task.status = statusFailed
+	updateError = fmt.Errorf("recreating container %s during update: %w", task.container.ID(), updateError)
+
+	// The previous container has already been restored if the new one
+	// could not even be created.  Otherwise, keep the new container for
+	// inspection if rollbacks are disabled.
+	if newCtr == nil || !u.options.Rollback {
+		return []error{updateError}
+	}
+
+	// The update has failed and rollbacks are enabled.
+	u.progressf("Recreating container %s failed, rolling back", name)
+	errors := []error{updateError}
+	if err := task.rollbackImage(); err != nil {
+		errors = append(errors, fmt.Errorf("rolling back image for container %s: %w", task.container.ID(), err))
+	}
+	if err := u.runtime.RemoveContainer(ctx, newCtr, true, false, nil); err != nil {
+		return append(errors, fmt.Errorf("removing container %s during rollback: %w", newCtr.ID(), err))
+	}
+	if err := restoreContainer(ctx, u.runtime, previous, name); err != nil {
+		return append(errors, fmt.Errorf("restoring container %s during rollback: %w", task.container.ID(), err))
+	}
+
+	task.status = statusRolledBack
+	u.progressf("Rolled back container %s", name)
+	return errors
+}
+
+// recreateContainer replaces the task's container with a new one created from
+// the same configuration and the updated image.  The previous container is
+// stopped and renamed, and removed once the new container is running and
+// healthy.  If the new container cannot be created, the previous container
+// is restored and no container is returned.  Otherwise, the new and the
+// renamed previous container are returned, also on failure to start the new
+// container or to pass its healthcheck.
+func (t *task) recreateContainer(ctx context.Context) (*libpod.Container, *libpod.Container, error) {
+	ctr := t.container
+	runtime := t.auto.runtime
+
+	if ctr.IsInfra() {
+		return nil, nil, fmt.Errorf("infra container of pod %s cannot be recreated", ctr.PodID())
+	}
+	// Containers sharing a namespace with the container would lose it.
+	allContainers, err := runtime.GetAllContainers()
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, c := range allContainers {
+		if slices.Contains(c.Dependencies(), ctr.ID()) {
+			return nil, nil, fmt.Errorf("container %s depends on it: %w", c.ID(), define.ErrDepExists)
+		}
+	}
+
+	imageName := t.imageName()
+	spec := specgen.NewSpecGenerator(imageName, false)
+	if _, _, err := generate.ConfigToSpec(runtime, spec, ctr.ID()); err != nil {
+		return nil, nil, err
+	}
+	name := ctr.Name()
+	spec.Name = name
+	spec.Image = imageName
+	spec.RawImageName = imageName
+	if err := t.removeImageDefaults(ctx, spec); err != nil {
+		return nil, nil, err
+	}
+	warnings, err := generate.CompleteSpec(ctx, runtime, spec)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, w := range warnings {
+		logrus.Warnf("Recreating container %s: %s", ctr.ID(), w)
+	}
+	// If we do not pass term, running containers exit.
+	terminal := ctr.Terminal()
+	spec.Terminal = &terminal
+
+	if err := ctr.Stop(); err != nil {
+		return nil, nil, fmt.Errorf("stopping container: %w", err)
+	}
+	// Free the name for the new container but keep the previous one
+	// around for rollbacks.
+	previous, err := runtime.RenameContainer(ctx, ctr, fmt.Sprintf("%s-%.12s", name, ctr.ID()))
+	if err != nil {
+		if startErr := ctr.Start(ctx, true); startErr != nil {
+			logrus.Errorf("Restarting container %s: %v", ctr.ID(), startErr)
+		}
+		return nil, nil, fmt.Errorf("renaming container: %w", err)
+	}
+
+	newCtr, err := func() (*libpod.Container, error) {
+		rtSpec, spec, opts, err := generate.MakeContainer(ctx, runtime, spec, true, previous)
+		if err != nil {
+			return nil, err
+		}
+		return generate.ExecuteCreate(ctx, runtime, rtSpec, spec, false, opts...)
+	}()
+	if err != nil {
+		if restoreErr := restoreContainer(ctx, runtime, previous, name); restoreErr != nil {
+			logrus.Errorf("Restoring container %s: %v", ctr.ID(), restoreErr)
+		}
+		return nil, nil, fmt.Errorf("creating container: %w", err)
+	}
+	if err := t.recordTag(newCtr); err != nil {
+		return newCtr, previous, err
+	}
+
+	if err := newCtr.Start(ctx, true); err != nil {
+		return newCtr, previous, fmt.Errorf("starting container %s: %w", newCtr.ID(), err)
+	}
+	if err := waitHealthy(ctx, runtime, newCtr); err != nil {
+		return newCtr, previous, err
+	}
+
+	if err := runtime.RemoveContainer(ctx, previous, false, false, nil); err != nil {
+		logrus.Errorf("Removing container %s after update: %v", previous.ID(), err)
+	}
+	return newCtr, previous, nil
+}
+
+// restoreContainer gives the previous container its name back and starts it
+// again.
+func restoreContainer(ctx context.Context, runtime *libpod.Runtime, previous *libpod.Container, name string) error {
+	restored, err := runtime.RenameContainer(ctx, previous, name)
+	if err != nil {
+		return err
+	}
+	return restored.Start(ctx, true)
+}
+
+// removeImageDefaults removes settings from the spec which the container
+// inherited from its previous image, so the defaults of the updated image
+// apply to the new container.
+func (t *task) removeImageDefaults(ctx context.Context, spec *specgen.SpecGenerator) error {
+	data, err := t.image.Inspect(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if data.Config == nil {
+		return nil
+	}
+	for _, env := range data.Config.Env {
+		key, value, _ := strings.Cut(env, "=")
+		if v, ok := spec.Env[key]; ok && v == value {
+			delete(spec.Env, key)
+		}
+	}
+	for key, value := range data.Config.Labels {
+		if v, ok := spec.Labels[key]; ok && v == value {
+			delete(spec.Labels, key)
+		}
+	}
+	if spec.User == data.Config.User {
+		spec.User = ""
+	}
+	return nil
+}
+
+// waitHealthy waits for the container to pass its healthcheck.  Containers
+// without a healthcheck must still be running.
+func waitHealthy(ctx context.Context, runtime *libpod.Runtime, ctr *libpod.Container) error {
+	hc := ctr.HealthCheckConfig()
+	if hc == nil {
+		state, err := ctr.State()
+		if err != nil {
+			return err
+		}
+		if state != define.ContainerStateRunning {
+			return fmt.Errorf("container %s is %s after starting it", ctr.ID(), state)
+		}
+		return nil
+	}
+
+	interval := hc.Interval
+	if interval <= 0 {
+		interval, _ = time.ParseDuration(define.DefaultHealthCheckInterval)
+	}
+	retries := max(hc.Retries, 1)
+	deadline := time.Now().Add(hc.StartPeriod + time.Duration(retries)*interval)
+	for {
+		status, err := runtime.HealthCheck(ctx, ctr.ID())
+		if status == define.HealthCheckSuccess {
+			return nil
+		}
+		if status == define.HealthCheckContainerStopped || time.Now().After(deadline) {
+			if err == nil {
+				err = errors.New("healthcheck failed")
+			}
+			return fmt.Errorf("container %s is not healthy: %w", ctr.ID(), err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
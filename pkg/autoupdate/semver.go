@@ -0,0 +1,217 @@
+//go:build !remote
+
+package autoupdate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	"github.com/containers/common/libimage"
+	"github.com/containers/image/v5/docker"
+	"github.com/containers/image/v5/docker/reference"
+	"github.com/containers/podman/v5/libpod"
+	"github.com/containers/podman/v5/libpod/define"
+)
+
+// parseSemverConstraint parses the version constraint of the registry-semver
+// policy.  A tilde allows patch updates (e.g., ~1.4 matches 1.4.x), a caret
+// allows minor updates (e.g., ^1.4 matches 1.x.y from 1.4.0 on).  Other
+// constraints are parsed as ranges of github.com/blang/semver such as
+// ">=1.4.0 <2.0.0".
+func parseSemverConstraint(constraint string) (semver.Range, error) {
+	var upper semver.Version
+	switch {
+	case strings.HasPrefix(constraint, "~"):
+		lower, err := semver.ParseTolerant(constraint[1:])
+		if err != nil {
+			return nil, err
+		}
+		upper = semver.Version{Major: lower.Major, Minor: lower.Minor + 1}
+		// Without a minor version, ~1 is the same as ^1.
+		if !strings.Contains(constraint, ".") {
+			upper = semver.Version{Major: lower.Major + 1}
+		}
+		return semver.ParseRange(fmt.Sprintf(">=%s <%s", lower, upper))
+	case strings.HasPrefix(constraint, "^"):
+		lower, err := semver.ParseTolerant(constraint[1:])
+		if err != nil {
+			return nil, err
+		}
+		upper = semver.Version{Major: lower.Major + 1}
+		// Minor versions of 0.x releases may be incompatible.
+		if lower.Major == 0 && strings.Contains(constraint, ".") {
+			upper = semver.Version{Minor: lower.Minor + 1}
+		}
+		return semver.ParseRange(fmt.Sprintf(">=%s <%s", lower, upper))
+	default:
+		return semver.ParseRange(constraint)
+	}
+}
+
+// parseSemverTag parses an image tag as a semantic version.  Tags may be
+// prefixed with "v" and omit the minor and patch versions.  Pre-releases are
+// not considered as versions.
+func parseSemverTag(tag string) (semver.Version, bool) {
+	version, err := semver.ParseTolerant(tag)
+	if err != nil || len(version.Pre) > 0 {
+		return semver.Version{}, false
+	}
+	return version, true
+}
+
+// highestSemverTag returns the tag with the highest version matching the
+// constraint.  Tags lower than the current tag are ignored, so containers are
+// never downgraded.  If multiple tags denote the same version (e.g., 1.4 and
+// 1.4.0), the most specific one is returned.
+func highestSemverTag(tags []string, constraint semver.Range, current string) (string, bool) {
+	var (
+		highestTag     string
+		highestVersion semver.Version
+	)
+	floor, hasFloor := parseSemverTag(current)
+	for _, tag := range tags {
+		version, ok := parseSemverTag(tag)
+		if !ok || !constraint(version) || (hasFloor && version.LT(floor)) {
+			continue
+		}
+		if highestTag != "" {
+			cmp := version.Compare(highestVersion)
+			if cmp < 0 || (cmp == 0 && strings.Count(tag, ".") <= strings.Count(highestTag, ".")) {
+				continue
+			}
+		}
+		highestTag = tag
+		highestVersion = version
+	}
+	return highestTag, highestTag != ""
+}
+
+// semverUpdateAvailable looks up the highest tag of the image's repository
+// matching the constraint of the registry-semver policy and returns whether
+// its image differs from the local one.
+func (t *task) semverUpdateAvailable(ctx context.Context) (bool, error) {
+	named, err := reference.ParseNormalizedNamed(strings.TrimPrefix(t.rawImageName, "docker://"))
+	if err != nil {
+		return false, err
+	}
+	var current string
+	if tagged, ok := named.(reference.NamedTagged); ok {
+		current = tagged.Tag()
+	}
+	repo := reference.TrimNamed(named)
+	repoRef, err := docker.NewReference(reference.TagNameOnly(repo))
+	if err != nil {
+		return false, err
+	}
+
+	tags, err := docker.GetRepositoryTags(ctx, t.systemContext(), repoRef)
+	if err != nil {
+		return false, fmt.Errorf("listing tags of %s: %w", repo.Name(), err)
+	}
+	tag, found := highestSemverTag(tags, t.semverConstraint, current)
+	if !found {
+		return false, fmt.Errorf("no tag of %s matches the version constraint of the auto-update policy", repo.Name())
+	}
+	t.updateImageName = repo.Name() + ":" + tag
+
+	// The newer image has already been pulled for another task.
+	if _, exists := t.auto.updatedRawImages[t.updateImageName]; exists {
+		return true, nil
+	}
+
+	remoteRef, err := docker.ParseReference("//" + t.updateImageName)
+	if err != nil {
+		return false, err
+	}
+	options := &libimage.HasDifferentDigestOptions{
+		AuthFilePath:          t.authfile,
+		InsecureSkipTLSVerify: t.auto.options.InsecureSkipTLSVerify,
+	}
+	return t.image.HasDifferentDigest(ctx, remoteRef, options)
+}
+
+// semverUpdate pulls the image chosen by the registry-semver policy.  Systemd
+// units create their containers from the image reference in the unit, so the
+// image is tagged with the container's image name as well for them.  This is
+// a deliberate trade-off: the local tag (e.g., repo:1.4) then names the image
+// of the chosen tag (e.g., repo:1.4.7) instead of the image the registry
+// serves for it, which is the only way to run the update without changing the
+// unit.  The chosen tag is recorded in the state of the unit's containers
+// after the restart, see recordUnitTags().
+func (t *task) semverUpdate(ctx context.Context) error {
+	if err := t.registryUpdate(ctx); err != nil {
+		return err
+	}
+	if t.unit == "" || t.updateImageName == t.rawImageName {
+		return nil
+	}
+	image, _, err := t.auto.runtime.LibimageRuntime().LookupImage(t.updateImageName, nil)
+	if err != nil {
+		return err
+	}
+	return image.Tag(t.rawImageName)
+}
+
+// updateTag returns the tag chosen by the registry-semver policy.
+func (t *task) updateTag() string {
+	named, err := reference.ParseNormalizedNamed(t.updateImageName)
+	if err != nil {
+		return ""
+	}
+	if tagged, ok := named.(reference.NamedTagged); ok {
+		return tagged.Tag()
+	}
+	return ""
+}
+
+// recordTag records the tag chosen by the registry-semver policy in the state
+// of the container, so inspect shows it.  It is called for containers which
+// run the image of the tag, including containers a systemd unit created again
+// after an earlier update.
+func (t *task) recordTag(ctr *libpod.Container) error {
+	if t.policy != PolicyRegistrySemver || t.auto.options.DryRun {
+		return nil
+	}
+	tag := t.updateTag()
+	if tag == "" {
+		return nil
+	}
+	if err := ctr.SetAutoUpdateTag(tag); err != nil {
+		return fmt.Errorf("recording auto-update tag of container %s: %w", ctr.ID(), err)
+	}
+	return nil
+}
+
+// recordUnitTags records the tags chosen by the registry-semver policy on the
+// containers of the unit after it has been restarted.  The unit may have
+// created new containers, they are found by their unit and image name.
+func (u *updater) recordUnitTags(unit string, tasks []*task) []error {
+	if !slices.ContainsFunc(tasks, func(t *task) bool { return t.policy == PolicyRegistrySemver }) {
+		return nil
+	}
+	ctrs, err := u.runtime.GetAllContainers()
+	if err != nil {
+		return []error{err}
+	}
+	var errs []error
+	for _, ctr := range ctrs {
+		ctrUnit, hasUnit, err := u.systemdUnitForContainer(ctr, ctr.Labels())
+		if err != nil || !hasUnit || ctrUnit != unit {
+			continue
+		}
+		for _, t := range tasks {
+			if t.rawImageName != ctr.RawImageName() {
+				continue
+			}
+			if err := t.recordTag(ctr); err != nil && !errors.Is(err, define.ErrNoSuchCtr) && !errors.Is(err, define.ErrCtrRemoved) {
+				errs = append(errs, err)
+			}
+			break
+		}
+	}
+	return errs
+}
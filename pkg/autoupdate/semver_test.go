@@ -0,0 +1,63 @@
+//go:build !remote
+
+package autoupdate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSemverConstraint(t *testing.T) {
+	tests := []struct {
+		constraint string
+		matches    []string
+		mismatches []string
+	}{
+		{"~1.4", []string{"1.4.0", "1.4.9"}, []string{"1.3.9", "1.5.0", "2.0.0"}},
+		{"~1.4.2", []string{"1.4.2", "1.4.3"}, []string{"1.4.1", "1.5.0"}},
+		{"~1", []string{"1.0.0", "1.9.0"}, []string{"0.9.0", "2.0.0"}},
+		{"^1.4", []string{"1.4.0", "1.9.9"}, []string{"1.3.0", "2.0.0"}},
+		{"^0.4", []string{"0.4.0", "0.4.5"}, []string{"0.3.0", "0.5.0"}},
+		{">=1.4.0 <2.0.0", []string{"1.4.0", "1.9.0"}, []string{"1.3.0", "2.0.0"}},
+	}
+	for _, tt := range tests {
+		r, err := parseSemverConstraint(tt.constraint)
+		require.NoError(t, err, tt.constraint)
+		for _, v := range tt.matches {
+			version, ok := parseSemverTag(v)
+			require.True(t, ok, v)
+			assert.True(t, r(version), "%s should match %s", tt.constraint, v)
+		}
+		for _, v := range tt.mismatches {
+			version, ok := parseSemverTag(v)
+			require.True(t, ok, v)
+			assert.False(t, r(version), "%s should not match %s", tt.constraint, v)
+		}
+	}
+
+	for _, constraint := range []string{"", "~", "~foo", "^x.y", "latest"} {
+		_, err := parseSemverConstraint(constraint)
+		assert.Error(t, err, constraint)
+	}
+}
+
+func TestHighestSemverTag(t *testing.T) {
+	tags := []string{"latest", "1.3.0", "1.4", "1.4.0", "v1.4.2", "1.4.3-rc1", "1.5.0", "2.0"}
+	constraint, err := parseSemverConstraint("~1.4")
+	require.NoError(t, err)
+
+	tag, found := highestSemverTag(tags, constraint, "1.4.0")
+	assert.True(t, found)
+	assert.Equal(t, "v1.4.2", tag)
+
+	// Containers are never downgraded.
+	_, found = highestSemverTag(tags, constraint, "1.4.5")
+	assert.False(t, found)
+
+	// The most specific tag of a version is preferred.
+	tag, found = highestSemverTag([]string{"1.4", "1.4.0", "1"}, constraint, "latest")
+	assert.True(t, found)
+	assert.Equal(t, "1.4.0", tag)
+}
@@ -0,0 +1,95 @@
+//go:build !remote
+
+package autoupdate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/containers/image/v5/docker"
+	"github.com/containers/image/v5/docker/reference"
+	"github.com/containers/image/v5/image"
+	"github.com/containers/image/v5/manifest"
+	"github.com/containers/image/v5/signature"
+	"github.com/containers/podman/v5/pkg/trust"
+)
+
+// verifySignatures verifies the image the task is updated to against the
+// signature policy before it is pulled.  The update is refused unless the
+// policy requires signatures for the image, so unsigned updates never reach
+// containers.  The digest of the verified manifest is recorded, and
+// registryUpdate() pulls exactly this digest, so the registry cannot serve a
+// different image between the verification and the pull.
+func (t *task) verifySignatures(ctx context.Context) error {
+	if t.policy == PolicyLocalImage {
+		return errors.New("signatures of images in the local storage cannot be verified")
+	}
+
+	imageName := t.imageName()
+	ref, err := docker.ParseReference("//" + imageName)
+	if err != nil {
+		return err
+	}
+	sys := t.systemContext()
+	policyPath := trust.DefaultPolicyPath(sys)
+
+	reqTypes, err := trust.RequirementTypes(policyPath, ref)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(reqTypes, "signedBy") && !slices.Contains(reqTypes, "sigstoreSigned") {
+		return fmt.Errorf("refusing unsigned update: signature policy %s does not require signatures for %s", policyPath, imageName)
+	}
+
+	policy, err := signature.NewPolicyFromFile(policyPath)
+	if err != nil {
+		return err
+	}
+	policyContext, err := signature.NewPolicyContext(policy)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = policyContext.Destroy()
+	}()
+
+	src, err := ref.NewImageSource(ctx, sys)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	// Like pulls, verify the signatures of the top-level image, which may
+	// be a manifest list.
+	unparsed := image.UnparsedInstance(src, nil)
+	if _, err := policyContext.IsRunningImageAllowed(ctx, unparsed); err != nil {
+		return fmt.Errorf("verifying signatures of %s: %w", imageName, err)
+	}
+	// The unparsed image caches the manifest, these are the verified bytes.
+	rawManifest, _, err := unparsed.Manifest(ctx)
+	if err != nil {
+		return err
+	}
+	verifiedDigest, err := manifest.Digest(rawManifest)
+	if err != nil {
+		return err
+	}
+	t.verifiedDigest = verifiedDigest
+	return nil
+}
+
+// verifiedImageName returns the reference to the verified digest of the image
+// the task is updated to.
+func (t *task) verifiedImageName() (string, error) {
+	named, err := reference.ParseNormalizedNamed(t.imageName())
+	if err != nil {
+		return "", err
+	}
+	digested, err := reference.WithDigest(reference.TrimNamed(named), t.verifiedDigest)
+	if err != nil {
+		return "", err
+	}
+	return digested.String(), nil
+}
@@ -0,0 +1,30 @@
+//go:build !remote
+
+package autoupdate
+
+import (
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifiedImageName(t *testing.T) {
+	verified := digest.Digest("sha256:" + "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef")
+	tests := []struct {
+		rawImageName    string
+		updateImageName string
+		expected        string
+	}{
+		{"quay.io/foo/bar:latest", "", "quay.io/foo/bar@" + verified.String()},
+		{"quay.io/foo/bar:1.4", "quay.io/foo/bar:1.4.7", "quay.io/foo/bar@" + verified.String()},
+		{"localhost:5000/bar", "", "localhost:5000/bar@" + verified.String()},
+	}
+	for _, test := range tests {
+		task := &task{rawImageName: test.rawImageName, updateImageName: test.updateImageName, verifiedDigest: verified}
+		name, err := task.verifiedImageName()
+		require.NoError(t, err)
+		assert.Equal(t, test.expected, name)
+	}
+}
@@ -0,0 +1,287 @@
+//go:build !remote
+
+package autoupdate
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/containers/podman/v5/libpod/define"
+)
+
+// Stages of a staged rollout as recorded in the reports and events.  The
+// results of the soak period are only recorded in events.
+const (
+	stageCanary     = "canary"      // First update of an image
+	stageSoaked     = "soaked"      // The canary stayed healthy for the soak period
+	stageSoakFailed = "soak-failed" // The canary failed during the soak period
+	stageRollout    = "rollout"     // Update following a soaked canary
+	stageHeld       = "held"        // Update held back after a failed canary
+)
+
+// stageAttribute is the attribute of auto-update events denoting the stage of
+// a staged rollout.
+const stageAttribute = "io.containers.autoupdate.stage"
+
+// soakCheckInterval is the interval of checking the health of the canaries
+// during the soak period.
+const soakCheckInterval = 5 * time.Second
+
+// updateGroup is a systemd unit, or a container not running in one, which is
+// updated as a whole.
+type updateGroup struct {
+	unit  string
+	tasks []*task
+}
+
+// updateGroup auto updates the tasks of the group.
+func (u *updater) updateGroup(ctx context.Context, group *updateGroup) []error {
+	if group.unit != "" {
+		return u.updateUnit(ctx, group.unit, group.tasks)
+	}
+	return u.updateContainer(ctx, group.tasks[0])
+}
+
+// setStage sets the stage of the group's tasks and records it in an event.
+func (group *updateGroup) setStage(stage string) {
+	for _, task := range group.tasks {
+		task.stage = stage
+	}
+	group.recordStage(stage)
+}
+
+// recordStage records the stage in an event for each container of the group.
+func (group *updateGroup) recordStage(stage string) {
+	for _, task := range group.tasks {
+		// Updated containers have been replaced, so look them up by
+		// name.
+		ctr, err := task.auto.runtime.LookupContainer(task.container.Name())
+		if err != nil {
+			ctr = task.container
+		}
+		ctr.NewAutoUpdateEvent(map[string]string{stageAttribute: stage})
+	}
+}
+
+// restarted returns whether the group has been restarted with an update.
+func (group *updateGroup) restarted() bool {
+	return slices.ContainsFunc(group.tasks, func(t *task) bool { return t.restarted })
+}
+
+// updateGroups returns the update groups of all tasks, sorted by unit and
+// container name to make the choice of canaries predictable.
+func (u *updater) updateGroups() []*updateGroup {
+	units := make([]string, 0, len(u.unitToTasks))
+	for unit := range u.unitToTasks {
+		units = append(units, unit)
+	}
+	sort.Strings(units)
+
+	groups := make([]*updateGroup, 0, len(units)+len(u.recreateTasks))
+	for _, unit := range units {
+		groups = append(groups, &updateGroup{unit: unit, tasks: u.unitToTasks[unit]})
+	}
+	recreateTasks := slices.Clone(u.recreateTasks)
+	sort.Slice(recreateTasks, func(i, j int) bool {
+		return recreateTasks[i].container.Name() < recreateTasks[j].container.Name()
+	})
+	for _, t := range recreateTasks {
+		groups = append(groups, &updateGroup{tasks: []*task{t}})
+	}
+	return groups
+}
+
+// stagedUpdate rolls out updates in stages.  For each image, the first group
+// which gets updated is the canary of the image.  Once the canaries stayed
+// healthy for the soak period, the remaining groups are updated.  Groups
+// using an image whose canary failed are held back.
+func (u *updater) stagedUpdate(ctx context.Context) []error {
+	var (
+		errs      []error
+		canaries  []*updateGroup
+		remaining []*updateGroup
+	)
+	imageCanaries := make(map[string]*updateGroup)
+	for _, group := range u.updateGroups() {
+		needsCanary := slices.ContainsFunc(group.tasks, func(t *task) bool {
+			return imageCanaries[t.rawImageName] == nil
+		})
+		if !needsCanary {
+			remaining = append(remaining, group)
+			continue
+		}
+
+		errs = append(errs, u.updateGroup(ctx, group)...)
+		// Groups which have not been restarted, for instance because
+		// no update is available, are no canaries.
+		if !group.restarted() {
+			continue
+		}
+		for _, task := range group.tasks {
+			if imageCanaries[task.rawImageName] == nil {
+				imageCanaries[task.rawImageName] = group
+			}
+		}
+		canaries = append(canaries, group)
+		group.setStage(stageCanary)
+	}
+
+	if len(canaries) == 0 {
+		return errs
+	}
+
+	failed := u.soak(ctx, canaries)
+	for _, group := range canaries {
+		err, isFailed := failed[group]
+		if !isFailed {
+			group.recordStage(stageSoaked)
+			continue
+		}
+		group.recordStage(stageSoakFailed)
+		if err == nil {
+			// The update itself has failed and has already been
+			// reported.
+			continue
+		}
+		errs = append(errs, err)
+		// Recreated containers cannot be rolled back after the
+		// previous container has been removed.
+		if group.unit != "" && u.options.Rollback {
+			u.progressf("%s failed during the soak period, rolling back", group.unit)
+			errs = append(errs, u.rollbackUnit(ctx, group.unit, group.tasks)...)
+		}
+	}
+
+	for _, group := range remaining {
+		held := slices.ContainsFunc(group.tasks, func(t *task) bool {
+			canary := imageCanaries[t.rawImageName]
+			_, isFailed := failed[canary]
+			return isFailed
+		})
+		if held {
+			errs = append(errs, u.holdGroup(ctx, group)...)
+			continue
+		}
+		errs = append(errs, u.updateGroup(ctx, group)...)
+		if group.restarted() {
+			group.setStage(stageRollout)
+		}
+	}
+	return errs
+}
+
+// soak waits for the soak period and checks the health of the canaries.  It
+// returns the failed canaries along with the error of the failed health
+// check.  Canaries whose update has already failed are returned without an
+// error.
+func (u *updater) soak(ctx context.Context, canaries []*updateGroup) map[*updateGroup]error {
+	failed := make(map[*updateGroup]error)
+	var soaking []*updateGroup
+	for _, group := range canaries {
+		if slices.ContainsFunc(group.tasks, func(t *task) bool { return t.status != statusUpdated }) {
+			failed[group] = nil
+			continue
+		}
+		soaking = append(soaking, group)
+	}
+	if len(soaking) == 0 {
+		return failed
+	}
+
+	u.progressf("Soaking canaries for %s", u.options.SoakPeriod)
+	deadline := time.Now().Add(u.options.SoakPeriod)
+	for {
+		for _, group := range soaking {
+			if _, isFailed := failed[group]; isFailed {
+				continue
+			}
+			if err := u.groupHealthy(ctx, group); err != nil {
+				failed[group] = err
+			}
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return failed
+		}
+		select {
+		case <-ctx.Done():
+			for _, group := range soaking {
+				if _, isFailed := failed[group]; !isFailed {
+					failed[group] = ctx.Err()
+				}
+			}
+			return failed
+		case <-time.After(min(remaining, soakCheckInterval)):
+		}
+	}
+}
+
+// groupHealthy returns an error if the unit of the group is not active or any
+// of its containers is not running or unhealthy.
+func (u *updater) groupHealthy(ctx context.Context, group *updateGroup) error {
+	if group.unit != "" {
+		prop, err := u.conn.GetUnitPropertyContext(ctx, group.unit, "ActiveState")
+		if err != nil {
+			return fmt.Errorf("checking state of unit %s: %w", group.unit, err)
+		}
+		if state, ok := prop.Value.Value().(string); !ok || state != "active" {
+			return fmt.Errorf("unit %s is %v during the soak period", group.unit, prop.Value.Value())
+		}
+	}
+
+	for _, task := range group.tasks {
+		// The container has been replaced, so look it up by name.
+		name := task.container.Name()
+		ctr, err := u.runtime.LookupContainer(name)
+		if err != nil {
+			return fmt.Errorf("looking up container %s during the soak period: %w", name, err)
+		}
+		state, err := ctr.State()
+		if err != nil {
+			return err
+		}
+		if state != define.ContainerStateRunning {
+			return fmt.Errorf("container %s is %s during the soak period", name, state)
+		}
+		if ctr.HealthCheckConfig() == nil {
+			continue
+		}
+		status, err := ctr.HealthCheckStatus()
+		if err != nil {
+			return err
+		}
+		if status == define.HealthCheckUnhealthy {
+			return fmt.Errorf("container %s is unhealthy during the soak period", name)
+		}
+	}
+	return nil
+}
+
+// holdGroup holds back the update of the group after a failed canary.  The
+// tasks report whether an update is pending.
+func (u *updater) holdGroup(ctx context.Context, group *updateGroup) []error {
+	var errs []error
+	for _, task := range group.tasks {
+		updateAvailable, err := task.updateAvailable(ctx)
+		switch {
+		case err != nil:
+			task.status = statusFailed
+			errs = append(errs, fmt.Errorf("checking image updates for container %s: %w", task.container.ID(), err))
+		case updateAvailable:
+			task.status = statusPending
+		default:
+			task.status = statusNotUpdated
+		}
+	}
+	if group.unit != "" {
+		u.progressf("Holding back the update of %s", group.unit)
+	} else {
+		u.progressf("Holding back the update of container %s", group.tasks[0].container.Name())
+	}
+	group.setStage(stageHeld)
+	return errs
+}
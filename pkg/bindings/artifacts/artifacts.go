@@ -0,0 +1,278 @@
+package artifacts
+
+import (
+	"archive/tar"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	imageTypes "github.com/containers/image/v5/types"
+	"github.com/containers/podman/v5/pkg/auth"
+	"github.com/containers/podman/v5/pkg/bindings"
+	entitiesTypes "github.com/containers/podman/v5/pkg/domain/entities/types"
+)
+
+// List returns the artifacts in the local artifact store.
+func List(ctx context.Context, options *ListOptions) ([]*entitiesTypes.ArtifactListReport, error) {
+	var artifacts []*entitiesTypes.ArtifactListReport
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params, err := options.ToParams()
+	if err != nil {
+		return nil, err
+	}
+	response, err := conn.DoRequest(ctx, nil, http.MethodGet, "/artifacts/json", params, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	return artifacts, response.Process(&artifacts)
+}
+
+// Inspect returns the artifact with the given name or digest.
+func Inspect(ctx context.Context, nameOrDigest string, options *InspectOptions) (*entitiesTypes.ArtifactInspectReport, error) {
+	var report entitiesTypes.ArtifactInspectReport
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params, err := options.ToParams()
+	if err != nil {
+		return nil, err
+	}
+	response, err := conn.DoRequest(ctx, nil, http.MethodGet, "/artifacts/%s/json", params, nil, nameOrDigest)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	return &report, response.Process(&report)
+}
+
+// Add creates the artifact name from the content of file, stored under
+// fileName, or adds it to the existing artifact if options.Append is set.
+func Add(ctx context.Context, name, fileName string, file io.Reader, options *AddOptions) (*entitiesTypes.ArtifactAddReport, error) {
+	var report entitiesTypes.ArtifactAddReport
+	if options == nil {
+		options = new(AddOptions)
+	}
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params, err := options.ToParams()
+	if err != nil {
+		return nil, err
+	}
+	params.Set("name", name)
+	params.Set("fileName", fileName)
+	header := make(http.Header)
+	header.Set("Content-Type", "application/octet-stream")
+	response, err := conn.DoRequest(ctx, file, http.MethodPost, "/artifacts/add", params, header)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	return &report, response.Process(&report)
+}
+
+// AddFiles creates the artifact name from the local files at paths, stored
+// under their base names, or adds them to the existing artifact if
+// options.Append is set.  The files are sent in a single tar archive, so
+// either all or none of them are added.
+func AddFiles(ctx context.Context, name string, paths []string, options *AddOptions) (*entitiesTypes.ArtifactAddReport, error) {
+	var report entitiesTypes.ArtifactAddReport
+	if options == nil {
+		options = new(AddOptions)
+	}
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params, err := options.ToParams()
+	if err != nil {
+		return nil, err
+	}
+	params.Set("name", name)
+
+	reader, writer := io.Pipe()
+	go func() {
+		writer.CloseWithError(writeArtifactArchive(writer, paths))
+	}()
+	defer reader.Close()
+
+	header := make(http.Header)
+	header.Set("Content-Type", "application/x-tar")
+	response, err := conn.DoRequest(ctx, reader, http.MethodPost, "/artifacts/add", params, header)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	return &report, response.Process(&report)
+}
+
+// writeArtifactArchive writes a tar archive with the files at paths to w.
+func writeArtifactArchive(w io.Writer, paths []string) error {
+	tw := tar.NewWriter(w)
+	for _, path := range paths {
+		err := func() error {
+			f, err := os.Open(path)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			info, err := f.Stat()
+			if err != nil {
+				return err
+			}
+			if !info.Mode().IsRegular() {
+				return fmt.Errorf("%s is not a regular file", path)
+			}
+			hdr := &tar.Header{
+				Typeflag: tar.TypeReg,
+				Name:     filepath.Base(path),
+				Mode:     0o644,
+				Size:     info.Size(),
+				ModTime:  info.ModTime(),
+			}
+			if err := tw.WriteHeader(hdr); err != nil {
+				return err
+			}
+			_, err = io.Copy(tw, f)
+			return err
+		}()
+		if err != nil {
+			return err
+		}
+	}
+	return tw.Close()
+}
+
+// Pull pulls the artifact name from a registry into the local artifact store.
+func Pull(ctx context.Context, name string, options *PullOptions) (*entitiesTypes.ArtifactPullReport, error) {
+	var report entitiesTypes.ArtifactPullReport
+	if options == nil {
+		options = new(PullOptions)
+	}
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	header, err := auth.MakeXRegistryAuthHeader(&imageTypes.SystemContext{AuthFilePath: options.GetAuthfile()}, options.GetUsername(), options.GetPassword())
+	if err != nil {
+		return nil, err
+	}
+	params, err := options.ToParams()
+	if err != nil {
+		return nil, err
+	}
+	// SkipTLSVerify is special. It's not being serialized by ToParams()
+	// because we need to flip the boolean.
+	if options.SkipTLSVerify != nil {
+		params.Set("tlsVerify", strconv.FormatBool(!options.GetSkipTLSVerify()))
+	}
+	params.Set("name", name)
+	response, err := conn.DoRequest(ctx, nil, http.MethodPost, "/artifacts/pull", params, header)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	return &report, response.Process(&report)
+}
+
+// Push pushes the artifact with the given name or digest to destination, or
+// to its own name if destination is empty.
+func Push(ctx context.Context, nameOrDigest, destination string, options *PushOptions) (*entitiesTypes.ArtifactPushReport, error) {
+	var report entitiesTypes.ArtifactPushReport
+	if options == nil {
+		options = new(PushOptions)
+	}
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	header, err := auth.MakeXRegistryAuthHeader(&imageTypes.SystemContext{AuthFilePath: options.GetAuthfile()}, options.GetUsername(), options.GetPassword())
+	if err != nil {
+		return nil, err
+	}
+	params, err := options.ToParams()
+	if err != nil {
+		return nil, err
+	}
+	// SkipTLSVerify is special. It's not being serialized by ToParams()
+	// because we need to flip the boolean.
+	if options.SkipTLSVerify != nil {
+		params.Set("tlsVerify", strconv.FormatBool(!options.GetSkipTLSVerify()))
+	}
+	params.Set("destination", destination)
+	response, err := conn.DoRequest(ctx, nil, http.MethodPost, "/artifacts/%s/push", params, header, nameOrDigest)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	return &report, response.Process(&report)
+}
+
+// Remove removes artifacts from the local artifact store.
+func Remove(ctx context.Context, names []string, options *RemoveOptions) (*entitiesTypes.ArtifactRemoveReport, error) {
+	var report entitiesTypes.ArtifactRemoveReport
+	if options == nil {
+		options = new(RemoveOptions)
+	}
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params, err := options.ToParams()
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range names {
+		params.Add("artifacts", name)
+	}
+	response, err := conn.DoRequest(ctx, nil, http.MethodDelete, "/artifacts/remove", params, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	return &report, response.Process(&report)
+}
+
+// Extract writes the files of the artifact with the given name or digest to w
+// as a tar archive, each file named after its title.
+func Extract(ctx context.Context, nameOrDigest string, w io.Writer, options *ExtractOptions) error {
+	if options == nil {
+		options = new(ExtractOptions)
+	}
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return err
+	}
+	params, err := options.ToParams()
+	if err != nil {
+		return err
+	}
+	response, err := conn.DoRequest(ctx, nil, http.MethodGet, "/artifacts/%s/extract", params, nil, nameOrDigest)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if !response.IsSuccess() {
+		return response.Process(nil)
+	}
+	_, err = io.Copy(w, response.Body)
+	return err
+}
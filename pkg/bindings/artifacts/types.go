@@ -0,0 +1,85 @@
+package artifacts
+
+// ListOptions are optional options for listing artifacts
+//
+//go:generate go run ../generator/generator.go ListOptions
+type ListOptions struct {
+}
+
+// InspectOptions are optional options for inspecting artifacts
+//
+//go:generate go run ../generator/generator.go InspectOptions
+type InspectOptions struct {
+}
+
+// AddOptions are optional options for adding files to artifacts
+//
+//go:generate go run ../generator/generator.go AddOptions
+type AddOptions struct {
+	// Annotations of the artifact
+	Annotations map[string]string
+	// ArtifactType is the artifact type of a new artifact
+	ArtifactType *string
+	// FileType is the media type of the file
+	FileType *string
+	// Append adds the file to the artifact if it already exists
+	Append *bool
+}
+
+// PullOptions are optional options for pulling artifacts
+//
+//go:generate go run ../generator/generator.go PullOptions
+type PullOptions struct {
+	// Authfile is the path to the authentication file. Ignored for remote
+	// calls.
+	Authfile *string `schema:"-"`
+	// Password for authenticating against the registry.
+	Password *string `schema:"-"`
+	// Retry number of times to retry pull in case of failure
+	Retry *uint
+	// RetryDelay between retries in case of pull failures
+	RetryDelay *string
+	// SkipTLSVerify to skip HTTPS and certificate verification.
+	SkipTLSVerify *bool `schema:"-"`
+	// Username for authenticating against the registry.
+	Username *string `schema:"-"`
+}
+
+// PushOptions are optional options for pushing artifacts
+//
+//go:generate go run ../generator/generator.go PushOptions
+type PushOptions struct {
+	// Authfile is the path to the authentication file. Ignored for remote
+	// calls.
+	Authfile *string `schema:"-"`
+	// Password for authenticating against the registry.
+	Password *string `schema:"-"`
+	// Retry number of times to retry push in case of failure
+	Retry *uint
+	// RetryDelay between retries in case of push failures
+	RetryDelay *string
+	// SkipTLSVerify to skip HTTPS and certificate verification.
+	SkipTLSVerify *bool `schema:"-"`
+	// Username for authenticating against the registry.
+	Username *string `schema:"-"`
+}
+
+// RemoveOptions are optional options for removing artifacts
+//
+//go:generate go run ../generator/generator.go RemoveOptions
+type RemoveOptions struct {
+	// All removes all artifacts
+	All *bool
+	// Force removes the containers using the artifacts
+	Force *bool
+}
+
+// ExtractOptions are optional options for extracting artifacts
+//
+//go:generate go run ../generator/generator.go ExtractOptions
+type ExtractOptions struct {
+	// Digest only extracts the file with this digest
+	Digest *string
+	// Title only extracts the file with this title
+	Title *string
+}
@@ -0,0 +1,78 @@
+// Code generated by go generate; DO NOT EDIT.
+package artifacts
+
+import (
+	"net/url"
+
+	"github.com/containers/podman/v5/pkg/bindings/internal/util"
+)
+
+// Changed returns true if named field has been set
+func (o *AddOptions) Changed(fieldName string) bool {
+	return util.Changed(o, fieldName)
+}
+
+// ToParams formats struct fields to be passed to API service
+func (o *AddOptions) ToParams() (url.Values, error) {
+	return util.ToParams(o)
+}
+
+// WithAnnotations set field Annotations to given value
+func (o *AddOptions) WithAnnotations(value map[string]string) *AddOptions {
+	o.Annotations = value
+	return o
+}
+
+// GetAnnotations returns value of field Annotations
+func (o *AddOptions) GetAnnotations() map[string]string {
+	if o.Annotations == nil {
+		var z map[string]string
+		return z
+	}
+	return o.Annotations
+}
+
+// WithArtifactType set field ArtifactType to given value
+func (o *AddOptions) WithArtifactType(value string) *AddOptions {
+	o.ArtifactType = &value
+	return o
+}
+
+// GetArtifactType returns value of field ArtifactType
+func (o *AddOptions) GetArtifactType() string {
+	if o.ArtifactType == nil {
+		var z string
+		return z
+	}
+	return *o.ArtifactType
+}
+
+// WithFileType set field FileType to given value
+func (o *AddOptions) WithFileType(value string) *AddOptions {
+	o.FileType = &value
+	return o
+}
+
+// GetFileType returns value of field FileType
+func (o *AddOptions) GetFileType() string {
+	if o.FileType == nil {
+		var z string
+		return z
+	}
+	return *o.FileType
+}
+
+// WithAppend set field Append to given value
+func (o *AddOptions) WithAppend(value bool) *AddOptions {
+	o.Append = &value
+	return o
+}
+
+// GetAppend returns value of field Append
+func (o *AddOptions) GetAppend() bool {
+	if o.Append == nil {
+		var z bool
+		return z
+	}
+	return *o.Append
+}
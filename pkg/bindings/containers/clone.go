@@ -0,0 +1,44 @@
+package containers
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/containers/podman/v5/pkg/bindings"
+	"github.com/containers/podman/v5/pkg/domain/entities/types"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Clone creates a copy of the given container.  Resource limits set in the
+// options replace the ones of the original container.
+func Clone(ctx context.Context, nameOrID string, options *CloneOptions) (types.ContainerCreateResponse, error) {
+	var ccr types.ContainerCreateResponse
+	if options == nil {
+		options = new(CloneOptions)
+	}
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return ccr, err
+	}
+	params, err := options.ToParams()
+	if err != nil {
+		return ccr, err
+	}
+	var requestBody io.Reader
+	if options.Resources != nil {
+		requestData, err := jsoniter.MarshalToString(options.Resources)
+		if err != nil {
+			return ccr, err
+		}
+		requestBody = strings.NewReader(requestData)
+	}
+	response, err := conn.DoRequest(ctx, requestBody, http.MethodPost, "/containers/%s/clone", params, nil, nameOrID)
+	if err != nil {
+		return ccr, err
+	}
+	defer response.Body.Close()
+
+	return ccr, response.Process(&ccr)
+}
@@ -5,6 +5,7 @@ import (
 	"io"
 
 	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/pkg/api/handlers"
 )
 
 // LogOptions describe finer control of log content or
@@ -273,6 +274,19 @@ type RenameOptions struct {
 	Name *string
 }
 
+// CloneOptions are optional options for cloning containers
+//
+//go:generate go run ../generator/generator.go CloneOptions
+type CloneOptions struct {
+	Destroy   *bool
+	Force     *bool
+	Image     *string
+	Name      *string
+	Pod       *string
+	Resources *handlers.CloneResources `schema:"-"`
+	Run       *bool
+}
+
 // ResizeTTYOptions are optional options for resizing
 // container TTYs
 //
@@ -0,0 +1,124 @@
+// Code generated by go generate; DO NOT EDIT.
+package containers
+
+import (
+	"net/url"
+
+	"github.com/containers/podman/v5/pkg/api/handlers"
+	"github.com/containers/podman/v5/pkg/bindings/internal/util"
+)
+
+// Changed returns true if named field has been set
+func (o *CloneOptions) Changed(fieldName string) bool {
+	return util.Changed(o, fieldName)
+}
+
+// ToParams formats struct fields to be passed to API service
+func (o *CloneOptions) ToParams() (url.Values, error) {
+	return util.ToParams(o)
+}
+
+// WithDestroy set field Destroy to given value
+func (o *CloneOptions) WithDestroy(value bool) *CloneOptions {
+	o.Destroy = &value
+	return o
+}
+
+// GetDestroy returns value of field Destroy
+func (o *CloneOptions) GetDestroy() bool {
+	if o.Destroy == nil {
+		var z bool
+		return z
+	}
+	return *o.Destroy
+}
+
+// WithForce set field Force to given value
+func (o *CloneOptions) WithForce(value bool) *CloneOptions {
+	o.Force = &value
+	return o
+}
+
+// GetForce returns value of field Force
+func (o *CloneOptions) GetForce() bool {
+	if o.Force == nil {
+		var z bool
+		return z
+	}
+	return *o.Force
+}
+
+// WithImage set field Image to given value
+func (o *CloneOptions) WithImage(value string) *CloneOptions {
+	o.Image = &value
+	return o
+}
+
+// GetImage returns value of field Image
+func (o *CloneOptions) GetImage() string {
+	if o.Image == nil {
+		var z string
+		return z
+	}
+	return *o.Image
+}
+
+// WithName set field Name to given value
+func (o *CloneOptions) WithName(value string) *CloneOptions {
+	o.Name = &value
+	return o
+}
+
+// GetName returns value of field Name
+func (o *CloneOptions) GetName() string {
+	if o.Name == nil {
+		var z string
+		return z
+	}
+	return *o.Name
+}
+
+// WithPod set field Pod to given value
+func (o *CloneOptions) WithPod(value string) *CloneOptions {
+	o.Pod = &value
+	return o
+}
+
+// GetPod returns value of field Pod
+func (o *CloneOptions) GetPod() string {
+	if o.Pod == nil {
+		var z string
+		return z
+	}
+	return *o.Pod
+}
+
+// WithResources set field Resources to given value
+func (o *CloneOptions) WithResources(value handlers.CloneResources) *CloneOptions {
+	o.Resources = &value
+	return o
+}
+
+// GetResources returns value of field Resources
+func (o *CloneOptions) GetResources() handlers.CloneResources {
+	if o.Resources == nil {
+		var z handlers.CloneResources
+		return z
+	}
+	return *o.Resources
+}
+
+// WithRun set field Run to given value
+func (o *CloneOptions) WithRun(value bool) *CloneOptions {
+	o.Run = &value
+	return o
+}
+
+// GetRun returns value of field Run
+func (o *CloneOptions) GetRun() bool {
+	if o.Run == nil {
+		var z bool
+		return z
+	}
+	return *o.Run
+}
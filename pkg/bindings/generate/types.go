@@ -12,6 +12,8 @@ type KubeOptions struct {
 	Type *string
 	// Replicas - the value to set in the replicas field for a Deployment
 	Replicas *int32
+	// Schedule - the cron schedule of a CronJob
+	Schedule *string
 	// NoTrunc - don't truncate annotations to the Kubernetes maximum length of 63 characters
 	NoTrunc *bool
 }
@@ -51,22 +51,23 @@ func CreateWithOptions(ctx context.Context, network *types.Network, extraCreateO
 }
 
 // Updates an existing netavark network config
-func Update(ctx context.Context, netNameOrID string, options *UpdateOptions) error {
+func Update(ctx context.Context, netNameOrID string, options *UpdateOptions) (*entitiesTypes.NetworkUpdateReport, error) {
+	var report entitiesTypes.NetworkUpdateReport
 	conn, err := bindings.GetClient(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	networkConfig, err := jsoniter.MarshalToString(options)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	reader := strings.NewReader(networkConfig)
 	response, err := conn.DoRequest(ctx, reader, http.MethodPost, "/networks/%s/update", nil, nil, netNameOrID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer response.Body.Close()
-	return response.Process(nil)
+	return &report, response.Process(&report)
 }
 
 // Inspect returns information about a network configuration
@@ -2,6 +2,8 @@ package network
 
 import (
 	"net"
+
+	"github.com/containers/common/libnetwork/types"
 )
 
 // CreateOptions are optional options for creating networks
@@ -62,8 +64,18 @@ type ListOptions struct {
 //
 //go:generate go run ../generator/generator.go UpdateOptions
 type UpdateOptions struct {
-	AddDNSServers    []string `json:"adddnsservers"`
-	RemoveDNSServers []string `json:"removednsservers"`
+	AddDNSServers    []string           `json:"adddnsservers"`
+	RemoveDNSServers []string           `json:"removednsservers"`
+	AddSubnets       []types.Subnet     `json:"addsubnets"`
+	RemoveSubnets    []types.IPNet      `json:"removesubnets"`
+	AddIPRanges      []types.LeaseRange `json:"addipranges"`
+	RemoveIPRanges   []types.LeaseRange `json:"removeipranges"`
+	AddLabels        map[string]string  `json:"addlabels"`
+	RemoveLabels     []string           `json:"removelabels"`
+	AddOptions       map[string]string  `json:"addoptions"`
+	RemoveOptions    []string           `json:"removeoptions"`
+	Internal         *bool              `json:"internal,omitempty"`
+	DNSEnabled       *bool              `json:"dnsenabled,omitempty"`
 }
 
 // DisconnectOptions are optional options for disconnecting
@@ -4,6 +4,7 @@ package network
 import (
 	"net/url"
 
+	"github.com/containers/common/libnetwork/types"
 	"github.com/containers/podman/v5/pkg/bindings/internal/util"
 )
 
@@ -46,3 +47,153 @@ func (o *UpdateOptions) GetRemoveDNSServers() []string {
 	}
 	return o.RemoveDNSServers
 }
+
+// WithAddSubnets set field AddSubnets to given value
+func (o *UpdateOptions) WithAddSubnets(value []types.Subnet) *UpdateOptions {
+	o.AddSubnets = value
+	return o
+}
+
+// GetAddSubnets returns value of field AddSubnets
+func (o *UpdateOptions) GetAddSubnets() []types.Subnet {
+	if o.AddSubnets == nil {
+		var z []types.Subnet
+		return z
+	}
+	return o.AddSubnets
+}
+
+// WithRemoveSubnets set field RemoveSubnets to given value
+func (o *UpdateOptions) WithRemoveSubnets(value []types.IPNet) *UpdateOptions {
+	o.RemoveSubnets = value
+	return o
+}
+
+// GetRemoveSubnets returns value of field RemoveSubnets
+func (o *UpdateOptions) GetRemoveSubnets() []types.IPNet {
+	if o.RemoveSubnets == nil {
+		var z []types.IPNet
+		return z
+	}
+	return o.RemoveSubnets
+}
+
+// WithAddIPRanges set field AddIPRanges to given value
+func (o *UpdateOptions) WithAddIPRanges(value []types.LeaseRange) *UpdateOptions {
+	o.AddIPRanges = value
+	return o
+}
+
+// GetAddIPRanges returns value of field AddIPRanges
+func (o *UpdateOptions) GetAddIPRanges() []types.LeaseRange {
+	if o.AddIPRanges == nil {
+		var z []types.LeaseRange
+		return z
+	}
+	return o.AddIPRanges
+}
+
+// WithRemoveIPRanges set field RemoveIPRanges to given value
+func (o *UpdateOptions) WithRemoveIPRanges(value []types.LeaseRange) *UpdateOptions {
+	o.RemoveIPRanges = value
+	return o
+}
+
+// GetRemoveIPRanges returns value of field RemoveIPRanges
+func (o *UpdateOptions) GetRemoveIPRanges() []types.LeaseRange {
+	if o.RemoveIPRanges == nil {
+		var z []types.LeaseRange
+		return z
+	}
+	return o.RemoveIPRanges
+}
+
+// WithAddLabels set field AddLabels to given value
+func (o *UpdateOptions) WithAddLabels(value map[string]string) *UpdateOptions {
+	o.AddLabels = value
+	return o
+}
+
+// GetAddLabels returns value of field AddLabels
+func (o *UpdateOptions) GetAddLabels() map[string]string {
+	if o.AddLabels == nil {
+		var z map[string]string
+		return z
+	}
+	return o.AddLabels
+}
+
+// WithRemoveLabels set field RemoveLabels to given value
+func (o *UpdateOptions) WithRemoveLabels(value []string) *UpdateOptions {
+	o.RemoveLabels = value
+	return o
+}
+
+// GetRemoveLabels returns value of field RemoveLabels
+func (o *UpdateOptions) GetRemoveLabels() []string {
+	if o.RemoveLabels == nil {
+		var z []string
+		return z
+	}
+	return o.RemoveLabels
+}
+
+// WithAddOptions set field AddOptions to given value
+func (o *UpdateOptions) WithAddOptions(value map[string]string) *UpdateOptions {
+	o.AddOptions = value
+	return o
+}
+
+// GetAddOptions returns value of field AddOptions
+func (o *UpdateOptions) GetAddOptions() map[string]string {
+	if o.AddOptions == nil {
+		var z map[string]string
+		return z
+	}
+	return o.AddOptions
+}
+
+// WithRemoveOptions set field RemoveOptions to given value
+func (o *UpdateOptions) WithRemoveOptions(value []string) *UpdateOptions {
+	o.RemoveOptions = value
+	return o
+}
+
+// GetRemoveOptions returns value of field RemoveOptions
+func (o *UpdateOptions) GetRemoveOptions() []string {
+	if o.RemoveOptions == nil {
+		var z []string
+		return z
+	}
+	return o.RemoveOptions
+}
+
+// WithInternal set field Internal to given value
+func (o *UpdateOptions) WithInternal(value bool) *UpdateOptions {
+	o.Internal = &value
+	return o
+}
+
+// GetInternal returns value of field Internal
+func (o *UpdateOptions) GetInternal() bool {
+	if o.Internal == nil {
+		var z bool
+		return z
+	}
+	return *o.Internal
+}
+
+// WithDNSEnabled set field DNSEnabled to given value
+func (o *UpdateOptions) WithDNSEnabled(value bool) *UpdateOptions {
+	o.DNSEnabled = &value
+	return o
+}
+
+// GetDNSEnabled returns value of field DNSEnabled
+func (o *UpdateOptions) GetDNSEnabled() bool {
+	if o.DNSEnabled == nil {
+		var z bool
+		return z
+	}
+	return *o.DNSEnabled
+}
@@ -2,8 +2,12 @@ package pods
 
 import (
 	"context"
+	"errors"
+	"io"
 	"net/http"
 	"net/url"
+	"os"
+	"strconv"
 	"strings"
 
 	"github.com/containers/podman/v5/pkg/api/handlers"
@@ -340,3 +344,116 @@ func Stats(ctx context.Context, namesOrIDs []string, options *StatsOptions) ([]*
 
 	return reports, response.Process(&reports)
 }
+
+// Update updates the resource limits of a pod and optionally the restart
+// policy and healthcheck on-failure action of its containers.  The ID of the
+// pod is returned.
+func Update(ctx context.Context, options *entitiesTypes.PodUpdateOptions) (string, error) {
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	params := url.Values{}
+	if options.Specgen.RestartPolicy != "" {
+		params.Set("restartPolicy", options.Specgen.RestartPolicy)
+		if options.Specgen.RestartRetries != nil {
+			params.Set("restartRetries", strconv.Itoa(int(*options.Specgen.RestartRetries)))
+		}
+	}
+	if options.HealthOnFailure != nil {
+		params.Set("healthOnFailure", *options.HealthOnFailure)
+	}
+	requestData, err := jsoniter.MarshalToString(options.Specgen.ResourceLimits)
+	if err != nil {
+		return "", err
+	}
+	stringReader := strings.NewReader(requestData)
+	response, err := conn.DoRequest(ctx, stringReader, http.MethodPost, "/pods/%s/update", params, nil, options.NameOrID)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+
+	var id string
+	return id, response.Process(&id)
+}
+
+// Checkpoint checkpoints all containers of the given pod and writes the
+// checkpoint of the pod to the archive given by the Export option.
+func Checkpoint(ctx context.Context, nameOrID string, options *CheckpointOptions) error {
+	if options == nil {
+		options = new(CheckpointOptions)
+	}
+	if options.GetExport() == "" {
+		return errors.New("an export archive must be set to checkpoint a pod")
+	}
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return err
+	}
+	params, err := options.ToParams()
+	if err != nil {
+		return err
+	}
+	// The server always exports the checkpoint to the response body.
+	params.Del("export")
+
+	response, err := conn.DoRequest(ctx, nil, http.MethodPost, "/pods/%s/checkpoint", params, nil, nameOrID)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return response.Process(nil)
+	}
+
+	f, err := os.OpenFile(options.GetExport(), os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(f, response.Body)
+	return err
+}
+
+// Restore recreates a pod and restores all of its containers from the pod
+// checkpoint archive given by the ImportArchive option.
+func Restore(ctx context.Context, options *RestoreOptions) (*entitiesTypes.PodRestoreReport, error) {
+	var report entitiesTypes.PodRestoreReport
+	if options == nil {
+		options = new(RestoreOptions)
+	}
+	if options.GetImportArchive() == "" {
+		return nil, errors.New("an import archive must be set to restore a pod")
+	}
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params, err := options.ToParams()
+	if err != nil {
+		return nil, err
+	}
+	params.Del("importarchive")
+	// The server splits the ports to publish on white space.
+	params.Del("publishports")
+	if len(options.PublishPorts) > 0 {
+		params.Set("publishPorts", strings.Join(options.PublishPorts, " "))
+	}
+
+	archive, err := os.Open(options.GetImportArchive())
+	if err != nil {
+		return nil, err
+	}
+	defer archive.Close()
+
+	response, err := conn.DoRequest(ctx, archive, http.MethodPost, "/pods/restore", params, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	return &report, response.Process(&report)
+}
@@ -90,3 +90,35 @@ type RemoveOptions struct {
 //go:generate go run ../generator/generator.go ExistsOptions
 type ExistsOptions struct {
 }
+
+// CheckpointOptions are optional options for checkpointing pods
+//
+//go:generate go run ../generator/generator.go CheckpointOptions
+type CheckpointOptions struct {
+	// Export is the path of the archive the checkpoint of the pod is written to.
+	Export         *string
+	Compress       *string
+	FileLocks      *bool
+	IgnoreRootfs   *bool
+	IgnoreVolumes  *bool
+	Keep           *bool
+	LeaveRunning   *bool
+	TCPEstablished *bool
+}
+
+// RestoreOptions are optional options for restoring pods
+//
+//go:generate go run ../generator/generator.go RestoreOptions
+type RestoreOptions struct {
+	// ImportArchive is the path to an archive which contains the pod checkpoint.
+	ImportArchive   *string
+	FileLocks       *bool
+	IgnoreRootfs    *bool
+	IgnoreStaticIP  *bool
+	IgnoreStaticMAC *bool
+	IgnoreVolumes   *bool
+	Keep            *bool
+	Name            *string
+	PublishPorts    []string
+	TCPEstablished  *bool
+}
@@ -0,0 +1,177 @@
+package quadlets
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/pkg/bindings"
+	entitiesTypes "github.com/containers/podman/v5/pkg/domain/entities/types"
+	"github.com/containers/podman/v5/pkg/systemd/quadlet"
+)
+
+// Install installs the Quadlets in paths, which are Quadlet files or
+// directories containing Quadlet files, with the files they reference.
+func Install(ctx context.Context, paths []string, options *InstallOptions) (*entitiesTypes.QuadletInstallReport, error) {
+	units, err := quadlet.FindInstallUnits(paths)
+	if err != nil {
+		return nil, err
+	}
+
+	// The Quadlet files are placed at the root of the archive, and the files
+	// they reference relative to them.
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	sources := make(map[string]string)
+	addFile := func(name, src string) error {
+		if prev, ok := sources[name]; ok {
+			// Units of the same directory may share their assets
+			if prev == src {
+				return nil
+			}
+			return fmt.Errorf("%s and %s are both installed as %s: %w", prev, src, name, define.ErrInvalidArg)
+		}
+		sources[name] = src
+		return addTarFile(tw, name, src)
+	}
+	for _, unit := range units {
+		name := filepath.Base(unit.Path)
+		for _, asset := range unit.Assets {
+			if err := addFile(filepath.ToSlash(asset), filepath.Join(filepath.Dir(unit.Path), asset)); err != nil {
+				return nil, fmt.Errorf("installing %s of Quadlet %s: %w", asset, name, err)
+			}
+		}
+		if err := addFile(name, unit.Path); err != nil {
+			return nil, fmt.Errorf("installing Quadlet %s: %w", name, err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	report, err := InstallWithBody(ctx, &buf, options)
+	if err != nil {
+		return report, err
+	}
+	// The service reports the installed files by name, map them back to
+	// the local paths
+	installed := make(map[string]string, len(report.InstalledQuadlets))
+	for _, unit := range units {
+		if dst, ok := report.InstalledQuadlets[filepath.Base(unit.Path)]; ok {
+			installed[unit.Path] = dst
+		}
+	}
+	report.InstalledQuadlets = installed
+	return report, nil
+}
+
+func addTarFile(tw *tar.Writer, name, src string) error {
+	content, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	if err := tw.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeReg, Mode: 0o644, Size: int64(len(content))}); err != nil {
+		return err
+	}
+	_, err = tw.Write(content)
+	return err
+}
+
+// InstallWithBody installs the Quadlets in the tar archive body. The Quadlet
+// files must be at the root of the archive.
+func InstallWithBody(ctx context.Context, body io.Reader, options *InstallOptions) (*entitiesTypes.QuadletInstallReport, error) {
+	var report entitiesTypes.QuadletInstallReport
+	if options == nil {
+		options = new(InstallOptions)
+	}
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params, err := options.ToParams()
+	if err != nil {
+		return nil, err
+	}
+	header := make(http.Header)
+	header.Set("Content-Type", "application/x-tar")
+	response, err := conn.DoRequest(ctx, body, http.MethodPost, "/quadlets", params, header)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	return &report, response.Process(&report)
+}
+
+// List returns the installed Quadlets.
+func List(ctx context.Context, options *ListOptions) ([]*entitiesTypes.ListQuadlet, error) {
+	var quadlets []*entitiesTypes.ListQuadlet
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params, err := options.ToParams()
+	if err != nil {
+		return nil, err
+	}
+	response, err := conn.DoRequest(ctx, nil, http.MethodGet, "/quadlets/json", params, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	return quadlets, response.Process(&quadlets)
+}
+
+// Print returns the content of an installed Quadlet file.
+func Print(ctx context.Context, name string) (string, error) {
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return "", err
+	}
+	response, err := conn.DoRequest(ctx, nil, http.MethodGet, "/quadlets/%s/file", nil, nil, name)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+
+	if !response.IsSuccess() {
+		return "", response.Process(nil)
+	}
+	content, err := io.ReadAll(response.Body)
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
+
+// Remove removes installed Quadlets and the files installed alongside them.
+func Remove(ctx context.Context, names []string, options *RemoveOptions) (*entitiesTypes.QuadletRemoveReport, error) {
+	var report entitiesTypes.QuadletRemoveReport
+	if options == nil {
+		options = new(RemoveOptions)
+	}
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params, err := options.ToParams()
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range names {
+		params.Add("quadlets", name)
+	}
+	response, err := conn.DoRequest(ctx, nil, http.MethodDelete, "/quadlets", params, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	return &report, response.Process(&report)
+}
@@ -0,0 +1,31 @@
+package quadlets
+
+// InstallOptions are optional options for installing Quadlets
+//
+//go:generate go run ../generator/generator.go InstallOptions
+type InstallOptions struct {
+	// Replace existing Quadlets and files with the same name
+	Replace *bool
+	// ReloadSystemd reloads systemd after installing the Quadlets
+	ReloadSystemd *bool
+}
+
+// ListOptions are optional options for listing Quadlets
+//
+//go:generate go run ../generator/generator.go ListOptions
+type ListOptions struct {
+}
+
+// RemoveOptions are optional options for removing Quadlets
+//
+//go:generate go run ../generator/generator.go RemoveOptions
+type RemoveOptions struct {
+	// All removes all installed Quadlets
+	All *bool
+	// Force stops running Quadlets before removing them
+	Force *bool
+	// Ignore Quadlets which are not installed
+	Ignore *bool
+	// ReloadSystemd reloads systemd after removing the Quadlets
+	ReloadSystemd *bool
+}
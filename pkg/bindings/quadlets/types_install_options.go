@@ -0,0 +1,48 @@
+// Code generated by go generate; DO NOT EDIT.
+package quadlets
+
+import (
+	"net/url"
+
+	"github.com/containers/podman/v5/pkg/bindings/internal/util"
+)
+
+// Changed returns true if named field has been set
+func (o *InstallOptions) Changed(fieldName string) bool {
+	return util.Changed(o, fieldName)
+}
+
+// ToParams formats struct fields to be passed to API service
+func (o *InstallOptions) ToParams() (url.Values, error) {
+	return util.ToParams(o)
+}
+
+// WithReplace set field Replace to given value
+func (o *InstallOptions) WithReplace(value bool) *InstallOptions {
+	o.Replace = &value
+	return o
+}
+
+// GetReplace returns value of field Replace
+func (o *InstallOptions) GetReplace() bool {
+	if o.Replace == nil {
+		var z bool
+		return z
+	}
+	return *o.Replace
+}
+
+// WithReloadSystemd set field ReloadSystemd to given value
+func (o *InstallOptions) WithReloadSystemd(value bool) *InstallOptions {
+	o.ReloadSystemd = &value
+	return o
+}
+
+// GetReloadSystemd returns value of field ReloadSystemd
+func (o *InstallOptions) GetReloadSystemd() bool {
+	if o.ReloadSystemd == nil {
+		var z bool
+		return z
+	}
+	return *o.ReloadSystemd
+}
@@ -0,0 +1,18 @@
+// Code generated by go generate; DO NOT EDIT.
+package quadlets
+
+import (
+	"net/url"
+
+	"github.com/containers/podman/v5/pkg/bindings/internal/util"
+)
+
+// Changed returns true if named field has been set
+func (o *ListOptions) Changed(fieldName string) bool {
+	return util.Changed(o, fieldName)
+}
+
+// ToParams formats struct fields to be passed to API service
+func (o *ListOptions) ToParams() (url.Values, error) {
+	return util.ToParams(o)
+}
@@ -71,6 +71,47 @@ func Remove(ctx context.Context, nameOrID string) error {
 	return response.Process(nil)
 }
 
+// Rekey encrypts the data of a file driver secret again with the given driver options
+func Rekey(ctx context.Context, nameOrID string, options *RekeyOptions) (*entitiesTypes.SecretRekeyReport, error) {
+	var (
+		report *entitiesTypes.SecretRekeyReport
+	)
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params, err := options.ToParams()
+	if err != nil {
+		return nil, err
+	}
+	response, err := conn.DoRequest(ctx, nil, http.MethodPost, "/secrets/%s/rekey", params, nil, nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	return report, response.Process(&report)
+}
+
+// Update stores new data for a secret as a new version and rotates it in the
+// running containers using it with the rotate option
+func Update(ctx context.Context, nameOrID string, reader io.Reader) (*entitiesTypes.SecretUpdateReport, error) {
+	var (
+		report *entitiesTypes.SecretUpdateReport
+	)
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	response, err := conn.DoRequest(ctx, reader, http.MethodPost, "/secrets/%s/update", nil, nil, nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	return report, response.Process(&report)
+}
+
 // Create creates a secret given some data
 func Create(ctx context.Context, reader io.Reader, options *CreateOptions) (*entitiesTypes.SecretCreateReport, error) {
 	var (
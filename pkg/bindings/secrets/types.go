@@ -20,6 +20,13 @@ type InspectOptions struct {
 type RemoveOptions struct {
 }
 
+// RekeyOptions are optional options for rekeying secrets
+//
+//go:generate go run ../generator/generator.go RekeyOptions
+type RekeyOptions struct {
+	DriverOpts map[string]string
+}
+
 // CreateOptions are optional options for Creating secrets
 //
 //go:generate go run ../generator/generator.go CreateOptions
@@ -0,0 +1,33 @@
+// Code generated by go generate; DO NOT EDIT.
+package secrets
+
+import (
+	"net/url"
+
+	"github.com/containers/podman/v5/pkg/bindings/internal/util"
+)
+
+// Changed returns true if named field has been set
+func (o *RekeyOptions) Changed(fieldName string) bool {
+	return util.Changed(o, fieldName)
+}
+
+// ToParams formats struct fields to be passed to API service
+func (o *RekeyOptions) ToParams() (url.Values, error) {
+	return util.ToParams(o)
+}
+
+// WithDriverOpts set field DriverOpts to given value
+func (o *RekeyOptions) WithDriverOpts(value map[string]string) *RekeyOptions {
+	o.DriverOpts = value
+	return o
+}
+
+// GetDriverOpts returns value of field DriverOpts
+func (o *RekeyOptions) GetDriverOpts() map[string]string {
+	if o.DriverOpts == nil {
+		var z map[string]string
+		return z
+	}
+	return o.DriverOpts
+}
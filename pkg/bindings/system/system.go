@@ -3,11 +3,17 @@ package system
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"os"
+	"strconv"
 	"time"
 
+	imageTypes "github.com/containers/image/v5/types"
 	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/pkg/auth"
 	"github.com/containers/podman/v5/pkg/bindings"
 	"github.com/containers/podman/v5/pkg/domain/entities/types"
 	"github.com/sirupsen/logrus"
@@ -170,3 +176,74 @@ func DiskUsage(ctx context.Context, options *DiskOptions) (*types.SystemDfReport
 
 	return &report, response.Process(&report)
 }
+
+// AutoUpdate auto-updates containers according to their auto-update policy.
+// The progress of the auto-update is written to the progress writer of the
+// options or to stderr.  Errors of individual containers do not prevent
+// others from being updated and are returned along with the reports.
+func AutoUpdate(ctx context.Context, options *AutoUpdateOptions) ([]*types.AutoUpdateReport, []error) {
+	if options == nil {
+		options = new(AutoUpdateOptions)
+	}
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, []error{err}
+	}
+	params, err := options.ToParams()
+	if err != nil {
+		return nil, []error{err}
+	}
+	// SkipTLSVerify is special.  It's not being serialized by ToParams()
+	// because we need to flip the boolean.
+	if options.SkipTLSVerify != nil {
+		params.Set("tlsVerify", strconv.FormatBool(!options.GetSkipTLSVerify()))
+	}
+
+	// Only send credentials of the client if asked for, so the server
+	// falls back to its own credentials otherwise.
+	var header http.Header
+	if options.Authfile != nil {
+		header, err = auth.MakeXRegistryAuthHeader(&imageTypes.SystemContext{AuthFilePath: options.GetAuthfile()}, "", "")
+		if err != nil {
+			return nil, []error{err}
+		}
+	}
+
+	response, err := conn.DoRequest(ctx, nil, http.MethodPost, "/system/auto-update", params, header)
+	if err != nil {
+		return nil, []error{err}
+	}
+	defer response.Body.Close()
+
+	if !response.IsSuccess() {
+		return nil, []error{response.Process(err)}
+	}
+
+	var writer io.Writer = os.Stderr
+	if progressWriter := options.GetProgressWriter(); progressWriter != nil {
+		writer = progressWriter
+	}
+
+	dec := json.NewDecoder(response.Body)
+	var (
+		reports    []*types.AutoUpdateReport
+		updateErrs []error
+	)
+	for {
+		var report types.AutoUpdateStreamReport
+		if err := dec.Decode(&report); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return reports, append(updateErrs, fmt.Errorf("failed to parse auto-update results stream: %w", err))
+		}
+		if report.Stream != "" {
+			fmt.Fprint(writer, report.Stream)
+		}
+		reports = append(reports, report.Reports...)
+		for _, e := range report.Errors {
+			updateErrs = append(updateErrs, errors.New(e))
+		}
+	}
+	return reports, updateErrs
+}
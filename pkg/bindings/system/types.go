@@ -1,5 +1,7 @@
 package system
 
+import "io"
+
 // EventsOptions are optional options for monitoring events
 //
 //go:generate go run ../generator/generator.go EventsOptions
@@ -48,3 +50,32 @@ type CheckOptions struct {
 	RepairLossy                 *bool   `schema:"repair_lossy"`
 	UnreferencedLayerMaximumAge *string `schema:"unreferenced_layer_max_age"`
 }
+
+// AutoUpdateOptions are optional options for auto-updating containers
+//
+//go:generate go run ../generator/generator.go AutoUpdateOptions
+type AutoUpdateOptions struct {
+	// Authfile is the path to the authentication file of the client.
+	// Its credentials are sent to the server, which uses its own
+	// credentials if not set.
+	Authfile *string `schema:"-"`
+	// DryRun only checks for pending updates.
+	DryRun *bool
+	// ProgressWriter is a writer where the progress of the auto-update is
+	// sent.  Defaults to stderr.
+	ProgressWriter *io.Writer `schema:"-"`
+	// Recreate containers which do not run in a systemd unit with the
+	// updated image.
+	Recreate *bool
+	// RequireSignatures refuses updates to images which are not signed
+	// according to the signature policy of the server.
+	RequireSignatures *bool
+	// Rollback to the previous image if restarting a systemd unit with
+	// the new image fails.
+	Rollback *bool
+	// SkipTLSVerify to skip HTTPS and certificate verification.
+	SkipTLSVerify *bool `schema:"-"`
+	// SoakPeriod enables staged rollouts, see
+	// entities.AutoUpdateOptions.  Formatted as a Go duration.
+	SoakPeriod *string
+}
@@ -0,0 +1,139 @@
+// Code generated by go generate; DO NOT EDIT.
+package system
+
+import (
+	"io"
+	"net/url"
+
+	"github.com/containers/podman/v5/pkg/bindings/internal/util"
+)
+
+// Changed returns true if named field has been set
+func (o *AutoUpdateOptions) Changed(fieldName string) bool {
+	return util.Changed(o, fieldName)
+}
+
+// ToParams formats struct fields to be passed to API service
+func (o *AutoUpdateOptions) ToParams() (url.Values, error) {
+	return util.ToParams(o)
+}
+
+// WithAuthfile set field Authfile to given value
+func (o *AutoUpdateOptions) WithAuthfile(value string) *AutoUpdateOptions {
+	o.Authfile = &value
+	return o
+}
+
+// GetAuthfile returns value of field Authfile
+func (o *AutoUpdateOptions) GetAuthfile() string {
+	if o.Authfile == nil {
+		var z string
+		return z
+	}
+	return *o.Authfile
+}
+
+// WithDryRun set field DryRun to given value
+func (o *AutoUpdateOptions) WithDryRun(value bool) *AutoUpdateOptions {
+	o.DryRun = &value
+	return o
+}
+
+// GetDryRun returns value of field DryRun
+func (o *AutoUpdateOptions) GetDryRun() bool {
+	if o.DryRun == nil {
+		var z bool
+		return z
+	}
+	return *o.DryRun
+}
+
+// WithProgressWriter set field ProgressWriter to given value
+func (o *AutoUpdateOptions) WithProgressWriter(value io.Writer) *AutoUpdateOptions {
+	o.ProgressWriter = &value
+	return o
+}
+
+// GetProgressWriter returns value of field ProgressWriter
+func (o *AutoUpdateOptions) GetProgressWriter() io.Writer {
+	if o.ProgressWriter == nil {
+		var z io.Writer
+		return z
+	}
+	return *o.ProgressWriter
+}
+
+// WithRecreate set field Recreate to given value
+func (o *AutoUpdateOptions) WithRecreate(value bool) *AutoUpdateOptions {
+	o.Recreate = &value
+	return o
+}
+
+// GetRecreate returns value of field Recreate
+func (o *AutoUpdateOptions) GetRecreate() bool {
+	if o.Recreate == nil {
+		var z bool
+		return z
+	}
+	return *o.Recreate
+}
+
+// WithRequireSignatures set field RequireSignatures to given value
+func (o *AutoUpdateOptions) WithRequireSignatures(value bool) *AutoUpdateOptions {
+	o.RequireSignatures = &value
+	return o
+}
+
+// GetRequireSignatures returns value of field RequireSignatures
+func (o *AutoUpdateOptions) GetRequireSignatures() bool {
+	if o.RequireSignatures == nil {
+		var z bool
+		return z
+	}
+	return *o.RequireSignatures
+}
+
+// WithRollback set field Rollback to given value
+func (o *AutoUpdateOptions) WithRollback(value bool) *AutoUpdateOptions {
+	o.Rollback = &value
+	return o
+}
+
+// GetRollback returns value of field Rollback
+func (o *AutoUpdateOptions) GetRollback() bool {
+	if o.Rollback == nil {
+		var z bool
+		return z
+	}
+	return *o.Rollback
+}
+
+// WithSkipTLSVerify set field SkipTLSVerify to given value
+func (o *AutoUpdateOptions) WithSkipTLSVerify(value bool) *AutoUpdateOptions {
+	o.SkipTLSVerify = &value
+	return o
+}
+
+// GetSkipTLSVerify returns value of field SkipTLSVerify
+func (o *AutoUpdateOptions) GetSkipTLSVerify() bool {
+	if o.SkipTLSVerify == nil {
+		var z bool
+		return z
+	}
+	return *o.SkipTLSVerify
+}
+
+// WithSoakPeriod set field SoakPeriod to given value
+func (o *AutoUpdateOptions) WithSoakPeriod(value string) *AutoUpdateOptions {
+	o.SoakPeriod = &value
+	return o
+}
+
+// GetSoakPeriod returns value of field SoakPeriod
+func (o *AutoUpdateOptions) GetSoakPeriod() string {
+	if o.SoakPeriod == nil {
+		var z string
+		return z
+	}
+	return *o.SoakPeriod
+}
@@ -43,3 +43,39 @@ type RemoveOptions struct {
 //go:generate go run ../generator/generator.go ExistsOptions
 type ExistsOptions struct {
 }
+
+// CloneOptions are optional options for cloning volumes
+//
+//go:generate go run ../generator/generator.go CloneOptions
+type CloneOptions struct {
+	// Name of the new volume
+	Name *string
+	// Snapshot of the volume to clone instead of its current data
+	Snapshot *string
+}
+
+// CreateSnapshotOptions are optional options for creating volume snapshots
+//
+//go:generate go run ../generator/generator.go CreateSnapshotOptions
+type CreateSnapshotOptions struct {
+	// Name of the snapshot, defaults to a name based on the current time
+	Name *string
+}
+
+// ListSnapshotsOptions are optional options for listing volume snapshots
+//
+//go:generate go run ../generator/generator.go ListSnapshotsOptions
+type ListSnapshotsOptions struct {
+}
+
+// RestoreSnapshotOptions are optional options for restoring volume snapshots
+//
+//go:generate go run ../generator/generator.go RestoreSnapshotOptions
+type RestoreSnapshotOptions struct {
+}
+
+// RemoveSnapshotOptions are optional options for removing volume snapshots
+//
+//go:generate go run ../generator/generator.go RemoveSnapshotOptions
+type RemoveSnapshotOptions struct {
+}
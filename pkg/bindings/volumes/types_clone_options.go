@@ -0,0 +1,48 @@
+// Code generated by go generate; DO NOT EDIT.
+package volumes
+
+import (
+	"net/url"
+
+	"github.com/containers/podman/v5/pkg/bindings/internal/util"
+)
+
+// Changed returns true if named field has been set
+func (o *CloneOptions) Changed(fieldName string) bool {
+	return util.Changed(o, fieldName)
+}
+
+// ToParams formats struct fields to be passed to API service
+func (o *CloneOptions) ToParams() (url.Values, error) {
+	return util.ToParams(o)
+}
+
+// WithName set field Name to given value
+func (o *CloneOptions) WithName(value string) *CloneOptions {
+	o.Name = &value
+	return o
+}
+
+// GetName returns value of field Name
+func (o *CloneOptions) GetName() string {
+	if o.Name == nil {
+		var z string
+		return z
+	}
+	return *o.Name
+}
+
+// WithSnapshot set field Snapshot to given value
+func (o *CloneOptions) WithSnapshot(value string) *CloneOptions {
+	o.Snapshot = &value
+	return o
+}
+
+// GetSnapshot returns value of field Snapshot
+func (o *CloneOptions) GetSnapshot() string {
+	if o.Snapshot == nil {
+		var z string
+		return z
+	}
+	return *o.Snapshot
+}
@@ -139,3 +139,109 @@ func Exists(ctx context.Context, nameOrID string, options *ExistsOptions) (bool,
 
 	return response.IsSuccess(), nil
 }
+
+// Clone creates a new volume with the configuration and a copy of the data of
+// the given volume, or of one of its snapshots.
+func Clone(ctx context.Context, nameOrID string, options *CloneOptions) (*entitiesTypes.VolumeConfigResponse, error) {
+	var (
+		v entitiesTypes.VolumeConfigResponse
+	)
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params, err := options.ToParams()
+	if err != nil {
+		return nil, err
+	}
+	response, err := conn.DoRequest(ctx, nil, http.MethodPost, "/volumes/%s/clone", params, nil, nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	return &v, response.Process(&v)
+}
+
+// CreateSnapshot takes a snapshot of the data of a volume.
+func CreateSnapshot(ctx context.Context, nameOrID string, options *CreateSnapshotOptions) (*entitiesTypes.VolumeSnapshotReport, error) {
+	var (
+		snapshot entitiesTypes.VolumeSnapshotReport
+	)
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params, err := options.ToParams()
+	if err != nil {
+		return nil, err
+	}
+	response, err := conn.DoRequest(ctx, nil, http.MethodPost, "/volumes/%s/snapshots", params, nil, nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	return &snapshot, response.Process(&snapshot)
+}
+
+// ListSnapshots returns the snapshots of a volume, oldest first.
+func ListSnapshots(ctx context.Context, nameOrID string, options *ListSnapshotsOptions) ([]*entitiesTypes.VolumeSnapshotReport, error) {
+	var (
+		snapshots []*entitiesTypes.VolumeSnapshotReport
+	)
+	if options == nil {
+		options = new(ListSnapshotsOptions)
+	}
+	_ = options
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	response, err := conn.DoRequest(ctx, nil, http.MethodGet, "/volumes/%s/snapshots", nil, nil, nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	return snapshots, response.Process(&snapshots)
+}
+
+// RestoreSnapshot replaces the data of a volume with the data of one of its
+// snapshots. The volume must not be used by a running container.
+func RestoreSnapshot(ctx context.Context, nameOrID, snapshot string, options *RestoreSnapshotOptions) error {
+	if options == nil {
+		options = new(RestoreSnapshotOptions)
+	}
+	_ = options
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return err
+	}
+	response, err := conn.DoRequest(ctx, nil, http.MethodPost, "/volumes/%s/snapshots/%s/restore", nil, nil, nameOrID, snapshot)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	return response.Process(nil)
+}
+
+// RemoveSnapshot removes a snapshot of a volume.
+func RemoveSnapshot(ctx context.Context, nameOrID, snapshot string, options *RemoveSnapshotOptions) error {
+	if options == nil {
+		options = new(RemoveSnapshotOptions)
+	}
+	_ = options
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return err
+	}
+	response, err := conn.DoRequest(ctx, nil, http.MethodDelete, "/volumes/%s/snapshots/%s", nil, nil, nameOrID, snapshot)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	return response.Process(nil)
+}
@@ -7,9 +7,12 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 
 	metadata "github.com/checkpoint-restore/checkpointctl/lib"
 	"github.com/containers/common/libimage"
+	"github.com/containers/common/libnetwork/types"
 	"github.com/containers/common/pkg/config"
 	"github.com/containers/podman/v5/libpod"
 	ann "github.com/containers/podman/v5/pkg/annotations"
@@ -187,12 +190,7 @@ func CRImportCheckpoint(ctx context.Context, runtime *libpod.Runtime, restoreOpt
 	}
 
 	if len(restoreOptions.PublishPorts) > 0 {
-		pubPorts, err := specgenutil.CreatePortBindings(restoreOptions.PublishPorts)
-		if err != nil {
-			return nil, err
-		}
-
-		ports, err := generate.ParsePortMapping(pubPorts, nil)
+		ports, err := crPortMappings(restoreOptions.PublishPorts)
 		if err != nil {
 			return nil, err
 		}
@@ -233,3 +231,206 @@ func CRImportCheckpoint(ctx context.Context, runtime *libpod.Runtime, restoreOpt
 	containers = append(containers, container)
 	return containers, nil
 }
+
+// crPortMappings parses the ports to publish of a restored container.
+func crPortMappings(publishPorts []string) ([]types.PortMapping, error) {
+	pubPorts, err := specgenutil.CreatePortBindings(publishPorts)
+	if err != nil {
+		return nil, err
+	}
+	return generate.ParsePortMapping(pubPorts, nil)
+}
+
+// CRImportPodCheckpoint re-creates a pod from a pod checkpoint archive and
+// restores all of its containers into it.  The infra container of the pod
+// is recreated from its configuration and started before the containers
+// are restored.
+func CRImportPodCheckpoint(ctx context.Context, runtime *libpod.Runtime, restoreOptions entities.PodRestoreOptions) (_ *libpod.Pod, _ []*libpod.Container, retErr error) {
+	dir, err := os.MkdirTemp("", "pod-checkpoint")
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() {
+		if err := os.RemoveAll(dir); err != nil {
+			logrus.Errorf("Could not recursively remove %s: %q", dir, err)
+		}
+	}()
+	if err := crutils.CRImportPodCheckpoint(dir, restoreOptions.Import); err != nil {
+		return nil, nil, err
+	}
+
+	podConfig := new(libpod.PodConfig)
+	if _, err := metadata.ReadJSONFile(podConfig, dir, metadata.PodDumpFile); err != nil {
+		return nil, nil, err
+	}
+	oldPodName := podConfig.Name
+	newName := restoreOptions.Name != ""
+	if newName {
+		podConfig.ID = ""
+		podConfig.Name = restoreOptions.Name
+	}
+
+	pod, err := runtime.RestorePod(ctx, podConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() {
+		if retErr != nil {
+			if _, err := runtime.RemovePod(ctx, pod, true, true, nil); err != nil {
+				logrus.Errorf("Removing pod %s after failed restore: %v", pod.ID(), err)
+			}
+		}
+	}()
+
+	if pod.HasInfraContainer() {
+		if err := crRestorePodInfra(ctx, runtime, pod, filepath.Join(dir, crutils.PodCheckpointInfraDirectory), restoreOptions, newName); err != nil {
+			return nil, nil, fmt.Errorf("restoring infra container of pod %s: %w", pod.Name(), err)
+		}
+	}
+
+	ctrsDir := filepath.Join(dir, crutils.PodCheckpointContainersDirectory)
+	entries, err := os.ReadDir(ctrsDir)
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading containers of pod checkpoint: %w", err)
+	}
+
+	containers := make([]*libpod.Container, 0, len(entries))
+	for _, entry := range entries {
+		id, ok := strings.CutSuffix(entry.Name(), ".tar")
+		if !ok {
+			continue
+		}
+		input := filepath.Join(ctrsDir, entry.Name())
+		configDir := filepath.Join(dir, "config", id)
+		if err := crutils.CRImportCheckpointConfigOnly(configDir, input); err != nil {
+			return nil, nil, err
+		}
+
+		ctrOptions := entities.RestoreOptions{
+			Import:        input,
+			IgnoreVolumes: restoreOptions.IgnoreVolumes,
+			Pod:           pod.ID(),
+		}
+		if newName {
+			ctrConfig := new(libpod.ContainerConfig)
+			if _, err := metadata.ReadJSONFile(ctrConfig, configDir, metadata.ConfigDumpFile); err != nil {
+				return nil, nil, err
+			}
+			ctrOptions.Name = podCheckpointContainerName(ctrConfig.Name, oldPodName, restoreOptions.Name)
+		}
+
+		ctrs, err := CRImportCheckpoint(ctx, runtime, ctrOptions, configDir)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(ctrs) != 1 {
+			return nil, nil, fmt.Errorf("expected to import one container from %s, got %d", entry.Name(), len(ctrs))
+		}
+
+		if _, _, err := ctrs[0].Restore(ctx, libpod.ContainerCheckpointOptions{
+			TargetFile:      input,
+			Name:            ctrOptions.Name,
+			Pod:             pod.ID(),
+			Keep:            restoreOptions.Keep,
+			TCPEstablished:  restoreOptions.TCPEstablished,
+			IgnoreRootfs:    restoreOptions.IgnoreRootFS,
+			IgnoreVolumes:   restoreOptions.IgnoreVolumes,
+			IgnoreStaticIP:  restoreOptions.IgnoreStaticIP,
+			IgnoreStaticMAC: restoreOptions.IgnoreStaticMAC,
+			FileLocks:       restoreOptions.FileLocks,
+		}); err != nil {
+			return nil, nil, fmt.Errorf("restoring container %s of pod %s: %w", ctrs[0].Name(), pod.Name(), err)
+		}
+		containers = append(containers, ctrs[0])
+	}
+
+	return pod, containers, nil
+}
+
+// crRestorePodInfra re-creates the infra container of a restored pod from
+// the configuration in dir and starts it, so that the containers of the
+// pod can be restored into its namespaces.
+func crRestorePodInfra(ctx context.Context, runtime *libpod.Runtime, pod *libpod.Pod, dir string, restoreOptions entities.PodRestoreOptions, newName bool) error {
+	infraSpec := new(spec.Spec)
+	if _, err := metadata.ReadJSONFile(infraSpec, dir, metadata.SpecDumpFile); err != nil {
+		return err
+	}
+	infraConfig := new(libpod.ContainerConfig)
+	if _, err := metadata.ReadJSONFile(infraConfig, dir, metadata.ConfigDumpFile); err != nil {
+		return err
+	}
+
+	if newName {
+		infraConfig.ID = ""
+		infraConfig.Name = pod.ID()[:12] + "-infra"
+	}
+	infraConfig.Pod = pod.ID()
+	cgroupPath, err := pod.CgroupPath()
+	if err != nil {
+		return fmt.Errorf("cannot retrieve cgroup path from pod %q: %w", pod.ID(), err)
+	}
+	infraConfig.CgroupParent = cgroupPath
+
+	if restoreOptions.IgnoreStaticIP || restoreOptions.IgnoreStaticMAC {
+		for net, opts := range infraConfig.Networks {
+			if restoreOptions.IgnoreStaticIP {
+				opts.StaticIPs = nil
+			}
+			if restoreOptions.IgnoreStaticMAC {
+				opts.StaticMAC = nil
+			}
+			infraConfig.Networks[net] = opts
+		}
+		if restoreOptions.IgnoreStaticIP {
+			infraConfig.StaticIP = nil
+		}
+		if restoreOptions.IgnoreStaticMAC {
+			infraConfig.StaticMAC = nil
+		}
+	}
+
+	if len(restoreOptions.PublishPorts) > 0 {
+		ports, err := crPortMappings(restoreOptions.PublishPorts)
+		if err != nil {
+			return err
+		}
+		infraConfig.PortMappings = ports
+	}
+
+	// The local pause image is built on demand, it does not exist when
+	// the pod is restored on another host.
+	imageName := infraConfig.RootfsImageName
+	if _, _, err := runtime.LibimageRuntime().LookupImage(imageName, nil); err != nil && strings.HasPrefix(imageName, "localhost/podman-pause:") {
+		imageName = ""
+	}
+	imageName, err = generate.PullOrBuildInfraImage(runtime, imageName)
+	if err != nil {
+		return err
+	}
+	img, _, err := runtime.LibimageRuntime().LookupImage(imageName, nil)
+	if err != nil {
+		return err
+	}
+	infraConfig.RootfsImageName = imageName
+	infraConfig.RootfsImageID = img.ID()
+
+	infra, err := runtime.RestoreContainer(ctx, infraSpec, infraConfig)
+	if err != nil {
+		return err
+	}
+	if _, err := runtime.AddInfra(ctx, pod, infra); err != nil {
+		return err
+	}
+	return infra.Start(ctx, false)
+}
+
+// podCheckpointContainerName returns the name of a container that is
+// restored into a pod with a new name.  The name of the checkpointed pod is
+// replaced by the new name if the container name starts with it, otherwise
+// the new name of the pod is prepended.
+func podCheckpointContainerName(ctrName, oldPodName, newPodName string) string {
+	if suffix, ok := strings.CutPrefix(ctrName, oldPodName+"-"); ok {
+		return newPodName + "-" + suffix
+	}
+	return newPodName + "-" + ctrName
+}
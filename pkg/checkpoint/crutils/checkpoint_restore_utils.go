@@ -18,6 +18,33 @@ import (
 // This file mainly exists to make the checkpoint/restore functions
 // available for other users. One possible candidate would be CRI-O.
 
+const (
+	// PodCheckpointInfraDirectory is the directory of a pod checkpoint
+	// archive holding the "config.dump" and "spec.dump" of the infra
+	// container.
+	PodCheckpointInfraDirectory = "infra"
+	// PodCheckpointContainersDirectory is the directory of a pod
+	// checkpoint archive holding the checkpoint archives of all
+	// containers of the pod.
+	PodCheckpointContainersDirectory = "containers"
+)
+
+// CRImportPodCheckpoint imports the pod checkpoint archive (input) into
+// the directory destination.
+func CRImportPodCheckpoint(destination, input string) error {
+	archiveFile, err := os.Open(input)
+	if err != nil {
+		return fmt.Errorf("failed to open pod checkpoint archive %s for import: %w", input, err)
+	}
+
+	defer archiveFile.Close()
+	if err = archive.Untar(archiveFile, destination, nil); err != nil {
+		return fmt.Errorf("unpacking of pod checkpoint archive %s failed: %w", input, err)
+	}
+
+	return nil
+}
+
 // CRImportCheckpointWithoutConfig imports the checkpoint archive (input)
 // into the directory destination without "config.dump" and "spec.dump"
 func CRImportCheckpointWithoutConfig(destination, input string) error {
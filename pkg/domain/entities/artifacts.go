@@ -0,0 +1,113 @@
+package entities
+
+import (
+	"io"
+
+	"github.com/containers/image/v5/types"
+	entitiesTypes "github.com/containers/podman/v5/pkg/domain/entities/types"
+	"github.com/containers/podman/v5/pkg/libartifact"
+)
+
+// ArtifactAddOptions controls the creation of an artifact from files
+type ArtifactAddOptions struct {
+	// Annotations are added to the manifest of the artifact
+	Annotations map[string]string
+	// ArtifactType is the type of the artifact
+	ArtifactType string
+	// FileType is the media type of the added files
+	FileType string
+	// Append adds the files to an existing artifact
+	Append bool
+}
+
+type ArtifactAddReport = entitiesTypes.ArtifactAddReport
+
+// ArtifactExtractOptions selects the files of an artifact to extract
+type ArtifactExtractOptions = libartifact.ExtractOptions
+
+// ArtifactInspectOptions controls the inspection of an artifact
+type ArtifactInspectOptions struct{}
+
+type ArtifactInspectReport = entitiesTypes.ArtifactInspectReport
+
+// ArtifactListOptions controls the listing of artifacts
+type ArtifactListOptions struct{}
+
+type ArtifactListReport = entitiesTypes.ArtifactListReport
+
+// ArtifactPullOptions controls pulling an artifact from a registry
+type ArtifactPullOptions struct {
+	// Authfile is the path to the authentication file. Ignored for remote
+	// calls.
+	Authfile string
+	// CertDir is the path to certificate directories.  Ignored for remote
+	// calls.
+	CertDir string
+	// Username for authenticating against the registry.
+	Username string
+	// Password for authenticating against the registry.
+	Password string
+	// Quiet can be specified to suppress pull progress when pulling.
+	Quiet bool
+	// Retry number of times to retry pull in case of failure
+	Retry *uint
+	// RetryDelay between retries in case of pull failures
+	RetryDelay string
+	// SignaturePolicy to use when pulling.  Ignored for remote calls.
+	SignaturePolicy string
+	// SkipTLSVerify to skip HTTPS and certificate verification.
+	SkipTLSVerify types.OptionalBool
+	// Writer is used to display copy information including progress bars.
+	Writer io.Writer
+}
+
+type ArtifactPullReport = entitiesTypes.ArtifactPullReport
+
+// ArtifactPushOptions controls pushing an artifact to a registry
+type ArtifactPushOptions struct {
+	// Authfile is the path to the authentication file. Ignored for remote
+	// calls.
+	Authfile string
+	// CertDir is the path to certificate directories.  Ignored for remote
+	// calls.
+	CertDir string
+	// Username for authenticating against the registry.
+	Username string
+	// Password for authenticating against the registry.
+	Password string
+	// Quiet can be specified to suppress push progress when pushing.
+	Quiet bool
+	// Retry number of times to retry push in case of failure
+	Retry *uint
+	// RetryDelay between retries in case of push failures
+	RetryDelay string
+	// SignBy adds a signature at the destination using the specified key.
+	// Ignored for remote calls.
+	SignBy string
+	// SignPassphrase, if non-empty, specifies a passphrase to use when signing
+	// with the key ID from SignBy.
+	SignPassphrase string
+	// SignBySigstorePrivateKeyFile, if non-empty, asks for a signature to be added
+	// during the copy, using a sigstore private key file at the provided path.
+	// Ignored for remote calls.
+	SignBySigstorePrivateKeyFile string
+	// SignSigstorePrivateKeyPassphrase is the passphrase to use when signing with
+	// SignBySigstorePrivateKeyFile.
+	SignSigstorePrivateKeyPassphrase []byte
+	// SkipTLSVerify to skip HTTPS and certificate verification.
+	SkipTLSVerify types.OptionalBool
+	// Writer is used to display copy information including progress bars.
+	Writer io.Writer
+}
+
+type ArtifactPushReport = entitiesTypes.ArtifactPushReport
+
+// ArtifactRemoveOptions controls the removal of artifacts
+type ArtifactRemoveOptions struct {
+	// All removes all artifacts
+	All bool
+	// Force removes the containers using the artifacts
+	Force bool
+}
+
+type ArtifactRemoveReport = entitiesTypes.ArtifactRemoveReport
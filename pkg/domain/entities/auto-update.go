@@ -1,6 +1,12 @@
 package entities
 
-import "github.com/containers/image/v5/types"
+import (
+	"io"
+	"time"
+
+	"github.com/containers/image/v5/types"
+	entitiesTypes "github.com/containers/podman/v5/pkg/domain/entities/types"
+)
 
 // AutoUpdateOptions are the options for running auto-update.
 type AutoUpdateOptions struct {
@@ -10,27 +16,32 @@ type AutoUpdateOptions struct {
 	// pending, it will be indicated in the Updated field of
 	// AutoUpdateReport.
 	DryRun bool
+	// Recreate containers which do not run in a systemd unit with the
+	// updated image.  Otherwise, updating such containers fails.
+	Recreate bool
 	// If restarting the service with the new image failed, restart it
 	// another time with the previous image.
 	Rollback bool
 	// Allow contacting registries over HTTP, or HTTPS with failed TLS
 	// verification. Note that this does not affect other TLS connections.
 	InsecureSkipTLSVerify types.OptionalBool
+	// ProgressWriter, if set, receives the progress of the auto-update,
+	// such as the output of image pulls and the restarts of systemd
+	// units.  Otherwise, image pulls are reported on stderr.
+	ProgressWriter io.Writer
+	// RequireSignatures refuses updates to images which the signature
+	// policy does not require to be signed or whose signatures cannot be
+	// verified.
+	RequireSignatures bool
+	// SoakPeriod, if set, enables staged rollouts.  One systemd unit or
+	// container per image is updated first and must stay healthy for the
+	// soak period before the others are updated.
+	SoakPeriod time.Duration
 }
 
 // AutoUpdateReport contains the results from running auto-update.
-type AutoUpdateReport struct {
-	// ID of the container *before* an update.
-	ContainerID string
-	// Name of the container *before* an update.
-	ContainerName string
-	// Name of the image.
-	ImageName string
-	// The configured auto-update policy.
-	Policy string
-	// SystemdUnit running a container configured for auto updates.
-	SystemdUnit string
-	// Indicates the update status: true, false, failed, pending (see
-	// DryRun).
-	Updated string
-}
+type AutoUpdateReport = entitiesTypes.AutoUpdateReport
+
+// AutoUpdateStreamReport is a message of the streamed output of the
+// auto-update endpoint of the remote API.
+type AutoUpdateStreamReport = entitiesTypes.AutoUpdateStreamReport
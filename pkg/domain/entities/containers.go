@@ -276,6 +276,22 @@ type ContainerLogsOptions struct {
 	StderrWriter io.Writer
 }
 
+// ContainerLogForwardOptions describes the options to forward the logs of a
+// container to the collector of its log driver.
+type ContainerLogForwardOptions struct {
+	// Tag of the forwarded log messages.
+	Tag string
+	// Ready is called once the log is followed.
+	Ready func()
+}
+
+// ContainerLogRotateOptions describes the options to keep the rotated log
+// files of a container.
+type ContainerLogRotateOptions struct {
+	// Ready is called once the log is followed.
+	Ready func()
+}
+
 // ExecOptions describes the cli values to exec into
 // a container
 type ExecOptions struct {
@@ -34,6 +34,8 @@ type ContainerEngine interface { //nolint:interfacebloat
 	ContainerKill(ctx context.Context, namesOrIds []string, options KillOptions) ([]*KillReport, error)
 	ContainerList(ctx context.Context, options ContainerListOptions) ([]ListContainer, error)
 	ContainerListExternal(ctx context.Context) ([]ListContainer, error)
+	ContainerLogForward(ctx context.Context, nameOrID string, options ContainerLogForwardOptions) error
+	ContainerLogRotate(ctx context.Context, nameOrID string, options ContainerLogRotateOptions) error
 	ContainerLogs(ctx context.Context, containers []string, options ContainerLogsOptions) error
 	ContainerMount(ctx context.Context, nameOrIDs []string, options ContainerMountOptions) ([]*ContainerMountReport, error)
 	ContainerPause(ctx context.Context, namesOrIds []string, options PauseUnPauseOptions) ([]*PauseUnpauseReport, error)
@@ -61,13 +63,14 @@ type ContainerEngine interface { //nolint:interfacebloat
 	GenerateKube(ctx context.Context, nameOrIDs []string, opts GenerateKubeOptions) (*GenerateKubeReport, error)
 	SystemPrune(ctx context.Context, options SystemPruneOptions) (*SystemPruneReport, error)
 	HealthCheckRun(ctx context.Context, nameOrID string, options HealthCheckOptions) (*define.HealthCheckResults, error)
+	HealthCheckReadiness(ctx context.Context, nameOrID string) error
 	Info(ctx context.Context) (*define.Info, error)
 	KubeApply(ctx context.Context, body io.Reader, opts ApplyOptions) error
 	Locks(ctx context.Context) (*LocksReport, error)
 	Migrate(ctx context.Context, options SystemMigrateOptions) error
 	NetworkConnect(ctx context.Context, networkname string, options NetworkConnectOptions) error
 	NetworkCreate(ctx context.Context, network netTypes.Network, createOptions *netTypes.NetworkCreateOptions) (*netTypes.Network, error)
-	NetworkUpdate(ctx context.Context, networkname string, options NetworkUpdateOptions) error
+	NetworkUpdate(ctx context.Context, networkname string, options NetworkUpdateOptions) (*NetworkUpdateReport, error)
 	NetworkDisconnect(ctx context.Context, networkname string, options NetworkDisconnectOptions) error
 	NetworkExists(ctx context.Context, networkname string) (*BoolReport, error)
 	NetworkInspect(ctx context.Context, namesOrIds []string, options InspectOptions) ([]NetworkInspectReport, []error, error)
@@ -78,6 +81,7 @@ type ContainerEngine interface { //nolint:interfacebloat
 	PlayKube(ctx context.Context, body io.Reader, opts PlayKubeOptions) (*PlayKubeReport, error)
 	PlayKubeDown(ctx context.Context, body io.Reader, opts PlayKubeDownOptions) (*PlayKubeReport, error)
 	PodCreate(ctx context.Context, specg PodSpec) (*PodCreateReport, error)
+	PodCheckpoint(ctx context.Context, nameOrID string, options PodCheckpointOptions) (*PodCheckpointReport, error)
 	PodClone(ctx context.Context, podClone PodCloneOptions) (*PodCloneReport, error)
 	PodExists(ctx context.Context, nameOrID string) (*BoolReport, error)
 	PodInspect(ctx context.Context, namesOrID []string, options InspectOptions) ([]*PodInspectReport, []error, error)
@@ -87,12 +91,18 @@ type ContainerEngine interface { //nolint:interfacebloat
 	PodPrune(ctx context.Context, options PodPruneOptions) ([]*PodPruneReport, error)
 	PodPs(ctx context.Context, options PodPSOptions) ([]*ListPodsReport, error)
 	PodRestart(ctx context.Context, namesOrIds []string, options PodRestartOptions) ([]*PodRestartReport, error)
+	PodRestore(ctx context.Context, options PodRestoreOptions) (*PodRestoreReport, error)
 	PodRm(ctx context.Context, namesOrIds []string, options PodRmOptions) ([]*PodRmReport, error)
 	PodStart(ctx context.Context, namesOrIds []string, options PodStartOptions) ([]*PodStartReport, error)
 	PodStats(ctx context.Context, namesOrIds []string, options PodStatsOptions) ([]*PodStatsReport, error)
 	PodStop(ctx context.Context, namesOrIds []string, options PodStopOptions) ([]*PodStopReport, error)
 	PodTop(ctx context.Context, options PodTopOptions) (*StringSliceReport, error)
 	PodUnpause(ctx context.Context, namesOrIds []string, options PodunpauseOptions) ([]*PodUnpauseReport, error)
+	PodUpdate(ctx context.Context, options *PodUpdateOptions) (string, error)
+	QuadletInstall(ctx context.Context, paths []string, options QuadletInstallOptions) (*QuadletInstallReport, error)
+	QuadletList(ctx context.Context, options QuadletListOptions) ([]*ListQuadlet, error)
+	QuadletPrint(ctx context.Context, quadlet string) (string, error)
+	QuadletRemove(ctx context.Context, quadlets []string, options QuadletRemoveOptions) (*QuadletRemoveReport, error)
 	Renumber(ctx context.Context) error
 	Reset(ctx context.Context) error
 	SetupRootless(ctx context.Context, noMoveProcess bool, cgroupMode string) error
@@ -100,12 +110,15 @@ type ContainerEngine interface { //nolint:interfacebloat
 	SecretInspect(ctx context.Context, nameOrIDs []string, options SecretInspectOptions) ([]*SecretInfoReport, []error, error)
 	SecretList(ctx context.Context, opts SecretListRequest) ([]*SecretInfoReport, error)
 	SecretRm(ctx context.Context, nameOrID []string, opts SecretRmOptions) ([]*SecretRmReport, error)
+	SecretRekey(ctx context.Context, nameOrIDs []string, opts SecretRekeyOptions) ([]*SecretRekeyReport, error)
+	SecretUpdate(ctx context.Context, nameOrID string, reader io.Reader) (*SecretUpdateReport, error)
 	SecretExists(ctx context.Context, nameOrID string) (*BoolReport, error)
 	Shutdown(ctx context.Context)
 	SystemDf(ctx context.Context, options SystemDfOptions) (*SystemDfReport, error)
 	SystemCheck(ctx context.Context, options SystemCheckOptions) (*SystemCheckReport, error)
 	Unshare(ctx context.Context, args []string, options SystemUnshareOptions) error
 	Version(ctx context.Context) (*SystemVersionReport, error)
+	VolumeClone(ctx context.Context, nameOrID string, opts VolumeCloneOptions) (*IDOrNameResponse, error)
 	VolumeCreate(ctx context.Context, opts VolumeCreateOptions) (*IDOrNameResponse, error)
 	VolumeExists(ctx context.Context, namesOrID string) (*BoolReport, error)
 	VolumeMounted(ctx context.Context, namesOrID string) (*BoolReport, error)
@@ -116,4 +129,8 @@ type ContainerEngine interface { //nolint:interfacebloat
 	VolumeRm(ctx context.Context, namesOrIds []string, opts VolumeRmOptions) ([]*VolumeRmReport, error)
 	VolumeUnmount(ctx context.Context, namesOrIds []string) ([]*VolumeUnmountReport, error)
 	VolumeReload(ctx context.Context) (*VolumeReloadReport, error)
+	VolumeSnapshotCreate(ctx context.Context, nameOrID, snapshot string) (*VolumeSnapshotReport, error)
+	VolumeSnapshotList(ctx context.Context, nameOrID string) ([]*VolumeSnapshotReport, error)
+	VolumeSnapshotRestore(ctx context.Context, nameOrID, snapshot string) error
+	VolumeSnapshotRm(ctx context.Context, nameOrID string, snapshots []string) ([]*VolumeSnapshotRmReport, error)
 }
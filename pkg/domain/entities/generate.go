@@ -36,6 +36,8 @@ type GenerateKubeOptions struct {
 	Type string
 	// Replicas - the value to set in the replicas field for a Deployment
 	Replicas int32
+	// Schedule - the cron schedule of a CronJob
+	Schedule string
 	// UseLongAnnotations - don't truncate annotations to the Kubernetes maximum length of 63 characters
 	UseLongAnnotations bool
 }
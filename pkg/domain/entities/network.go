@@ -3,6 +3,7 @@ package entities
 import (
 	"net"
 
+	"github.com/containers/common/libnetwork/types"
 	entitiesTypes "github.com/containers/podman/v5/pkg/domain/entities/types"
 )
 
@@ -55,10 +56,23 @@ type NetworkCreateOptions struct {
 
 // NetworkUpdateOptions describes options to update a network
 type NetworkUpdateOptions struct {
-	AddDNSServers    []string `json:"adddnsservers"`
-	RemoveDNSServers []string `json:"removednsservers"`
+	AddDNSServers    []string           `json:"adddnsservers"`
+	RemoveDNSServers []string           `json:"removednsservers"`
+	AddSubnets       []types.Subnet     `json:"addsubnets"`
+	RemoveSubnets    []types.IPNet      `json:"removesubnets"`
+	AddIPRanges      []types.LeaseRange `json:"addipranges"`
+	RemoveIPRanges   []types.LeaseRange `json:"removeipranges"`
+	AddLabels        map[string]string  `json:"addlabels"`
+	RemoveLabels     []string           `json:"removelabels"`
+	AddOptions       map[string]string  `json:"addoptions"`
+	RemoveOptions    []string           `json:"removeoptions"`
+	Internal         *bool              `json:"internal,omitempty"`
+	DNSEnabled       *bool              `json:"dnsenabled,omitempty"`
 }
 
+// NetworkUpdateReport describes the result of a network update
+type NetworkUpdateReport = entitiesTypes.NetworkUpdateReport
+
 // NetworkCreateReport describes a created network for the cli
 type NetworkCreateReport = entitiesTypes.NetworkCreateReport
 
@@ -86,6 +86,9 @@ type PlayKubePod = entitiesTypes.PlayKubePod
 // PlayKubeVolume represents a single volume created by play kube.
 type PlayKubeVolume entitiesTypes.PlayKubeVolume
 
+// PlayKubeCronJob describes how the pod of a CronJob is scheduled.
+type PlayKubeCronJob = entitiesTypes.PlayKubeCronJob
+
 // PlayKubeReport contains the results of running play kube.
 type PlayKubeReport = entitiesTypes.PlayKubeReport
 type KubePlayReport = entitiesTypes.KubePlayReport
@@ -8,6 +8,7 @@ import (
 	"github.com/containers/podman/v5/pkg/domain/entities/types"
 	"github.com/containers/podman/v5/pkg/specgen"
 	"github.com/containers/podman/v5/pkg/util"
+	"github.com/containers/storage/pkg/archive"
 	"github.com/opencontainers/runtime-spec/specs-go"
 )
 
@@ -52,6 +53,42 @@ type PodRestartOptions struct {
 }
 
 type PodRestartReport = types.PodRestartReport
+
+// PodUpdateOptions are the options for updating a pod
+type PodUpdateOptions = types.PodUpdateOptions
+
+// PodCheckpointOptions are the options for checkpointing a pod into a
+// single archive.
+type PodCheckpointOptions struct {
+	Export         string
+	Compression    archive.Compression
+	FileLocks      bool
+	IgnoreRootFS   bool
+	IgnoreVolumes  bool
+	Keep           bool
+	LeaveRunning   bool
+	TCPEstablished bool
+}
+
+type PodCheckpointReport = types.PodCheckpointReport
+
+// PodRestoreOptions are the options for restoring a pod from a pod
+// checkpoint archive.
+type PodRestoreOptions struct {
+	Import          string
+	Name            string
+	FileLocks       bool
+	IgnoreRootFS    bool
+	IgnoreStaticIP  bool
+	IgnoreStaticMAC bool
+	IgnoreVolumes   bool
+	Keep            bool
+	PublishPorts    []string
+	TCPEstablished  bool
+}
+
+type PodRestoreReport = types.PodRestoreReport
+
 type PodStartOptions struct {
 	All    bool
 	Latest bool
@@ -0,0 +1,34 @@
+package entities
+
+import (
+	"github.com/containers/podman/v5/pkg/domain/entities/types"
+)
+
+// QuadletInstallOptions controls the installation of Quadlet units
+type QuadletInstallOptions struct {
+	// Replace existing units and files with the same name
+	Replace bool
+	// ReloadSystemd reloads systemd after installing the units
+	ReloadSystemd bool
+}
+
+type QuadletInstallReport = types.QuadletInstallReport
+
+// QuadletListOptions controls the listing of installed Quadlet units
+type QuadletListOptions struct{}
+
+type ListQuadlet = types.ListQuadlet
+
+// QuadletRemoveOptions controls the removal of installed Quadlet units
+type QuadletRemoveOptions struct {
+	// All removes all installed units
+	All bool
+	// Force stops running units before removing them
+	Force bool
+	// Ignore units which are not installed
+	Ignore bool
+	// ReloadSystemd reloads systemd after removing the units
+	ReloadSystemd bool
+}
+
+type QuadletRemoveReport = types.QuadletRemoveReport
@@ -31,12 +31,23 @@ type SecretRmOptions struct {
 
 type SecretRmReport = types.SecretRmReport
 
+type SecretUpdateReport = types.SecretUpdateReport
+
+type SecretRekeyOptions struct {
+	All        bool
+	DriverOpts map[string]string
+}
+
+type SecretRekeyReport = types.SecretRekeyReport
+
 type SecretInfoReport = types.SecretInfoReport
 
 type SecretInfoReportCompat = types.SecretInfoReportCompat
 
 type SecretVersion = types.SecretVersion
 
+type SecretDataVersion = types.SecretDataVersion
+
 type SecretSpec = types.SecretSpec
 
 type SecretDriverSpec = types.SecretDriverSpec
@@ -62,6 +73,24 @@ type SwagSecretCreateResponse struct {
 	}
 }
 
+// Secret update response
+// swagger:response SecretUpdateResponse
+type SwagSecretUpdateResponse struct {
+	// in:body
+	Body struct {
+		SecretUpdateReport
+	}
+}
+
+// Secret rekey response
+// swagger:response SecretRekeyResponse
+type SwagSecretRekeyResponse struct {
+	// in:body
+	Body struct {
+		ID string
+	}
+}
+
 // Secret list response
 // swagger:response SecretListResponse
 type SwagSecretListResponse struct {
@@ -0,0 +1,36 @@
+package types
+
+import (
+	"github.com/containers/podman/v5/pkg/libartifact"
+	"github.com/opencontainers/go-digest"
+)
+
+// ArtifactAddReport describes an artifact created from files
+type ArtifactAddReport struct {
+	ArtifactDigest *digest.Digest
+}
+
+// ArtifactInspectReport describes an artifact in the local store
+type ArtifactInspectReport struct {
+	*libartifact.Artifact
+}
+
+// ArtifactListReport describes an artifact in the local store
+type ArtifactListReport struct {
+	*libartifact.Artifact
+}
+
+// ArtifactPullReport describes an artifact pulled from a registry
+type ArtifactPullReport struct {
+	ArtifactDigest *digest.Digest
+}
+
+// ArtifactPushReport describes an artifact pushed to a registry
+type ArtifactPushReport struct {
+	ArtifactDigest *digest.Digest
+}
+
+// ArtifactRemoveReport lists the digests of the removed artifacts
+type ArtifactRemoveReport struct {
+	ArtifactDigests []*digest.Digest
+}
@@ -0,0 +1,38 @@
+package types
+
+// AutoUpdateReport contains the results from running auto-update.
+type AutoUpdateReport struct {
+	// ID of the container *before* an update.
+	ContainerID string
+	// Name of the container *before* an update.
+	ContainerName string
+	// Name of the image.
+	ImageName string
+	// Name of the image the container is updated to if it differs from
+	// ImageName (see the registry-semver policy).
+	NewImageName string `json:",omitempty"`
+	// The configured auto-update policy.
+	Policy string
+	// SystemdUnit running a container configured for auto updates.
+	SystemdUnit string
+	// Indicates the update status: true, false, failed, pending (see
+	// DryRun).
+	Updated string
+	// Stage of a staged rollout (see SoakPeriod): canary, rollout or
+	// held if the update was held back after a failed canary.
+	Stage string `json:",omitempty"`
+}
+
+// AutoUpdateStreamReport is a message of the streamed output of the
+// auto-update endpoint of the remote API.  Progress messages are sent in
+// Stream, the last message contains the Reports and Errors.
+type AutoUpdateStreamReport struct {
+	// Stream contains a progress message, such as the output of an
+	// image pull or the restart of a systemd unit.
+	Stream string `json:"stream,omitempty"`
+	// Reports of the auto-updated containers.
+	Reports []*AutoUpdateReport `json:"reports,omitempty"`
+	// Errors encountered during auto-update.  They do not necessarily
+	// prevent the other containers from being updated.
+	Errors []string `json:"errors,omitempty"`
+}
@@ -36,6 +36,14 @@ type NetworkCreateReport struct {
 	Name string
 }
 
+// NetworkUpdateReport describes the result of a network update
+type NetworkUpdateReport struct {
+	Name string
+	// RestartRequired lists the containers which could not be
+	// reconfigured and need a restart to use the updated network
+	RestartRequired []string
+}
+
 type NetworkInspectReport struct {
 	commonTypes.Network
 
@@ -19,9 +19,27 @@ type PlayKubeVolume struct {
 	Name string
 }
 
+// PlayKubeCronJob describes how the pod of a CronJob is scheduled.
+type PlayKubeCronJob struct {
+	// Name - name of the CronJob.
+	Name string
+	// PodID - ID of the pod started on schedule.
+	PodID string
+	// Schedule - cron schedule of the CronJob.
+	Schedule string
+	// Timer - name of the systemd timer starting the pod.  Empty if the
+	// CronJob is suspended or if systemd timers are not available and the
+	// schedule is run by the Podman API service.
+	Timer string
+	// Suspended - the CronJob is suspended and the pod is not scheduled.
+	Suspended bool
+}
+
 type PlayKubeReport struct {
 	// Pods - pods created by play kube.
 	Pods []PlayKubePod
+	// CronJobs - schedules of the pods created for CronJobs.
+	CronJobs []PlayKubeCronJob
 	// Volumes - volumes created by play kube.
 	Volumes []PlayKubeVolume
 	PlayKubeTeardown
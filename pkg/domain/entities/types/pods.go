@@ -32,6 +32,28 @@ type PodRestartReport struct {
 	Id   string //nolint:revive,stylecheck
 }
 
+// PodUpdateOptions are the options for updating the resource limits of a pod
+// and the restart policy and healthcheck on-failure action of its containers.
+type PodUpdateOptions struct {
+	NameOrID string
+	// Specgen holds the resource limits and the restart policy.
+	Specgen *specgen.SpecGenerator
+	// HealthOnFailure is set as healthcheck on-failure action of all
+	// containers of the pod if not nil.
+	HealthOnFailure *string
+}
+
+type PodCheckpointReport struct {
+	Id       string //nolint:revive,stylecheck
+	RawInput string
+}
+
+type PodRestoreReport struct {
+	Id string //nolint:revive,stylecheck
+	// Containers are the IDs of the restored containers of the pod.
+	Containers []string
+}
+
 type PodStartReport struct {
 	Errs     []error
 	Id       string //nolint:revive,stylecheck
@@ -0,0 +1,28 @@
+package types
+
+// QuadletInstallReport describes the installed Quadlet units
+type QuadletInstallReport struct {
+	// InstalledQuadlets maps the installed unit files to their path in
+	// the Quadlet unit directory
+	InstalledQuadlets map[string]string
+}
+
+// ListQuadlet describes an installed Quadlet unit
+type ListQuadlet struct {
+	// Name of the unit file
+	Name string
+	// UnitName is the name of the systemd service generated from the unit
+	UnitName string
+	// Path of the unit file
+	Path string
+	// Status is the active state of the systemd service
+	Status string
+}
+
+// QuadletRemoveReport describes the removed Quadlet units
+type QuadletRemoveReport struct {
+	// Removed are the names of the removed unit files
+	Removed []string
+	// Errors maps the units which could not be removed to the reason
+	Errors map[string]string
+}
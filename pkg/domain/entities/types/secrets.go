@@ -14,6 +14,11 @@ type SecretVersion struct {
 	Index int
 }
 
+type SecretDataVersion struct {
+	Version   int
+	CreatedAt time.Time
+}
+
 type SecretDriverSpec struct {
 	Name    string
 	Options map[string]string
@@ -36,12 +41,26 @@ type SecretRmReport struct {
 	Err error
 }
 
+type SecretUpdateReport struct {
+	ID      string
+	Version int
+	// Rotated are the IDs of the running containers which got the new
+	// version of the secret
+	Rotated []string
+}
+
+type SecretRekeyReport struct {
+	ID  string
+	Err error
+}
+
 type SecretInfoReport struct {
 	ID         string
 	CreatedAt  time.Time
 	UpdatedAt  time.Time
 	Spec       SecretSpec
-	SecretData string `json:"SecretData,omitempty"`
+	Versions   []SecretDataVersion `json:"Versions,omitempty"`
+	SecretData string              `json:"SecretData,omitempty"`
 }
 
 type SecretInfoReportCompat struct {
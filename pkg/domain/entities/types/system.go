@@ -7,9 +7,11 @@ import (
 	"github.com/containers/podman/v5/pkg/domain/entities/reports"
 )
 
-// ServiceOptions provides the input for starting an API and sidecar pprof services
+// ServiceOptions provides the input for starting an API and sidecar pprof and metrics services
 type ServiceOptions struct {
 	CorsHeaders string        // Cross-Origin Resource Sharing (CORS) headers
+	Metrics     bool          // Expose Prometheus metrics on the API endpoint
+	MetricsAddr string        // Network address to bind Prometheus metrics service
 	PProfAddr   string        // Network address to bind pprof profiles service
 	Timeout     time.Duration // Duration of inactivity the service should wait before shutting down
 	URI         string        // Path to unix domain socket service should listen on
@@ -35,6 +37,16 @@ type SystemCheckReport struct {
 	RemovedImages     map[string][]string // image ID → names
 	Containers        map[string][]string // container ID → what was detected
 	RemovedContainers map[string]string   // container ID → name
+	// Inconsistencies between the libpod database and storage, or within
+	// the database itself.
+	DBContainers        map[string][]string // container ID → what was detected
+	Pods                map[string][]string // pod ID → what was detected
+	RemovedPods         map[string]string   // pod ID → name
+	RepairedPods        []string            // pod ID
+	Volumes             map[string][]string // volume name → what was detected
+	RepairedVolumes     []string            // volume name
+	ExecSessions        map[string][]string // exec session ID → what was detected
+	RemovedExecSessions []string            // exec session ID
 }
 
 // SystemPruneOptions provides options to prune system.
@@ -56,9 +68,10 @@ type SystemPruneReport struct {
 }
 
 // SystemMigrateOptions describes the options needed for the
-// cli to migrate runtimes of containers
+// cli to migrate runtimes of containers and the database backend
 type SystemMigrateOptions struct {
 	NewRuntime string
+	Database   string
 }
 
 // SystemDfOptions describes the options for getting df information
@@ -106,6 +119,8 @@ type SystemDfVolumeReport struct {
 	Links           int
 	Size            int64
 	ReclaimableSize int64
+	// Quota is the maximum size of the volume, 0 if unlimited
+	Quota uint64
 }
 
 // SystemVersionReport describes version information about the running Podman service
@@ -36,6 +36,15 @@ type VolumeReloadReport struct {
 	define.VolumeReload
 }
 
+type VolumeSnapshotReport struct {
+	define.InspectVolumeSnapshot
+}
+
+type VolumeSnapshotRmReport struct {
+	Err  error
+	Name string
+}
+
 type VolumeMountReport struct {
 	Err  error
 	Id   string //nolint:revive,stylecheck
@@ -37,6 +37,20 @@ type VolumeListReport = types.VolumeListReport
 // VolumeReloadReport describes the response from reload volume plugins
 type VolumeReloadReport = types.VolumeReloadReport
 
+// VolumeSnapshotReport describes a snapshot of a volume
+type VolumeSnapshotReport = types.VolumeSnapshotReport
+
+// VolumeSnapshotRmReport describes the response from removing a volume snapshot
+type VolumeSnapshotRmReport = types.VolumeSnapshotRmReport
+
+// VolumeCloneOptions describes the options for cloning a volume
+type VolumeCloneOptions struct {
+	// Name of the new volume. Can be left blank
+	Name string
+	// Snapshot of the source volume to clone instead of its current data
+	Snapshot string
+}
+
 /*
  * Docker API compatibility types
  */
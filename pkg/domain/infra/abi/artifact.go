@@ -0,0 +1,227 @@
+//go:build !remote
+
+package abi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/containers/common/libimage"
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/containers/podman/v5/pkg/libartifact"
+	"github.com/containers/podman/v5/pkg/libartifact/store"
+	"github.com/opencontainers/go-digest"
+)
+
+func (ir *ImageEngine) getArtifactStore() (*store.ArtifactStore, error) {
+	return ir.Libpod.ArtifactStore()
+}
+
+func (ir *ImageEngine) ArtifactAdd(ctx context.Context, name string, paths []string, opts entities.ArtifactAddOptions) (*entities.ArtifactAddReport, error) {
+	artStore, err := ir.getArtifactStore()
+	if err != nil {
+		return nil, err
+	}
+	addOptions := libartifact.AddOptions{
+		Annotations:  opts.Annotations,
+		ArtifactType: opts.ArtifactType,
+		FileType:     opts.FileType,
+		Append:       opts.Append,
+	}
+	artifactDigest, err := artStore.Add(ctx, name, paths, &addOptions)
+	if err != nil {
+		return nil, err
+	}
+	return &entities.ArtifactAddReport{ArtifactDigest: artifactDigest}, nil
+}
+
+func (ir *ImageEngine) ArtifactExtract(ctx context.Context, name string, target string, opts entities.ArtifactExtractOptions) error {
+	artStore, err := ir.getArtifactStore()
+	if err != nil {
+		return err
+	}
+	return artStore.Extract(ctx, name, target, &opts)
+}
+
+func (ir *ImageEngine) ArtifactInspect(ctx context.Context, name string, _ entities.ArtifactInspectOptions) (*entities.ArtifactInspectReport, error) {
+	artStore, err := ir.getArtifactStore()
+	if err != nil {
+		return nil, err
+	}
+	artifact, err := artStore.Inspect(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return &entities.ArtifactInspectReport{Artifact: artifact}, nil
+}
+
+func (ir *ImageEngine) ArtifactList(ctx context.Context, _ entities.ArtifactListOptions) ([]*entities.ArtifactListReport, error) {
+	artStore, err := ir.getArtifactStore()
+	if err != nil {
+		return nil, err
+	}
+	artifacts, err := artStore.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	reports := make([]*entities.ArtifactListReport, 0, len(artifacts))
+	for _, artifact := range artifacts {
+		reports = append(reports, &entities.ArtifactListReport{Artifact: artifact})
+	}
+	return reports, nil
+}
+
+// artifactCopyOptions returns the options to copy an artifact from or to a
+// registry, with the same authentication, signature policy and retries as
+// images.
+func artifactCopyOptions(authfile, certDir, username, password string, retry *uint, retryDelay string, quiet bool) (libimage.CopyOptions, error) {
+	copyOptions := libimage.CopyOptions{
+		AuthFilePath: authfile,
+		CertDirPath:  certDir,
+		Username:     username,
+		Password:     password,
+		MaxRetries:   retry,
+	}
+	if retryDelay != "" {
+		duration, err := time.ParseDuration(retryDelay)
+		if err != nil {
+			return copyOptions, err
+		}
+		copyOptions.RetryDelay = &duration
+	}
+	if !quiet {
+		copyOptions.Writer = os.Stderr
+	}
+	return copyOptions, nil
+}
+
+func (ir *ImageEngine) ArtifactPull(ctx context.Context, name string, opts entities.ArtifactPullOptions) (*entities.ArtifactPullReport, error) {
+	artStore, err := ir.getArtifactStore()
+	if err != nil {
+		return nil, err
+	}
+	copyOptions, err := artifactCopyOptions(opts.Authfile, opts.CertDir, opts.Username, opts.Password, opts.Retry, opts.RetryDelay, opts.Quiet)
+	if err != nil {
+		return nil, err
+	}
+	copyOptions.SignaturePolicyPath = opts.SignaturePolicy
+	copyOptions.InsecureSkipTLSVerify = opts.SkipTLSVerify
+	if opts.Writer != nil {
+		copyOptions.Writer = opts.Writer
+	}
+
+	artifactDigest, err := artStore.Pull(ctx, name, copyOptions)
+	if err != nil {
+		return nil, err
+	}
+	return &entities.ArtifactPullReport{ArtifactDigest: artifactDigest}, nil
+}
+
+func (ir *ImageEngine) ArtifactPush(ctx context.Context, name string, destination string, opts entities.ArtifactPushOptions) (*entities.ArtifactPushReport, error) {
+	artStore, err := ir.getArtifactStore()
+	if err != nil {
+		return nil, err
+	}
+	copyOptions, err := artifactCopyOptions(opts.Authfile, opts.CertDir, opts.Username, opts.Password, opts.Retry, opts.RetryDelay, opts.Quiet)
+	if err != nil {
+		return nil, err
+	}
+	copyOptions.InsecureSkipTLSVerify = opts.SkipTLSVerify
+	copyOptions.SignBy = opts.SignBy
+	copyOptions.SignPassphrase = opts.SignPassphrase
+	copyOptions.SignBySigstorePrivateKeyFile = opts.SignBySigstorePrivateKeyFile
+	copyOptions.SignSigstorePrivateKeyPassphrase = opts.SignSigstorePrivateKeyPassphrase
+	if opts.Writer != nil {
+		copyOptions.Writer = opts.Writer
+	}
+
+	artifactDigest, err := artStore.Push(ctx, name, destination, copyOptions)
+	if err != nil {
+		return nil, err
+	}
+	return &entities.ArtifactPushReport{ArtifactDigest: artifactDigest}, nil
+}
+
+func (ir *ImageEngine) ArtifactRm(ctx context.Context, names []string, opts entities.ArtifactRemoveOptions) (*entities.ArtifactRemoveReport, error) {
+	artStore, err := ir.getArtifactStore()
+	if err != nil {
+		return nil, err
+	}
+	if opts.All {
+		artifacts, err := artStore.List(ctx)
+		if err != nil {
+			return nil, err
+		}
+		names = make([]string, 0, len(artifacts))
+		for _, artifact := range artifacts {
+			names = append(names, artifact.Name)
+		}
+	}
+
+	report := &entities.ArtifactRemoveReport{ArtifactDigests: []*digest.Digest{}}
+	var errs []error
+	for _, name := range names {
+		if err := ir.removeArtifactContainers(ctx, artStore, name, opts.Force); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		artifactDigest, err := artStore.Remove(ctx, name)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		report.ArtifactDigests = append(report.ArtifactDigests, artifactDigest)
+	}
+	if len(errs) > 0 {
+		return report, errors.Join(errs...)
+	}
+	return report, nil
+}
+
+// removeArtifactContainers checks that no container mounts the artifact
+// name, or removes these containers if force is set.
+func (ir *ImageEngine) removeArtifactContainers(ctx context.Context, artStore *store.ArtifactStore, name string, force bool) error {
+	artifact, err := artStore.Inspect(ctx, name)
+	if err != nil {
+		return err
+	}
+	ctrs, err := ir.Libpod.GetAllContainers()
+	if err != nil {
+		return err
+	}
+	for _, ctr := range ctrs {
+		used := false
+		for _, volume := range ctr.ArtifactVolumes() {
+			mounted, err := artStore.Inspect(ctx, volume.Source)
+			if err == nil && mounted.Digest == artifact.Digest {
+				used = true
+				break
+			}
+		}
+		if !used {
+			continue
+		}
+		if !force {
+			return fmt.Errorf("artifact %s is mounted by container %s: %w", name, ctr.ID(), define.ErrArtifactInUse)
+		}
+		if err := ir.Libpod.RemoveContainer(ctx, ctr, true, false, nil); err != nil && !errors.Is(err, define.ErrNoSuchCtr) {
+			return fmt.Errorf("removing container %s using artifact %s: %w", ctr.ID(), name, err)
+		}
+	}
+	return nil
+}
+
+// ArtifactExtractTarStream writes the selected files of the artifact name to
+// w as a tar archive, for clients which cannot access the local store.
+func (ir *ImageEngine) ArtifactExtractTarStream(ctx context.Context, w io.Writer, name string, opts entities.ArtifactExtractOptions) error {
+	artStore, err := ir.getArtifactStore()
+	if err != nil {
+		return err
+	}
+	return artStore.ExtractTarStream(ctx, w, name, &opts)
+}
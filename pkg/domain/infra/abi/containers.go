@@ -1226,6 +1226,22 @@ func (ic *ContainerEngine) ContainerWaitForExitCode(ctx context.Context, ctr *li
 	return int(exitCode), nil
 }
 
+func (ic *ContainerEngine) ContainerLogForward(ctx context.Context, nameOrID string, options entities.ContainerLogForwardOptions) error {
+	ctr, err := ic.Libpod.LookupContainer(nameOrID)
+	if err != nil {
+		return err
+	}
+	return ctr.ForwardLogs(ctx, options.Tag, options.Ready)
+}
+
+func (ic *ContainerEngine) ContainerLogRotate(ctx context.Context, nameOrID string, options entities.ContainerLogRotateOptions) error {
+	ctr, err := ic.Libpod.LookupContainer(nameOrID)
+	if err != nil {
+		return err
+	}
+	return ctr.RotateLogs(ctx, options.Ready)
+}
+
 func (ic *ContainerEngine) ContainerLogs(ctx context.Context, namesOrIds []string, options entities.ContainerLogsOptions) error {
 	if options.StdoutWriter == nil && options.StderrWriter == nil {
 		return errors.New("no io.Writer set for container logs")
@@ -15,6 +15,7 @@ import (
 	k8sAPI "github.com/containers/podman/v5/pkg/k8s.io/api/core/v1"
 	"github.com/containers/podman/v5/pkg/specgen"
 	generateUtils "github.com/containers/podman/v5/pkg/specgen/generate"
+	"github.com/containers/podman/v5/pkg/specgen/generate/kube"
 	"github.com/containers/podman/v5/pkg/systemd/generate"
 	"sigs.k8s.io/yaml"
 )
@@ -112,8 +113,16 @@ func (ic *ContainerEngine) GenerateKube(ctx context.Context, nameOrIDs []string,
 		content     [][]byte
 	)
 
-	if options.Replicas > 1 && options.Type != define.K8sKindDeployment {
-		return nil, fmt.Errorf("--replicas can only be set when --type is set to deployment")
+	if options.Replicas > 1 && options.Type != define.K8sKindDeployment && options.Type != define.K8sKindStatefulSet {
+		return nil, fmt.Errorf("--replicas can only be set when --type is set to deployment or statefulset")
+	}
+	if options.Schedule != "" {
+		if options.Type != define.K8sKindCronJob {
+			return nil, fmt.Errorf("--schedule can only be set when --type is set to cronjob")
+		}
+		if _, err := kube.ParseCronSchedule(options.Schedule, ""); err != nil {
+			return nil, err
+		}
 	}
 	if options.Replicas < 1 {
 		return nil, fmt.Errorf("--replicas has to be greater than or equal to 1. By default, --replicas is set to 1")
@@ -254,6 +263,26 @@ func (ic *ContainerEngine) GenerateKube(ctx context.Context, nameOrIDs []string,
 				return nil, err
 			}
 			typeContent = append(typeContent, b)
+		case define.K8sKindStatefulSet:
+			set, err := libpod.GenerateForKubeStatefulSet(ctx, libpod.ConvertV1PodToYAMLPod(po), options)
+			if err != nil {
+				return nil, err
+			}
+			b, err := generateKubeYAML(set)
+			if err != nil {
+				return nil, err
+			}
+			typeContent = append(typeContent, b)
+		case define.K8sKindCronJob:
+			cronJob, err := libpod.GenerateForKubeCronJob(ctx, libpod.ConvertV1PodToYAMLPod(po), &define.PodCronJob{Schedule: options.Schedule}, options)
+			if err != nil {
+				return nil, err
+			}
+			b, err := generateKubeYAML(cronJob)
+			if err != nil {
+				return nil, err
+			}
+			typeContent = append(typeContent, b)
 		case define.K8sKindPod:
 			b, err := generateKubeYAML(libpod.ConvertV1PodToYAMLPod(po))
 			if err != nil {
@@ -261,7 +290,7 @@ func (ic *ContainerEngine) GenerateKube(ctx context.Context, nameOrIDs []string,
 			}
 			typeContent = append(typeContent, b)
 		default:
-			return nil, fmt.Errorf("invalid generation type - only pods, deployments, jobs, and daemonsets are currently supported: %+v", options.Type)
+			return nil, fmt.Errorf("invalid generation type - only pods, deployments, jobs, daemonsets, statefulsets, and cronjobs are currently supported: %+v", options.Type)
 		}
 
 		if options.Service {
@@ -331,6 +360,35 @@ func getKubePods(ctx context.Context, pods []*libpod.Pod, options entities.Gener
 				return nil, nil, err
 			}
 			out = append(out, b)
+		case define.K8sKindStatefulSet:
+			set, err := libpod.GenerateForKubeStatefulSet(ctx, libpod.ConvertV1PodToYAMLPod(po), options)
+			if err != nil {
+				return nil, nil, err
+			}
+			b, err := generateKubeYAML(set)
+			if err != nil {
+				return nil, nil, err
+			}
+			out = append(out, b)
+		case define.K8sKindCronJob:
+			// Pods created from a CronJob keep their schedule.
+			cronJob := p.CronJob()
+			if cronJob == nil {
+				cronJob = &define.PodCronJob{}
+			}
+			if options.Schedule != "" {
+				cronJob.Schedule = options.Schedule
+				cronJob.TimeZone = ""
+			}
+			cj, err := libpod.GenerateForKubeCronJob(ctx, libpod.ConvertV1PodToYAMLPod(po), cronJob, options)
+			if err != nil {
+				return nil, nil, err
+			}
+			b, err := generateKubeYAML(cj)
+			if err != nil {
+				return nil, nil, err
+			}
+			out = append(out, b)
 		case define.K8sKindPod:
 			b, err := generateKubeYAML(libpod.ConvertV1PodToYAMLPod(po))
 			if err != nil {
@@ -338,7 +396,7 @@ func getKubePods(ctx context.Context, pods []*libpod.Pod, options entities.Gener
 			}
 			out = append(out, b)
 		default:
-			return nil, nil, fmt.Errorf("invalid generation type - only pods, deployments, jobs, and daemonsets are currently supported")
+			return nil, nil, fmt.Errorf("invalid generation type - only pods, deployments, jobs, daemonsets, statefulsets, and cronjobs are currently supported")
 		}
 
 		if options.Service {
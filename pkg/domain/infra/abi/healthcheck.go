@@ -25,3 +25,7 @@ func (ic *ContainerEngine) HealthCheckRun(ctx context.Context, nameOrID string,
 	}
 	return &report, nil
 }
+
+func (ic *ContainerEngine) HealthCheckReadiness(ctx context.Context, nameOrID string) error {
+	return ic.Libpod.ReadinessProbe(ctx, nameOrID)
+}
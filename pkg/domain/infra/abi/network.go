@@ -13,20 +13,36 @@ import (
 	"github.com/containers/common/libnetwork/slirp4netns"
 	"github.com/containers/common/libnetwork/types"
 	netutil "github.com/containers/common/libnetwork/util"
+	"github.com/containers/podman/v5/libpod"
 	"github.com/containers/podman/v5/libpod/define"
 	"github.com/containers/podman/v5/libpod/events"
 	"github.com/containers/podman/v5/pkg/domain/entities"
 )
 
-func (ic *ContainerEngine) NetworkUpdate(ctx context.Context, netName string, options entities.NetworkUpdateOptions) error {
-	var networkUpdateOptions types.NetworkUpdateOptions
-	networkUpdateOptions.AddDNSServers = options.AddDNSServers
-	networkUpdateOptions.RemoveDNSServers = options.RemoveDNSServers
-	err := ic.Libpod.Network().NetworkUpdate(netName, networkUpdateOptions)
-	if err != nil {
-		return err
+func (ic *ContainerEngine) NetworkUpdate(ctx context.Context, netName string, options entities.NetworkUpdateOptions) (*entities.NetworkUpdateReport, error) {
+	networkUpdateOptions := libpod.NetworkUpdateOptions{
+		NetworkUpdateOptions: types.NetworkUpdateOptions{
+			AddDNSServers:    options.AddDNSServers,
+			RemoveDNSServers: options.RemoveDNSServers,
+		},
+		AddSubnets:     options.AddSubnets,
+		RemoveSubnets:  options.RemoveSubnets,
+		AddIPRanges:    options.AddIPRanges,
+		RemoveIPRanges: options.RemoveIPRanges,
+		AddLabels:      options.AddLabels,
+		RemoveLabels:   options.RemoveLabels,
+		AddOptions:     options.AddOptions,
+		RemoveOptions:  options.RemoveOptions,
+		Internal:       options.Internal,
+		DNSEnabled:     options.DNSEnabled,
 	}
-	return nil
+	// The containers which need a restart are also reported when the
+	// update failed after some of them were reconnected.
+	restartRequired, err := ic.Libpod.NetworkUpdate(netName, networkUpdateOptions)
+	return &entities.NetworkUpdateReport{
+		Name:            netName,
+		RestartRequired: restartRequired,
+	}, err
 }
 
 func (ic *ContainerEngine) NetworkList(ctx context.Context, options entities.NetworkListOptions) ([]types.Network, error) {
@@ -4,6 +4,7 @@ package parse
 
 import (
 	"fmt"
+	"maps"
 	"strconv"
 	"strings"
 
@@ -13,6 +14,33 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+// LocalVolumeOptions returns the options of a volume using the local driver
+// with the size and inodes options moved into "o", so --opt size=10G is the
+// same as --opt o=size=10G. The options of other drivers are returned as is.
+func LocalVolumeOptions(driver string, opts map[string]string) map[string]string {
+	if driver != "" && driver != define.VolumeDriverLocal {
+		return opts
+	}
+	_, hasSize := opts["size"]
+	_, hasInodes := opts["inodes"]
+	if !hasSize && !hasInodes {
+		return opts
+	}
+	opts = maps.Clone(opts)
+	for _, key := range []string{"size", "inodes"} {
+		val, ok := opts[key]
+		if !ok {
+			continue
+		}
+		delete(opts, key)
+		if opts["o"] != "" {
+			opts["o"] += ","
+		}
+		opts["o"] += key + "=" + val
+	}
+	return opts
+}
+
 // Handle volume options from CLI.
 // Parse "o" option to find UID, GID, Size.
 func VolumeOptions(opts map[string]string) ([]libpod.VolumeCreateOption, error) {
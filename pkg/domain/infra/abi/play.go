@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -32,6 +33,7 @@ import (
 	v1apps "github.com/containers/podman/v5/pkg/k8s.io/api/apps/v1"
 	v1 "github.com/containers/podman/v5/pkg/k8s.io/api/core/v1"
 	metav1 "github.com/containers/podman/v5/pkg/k8s.io/apimachinery/pkg/apis/meta/v1"
+	"github.com/containers/podman/v5/pkg/k8s.io/apimachinery/pkg/util/intstr"
 	"github.com/containers/podman/v5/pkg/specgen"
 	"github.com/containers/podman/v5/pkg/specgen/generate"
 	"github.com/containers/podman/v5/pkg/specgen/generate/kube"
@@ -55,6 +57,9 @@ const sdNotifyAnnotation = "io.containers.sdnotify"
 // default network created/used by kube
 const kubeDefaultNetwork = "podman-default-kube-network"
 
+// label carrying the stable name of a pod of a StatefulSet
+const statefulSetPodNameLabel = "statefulset.kubernetes.io/pod-name"
+
 // createServiceContainer creates a container that can later on
 // be associated with the pods of a K8s yaml.  It will be started along with
 // the first pod.
@@ -281,6 +286,7 @@ func (ic *ContainerEngine) PlayKube(ctx context.Context, body io.Reader, options
 	ipIndex := 0
 
 	var configMaps []v1.ConfigMap
+	services := newKubeServices()
 
 	ranContainers := false
 	// FIXME: both, the service container and the proxies, should ideally
@@ -311,7 +317,7 @@ func (ic *ContainerEngine) PlayKube(ctx context.Context, body io.Reader, options
 		}
 
 		// TODO: create constants for the various "kinds" of yaml files.
-		if options.ServiceContainer && serviceContainer == nil && (kind == "Pod" || kind == "Deployment") {
+		if options.ServiceContainer && serviceContainer == nil && (kind == "Pod" || kind == "Deployment" || kind == "StatefulSet") {
 			ctr, err := ic.createServiceContainer(ctx, k8sName(content, "service"), options)
 			if err != nil {
 				return nil, err
@@ -356,7 +362,7 @@ func (ic *ContainerEngine) PlayKube(ctx context.Context, body io.Reader, options
 				return nil, err
 			}
 
-			r, proxies, err := ic.playKubePod(ctx, podTemplateSpec.ObjectMeta.Name, &podTemplateSpec, options, &ipIndex, podYAML.Annotations, configMaps, serviceContainer)
+			r, proxies, err := ic.playKubePod(ctx, podTemplateSpec.ObjectMeta.Name, &podTemplateSpec, options, &ipIndex, podYAML.Annotations, configMaps, services, serviceContainer, nil)
 			if err != nil {
 				return nil, err
 			}
@@ -372,7 +378,7 @@ func (ic *ContainerEngine) PlayKube(ctx context.Context, body io.Reader, options
 				return nil, fmt.Errorf("unable to read YAML as Kube DaemonSet: %w", err)
 			}
 
-			r, proxies, err := ic.playKubeDaemonSet(ctx, &daemonSetYAML, options, &ipIndex, configMaps, serviceContainer)
+			r, proxies, err := ic.playKubeDaemonSet(ctx, &daemonSetYAML, options, &ipIndex, configMaps, services, serviceContainer)
 			if err != nil {
 				return nil, err
 			}
@@ -388,12 +394,29 @@ func (ic *ContainerEngine) PlayKube(ctx context.Context, body io.Reader, options
 				return nil, fmt.Errorf("unable to read YAML as Kube Deployment: %w", err)
 			}
 
-			r, proxies, err := ic.playKubeDeployment(ctx, &deploymentYAML, options, &ipIndex, configMaps, serviceContainer)
+			r, proxies, err := ic.playKubeDeployment(ctx, &deploymentYAML, options, &ipIndex, configMaps, services, serviceContainer)
+			if err != nil {
+				return nil, err
+			}
+			notifyProxies = append(notifyProxies, proxies...)
+
+			report.Pods = append(report.Pods, r.Pods...)
+			validKinds++
+			ranContainers = true
+		case "StatefulSet":
+			var statefulSetYAML v1apps.StatefulSet
+
+			if err := yaml.Unmarshal(document, &statefulSetYAML); err != nil {
+				return nil, fmt.Errorf("unable to read YAML as Kube StatefulSet: %w", err)
+			}
+
+			r, proxies, err := ic.playKubeStatefulSet(ctx, &statefulSetYAML, options, &ipIndex, configMaps, services, serviceContainer)
 			if err != nil {
 				return nil, err
 			}
 			notifyProxies = append(notifyProxies, proxies...)
 
+			report.Volumes = append(report.Volumes, r.Volumes...)
 			report.Pods = append(report.Pods, r.Pods...)
 			validKinds++
 			ranContainers = true
@@ -404,7 +427,7 @@ func (ic *ContainerEngine) PlayKube(ctx context.Context, body io.Reader, options
 				return nil, fmt.Errorf("unable to read YAML as Kube Job: %w", err)
 			}
 
-			r, proxies, err := ic.playKubeJob(ctx, &jobYAML, options, &ipIndex, configMaps, serviceContainer)
+			r, proxies, err := ic.playKubeJob(ctx, &jobYAML, options, &ipIndex, configMaps, services, serviceContainer)
 			if err != nil {
 				return nil, err
 			}
@@ -413,6 +436,22 @@ func (ic *ContainerEngine) PlayKube(ctx context.Context, body io.Reader, options
 			report.Pods = append(report.Pods, r.Pods...)
 			validKinds++
 			ranContainers = true
+		case "CronJob":
+			var cronJobYAML v1.CronJob
+
+			if err := yaml.Unmarshal(document, &cronJobYAML); err != nil {
+				return nil, fmt.Errorf("unable to read YAML as Kube CronJob: %w", err)
+			}
+
+			r, proxies, err := ic.playKubeCronJob(ctx, &cronJobYAML, options, &ipIndex, configMaps, services)
+			if err != nil {
+				return nil, err
+			}
+			notifyProxies = append(notifyProxies, proxies...)
+
+			report.Pods = append(report.Pods, r.Pods...)
+			report.CronJobs = append(report.CronJobs, r.CronJobs...)
+			validKinds++
 		case "PersistentVolumeClaim":
 			var pvcYAML v1.PersistentVolumeClaim
 
@@ -447,6 +486,13 @@ func (ic *ContainerEngine) PlayKube(ctx context.Context, body io.Reader, options
 				return nil, fmt.Errorf("unable to read YAML as Kube ConfigMap: %w", err)
 			}
 			configMaps = append(configMaps, configMap)
+		case "Service":
+			var service v1.Service
+
+			if err := yaml.Unmarshal(document, &service); err != nil {
+				return nil, fmt.Errorf("unable to read YAML as Kube Service: %w", err)
+			}
+			services.services = append(services.services, service)
 		case "Secret":
 			var secret v1.Secret
 
@@ -514,7 +560,7 @@ func (ic *ContainerEngine) PlayKube(ctx context.Context, body io.Reader, options
 	return report, nil
 }
 
-func (ic *ContainerEngine) playKubeDaemonSet(ctx context.Context, daemonSetYAML *v1apps.DaemonSet, options entities.PlayKubeOptions, ipIndex *int, configMaps []v1.ConfigMap, serviceContainer *libpod.Container) (*entities.PlayKubeReport, []*notifyproxy.NotifyProxy, error) {
+func (ic *ContainerEngine) playKubeDaemonSet(ctx context.Context, daemonSetYAML *v1apps.DaemonSet, options entities.PlayKubeOptions, ipIndex *int, configMaps []v1.ConfigMap, services *kubeServices, serviceContainer *libpod.Container) (*entities.PlayKubeReport, []*notifyproxy.NotifyProxy, error) {
 	var (
 		daemonSetName string
 		podSpec       v1.PodTemplateSpec
@@ -528,7 +574,7 @@ func (ic *ContainerEngine) playKubeDaemonSet(ctx context.Context, daemonSetYAML
 	podSpec = daemonSetYAML.Spec.Template
 
 	podName := fmt.Sprintf("%s-pod", daemonSetName)
-	podReport, proxies, err := ic.playKubePod(ctx, podName, &podSpec, options, ipIndex, daemonSetYAML.Annotations, configMaps, serviceContainer)
+	podReport, proxies, err := ic.playKubePod(ctx, podName, &podSpec, options, ipIndex, daemonSetYAML.Annotations, configMaps, services, serviceContainer, nil)
 	if err != nil {
 		return nil, nil, fmt.Errorf("encountered while bringing up pod %s: %w", podName, err)
 	}
@@ -537,7 +583,7 @@ func (ic *ContainerEngine) playKubeDaemonSet(ctx context.Context, daemonSetYAML
 	return &report, proxies, nil
 }
 
-func (ic *ContainerEngine) playKubeDeployment(ctx context.Context, deploymentYAML *v1apps.Deployment, options entities.PlayKubeOptions, ipIndex *int, configMaps []v1.ConfigMap, serviceContainer *libpod.Container) (*entities.PlayKubeReport, []*notifyproxy.NotifyProxy, error) {
+func (ic *ContainerEngine) playKubeDeployment(ctx context.Context, deploymentYAML *v1apps.Deployment, options entities.PlayKubeOptions, ipIndex *int, configMaps []v1.ConfigMap, services *kubeServices, serviceContainer *libpod.Container) (*entities.PlayKubeReport, []*notifyproxy.NotifyProxy, error) {
 	var (
 		deploymentName string
 		podSpec        v1.PodTemplateSpec
@@ -559,7 +605,7 @@ func (ic *ContainerEngine) playKubeDeployment(ctx context.Context, deploymentYAM
 	podSpec = deploymentYAML.Spec.Template
 
 	podName := fmt.Sprintf("%s-pod", deploymentName)
-	podReport, proxies, err := ic.playKubePod(ctx, podName, &podSpec, options, ipIndex, deploymentYAML.Annotations, configMaps, serviceContainer)
+	podReport, proxies, err := ic.playKubePod(ctx, podName, &podSpec, options, ipIndex, deploymentYAML.Annotations, configMaps, services, serviceContainer, nil)
 	if err != nil {
 		return nil, nil, fmt.Errorf("encountered while bringing up pod %s: %w", podName, err)
 	}
@@ -568,7 +614,96 @@ func (ic *ContainerEngine) playKubeDeployment(ctx context.Context, deploymentYAM
 	return &report, proxies, nil
 }
 
-func (ic *ContainerEngine) playKubeJob(ctx context.Context, jobYAML *v1.Job, options entities.PlayKubeOptions, ipIndex *int, configMaps []v1.ConfigMap, serviceContainer *libpod.Container) (*entities.PlayKubeReport, []*notifyproxy.NotifyProxy, error) {
+func (ic *ContainerEngine) playKubeStatefulSet(ctx context.Context, statefulSetYAML *v1apps.StatefulSet, options entities.PlayKubeOptions, ipIndex *int, configMaps []v1.ConfigMap, services *kubeServices, serviceContainer *libpod.Container) (*entities.PlayKubeReport, []*notifyproxy.NotifyProxy, error) {
+	var (
+		statefulSetName string
+		numReplicas     int32
+		report          entities.PlayKubeReport
+		notifyProxies   []*notifyproxy.NotifyProxy
+	)
+
+	statefulSetName = statefulSetYAML.ObjectMeta.Name
+	if statefulSetName == "" {
+		return nil, nil, errors.New("statefulSet does not have a name")
+	}
+	numReplicas = 1
+	if statefulSetYAML.Spec.Replicas != nil {
+		numReplicas = *statefulSetYAML.Spec.Replicas
+	}
+
+	mountLabel, err := getMountLabel(statefulSetYAML.Spec.Template.Spec.SecurityContext)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Pods are brought up in ordinal order, each one with its own set of
+	// volumes created from the volume claim templates.
+	for ordinal := range numReplicas {
+		podName := statefulSetPodName(statefulSetName, ordinal)
+		podSpec := statefulSetYAML.Spec.Template
+
+		podSpec.Labels = maps.Clone(podSpec.Labels)
+		if podSpec.Labels == nil {
+			podSpec.Labels = make(map[string]string)
+		}
+		podSpec.Labels[statefulSetPodNameLabel] = podName
+
+		// A claim takes precedence over a volume of the same name in the template.
+		claimed := make(map[string]bool, len(statefulSetYAML.Spec.VolumeClaimTemplates))
+		volumes := make([]v1.Volume, 0, len(podSpec.Spec.Volumes)+len(statefulSetYAML.Spec.VolumeClaimTemplates))
+		for _, claim := range statefulSetYAML.Spec.VolumeClaimTemplates {
+			if options.IsRemote {
+				if _, ok := claim.Annotations[util.VolumeImportSourceAnnotation]; ok {
+					return nil, nil, fmt.Errorf("importing volumes is not supported for remote requests")
+				}
+			}
+			pvc := claim
+			pvc.Name = statefulSetClaimName(claim.Name, podName)
+			r, err := ic.playKubePVC(ctx, mountLabel, &pvc)
+			if err != nil {
+				return nil, nil, fmt.Errorf("encountered while creating volume claim %s: %w", pvc.Name, err)
+			}
+			report.Volumes = append(report.Volumes, r.Volumes...)
+
+			claimed[claim.Name] = true
+			volumes = append(volumes, v1.Volume{
+				Name: claim.Name,
+				VolumeSource: v1.VolumeSource{
+					PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{ClaimName: pvc.Name},
+				},
+			})
+		}
+		for _, vol := range podSpec.Spec.Volumes {
+			if !claimed[vol.Name] {
+				volumes = append(volumes, vol)
+			}
+		}
+		podSpec.Spec.Volumes = volumes
+
+		podReport, proxies, err := ic.playKubePod(ctx, podName, &podSpec, options, ipIndex, statefulSetYAML.Annotations, configMaps, services, serviceContainer, nil)
+		if err != nil {
+			return nil, nil, fmt.Errorf("encountered while bringing up pod %s: %w", podName, err)
+		}
+		notifyProxies = append(notifyProxies, proxies...)
+		report.Pods = append(report.Pods, podReport.Pods...)
+	}
+
+	return &report, notifyProxies, nil
+}
+
+// statefulSetPodName returns the stable name of the pod with the given
+// ordinal of a StatefulSet.
+func statefulSetPodName(statefulSetName string, ordinal int32) string {
+	return fmt.Sprintf("%s-%d", statefulSetName, ordinal)
+}
+
+// statefulSetClaimName returns the name of the volume created from a
+// volume claim template for a pod of a StatefulSet.
+func statefulSetClaimName(claimName, podName string) string {
+	return fmt.Sprintf("%s-%s", claimName, podName)
+}
+
+func (ic *ContainerEngine) playKubeJob(ctx context.Context, jobYAML *v1.Job, options entities.PlayKubeOptions, ipIndex *int, configMaps []v1.ConfigMap, services *kubeServices, serviceContainer *libpod.Container) (*entities.PlayKubeReport, []*notifyproxy.NotifyProxy, error) {
 	var (
 		jobName string
 		podSpec v1.PodTemplateSpec
@@ -582,16 +717,92 @@ func (ic *ContainerEngine) playKubeJob(ctx context.Context, jobYAML *v1.Job, opt
 	podSpec = jobYAML.Spec.Template
 
 	podName := fmt.Sprintf("%s-pod", jobName)
-	podReport, proxies, err := ic.playKubePod(ctx, podName, &podSpec, options, ipIndex, jobYAML.Annotations, configMaps, serviceContainer)
+	podReport, proxies, err := ic.playKubePod(ctx, podName, &podSpec, options, ipIndex, jobYAML.Annotations, configMaps, services, serviceContainer, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("encountered while bringing up pod %s: %w", podName, err)
+	}
+	report.Pods = podReport.Pods
+
+	return &report, proxies, nil
+}
+
+func (ic *ContainerEngine) playKubeCronJob(ctx context.Context, cronJobYAML *v1.CronJob, options entities.PlayKubeOptions, ipIndex *int, configMaps []v1.ConfigMap, services *kubeServices) (*entities.PlayKubeReport, []*notifyproxy.NotifyProxy, error) {
+	var (
+		cronJobName string
+		timeZone    string
+		podSpec     v1.PodTemplateSpec
+		report      entities.PlayKubeReport
+	)
+
+	cronJobName = cronJobYAML.ObjectMeta.Name
+	if cronJobName == "" {
+		return nil, nil, errors.New("cronJob does not have a name")
+	}
+
+	if cronJobYAML.Spec.TimeZone != nil {
+		timeZone = *cronJobYAML.Spec.TimeZone
+	}
+	schedule, err := kube.ParseCronSchedule(cronJobYAML.Spec.Schedule, timeZone)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cronJob %s: %w", cronJobName, err)
+	}
+
+	cronJob := &define.PodCronJob{
+		Schedule:          cronJobYAML.Spec.Schedule,
+		Calendar:          schedule.OnCalendar(),
+		TimeZone:          timeZone,
+		ConcurrencyPolicy: define.CronJobConcurrencyForbid,
+		Suspend:           cronJobYAML.Spec.Suspend != nil && *cronJobYAML.Spec.Suspend,
+	}
+	switch cronJobYAML.Spec.ConcurrencyPolicy {
+	case v1.ReplaceConcurrent:
+		cronJob.ConcurrencyPolicy = define.CronJobConcurrencyReplace
+	case v1.AllowConcurrent:
+		// Allow is the Kubernetes default, but every run starts the same
+		// pod so runs cannot overlap.
+		logrus.Warnf("CronJob %s: concurrent runs are not supported by Podman, runs are skipped while the pod is running", cronJobName)
+	}
+
+	// The pod of a CronJob is only started by its schedule.
+	options.Start = types.OptionalBoolFalse
+	podSpec = cronJobYAML.Spec.JobTemplate.Spec.Template
+
+	podName := fmt.Sprintf("%s-pod", cronJobName)
+	podReport, proxies, err := ic.playKubePod(ctx, podName, &podSpec, options, ipIndex, cronJobYAML.Annotations, configMaps, services, nil, cronJob)
 	if err != nil {
 		return nil, nil, fmt.Errorf("encountered while bringing up pod %s: %w", podName, err)
 	}
 	report.Pods = podReport.Pods
 
+	playCronJob := entities.PlayKubeCronJob{
+		Name:      cronJobName,
+		PodID:     podReport.Pods[0].ID,
+		Schedule:  cronJob.Schedule,
+		Suspended: cronJob.Suspend,
+	}
+	if !cronJob.Suspend {
+		pod, err := ic.Libpod.LookupPod(playCronJob.PodID)
+		if err != nil {
+			return nil, nil, err
+		}
+		timer, err := pod.ScheduleCronJob()
+		switch {
+		case err == nil:
+			playCronJob.Timer = timer
+		case errors.Is(err, define.ErrNotImplemented):
+			// The Podman API service runs the schedules of all pods
+			// when systemd timers are not available.
+			logrus.Debugf("CronJob %s is not scheduled by a systemd timer: %v", cronJobName, err)
+		default:
+			return nil, nil, fmt.Errorf("scheduling pod %s: %w", podName, err)
+		}
+	}
+	report.CronJobs = append(report.CronJobs, playCronJob)
+
 	return &report, proxies, nil
 }
 
-func (ic *ContainerEngine) playKubePod(ctx context.Context, podName string, podYAML *v1.PodTemplateSpec, options entities.PlayKubeOptions, ipIndex *int, annotations map[string]string, configMaps []v1.ConfigMap, serviceContainer *libpod.Container) (*entities.PlayKubeReport, []*notifyproxy.NotifyProxy, error) {
+func (ic *ContainerEngine) playKubePod(ctx context.Context, podName string, podYAML *v1.PodTemplateSpec, options entities.PlayKubeOptions, ipIndex *int, annotations map[string]string, configMaps []v1.ConfigMap, services *kubeServices, serviceContainer *libpod.Container, cronJob *define.PodCronJob) (*entities.PlayKubeReport, []*notifyproxy.NotifyProxy, error) {
 	cfg, err := ic.Libpod.GetConfigNoCopy()
 	if err != nil {
 		return nil, nil, err
@@ -706,6 +917,13 @@ func (ic *ContainerEngine) playKubePod(ctx context.Context, podName string, podY
 	}
 	*ipIndex++
 
+	// Services selecting the pod publish their ports on the host, unless
+	// the pod already shares the network namespace of the host.
+	serviceAliases, err := services.applyToPod(podYAML, &podOpt, podOpt.Net.Network.NSMode != specgen.Host)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	if len(options.PublishPorts) > 0 {
 		publishPorts, err := specgenutil.CreatePortBindings(options.PublishPorts)
 		if err != nil {
@@ -870,6 +1088,8 @@ func (ic *ContainerEngine) playKubePod(ctx context.Context, podName string, podY
 	for _, container := range podYAML.Spec.Containers {
 		ctrNameAliases = append(ctrNameAliases, container.Name)
 	}
+	// Sibling pods can resolve the pod by the names of the Services selecting it.
+	ctrNameAliases = append(ctrNameAliases, serviceAliases...)
 	for k, v := range podSpec.PodSpecGen.Networks {
 		v.Aliases = append(v.Aliases, ctrNameAliases...)
 		podSpec.PodSpecGen.Networks[k] = v
@@ -878,6 +1098,7 @@ func (ic *ContainerEngine) playKubePod(ctx context.Context, podName string, podY
 	if serviceContainer != nil {
 		podSpec.PodSpecGen.ServiceContainerID = serviceContainer.ID()
 	}
+	podSpec.PodSpecGen.CronJob = cronJob
 
 	if options.Replace {
 		if _, err := ic.PodRm(ctx, []string{podName}, entities.PodRmOptions{Force: true, Ignore: true}); err != nil {
@@ -1421,10 +1642,124 @@ func mergePublishPorts(p *entities.PodCreateOptions, publishPortsOption []nettyp
 	p.Net.PublishPorts = publishPortsOption
 }
 
+// kubeServices holds the Services of a kube YAML.  Pods selected by a
+// Service publish the ports of the Service on the host and are reachable
+// under the name of the Service on their networks.
+type kubeServices struct {
+	services []v1.Service
+	// hostPorts maps the host ports already published to the Service
+	// publishing them.  Only the first pod of a Deployment or
+	// StatefulSet selected by a Service can publish its ports.
+	hostPorts map[string]string
+}
+
+func newKubeServices() *kubeServices {
+	return &kubeServices{hostPorts: make(map[string]string)}
+}
+
+// applyToPod adds the ports of all Services selecting the pod to the port
+// mappings of the pod and returns the names of the Services.  Ports
+// explicitly published in the pod spec take precedence.
+func (s *kubeServices) applyToPod(podYAML *v1.PodTemplateSpec, podOpt *entities.PodCreateOptions, publish bool) ([]string, error) {
+	var aliases []string
+	for _, service := range s.services {
+		if !serviceSelectsPod(&service, podYAML.Labels) {
+			continue
+		}
+		aliases = append(aliases, service.Name)
+
+		// Headless Services only resolve to the pods, there is nothing to publish.
+		if !publish || service.Spec.ClusterIP == v1.ClusterIPNone {
+			continue
+		}
+		for _, servicePort := range service.Spec.Ports {
+			port, err := servicePortMapping(servicePort, podYAML.Spec.Containers)
+			if err != nil {
+				return nil, fmt.Errorf("service %s: %w", service.Name, err)
+			}
+			if portAlreadyPublished(port, podOpt.Net.PublishPorts) {
+				continue
+			}
+			key := fmt.Sprintf("%d/%s", port.HostPort, port.Protocol)
+			if owner, ok := s.hostPorts[key]; ok {
+				if owner != service.Name {
+					return nil, fmt.Errorf("service %s: host port %s is already published by service %s", service.Name, key, owner)
+				}
+				logrus.Infof("Host port %s of service %s is already published by another pod", key, service.Name)
+				continue
+			}
+			s.hostPorts[key] = service.Name
+			podOpt.Net.PublishPorts = append(podOpt.Net.PublishPorts, port)
+		}
+	}
+	return aliases, nil
+}
+
+// serviceSelectsPod returns true if the labels of the pod match the selector
+// of the Service.  A Service without a selector does not select any pod.
+func serviceSelectsPod(service *v1.Service, labels map[string]string) bool {
+	if service.Spec.Type == v1.ServiceTypeExternalName || len(service.Spec.Selector) == 0 {
+		return false
+	}
+	for k, v := range service.Spec.Selector {
+		if value, ok := labels[k]; !ok || value != v {
+			return false
+		}
+	}
+	return true
+}
+
+// servicePortMapping converts the port of a Service into a port mapping.  The
+// nodePort is used as host port if set, the port of the Service otherwise.
+func servicePortMapping(servicePort v1.ServicePort, containers []v1.Container) (nettypes.PortMapping, error) {
+	containerPort := servicePort.Port
+	switch {
+	case servicePort.TargetPort.Type == intstr.String:
+		found := false
+		for _, container := range containers {
+			for _, port := range container.Ports {
+				if port.Name == servicePort.TargetPort.StrVal {
+					containerPort = port.ContainerPort
+					found = true
+				}
+			}
+		}
+		if !found {
+			return nettypes.PortMapping{}, fmt.Errorf("target port %q does not name a container port of the pod", servicePort.TargetPort.StrVal)
+		}
+	case servicePort.TargetPort.IntVal != 0:
+		containerPort = servicePort.TargetPort.IntVal
+	}
+
+	hostPort := servicePort.Port
+	if servicePort.NodePort != 0 {
+		hostPort = servicePort.NodePort
+	}
+	for _, port := range []int32{hostPort, containerPort} {
+		if port < 1 || port > 65535 {
+			return nettypes.PortMapping{}, fmt.Errorf("invalid port %d: must be between 1 and 65535", port)
+		}
+	}
+
+	protocol := servicePort.Protocol
+	if protocol == "" {
+		protocol = v1.ProtocolTCP
+	}
+	return nettypes.PortMapping{
+		HostPort:      uint16(hostPort),
+		ContainerPort: uint16(containerPort),
+		Protocol:      strings.ToLower(string(protocol)),
+	}, nil
+}
+
+// portAlreadyPublished returns true if the container port of the given port
+// mapping is already part of the published ports.  A Range of 0 publishes a
+// single port.
 func portAlreadyPublished(port nettypes.PortMapping, publishedPorts []nettypes.PortMapping) bool {
 	for _, publishedPort := range publishedPorts {
+		portRange := max(publishedPort.Range, 1)
 		if port.ContainerPort >= publishedPort.ContainerPort &&
-			port.ContainerPort < publishedPort.ContainerPort+publishedPort.Range &&
+			port.ContainerPort < publishedPort.ContainerPort+portRange &&
 			isSamePortProtocol(port.Protocol, publishedPort.Protocol) {
 			return true
 		}
@@ -1593,7 +1928,7 @@ func sortKubeKinds(documentList [][]byte) ([][]byte, error) {
 		}
 
 		switch kind {
-		case "Pod", "Deployment", "DaemonSet", "Job":
+		case "Pod", "Deployment", "DaemonSet", "Job", "StatefulSet", "CronJob":
 			sortedDocumentList = append(sortedDocumentList, document)
 		default:
 			sortedDocumentList = append([][]byte{document}, sortedDocumentList...)
@@ -1733,6 +2068,31 @@ func (ic *ContainerEngine) PlayKubeDown(ctx context.Context, body io.Reader, opt
 			jobName := jobYAML.ObjectMeta.Name
 			podName := fmt.Sprintf("%s-pod", jobName)
 			podNames = append(podNames, podName)
+		case "StatefulSet":
+			var statefulSetYAML v1apps.StatefulSet
+
+			if err := yaml.Unmarshal(document, &statefulSetYAML); err != nil {
+				return nil, fmt.Errorf("unable to read YAML as Kube StatefulSet: %w", err)
+			}
+			var numReplicas int32 = 1
+			if statefulSetYAML.Spec.Replicas != nil {
+				numReplicas = *statefulSetYAML.Spec.Replicas
+			}
+			for ordinal := range numReplicas {
+				podName := statefulSetPodName(statefulSetYAML.Name, ordinal)
+				podNames = append(podNames, podName)
+				for _, claim := range statefulSetYAML.Spec.VolumeClaimTemplates {
+					volumeNames = append(volumeNames, statefulSetClaimName(claim.Name, podName))
+				}
+			}
+		case "CronJob":
+			var cronJobYAML v1.CronJob
+
+			if err := yaml.Unmarshal(document, &cronJobYAML); err != nil {
+				return nil, fmt.Errorf("unable to read YAML as Kube CronJob: %w", err)
+			}
+			podName := fmt.Sprintf("%s-pod", cronJobYAML.Name)
+			podNames = append(podNames, podName)
 		case "PersistentVolumeClaim":
 			var pvcYAML v1.PersistentVolumeClaim
 			if err := yaml.Unmarshal(document, &pvcYAML); err != nil {
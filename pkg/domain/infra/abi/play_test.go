@@ -6,9 +6,13 @@ import (
 	"bytes"
 	"testing"
 
+	nettypes "github.com/containers/common/libnetwork/types"
+	"github.com/containers/podman/v5/pkg/domain/entities"
 	v1 "github.com/containers/podman/v5/pkg/k8s.io/api/core/v1"
 	v12 "github.com/containers/podman/v5/pkg/k8s.io/apimachinery/pkg/apis/meta/v1"
+	"github.com/containers/podman/v5/pkg/k8s.io/apimachinery/pkg/util/intstr"
 	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/yaml"
 )
 
 func TestReadConfigMapFromFile(t *testing.T) {
@@ -278,3 +282,200 @@ items:
 		})
 	}
 }
+
+func TestKubeServicesApplyToPod(t *testing.T) {
+	serviceYAML := `
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+spec:
+  type: NodePort
+  selector:
+    app: web
+  ports:
+  - name: http
+    port: 80
+    targetPort: http
+    nodePort: 30080
+  - name: metrics
+    port: 9090
+    protocol: UDP
+`
+	podYAML := `
+metadata:
+  labels:
+    app: web
+    tier: frontend
+spec:
+  containers:
+  - name: web
+    ports:
+    - name: http
+      containerPort: 8080
+`
+	tests := []struct {
+		name             string
+		selector         map[string]string
+		clusterIP        string
+		publish          bool
+		published        []nettypes.PortMapping
+		expectedAliases  []string
+		expectedPorts    []nettypes.PortMapping
+		expectError      bool
+		expectedErrorMsg string
+	}{
+		{
+			name:            "ServiceSelectsPod",
+			selector:        map[string]string{"app": "web"},
+			publish:         true,
+			expectedAliases: []string{"web"},
+			expectedPorts: []nettypes.PortMapping{
+				{HostPort: 30080, ContainerPort: 8080, Protocol: "tcp"},
+				{HostPort: 9090, ContainerPort: 9090, Protocol: "udp"},
+			},
+		},
+		{
+			name:     "SelectorDoesNotMatch",
+			selector: map[string]string{"app": "web", "tier": "backend"},
+			publish:  true,
+		},
+		{
+			name:    "NoSelector",
+			publish: true,
+		},
+		{
+			name:            "HeadlessService",
+			selector:        map[string]string{"app": "web"},
+			clusterIP:       v1.ClusterIPNone,
+			publish:         true,
+			expectedAliases: []string{"web"},
+		},
+		{
+			name:            "HostNetwork",
+			selector:        map[string]string{"app": "web"},
+			expectedAliases: []string{"web"},
+		},
+		{
+			name:            "ExplicitHostPort",
+			selector:        map[string]string{"app": "web"},
+			publish:         true,
+			published:       []nettypes.PortMapping{{HostPort: 8888, ContainerPort: 8080, Protocol: "tcp"}},
+			expectedAliases: []string{"web"},
+			expectedPorts: []nettypes.PortMapping{
+				{HostPort: 8888, ContainerPort: 8080, Protocol: "tcp"},
+				{HostPort: 9090, ContainerPort: 9090, Protocol: "udp"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var service v1.Service
+			assert.NoError(t, yaml.Unmarshal([]byte(serviceYAML), &service))
+			service.Spec.Selector = test.selector
+			service.Spec.ClusterIP = test.clusterIP
+			var pod v1.PodTemplateSpec
+			assert.NoError(t, yaml.Unmarshal([]byte(podYAML), &pod))
+
+			services := newKubeServices()
+			services.services = append(services.services, service)
+			podOpt := entities.PodCreateOptions{Net: &entities.NetOptions{PublishPorts: test.published}}
+
+			aliases, err := services.applyToPod(&pod, &podOpt, test.publish)
+			if test.expectError {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), test.expectedErrorMsg)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expectedAliases, aliases)
+				assert.Equal(t, test.expectedPorts, podOpt.Net.PublishPorts)
+			}
+		})
+	}
+}
+
+func TestKubeServicesHostPortConflict(t *testing.T) {
+	pod := v1.PodTemplateSpec{
+		ObjectMeta: v12.ObjectMeta{Labels: map[string]string{"app": "web"}},
+	}
+	newService := func(name string) v1.Service {
+		return v1.Service{
+			ObjectMeta: v12.ObjectMeta{Name: name},
+			Spec: v1.ServiceSpec{
+				Selector: map[string]string{"app": "web"},
+				Ports:    []v1.ServicePort{{Port: 80, TargetPort: intstr.FromInt(8080)}},
+			},
+		}
+	}
+
+	services := newKubeServices()
+	services.services = append(services.services, newService("web"))
+
+	// A second replica selected by the same Service does not publish the port again.
+	for range 2 {
+		podOpt := entities.PodCreateOptions{Net: &entities.NetOptions{}}
+		_, err := services.applyToPod(&pod, &podOpt, true)
+		assert.NoError(t, err)
+	}
+
+	services.services = append(services.services, newService("other"))
+	podOpt := entities.PodCreateOptions{Net: &entities.NetOptions{}}
+	_, err := services.applyToPod(&pod, &podOpt, true)
+	assert.ErrorContains(t, err, "host port 80/tcp is already published by service web")
+}
+
+func TestServicePortMappingErrors(t *testing.T) {
+	tests := []struct {
+		name             string
+		servicePort      v1.ServicePort
+		expectedErrorMsg string
+	}{
+		{
+			"UnknownNamedPort",
+			v1.ServicePort{Port: 80, TargetPort: intstr.FromString("http")},
+			`target port "http" does not name a container port of the pod`,
+		},
+		{
+			"InvalidNodePort",
+			v1.ServicePort{Port: 80, NodePort: 70000},
+			"invalid port 70000",
+		},
+		{
+			"MissingPort",
+			v1.ServicePort{},
+			"invalid port 0",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := servicePortMapping(test.servicePort, nil)
+			assert.ErrorContains(t, err, test.expectedErrorMsg)
+		})
+	}
+}
+
+func TestPortAlreadyPublished(t *testing.T) {
+	published := []nettypes.PortMapping{
+		{HostPort: 8080, ContainerPort: 80},
+		{HostPort: 9000, ContainerPort: 90, Range: 3, Protocol: "udp"},
+	}
+	tests := []struct {
+		name     string
+		port     nettypes.PortMapping
+		expected bool
+	}{
+		{"SinglePortWithoutRange", nettypes.PortMapping{ContainerPort: 80, Protocol: "tcp"}, true},
+		{"NextToSinglePort", nettypes.PortMapping{ContainerPort: 81}, false},
+		{"InRange", nettypes.PortMapping{ContainerPort: 92, Protocol: "udp"}, true},
+		{"AfterRange", nettypes.PortMapping{ContainerPort: 93, Protocol: "udp"}, false},
+		{"OtherProtocol", nettypes.PortMapping{ContainerPort: 90, Protocol: "tcp"}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, portAlreadyPublished(test.port, published))
+		})
+	}
+}
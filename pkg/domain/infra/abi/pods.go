@@ -6,16 +6,19 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"reflect"
 	"strconv"
 	"strings"
 
 	"github.com/containers/podman/v5/libpod"
 	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/pkg/checkpoint"
 	"github.com/containers/podman/v5/pkg/domain/entities"
 	dfilters "github.com/containers/podman/v5/pkg/domain/filters"
 	"github.com/containers/podman/v5/pkg/signal"
 	"github.com/containers/podman/v5/pkg/specgen"
 	"github.com/containers/podman/v5/pkg/specgen/generate"
+	"github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/sirupsen/logrus"
 )
 
@@ -189,6 +192,71 @@ func (ic *ContainerEngine) PodUnpause(ctx context.Context, namesOrIds []string,
 	return reports, nil
 }
 
+// PodUpdate updates the resource limits of the given pod and optionally the
+// restart policy and healthcheck on-failure action of its containers.
+func (ic *ContainerEngine) PodUpdate(ctx context.Context, options *entities.PodUpdateOptions) (string, error) {
+	if err := specgen.WeightDevices(options.Specgen); err != nil {
+		return "", err
+	}
+	if err := specgen.FinishThrottleDevices(options.Specgen); err != nil {
+		return "", err
+	}
+	pod, err := ic.Libpod.LookupPod(options.NameOrID)
+	if err != nil {
+		return "", err
+	}
+
+	// The resource limits are always set after parsing the devices,
+	// do not touch the pod cgroup unless something is to be changed.
+	resources := options.Specgen.ResourceLimits
+	if resources != nil && reflect.DeepEqual(*resources, specs.LinuxResources{}) {
+		resources = nil
+	}
+	var restartPolicy *string
+	if options.Specgen.RestartPolicy != "" {
+		restartPolicy = &options.Specgen.RestartPolicy
+	}
+
+	if err := pod.Update(ctx, resources, restartPolicy, options.Specgen.RestartRetries, options.HealthOnFailure); err != nil {
+		return "", err
+	}
+	return pod.ID(), nil
+}
+
+func (ic *ContainerEngine) PodCheckpoint(ctx context.Context, nameOrID string, options entities.PodCheckpointOptions) (*entities.PodCheckpointReport, error) {
+	pod, err := ic.Libpod.LookupPod(nameOrID)
+	if err != nil {
+		return nil, err
+	}
+
+	checkOpts := libpod.ContainerCheckpointOptions{
+		Keep:           options.Keep,
+		TCPEstablished: options.TCPEstablished,
+		TargetFile:     options.Export,
+		IgnoreRootfs:   options.IgnoreRootFS,
+		IgnoreVolumes:  options.IgnoreVolumes,
+		KeepRunning:    options.LeaveRunning,
+		Compression:    options.Compression,
+		FileLocks:      options.FileLocks,
+	}
+	if err := pod.Checkpoint(ctx, checkOpts); err != nil {
+		return nil, err
+	}
+	return &entities.PodCheckpointReport{Id: pod.ID(), RawInput: nameOrID}, nil
+}
+
+func (ic *ContainerEngine) PodRestore(ctx context.Context, options entities.PodRestoreOptions) (*entities.PodRestoreReport, error) {
+	pod, ctrs, err := checkpoint.CRImportPodCheckpoint(ctx, ic.Libpod, options)
+	if err != nil {
+		return nil, err
+	}
+	report := &entities.PodRestoreReport{Id: pod.ID()}
+	for _, ctr := range ctrs {
+		report.Containers = append(report.Containers, ctr.ID())
+	}
+	return report, nil
+}
+
 func (ic *ContainerEngine) PodStop(ctx context.Context, namesOrIds []string, options entities.PodStopOptions) ([]*entities.PodStopReport, error) {
 	reports := []*entities.PodStopReport{}
 	pods, err := getPodsByContext(options.All, options.Latest, namesOrIds, ic.Libpod)
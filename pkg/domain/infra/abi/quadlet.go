@@ -0,0 +1,482 @@
+//go:build !remote
+
+package abi
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/containers/podman/v5/pkg/rootless"
+	"github.com/containers/podman/v5/pkg/systemd"
+	"github.com/containers/podman/v5/pkg/systemd/parser"
+	"github.com/containers/podman/v5/pkg/systemd/quadlet"
+	"github.com/containers/storage/pkg/fileutils"
+	"github.com/coreos/go-systemd/v22/dbus"
+	"github.com/sirupsen/logrus"
+)
+
+// quadletAssetsFile returns the path of the file listing the files installed
+// alongside the Quadlet unit name
+func quadletAssetsFile(unitDir, name string) string {
+	return filepath.Join(unitDir, "."+name+".assets")
+}
+
+// quadletUnitDir returns the directory Quadlet units are installed into
+func quadletUnitDir() (string, error) {
+	return quadlet.UnitDir(rootless.IsRootless())
+}
+
+// quadletFilePath returns the path of the installed Quadlet unit name
+func quadletFilePath(unitDir, name string) (string, error) {
+	if name != filepath.Base(name) || !quadlet.IsQuadletFile(name) {
+		return "", fmt.Errorf("%q is not the name of a Quadlet file: %w", name, define.ErrInvalidArg)
+	}
+	path := filepath.Join(unitDir, name)
+	if err := fileutils.Exists(path); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", fmt.Errorf("%s: %w", name, define.ErrNoSuchQuadlet)
+		}
+		return "", err
+	}
+	return path, nil
+}
+
+// readQuadletAssets returns the files installed alongside the Quadlet unit name
+func readQuadletAssets(unitDir, name string) ([]string, error) {
+	f, err := os.Open(quadletAssetsFile(unitDir, name))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var assets []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if asset := strings.TrimSpace(scanner.Text()); asset != "" {
+			assets = append(assets, asset)
+		}
+	}
+	return assets, scanner.Err()
+}
+
+// quadletInstallFile is a file written to the Quadlet unit directory by
+// QuadletInstall
+type quadletInstallFile struct {
+	dst     string
+	content []byte
+	// tmp is the temporary file the content is staged in
+	tmp string
+	// existed is set if dst was installed before and is replaced
+	existed bool
+	// backup is a copy of the replaced file to restore on errors
+	backup string
+}
+
+// writeTemp writes the content to a new temporary file next to the
+// destination and returns its path.
+func writeTemp(dst, suffix string, content []byte, mode os.FileMode) (string, error) {
+	tmp, err := os.CreateTemp(filepath.Dir(dst), "."+filepath.Base(dst)+suffix)
+	if err != nil {
+		return "", err
+	}
+	_, err = tmp.Write(content)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmp.Name(), mode)
+	}
+	if err != nil {
+		if rmErr := os.Remove(tmp.Name()); rmErr != nil {
+			logrus.Errorf("Removing %s: %v", tmp.Name(), rmErr)
+		}
+		return "", err
+	}
+	return tmp.Name(), nil
+}
+
+// stage writes the content of the file to a temporary file next to its
+// destination.  A replaced file is copied to a backup first.
+func (f *quadletInstallFile) stage() error {
+	if err := os.MkdirAll(filepath.Dir(f.dst), 0o755); err != nil {
+		return err
+	}
+	if f.existed {
+		// Anything but a regular file cannot be replaced by the rename
+		// and hence needs no backup.
+		info, err := os.Lstat(f.dst)
+		if err != nil {
+			return err
+		}
+		if info.Mode().IsRegular() {
+			content, err := os.ReadFile(f.dst)
+			if err != nil {
+				return err
+			}
+			if f.backup, err = writeTemp(f.dst, ".bak", content, info.Mode().Perm()); err != nil {
+				return err
+			}
+		}
+	}
+	var err error
+	f.tmp, err = writeTemp(f.dst, ".tmp", f.content, 0o644)
+	return err
+}
+
+// removeTemp removes the given temporary file if it was created.
+func removeTemp(path string) {
+	if path == "" {
+		return
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		logrus.Errorf("Removing %s: %v", path, err)
+	}
+}
+
+// installQuadletFiles writes all files or none of them.  The files are
+// staged first and then renamed into place in order, so a unit is only
+// visible once the files it references are.  On error, the replaced files
+// are restored from their backups and the files which were not installed
+// before are removed.
+func installQuadletFiles(files []*quadletInstallFile) (retErr error) {
+	installed := 0
+	defer func() {
+		for i, f := range files {
+			switch {
+			case retErr == nil || i >= installed:
+				removeTemp(f.tmp)
+				removeTemp(f.backup)
+			case f.backup != "":
+				if err := os.Rename(f.backup, f.dst); err != nil {
+					logrus.Errorf("Restoring %s: %v", f.dst, err)
+				}
+			case !f.existed:
+				if err := os.Remove(f.dst); err != nil {
+					logrus.Errorf("Removing %s: %v", f.dst, err)
+				}
+			}
+		}
+	}()
+
+	for _, f := range files {
+		if err := f.stage(); err != nil {
+			return fmt.Errorf("writing %s: %w", f.dst, err)
+		}
+	}
+	for _, f := range files {
+		if err := os.Rename(f.tmp, f.dst); err != nil {
+			return fmt.Errorf("installing %s: %w", f.dst, err)
+		}
+		installed++
+	}
+	return nil
+}
+
+func reloadSystemd(ctx context.Context) error {
+	conn, err := systemd.ConnectToDBUS()
+	if err != nil {
+		return fmt.Errorf("connecting to systemd: %w", err)
+	}
+	defer conn.Close()
+	if err := conn.ReloadContext(ctx); err != nil {
+		return fmt.Errorf("reloading systemd: %w", err)
+	}
+	return nil
+}
+
+// QuadletInstall copies the Quadlet units in paths, and the files they
+// reference, into the Quadlet unit directory of the user.  Either all or
+// none of the units are installed.
+func (ic *ContainerEngine) QuadletInstall(ctx context.Context, paths []string, options entities.QuadletInstallOptions) (*entities.QuadletInstallReport, error) {
+	units, err := quadlet.FindInstallUnits(paths)
+	if err != nil {
+		return nil, err
+	}
+	unitDir, err := quadletUnitDir()
+	if err != nil {
+		return nil, err
+	}
+
+	// Check and read everything before installing anything
+	var files []*quadletInstallFile
+	sources := make(map[string]string)
+	addFile := func(src, file string) error {
+		dst := filepath.Join(unitDir, file)
+		if prev, ok := sources[dst]; ok {
+			// Units of the same directory may share their assets
+			if prev == src {
+				return nil
+			}
+			return fmt.Errorf("%s and %s are both installed as %s: %w", prev, src, file, define.ErrInvalidArg)
+		}
+		sources[dst] = src
+		f := &quadletInstallFile{dst: dst}
+		if err := fileutils.Exists(dst); err == nil {
+			if !options.Replace {
+				return fmt.Errorf("%s is already installed in %s: %w", file, unitDir, define.ErrQuadletExists)
+			}
+			f.existed = true
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		content, err := os.ReadFile(src)
+		if err != nil {
+			return err
+		}
+		f.content = content
+		files = append(files, f)
+		return nil
+	}
+	report := &entities.QuadletInstallReport{InstalledQuadlets: make(map[string]string, len(units))}
+	for _, unit := range units {
+		name := filepath.Base(unit.Path)
+		srcDir := filepath.Dir(unit.Path)
+		for _, asset := range unit.Assets {
+			if err := addFile(filepath.Join(srcDir, asset), asset); err != nil {
+				return nil, fmt.Errorf("installing %s of Quadlet %s: %w", asset, name, err)
+			}
+		}
+		// The list of assets replaces the one of a previous installation
+		assetsFile := &quadletInstallFile{
+			dst:     quadletAssetsFile(unitDir, name),
+			content: []byte(strings.Join(unit.Assets, "\n")),
+		}
+		if err := fileutils.Exists(assetsFile.dst); err == nil {
+			assetsFile.existed = true
+		}
+		files = append(files, assetsFile)
+		if err := addFile(unit.Path, name); err != nil {
+			return nil, fmt.Errorf("installing Quadlet %s: %w", name, err)
+		}
+		report.InstalledQuadlets[unit.Path] = filepath.Join(unitDir, name)
+	}
+
+	if err := installQuadletFiles(files); err != nil {
+		return nil, err
+	}
+
+	if options.ReloadSystemd {
+		if err := reloadSystemd(ctx); err != nil {
+			return report, err
+		}
+	}
+	return report, nil
+}
+
+// QuadletList lists the Quadlet units installed in the Quadlet unit directory
+// of the user, with the state of their services.
+func (ic *ContainerEngine) QuadletList(ctx context.Context, options entities.QuadletListOptions) ([]*entities.ListQuadlet, error) {
+	unitDir, err := quadletUnitDir()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(unitDir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return []*entities.ListQuadlet{}, nil
+		}
+		return nil, err
+	}
+
+	conn, err := systemd.ConnectToDBUS()
+	if err != nil {
+		logrus.Debugf("Connecting to systemd: %v", err)
+		conn = nil
+	} else {
+		defer conn.Close()
+	}
+
+	reports := make([]*entities.ListQuadlet, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !quadlet.IsQuadletFile(entry.Name()) {
+			continue
+		}
+		report := &entities.ListQuadlet{
+			Name:   entry.Name(),
+			Path:   filepath.Join(unitDir, entry.Name()),
+			Status: "unknown",
+		}
+		unit, err := parser.ParseUnitFile(report.Path)
+		if err != nil {
+			logrus.Warnf("Parsing Quadlet %s: %v", report.Path, err)
+		} else if report.UnitName, err = quadlet.ServiceName(unit); err != nil {
+			return nil, err
+		}
+		if conn != nil && report.UnitName != "" {
+			report.Status = quadletUnitStatus(ctx, conn, report.UnitName)
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+func quadletUnitStatus(ctx context.Context, conn *dbus.Conn, unitName string) string {
+	prop, err := conn.GetUnitPropertyContext(ctx, unitName, "ActiveState")
+	if err != nil {
+		logrus.Debugf("Getting state of systemd unit %s: %v", unitName, err)
+		return "unknown"
+	}
+	state, ok := prop.Value.Value().(string)
+	if !ok {
+		return "unknown"
+	}
+	return state
+}
+
+// QuadletPrint returns the content of the installed Quadlet unit.
+func (ic *ContainerEngine) QuadletPrint(ctx context.Context, name string) (string, error) {
+	unitDir, err := quadletUnitDir()
+	if err != nil {
+		return "", err
+	}
+	path, err := quadletFilePath(unitDir, name)
+	if err != nil {
+		return "", err
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
+
+// QuadletRemove removes installed Quadlet units and the files installed
+// alongside them, which are not used by other units.
+func (ic *ContainerEngine) QuadletRemove(ctx context.Context, names []string, options entities.QuadletRemoveOptions) (*entities.QuadletRemoveReport, error) {
+	unitDir, err := quadletUnitDir()
+	if err != nil {
+		return nil, err
+	}
+	if options.All {
+		quadlets, err := ic.QuadletList(ctx, entities.QuadletListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		names = make([]string, 0, len(quadlets))
+		for _, q := range quadlets {
+			names = append(names, q.Name)
+		}
+	}
+
+	conn, err := systemd.ConnectToDBUS()
+	if err != nil {
+		logrus.Debugf("Connecting to systemd: %v", err)
+		conn = nil
+	} else {
+		defer conn.Close()
+	}
+
+	report := &entities.QuadletRemoveReport{Removed: []string{}, Errors: make(map[string]string)}
+	for _, name := range names {
+		path, err := quadletFilePath(unitDir, name)
+		if err != nil {
+			if !options.Ignore || !errors.Is(err, define.ErrNoSuchQuadlet) {
+				report.Errors[name] = err.Error()
+			}
+			continue
+		}
+
+		if unit, err := parser.ParseUnitFile(path); err == nil && conn != nil {
+			unitName, err := quadlet.ServiceName(unit)
+			if err != nil {
+				report.Errors[name] = err.Error()
+				continue
+			}
+			if err := stopQuadletUnit(ctx, conn, unitName, options.Force); err != nil {
+				report.Errors[name] = err.Error()
+				continue
+			}
+		}
+
+		if err := removeQuadletFiles(unitDir, name); err != nil {
+			report.Errors[name] = err.Error()
+			continue
+		}
+		report.Removed = append(report.Removed, name)
+	}
+
+	if options.ReloadSystemd && len(report.Removed) > 0 {
+		if err := reloadSystemd(ctx); err != nil {
+			return report, err
+		}
+	}
+	return report, nil
+}
+
+// stopQuadletUnit stops the service of a Quadlet unit which is about to be
+// removed, if force is set. Active services are never removed otherwise.
+func stopQuadletUnit(ctx context.Context, conn *dbus.Conn, unitName string, force bool) error {
+	switch state := quadletUnitStatus(ctx, conn, unitName); state {
+	case "active", "activating", "reloading", "deactivating":
+		if !force {
+			return fmt.Errorf("systemd unit %s is %s, stop it first or use --force", unitName, state)
+		}
+	default:
+		return nil
+	}
+
+	stopChan := make(chan string)
+	if _, err := conn.StopUnitContext(ctx, unitName, "replace", stopChan); err != nil {
+		return fmt.Errorf("stopping systemd unit %s: %w", unitName, err)
+	}
+	if result := <-stopChan; result != "done" {
+		return fmt.Errorf("error stopping systemd unit %q expected %q but received %q", unitName, "done", result)
+	}
+	return nil
+}
+
+// removeQuadletFiles removes the Quadlet unit name and the files installed
+// alongside it which are not used by other installed units.
+func removeQuadletFiles(unitDir, name string) error {
+	assets, err := readQuadletAssets(unitDir, name)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(filepath.Join(unitDir, name)); err != nil {
+		return err
+	}
+	if err := os.Remove(quadletAssetsFile(unitDir, name)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if len(assets) == 0 {
+		return nil
+	}
+
+	// Keep the files still used by other units
+	used := make(map[string]bool)
+	entries, err := os.ReadDir(unitDir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !quadlet.IsQuadletFile(entry.Name()) {
+			continue
+		}
+		others, err := readQuadletAssets(unitDir, entry.Name())
+		if err != nil {
+			return err
+		}
+		for _, asset := range others {
+			used[asset] = true
+		}
+	}
+	for _, asset := range assets {
+		if used[asset] {
+			continue
+		}
+		if err := os.Remove(filepath.Join(unitDir, asset)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+//go:build !remote
+
+package abi
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuadletInstallAllOrNothing(t *testing.T) {
+	unitDir := t.TempDir()
+	t.Setenv("QUADLET_UNIT_DIRS", unitDir)
+	srcDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(srcDir, "a.container"), []byte("[Container]\nImage=alpine\nEnvironmentFile=a.env\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(srcDir, "a.env"), []byte("FOO=bar\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(srcDir, "b.container"), []byte("[Container]\nImage=alpine\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(unitDir, "b.container"), []byte("[Container]\nImage=busybox\n"), 0o644))
+
+	ic := &ContainerEngine{}
+	options := entities.QuadletInstallOptions{}
+	_, err := ic.QuadletInstall(context.Background(), []string{srcDir}, options)
+	assert.ErrorIs(t, err, define.ErrQuadletExists)
+	entries, err := os.ReadDir(unitDir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "nothing is installed if one unit conflicts")
+
+	// b.container cannot replace a directory, a.container and its assets
+	// are removed again
+	require.NoError(t, os.Remove(filepath.Join(unitDir, "b.container")))
+	require.NoError(t, os.MkdirAll(filepath.Join(unitDir, "b.container", "dir"), 0o755))
+	options.Replace = true
+	_, err = ic.QuadletInstall(context.Background(), []string{srcDir}, options)
+	assert.Error(t, err)
+	entries, err = os.ReadDir(unitDir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "b.container", entries[0].Name())
+
+	// replaced files are restored when a later file cannot be installed
+	require.NoError(t, os.WriteFile(filepath.Join(unitDir, "a.container"), []byte("[Container]\nImage=busybox\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(unitDir, "a.env"), []byte("FOO=old\n"), 0o644))
+	_, err = ic.QuadletInstall(context.Background(), []string{srcDir}, options)
+	assert.Error(t, err)
+	entries, err = os.ReadDir(unitDir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 3)
+	content, err := os.ReadFile(filepath.Join(unitDir, "a.container"))
+	require.NoError(t, err)
+	assert.Equal(t, "[Container]\nImage=busybox\n", string(content))
+	info, err := os.Stat(filepath.Join(unitDir, "a.container"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	content, err = os.ReadFile(filepath.Join(unitDir, "a.env"))
+	require.NoError(t, err)
+	assert.Equal(t, "FOO=old\n", string(content))
+
+	require.NoError(t, os.RemoveAll(filepath.Join(unitDir, "b.container")))
+	report, err := ic.QuadletInstall(context.Background(), []string{srcDir}, options)
+	require.NoError(t, err)
+	assert.Len(t, report.InstalledQuadlets, 2)
+	content, err = os.ReadFile(filepath.Join(unitDir, "a.env"))
+	require.NoError(t, err)
+	assert.Equal(t, "FOO=bar\n", string(content))
+}
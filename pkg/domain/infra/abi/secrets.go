@@ -11,8 +11,11 @@ import (
 	"strings"
 
 	"github.com/containers/common/pkg/secrets"
+	"github.com/containers/podman/v5/libpod"
+	"github.com/containers/podman/v5/libpod/define"
 	"github.com/containers/podman/v5/pkg/domain/entities"
 	"github.com/containers/podman/v5/pkg/domain/utils"
+	"github.com/containers/podman/v5/pkg/secretstore"
 )
 
 func (ic *ContainerEngine) SecretCreate(ctx context.Context, name string, reader io.Reader, options entities.SecretCreateOptions) (*entities.SecretCreateReport, error) {
@@ -92,7 +95,11 @@ func (ic *ContainerEngine) SecretInspect(ctx context.Context, nameOrIDs []string
 		if secret.UpdatedAt.IsZero() {
 			secret.UpdatedAt = secret.CreatedAt
 		}
-		reports = append(reports, secretToReportWithData(*secret, string(data)))
+		versions, err := manager.Versions(secret)
+		if err != nil {
+			return nil, nil, fmt.Errorf("inspecting secret %s: %w", nameOrID, err)
+		}
+		reports = append(reports, secretToReportWithData(*secret, versions, string(data)))
 	}
 
 	return reports, errs, nil
@@ -114,7 +121,11 @@ func (ic *ContainerEngine) SecretList(ctx context.Context, opts entities.SecretL
 			return nil, err
 		}
 		if result {
-			report = append(report, secretToReport(secret))
+			versions, err := manager.Versions(&secret)
+			if err != nil {
+				return nil, err
+			}
+			report = append(report, secretToReport(secret, versions))
 		}
 	}
 	return report, nil
@@ -151,6 +162,107 @@ func (ic *ContainerEngine) SecretRm(ctx context.Context, nameOrIDs []string, opt
 	return reports, nil
 }
 
+func (ic *ContainerEngine) SecretRekey(ctx context.Context, nameOrIDs []string, options entities.SecretRekeyOptions) ([]*entities.SecretRekeyReport, error) {
+	manager, err := ic.Libpod.SecretsManager()
+	if err != nil {
+		return nil, err
+	}
+	toRekey := nameOrIDs
+	if options.All {
+		allSecrs, err := manager.List()
+		if err != nil {
+			return nil, err
+		}
+		for _, secr := range allSecrs {
+			// only the file driver stores the secret data itself
+			if secr.Driver == "file" {
+				toRekey = append(toRekey, secr.ID)
+			}
+		}
+	}
+	// Containers restarted by their restart policy cannot read a key from
+	// the environment.
+	var ctrs []*libpod.Container
+	if options.DriverOpts[secretstore.EncryptOption] == secretstore.EncryptEnv {
+		ctrs, err = ic.Libpod.GetAllContainers()
+		if err != nil {
+			return nil, err
+		}
+	}
+	reports := make([]*entities.SecretRekeyReport, 0, len(toRekey))
+	for _, nameOrID := range toRekey {
+		if len(ctrs) > 0 {
+			if err := checkRestartedSecretUsers(manager, ctrs, nameOrID); err != nil {
+				reports = append(reports, &entities.SecretRekeyReport{Err: err})
+				continue
+			}
+		}
+		id, err := manager.Rekey(nameOrID, options.DriverOpts)
+		reports = append(reports, &entities.SecretRekeyReport{Err: err, ID: id})
+	}
+	return reports, nil
+}
+
+// checkRestartedSecretUsers returns an error if the secret is used by a
+// container with a restart policy.
+func checkRestartedSecretUsers(manager *secretstore.Manager, ctrs []*libpod.Container, nameOrID string) error {
+	secret, err := manager.Lookup(nameOrID)
+	if err != nil {
+		return err
+	}
+	for _, ctr := range ctrs {
+		policy := ctr.RestartPolicy()
+		if policy == define.RestartPolicyNone || policy == define.RestartPolicyNo {
+			continue
+		}
+		for _, secr := range ctr.Secrets() {
+			if secr.Name == secret.Name {
+				return fmt.Errorf("secret %s is used by container %s with restart policy %s, which cannot read a key from the environment when it is restarted: %w", secret.Name, ctr.ID(), policy, define.ErrInvalidArg)
+			}
+		}
+	}
+	return nil
+}
+
+func (ic *ContainerEngine) SecretUpdate(ctx context.Context, nameOrID string, reader io.Reader) (*entities.SecretUpdateReport, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	manager, err := ic.Libpod.SecretsManager()
+	if err != nil {
+		return nil, err
+	}
+	secret, version, err := manager.Update(nameOrID, data)
+	if err != nil {
+		return nil, err
+	}
+	report := &entities.SecretUpdateReport{
+		ID:      secret.ID,
+		Version: version,
+	}
+
+	ctrs, err := ic.Libpod.GetAllContainers()
+	if err != nil {
+		return nil, err
+	}
+	var errs []error
+	for _, ctr := range ctrs {
+		rotated, err := ctr.RotateSecret(secret.Name)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if rotated {
+			report.Rotated = append(report.Rotated, ctr.ID())
+		}
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("secret %s updated to version %d, but rotating it failed: %w", secret.Name, report.Version, errors.Join(errs...))
+	}
+	return report, nil
+}
+
 func (ic *ContainerEngine) SecretExists(ctx context.Context, nameOrID string) (*entities.BoolReport, error) {
 	manager, err := ic.Libpod.SecretsManager()
 	if err != nil {
@@ -165,11 +277,22 @@ func (ic *ContainerEngine) SecretExists(ctx context.Context, nameOrID string) (*
 	return &entities.BoolReport{Value: secret != nil}, nil
 }
 
-func secretToReport(secret secrets.Secret) *entities.SecretInfoReport {
-	return secretToReportWithData(secret, "")
+func secretToReport(secret secrets.Secret, versions []secretstore.Version) *entities.SecretInfoReport {
+	return secretToReportWithData(secret, versions, "")
 }
 
-func secretToReportWithData(secret secrets.Secret, data string) *entities.SecretInfoReport {
+func secretToReportWithData(secret secrets.Secret, secretVersions []secretstore.Version, data string) *entities.SecretInfoReport {
+	versions := make([]entities.SecretDataVersion, 0, len(secretVersions))
+	for _, version := range secretVersions {
+		versions = append(versions, entities.SecretDataVersion{
+			Version:   version.Version,
+			CreatedAt: version.CreatedAt,
+		})
+	}
+	if len(versions) == 0 {
+		// secrets created before versions were recorded
+		versions = append(versions, entities.SecretDataVersion{Version: 1, CreatedAt: secret.CreatedAt})
+	}
 	return &entities.SecretInfoReport{
 		ID:        secret.ID,
 		CreatedAt: secret.CreatedAt,
@@ -182,6 +305,7 @@ func secretToReportWithData(secret secrets.Secret, data string) *entities.Secret
 			},
 			Labels: secret.Labels,
 		},
+		Versions:   versions,
 		SecretData: data,
 	}
 }
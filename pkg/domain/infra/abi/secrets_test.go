@@ -8,12 +8,14 @@ import (
 
 	"github.com/containers/common/pkg/secrets"
 	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/containers/podman/v5/pkg/secretstore"
 	"github.com/stretchr/testify/assert"
 )
 
 func Test_secretToReport(t *testing.T) {
 	type args struct {
 		secret     secrets.Secret
+		versions   []secretstore.Version
 		secretData string
 	}
 	tests := []struct {
@@ -51,13 +53,47 @@ func Test_secretToReport(t *testing.T) {
 					},
 					Labels: map[string]string{"test-label": "test-value"},
 				},
+				Versions: []entities.SecretDataVersion{
+					{Version: 1, CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)},
+				},
 				SecretData: "test-secret-data",
 			},
 		},
+		{
+			name: "test secretToReport with versions",
+			args: args{
+				secret: secrets.Secret{
+					Name:      "test-name",
+					ID:        "test-id",
+					CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+					UpdatedAt: time.Date(2024, 2, 3, 0, 0, 0, 0, time.UTC),
+					Driver:    "test-driver",
+				},
+				versions: []secretstore.Version{
+					{Version: 1, CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)},
+					{Version: 2, CreatedAt: time.Date(2024, 2, 3, 0, 0, 0, 0, time.UTC)},
+				},
+			},
+			want: &entities.SecretInfoReport{
+				ID:        "test-id",
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt: time.Date(2024, 2, 3, 0, 0, 0, 0, time.UTC),
+				Spec: entities.SecretSpec{
+					Name: "test-name",
+					Driver: entities.SecretDriverSpec{
+						Name: "test-driver",
+					},
+				},
+				Versions: []entities.SecretDataVersion{
+					{Version: 1, CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)},
+					{Version: 2, CreatedAt: time.Date(2024, 2, 3, 0, 0, 0, 0, time.UTC)},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			assert.Equalf(t, tt.want, secretToReportWithData(tt.args.secret, tt.args.secretData), "secretToReport(%v)", tt.args.secret)
+			assert.Equalf(t, tt.want, secretToReportWithData(tt.args.secret, tt.args.versions, tt.args.secretData), "secretToReport(%v)", tt.args.secret)
 		})
 	}
 }
@@ -253,10 +253,24 @@ func (ic *ContainerEngine) SystemDf(ctx context.Context, options entities.System
 			// TODO: fix this.
 			continue
 		}
-		volSize, err := directory.Size(mountPoint)
+		// Prefer the usage reported by the quota backend, walking
+		// large volumes is slow.
+		var volSize int64
+		quota, err := v.QuotaUsage()
 		if err != nil {
+			if errors.Is(err, define.ErrNoSuchVolume) || errors.Is(err, define.ErrVolumeRemoved) {
+				continue
+			}
 			return nil, err
 		}
+		if quota != nil && quota.UsageAvailable {
+			volSize = quota.Used
+		} else {
+			volSize, err = directory.Size(mountPoint)
+			if err != nil {
+				return nil, err
+			}
+		}
 		inUse, err := v.VolumeInUse()
 		if err != nil {
 			if errors.Is(err, define.ErrNoSuchVolume) {
@@ -273,6 +287,9 @@ func (ic *ContainerEngine) SystemDf(ctx context.Context, options entities.System
 			Size:            volSize,
 			ReclaimableSize: reclaimableSize,
 		}
+		if quota != nil {
+			report.Quota = quota.Size
+		}
 		dfVolumes = append(dfVolumes, &report)
 	}
 
@@ -293,7 +310,7 @@ func (ic *ContainerEngine) Renumber(ctx context.Context) error {
 }
 
 func (ic *ContainerEngine) Migrate(ctx context.Context, options entities.SystemMigrateOptions) error {
-	return ic.Libpod.Migrate(options.NewRuntime)
+	return ic.Libpod.Migrate(options.NewRuntime, options.Database)
 }
 
 func (se SystemEngine) Shutdown(ctx context.Context) {
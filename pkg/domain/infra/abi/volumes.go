@@ -29,7 +29,7 @@ func (ic *ContainerEngine) VolumeCreate(ctx context.Context, opts entities.Volum
 		volumeOptions = append(volumeOptions, libpod.WithVolumeLabels(opts.Label))
 	}
 	if len(opts.Options) > 0 {
-		parsedOptions, err := parse.VolumeOptions(opts.Options)
+		parsedOptions, err := parse.VolumeOptions(parse.LocalVolumeOptions(opts.Driver, opts.Options))
 		if err != nil {
 			return nil, err
 		}
@@ -239,3 +239,75 @@ func (ic *ContainerEngine) VolumeReload(ctx context.Context) (*entities.VolumeRe
 	report := ic.Libpod.UpdateVolumePlugins(ctx)
 	return &entities.VolumeReloadReport{VolumeReload: *report}, nil
 }
+
+func (ic *ContainerEngine) VolumeClone(ctx context.Context, nameOrID string, opts entities.VolumeCloneOptions) (*entities.IDOrNameResponse, error) {
+	vol, err := ic.Libpod.LookupVolume(nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	clone, err := ic.Libpod.CloneVolume(ctx, vol, opts.Name, opts.Snapshot)
+	if err != nil {
+		return nil, err
+	}
+	return &entities.IDOrNameResponse{IDOrName: clone.Name()}, nil
+}
+
+func volumeSnapshotToReport(snapshot libpod.VolumeSnapshot) *entities.VolumeSnapshotReport {
+	return &entities.VolumeSnapshotReport{
+		InspectVolumeSnapshot: define.InspectVolumeSnapshot{
+			Name:      snapshot.Name,
+			CreatedAt: snapshot.CreatedTime,
+		},
+	}
+}
+
+func (ic *ContainerEngine) VolumeSnapshotCreate(ctx context.Context, nameOrID, snapshot string) (*entities.VolumeSnapshotReport, error) {
+	vol, err := ic.Libpod.LookupVolume(nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	created, err := vol.CreateSnapshot(snapshot)
+	if err != nil {
+		return nil, err
+	}
+	return volumeSnapshotToReport(*created), nil
+}
+
+func (ic *ContainerEngine) VolumeSnapshotList(ctx context.Context, nameOrID string) ([]*entities.VolumeSnapshotReport, error) {
+	vol, err := ic.Libpod.LookupVolume(nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	snapshots, err := vol.Snapshots()
+	if err != nil {
+		return nil, err
+	}
+	reports := make([]*entities.VolumeSnapshotReport, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		reports = append(reports, volumeSnapshotToReport(snapshot))
+	}
+	return reports, nil
+}
+
+func (ic *ContainerEngine) VolumeSnapshotRestore(ctx context.Context, nameOrID, snapshot string) error {
+	vol, err := ic.Libpod.LookupVolume(nameOrID)
+	if err != nil {
+		return err
+	}
+	return vol.RestoreSnapshot(snapshot)
+}
+
+func (ic *ContainerEngine) VolumeSnapshotRm(ctx context.Context, nameOrID string, snapshots []string) ([]*entities.VolumeSnapshotRmReport, error) {
+	vol, err := ic.Libpod.LookupVolume(nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	reports := make([]*entities.VolumeSnapshotRmReport, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		reports = append(reports, &entities.VolumeSnapshotRmReport{
+			Err:  vol.RemoveSnapshot(snapshot),
+			Name: snapshot,
+		})
+	}
+	return reports, nil
+}
@@ -0,0 +1,145 @@
+package tunnel
+
+import (
+	"archive/tar"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/containers/image/v5/types"
+	"github.com/containers/podman/v5/pkg/bindings/artifacts"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+)
+
+// ArtifactAdd checks the files and sends them all in one request, so that
+// either all or none of them are added to the artifact.
+func (ir *ImageEngine) ArtifactAdd(ctx context.Context, name string, paths []string, opts entities.ArtifactAddOptions) (*entities.ArtifactAddReport, error) {
+	if len(paths) == 0 {
+		return nil, errors.New("at least one file must be added to an artifact")
+	}
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.Mode().IsRegular() {
+			return nil, fmt.Errorf("%s is not a regular file", path)
+		}
+	}
+	options := new(artifacts.AddOptions)
+	options.WithAnnotations(opts.Annotations).WithArtifactType(opts.ArtifactType).WithFileType(opts.FileType).WithAppend(opts.Append)
+	return artifacts.AddFiles(ir.ClientCtx, name, paths, options)
+}
+
+func (ir *ImageEngine) ArtifactExtract(ctx context.Context, name string, target string, opts entities.ArtifactExtractOptions) error {
+	artifact, err := artifacts.Inspect(ir.ClientCtx, name, nil)
+	if err != nil {
+		return err
+	}
+	layers, err := artifact.SelectFiles(&opts)
+	if err != nil {
+		return err
+	}
+	info, err := os.Stat(target)
+	toDir := err == nil && info.IsDir()
+	if !toDir && len(layers) != 1 {
+		return fmt.Errorf("artifact %s has %d files, select one with a digest or title to extract it to a file", artifact.Name, len(layers))
+	}
+
+	// Extract by digest so the files match the inspected artifact, even if
+	// it is replaced meanwhile
+	options := new(artifacts.ExtractOptions)
+	if len(layers) == 1 {
+		options.WithDigest(layers[0].Digest.String())
+	} else {
+		options.WithDigest(opts.Digest).WithTitle(opts.Title)
+	}
+	reader, writer := io.Pipe()
+	go func() {
+		writer.CloseWithError(artifacts.Extract(ir.ClientCtx, artifact.Digest.String(), writer, options))
+	}()
+	defer reader.Close()
+
+	tr := tar.NewReader(reader)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		dest := target
+		if toDir {
+			if hdr.Name != filepath.Base(hdr.Name) || hdr.Name == ".." || hdr.Name == "." {
+				return fmt.Errorf("artifact %s has a file with the invalid title %q", artifact.Name, hdr.Name)
+			}
+			dest = filepath.Join(target, hdr.Name)
+		}
+		if err := extractFile(tr, dest); err != nil {
+			return err
+		}
+	}
+}
+
+func extractFile(r io.Reader, dest string) error {
+	f, err := os.OpenFile(dest, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return fmt.Errorf("extracting %s: %w", dest, err)
+	}
+	return f.Close()
+}
+
+func (ir *ImageEngine) ArtifactInspect(ctx context.Context, name string, opts entities.ArtifactInspectOptions) (*entities.ArtifactInspectReport, error) {
+	return artifacts.Inspect(ir.ClientCtx, name, nil)
+}
+
+func (ir *ImageEngine) ArtifactList(ctx context.Context, opts entities.ArtifactListOptions) ([]*entities.ArtifactListReport, error) {
+	return artifacts.List(ir.ClientCtx, nil)
+}
+
+func (ir *ImageEngine) ArtifactPull(ctx context.Context, name string, opts entities.ArtifactPullOptions) (*entities.ArtifactPullReport, error) {
+	options := new(artifacts.PullOptions)
+	options.WithAuthfile(opts.Authfile).WithUsername(opts.Username).WithPassword(opts.Password)
+	if s := opts.SkipTLSVerify; s != types.OptionalBoolUndefined {
+		options.WithSkipTLSVerify(s == types.OptionalBoolTrue)
+	}
+	if opts.Retry != nil {
+		options.WithRetry(*opts.Retry)
+	}
+	if opts.RetryDelay != "" {
+		options.WithRetryDelay(opts.RetryDelay)
+	}
+	return artifacts.Pull(ir.ClientCtx, name, options)
+}
+
+func (ir *ImageEngine) ArtifactPush(ctx context.Context, name string, destination string, opts entities.ArtifactPushOptions) (*entities.ArtifactPushReport, error) {
+	if opts.SignBy != "" || opts.SignBySigstorePrivateKeyFile != "" {
+		return nil, errors.New("signing artifacts is not supported for remote clients")
+	}
+	options := new(artifacts.PushOptions)
+	options.WithAuthfile(opts.Authfile).WithUsername(opts.Username).WithPassword(opts.Password)
+	if s := opts.SkipTLSVerify; s != types.OptionalBoolUndefined {
+		options.WithSkipTLSVerify(s == types.OptionalBoolTrue)
+	}
+	if opts.Retry != nil {
+		options.WithRetry(*opts.Retry)
+	}
+	if opts.RetryDelay != "" {
+		options.WithRetryDelay(opts.RetryDelay)
+	}
+	return artifacts.Push(ir.ClientCtx, name, destination, options)
+}
+
+func (ir *ImageEngine) ArtifactRm(ctx context.Context, names []string, opts entities.ArtifactRemoveOptions) (*entities.ArtifactRemoveReport, error) {
+	options := new(artifacts.RemoveOptions)
+	options.WithAll(opts.All).WithForce(opts.Force)
+	return artifacts.Remove(ir.ClientCtx, names, options)
+}
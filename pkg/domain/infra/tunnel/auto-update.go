@@ -2,11 +2,25 @@ package tunnel
 
 import (
 	"context"
-	"errors"
 
+	"github.com/containers/image/v5/types"
+	"github.com/containers/podman/v5/pkg/bindings/system"
 	"github.com/containers/podman/v5/pkg/domain/entities"
 )
 
 func (ic *ContainerEngine) AutoUpdate(ctx context.Context, options entities.AutoUpdateOptions) ([]*entities.AutoUpdateReport, []error) {
-	return nil, []error{errors.New("not implemented")}
+	opts := new(system.AutoUpdateOptions).WithDryRun(options.DryRun).WithRecreate(options.Recreate).WithRollback(options.Rollback).WithRequireSignatures(options.RequireSignatures)
+	if options.SoakPeriod > 0 {
+		opts.WithSoakPeriod(options.SoakPeriod.String())
+	}
+	if options.Authfile != "" {
+		opts.WithAuthfile(options.Authfile)
+	}
+	if s := options.InsecureSkipTLSVerify; s != types.OptionalBoolUndefined {
+		opts.WithSkipTLSVerify(s == types.OptionalBoolTrue)
+	}
+	if options.ProgressWriter != nil {
+		opts.WithProgressWriter(options.ProgressWriter)
+	}
+	return system.AutoUpdate(ic.ClientCtx, opts)
 }
@@ -511,6 +511,14 @@ func (ic *ContainerEngine) ContainerCreate(ctx context.Context, s *specgen.SpecG
 	return &entities.ContainerCreateReport{Id: response.ID}, nil
 }
 
+func (ic *ContainerEngine) ContainerLogForward(_ context.Context, _ string, _ entities.ContainerLogForwardOptions) error {
+	return errors.New("forwarding container logs is not supported on the remote client")
+}
+
+func (ic *ContainerEngine) ContainerLogRotate(_ context.Context, _ string, _ entities.ContainerLogRotateOptions) error {
+	return errors.New("rotating container logs is not supported on the remote client")
+}
+
 func (ic *ContainerEngine) ContainerLogs(_ context.Context, nameOrIDs []string, opts entities.ContainerLogsOptions) error {
 	since := opts.Since.Format(time.RFC3339)
 	until := opts.Until.Format(time.RFC3339)
@@ -1056,7 +1064,42 @@ func (ic *ContainerEngine) ContainerRename(ctx context.Context, nameOrID string,
 }
 
 func (ic *ContainerEngine) ContainerClone(ctx context.Context, ctrCloneOpts entities.ContainerCloneOptions) (*entities.ContainerCreateReport, error) {
-	return nil, errors.New("cloning a container is not supported on the remote client")
+	createOpts := ctrCloneOpts.CreateOpts
+	resources := handlers.CloneResources{
+		BlkIOWeight:       createOpts.BlkIOWeight,
+		BlkIOWeightDevice: createOpts.BlkIOWeightDevice,
+		CPUPeriod:         createOpts.CPUPeriod,
+		CPUQuota:          createOpts.CPUQuota,
+		CPURTPeriod:       createOpts.CPURTPeriod,
+		CPURTRuntime:      createOpts.CPURTRuntime,
+		CPUShares:         createOpts.CPUShares,
+		CPUS:              createOpts.CPUS,
+		CPUSetCPUs:        createOpts.CPUSetCPUs,
+		CPUSetMems:        createOpts.CPUSetMems,
+		DeviceReadBPs:     createOpts.DeviceReadBPs,
+		DeviceWriteBPs:    createOpts.DeviceWriteBPs,
+		Memory:            createOpts.Memory,
+		MemoryReservation: createOpts.MemoryReservation,
+		MemorySwap:        createOpts.MemorySwap,
+	}
+	if createOpts.MemorySwappiness >= 0 {
+		resources.MemorySwappiness = &createOpts.MemorySwappiness
+	}
+	options := new(containers.CloneOptions).WithDestroy(ctrCloneOpts.Destroy).WithForce(ctrCloneOpts.Force).WithRun(ctrCloneOpts.Run).WithResources(resources)
+	if ctrCloneOpts.Image != "" {
+		options.WithImage(ctrCloneOpts.Image)
+	}
+	if createOpts.Name != "" {
+		options.WithName(createOpts.Name)
+	}
+	if createOpts.Pod != "" {
+		options.WithPod(createOpts.Pod)
+	}
+	response, err := containers.Clone(ic.ClientCtx, ctrCloneOpts.ID, options)
+	if err != nil {
+		return nil, err
+	}
+	return &entities.ContainerCreateReport{Id: response.ID}, nil
 }
 
 // ContainerUpdate finds and updates the given container's cgroup config with the specified options
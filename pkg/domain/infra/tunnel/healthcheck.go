@@ -2,6 +2,7 @@ package tunnel
 
 import (
 	"context"
+	"errors"
 
 	"github.com/containers/podman/v5/libpod/define"
 	"github.com/containers/podman/v5/pkg/bindings/containers"
@@ -11,3 +12,7 @@ import (
 func (ic *ContainerEngine) HealthCheckRun(ctx context.Context, nameOrID string, options entities.HealthCheckOptions) (*define.HealthCheckResults, error) {
 	return containers.RunHealthCheck(ic.ClientCtx, nameOrID, nil)
 }
+
+func (ic *ContainerEngine) HealthCheckReadiness(_ context.Context, _ string) error {
+	return errors.New("running readiness probes is not supported on the remote client")
+}
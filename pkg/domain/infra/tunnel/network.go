@@ -12,8 +12,18 @@ import (
 	"github.com/containers/podman/v5/pkg/errorhandling"
 )
 
-func (ic *ContainerEngine) NetworkUpdate(ctx context.Context, netName string, opts entities.NetworkUpdateOptions) error {
+func (ic *ContainerEngine) NetworkUpdate(ctx context.Context, netName string, opts entities.NetworkUpdateOptions) (*entities.NetworkUpdateReport, error) {
 	options := new(network.UpdateOptions).WithAddDNSServers(opts.AddDNSServers).WithRemoveDNSServers(opts.RemoveDNSServers)
+	options.WithAddSubnets(opts.AddSubnets).WithRemoveSubnets(opts.RemoveSubnets)
+	options.WithAddIPRanges(opts.AddIPRanges).WithRemoveIPRanges(opts.RemoveIPRanges)
+	options.WithAddLabels(opts.AddLabels).WithRemoveLabels(opts.RemoveLabels)
+	options.WithAddOptions(opts.AddOptions).WithRemoveOptions(opts.RemoveOptions)
+	if opts.Internal != nil {
+		options.WithInternal(*opts.Internal)
+	}
+	if opts.DNSEnabled != nil {
+		options.WithDNSEnabled(*opts.DNSEnabled)
+	}
 	return network.Update(ic.ClientCtx, netName, options)
 }
 
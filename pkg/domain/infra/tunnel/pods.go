@@ -9,7 +9,9 @@ import (
 	"github.com/containers/podman/v5/pkg/bindings/pods"
 	"github.com/containers/podman/v5/pkg/domain/entities"
 	"github.com/containers/podman/v5/pkg/errorhandling"
+	"github.com/containers/podman/v5/pkg/specgen"
 	"github.com/containers/podman/v5/pkg/util"
+	"github.com/containers/storage/pkg/archive"
 )
 
 func (ic *ContainerEngine) PodExists(ctx context.Context, nameOrID string) (*entities.BoolReport, error) {
@@ -100,6 +102,59 @@ func (ic *ContainerEngine) PodUnpause(ctx context.Context, namesOrIds []string,
 	return reports, nil
 }
 
+// PodUpdate updates the resource limits of the given pod and optionally the
+// restart policy and healthcheck on-failure action of its containers.
+func (ic *ContainerEngine) PodUpdate(ctx context.Context, options *entities.PodUpdateOptions) (string, error) {
+	if err := specgen.WeightDevices(options.Specgen); err != nil {
+		return "", err
+	}
+	if err := specgen.FinishThrottleDevices(options.Specgen); err != nil {
+		return "", err
+	}
+	return pods.Update(ic.ClientCtx, options)
+}
+
+func (ic *ContainerEngine) PodCheckpoint(ctx context.Context, nameOrID string, opts entities.PodCheckpointOptions) (*entities.PodCheckpointReport, error) {
+	options := new(pods.CheckpointOptions)
+	options.WithExport(opts.Export)
+	options.WithFileLocks(opts.FileLocks)
+	options.WithIgnoreRootfs(opts.IgnoreRootFS)
+	options.WithIgnoreVolumes(opts.IgnoreVolumes)
+	options.WithKeep(opts.Keep)
+	options.WithLeaveRunning(opts.LeaveRunning)
+	options.WithTCPEstablished(opts.TCPEstablished)
+	switch opts.Compression {
+	case archive.Uncompressed:
+		options.WithCompress("none")
+	case archive.Gzip:
+		options.WithCompress("gzip")
+	case archive.Zstd:
+		options.WithCompress("zstd")
+	default:
+		return nil, fmt.Errorf("compression algorithm %d is not supported for pod checkpoints", opts.Compression)
+	}
+
+	if err := pods.Checkpoint(ic.ClientCtx, nameOrID, options); err != nil {
+		return nil, err
+	}
+	return &entities.PodCheckpointReport{RawInput: nameOrID}, nil
+}
+
+func (ic *ContainerEngine) PodRestore(ctx context.Context, opts entities.PodRestoreOptions) (*entities.PodRestoreReport, error) {
+	options := new(pods.RestoreOptions)
+	options.WithImportArchive(opts.Import)
+	options.WithFileLocks(opts.FileLocks)
+	options.WithIgnoreRootfs(opts.IgnoreRootFS)
+	options.WithIgnoreStaticIP(opts.IgnoreStaticIP)
+	options.WithIgnoreStaticMAC(opts.IgnoreStaticMAC)
+	options.WithIgnoreVolumes(opts.IgnoreVolumes)
+	options.WithKeep(opts.Keep)
+	options.WithName(opts.Name)
+	options.WithPublishPorts(opts.PublishPorts)
+	options.WithTCPEstablished(opts.TCPEstablished)
+	return pods.Restore(ic.ClientCtx, options)
+}
+
 func (ic *ContainerEngine) PodStop(ctx context.Context, namesOrIds []string, opts entities.PodStopOptions) ([]*entities.PodStopReport, error) {
 	timeout := -1
 	foundPods, err := getPodsByContext(ic.ClientCtx, opts.All, opts.Ignore, namesOrIds)
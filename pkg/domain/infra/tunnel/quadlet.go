@@ -0,0 +1,32 @@
+package tunnel
+
+import (
+	"context"
+
+	"github.com/containers/podman/v5/pkg/bindings/quadlets"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+)
+
+func (ic *ContainerEngine) QuadletInstall(ctx context.Context, paths []string, options entities.QuadletInstallOptions) (*entities.QuadletInstallReport, error) {
+	opts := new(quadlets.InstallOptions).
+		WithReplace(options.Replace).
+		WithReloadSystemd(options.ReloadSystemd)
+	return quadlets.Install(ic.ClientCtx, paths, opts)
+}
+
+func (ic *ContainerEngine) QuadletList(ctx context.Context, options entities.QuadletListOptions) ([]*entities.ListQuadlet, error) {
+	return quadlets.List(ic.ClientCtx, nil)
+}
+
+func (ic *ContainerEngine) QuadletPrint(ctx context.Context, quadlet string) (string, error) {
+	return quadlets.Print(ic.ClientCtx, quadlet)
+}
+
+func (ic *ContainerEngine) QuadletRemove(ctx context.Context, quadletNames []string, options entities.QuadletRemoveOptions) (*entities.QuadletRemoveReport, error) {
+	opts := new(quadlets.RemoveOptions).
+		WithAll(options.All).
+		WithForce(options.Force).
+		WithIgnore(options.Ignore).
+		WithReloadSystemd(options.ReloadSystemd)
+	return quadlets.Remove(ic.ClientCtx, quadletNames, opts)
+}
@@ -94,6 +94,37 @@ func (ic *ContainerEngine) SecretRm(ctx context.Context, nameOrIDs []string, opt
 	return allRm, nil
 }
 
+func (ic *ContainerEngine) SecretRekey(ctx context.Context, nameOrIDs []string, opts entities.SecretRekeyOptions) ([]*entities.SecretRekeyReport, error) {
+	toRekey := nameOrIDs
+	if opts.All {
+		allSecrets, err := secrets.List(ic.ClientCtx, nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, secret := range allSecrets {
+			// only the file driver stores the secret data itself
+			if secret.Spec.Driver.Name == "file" {
+				toRekey = append(toRekey, secret.ID)
+			}
+		}
+	}
+	options := new(secrets.RekeyOptions).WithDriverOpts(opts.DriverOpts)
+	reports := make([]*entities.SecretRekeyReport, 0, len(toRekey))
+	for _, nameOrID := range toRekey {
+		report, err := secrets.Rekey(ic.ClientCtx, nameOrID, options)
+		if err != nil {
+			reports = append(reports, &entities.SecretRekeyReport{Err: err})
+			continue
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+func (ic *ContainerEngine) SecretUpdate(ctx context.Context, nameOrID string, reader io.Reader) (*entities.SecretUpdateReport, error) {
+	return secrets.Update(ic.ClientCtx, nameOrID, reader)
+}
+
 func (ic *ContainerEngine) SecretExists(ctx context.Context, nameOrID string) (*entities.BoolReport, error) {
 	exists, err := secrets.Exists(ic.ClientCtx, nameOrID)
 	if err != nil {
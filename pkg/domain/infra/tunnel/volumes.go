@@ -113,3 +113,36 @@ func (ic *ContainerEngine) VolumeUnmount(ctx context.Context, nameOrIDs []string
 func (ic *ContainerEngine) VolumeReload(ctx context.Context) (*entities.VolumeReloadReport, error) {
 	return nil, errors.New("volume reload is not supported for remote clients")
 }
+
+func (ic *ContainerEngine) VolumeClone(ctx context.Context, nameOrID string, opts entities.VolumeCloneOptions) (*entities.IDOrNameResponse, error) {
+	options := new(volumes.CloneOptions).WithName(opts.Name).WithSnapshot(opts.Snapshot)
+	response, err := volumes.Clone(ic.ClientCtx, nameOrID, options)
+	if err != nil {
+		return nil, err
+	}
+	return &entities.IDOrNameResponse{IDOrName: response.Name}, nil
+}
+
+func (ic *ContainerEngine) VolumeSnapshotCreate(ctx context.Context, nameOrID, snapshot string) (*entities.VolumeSnapshotReport, error) {
+	options := new(volumes.CreateSnapshotOptions).WithName(snapshot)
+	return volumes.CreateSnapshot(ic.ClientCtx, nameOrID, options)
+}
+
+func (ic *ContainerEngine) VolumeSnapshotList(ctx context.Context, nameOrID string) ([]*entities.VolumeSnapshotReport, error) {
+	return volumes.ListSnapshots(ic.ClientCtx, nameOrID, nil)
+}
+
+func (ic *ContainerEngine) VolumeSnapshotRestore(ctx context.Context, nameOrID, snapshot string) error {
+	return volumes.RestoreSnapshot(ic.ClientCtx, nameOrID, snapshot, nil)
+}
+
+func (ic *ContainerEngine) VolumeSnapshotRm(ctx context.Context, nameOrID string, snapshots []string) ([]*entities.VolumeSnapshotRmReport, error) {
+	reports := make([]*entities.VolumeSnapshotRmReport, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		reports = append(reports, &entities.VolumeSnapshotRmReport{
+			Err:  volumes.RemoveSnapshot(ic.ClientCtx, nameOrID, snapshot, nil),
+			Name: snapshot,
+		})
+	}
+	return reports, nil
+}
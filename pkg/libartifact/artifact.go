@@ -0,0 +1,108 @@
+package libartifact
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/containers/image/v5/docker/reference"
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/opencontainers/go-digest"
+	specV1 "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// DefaultFileMediaType is the media type of files added to an artifact
+// without an explicit type.
+const DefaultFileMediaType = "application/octet-stream"
+
+// Artifact is an OCI artifact in the local artifact store
+type Artifact struct {
+	// Name is the fully qualified reference of the artifact
+	Name string
+	// Digest is the digest of the manifest of the artifact
+	Digest digest.Digest
+	// Manifest is the OCI manifest of the artifact
+	Manifest *specV1.Manifest
+}
+
+// TotalSizeBytes returns the combined size of the files of the artifact.
+func (a *Artifact) TotalSizeBytes() int64 {
+	var size int64
+	for _, layer := range a.Manifest.Layers {
+		size += layer.Size
+	}
+	return size
+}
+
+// Title returns the file name of a file of an artifact, from its title
+// annotation or, if it has none, its digest.
+func Title(layer specV1.Descriptor) string {
+	if title := layer.Annotations[specV1.AnnotationTitle]; title != "" {
+		return title
+	}
+	return layer.Digest.Encoded()
+}
+
+// SelectFiles returns the files of the artifact matching options, all files
+// if options select none.
+func (a *Artifact) SelectFiles(options *ExtractOptions) ([]specV1.Descriptor, error) {
+	wantDigest := options.Digest
+	if wantDigest != "" && !strings.Contains(wantDigest, ":") {
+		wantDigest = digest.Canonical.String() + ":" + wantDigest
+	}
+	var layers []specV1.Descriptor
+	for _, layer := range a.Manifest.Layers {
+		if wantDigest != "" && layer.Digest.String() != wantDigest {
+			continue
+		}
+		if options.Title != "" && Title(layer) != options.Title {
+			continue
+		}
+		layers = append(layers, layer)
+	}
+	if len(layers) == 0 {
+		return nil, fmt.Errorf("no file matching the given digest or title in artifact %s", a.Name)
+	}
+	return layers, nil
+}
+
+// ArtifactList is a list of artifacts
+type ArtifactList []*Artifact
+
+// GetByNameOrDigest returns the artifact with the given name, full digest or
+// unambiguous prefix of its digest.
+func (al ArtifactList) GetByNameOrDigest(nameOrDigest string) (*Artifact, error) {
+	if name, err := NormalizeName(nameOrDigest); err == nil {
+		for _, a := range al {
+			if a.Name == name {
+				return a, nil
+			}
+		}
+	}
+
+	prefix := strings.TrimPrefix(nameOrDigest, digest.Canonical.String()+":")
+	if len(prefix) >= 3 && strings.Trim(prefix, "0123456789abcdef") == "" {
+		var found *Artifact
+		for _, a := range al {
+			if strings.HasPrefix(a.Digest.Encoded(), prefix) {
+				if found != nil && found.Digest != a.Digest {
+					return nil, fmt.Errorf("digest prefix %q matches more than one artifact", nameOrDigest)
+				}
+				found = a
+			}
+		}
+		if found != nil {
+			return found, nil
+		}
+	}
+	return nil, fmt.Errorf("%s: %w", nameOrDigest, define.ErrNoSuchArtifact)
+}
+
+// NormalizeName returns the fully qualified reference of an artifact name,
+// adding the default registry and the latest tag if necessary.
+func NormalizeName(name string) (string, error) {
+	named, err := reference.ParseNormalizedNamed(name)
+	if err != nil {
+		return "", fmt.Errorf("parsing artifact name %q: %w", name, err)
+	}
+	return reference.TagNameOnly(named).String(), nil
+}
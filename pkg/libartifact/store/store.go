@@ -0,0 +1,618 @@
+//go:build !remote
+
+package store
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/containers/common/libimage"
+	"github.com/containers/image/v5/docker"
+	"github.com/containers/image/v5/oci/layout"
+	"github.com/containers/image/v5/pkg/blobinfocache/none"
+	"github.com/containers/image/v5/types"
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/pkg/libartifact"
+	"github.com/containers/storage/pkg/fileutils"
+	"github.com/containers/storage/pkg/ioutils"
+	"github.com/containers/storage/pkg/lockfile"
+	"github.com/opencontainers/go-digest"
+	specs "github.com/opencontainers/image-spec/specs-go"
+	specV1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/sirupsen/logrus"
+)
+
+// replacedSuffix is appended to the name of an artifact while it is being
+// replaced by a new one with the same name
+const replacedSuffix = "@replaced"
+
+// ArtifactStore stores OCI artifacts in an OCI image layout directory
+type ArtifactStore struct {
+	SystemContext *types.SystemContext
+	storePath     string
+	lock          *lockfile.LockFile
+}
+
+// NewArtifactStore returns the artifact store in storePath, creating it if it
+// does not exist yet.
+func NewArtifactStore(storePath string, sc *types.SystemContext) (*ArtifactStore, error) {
+	if storePath == "" {
+		return nil, errors.New("artifact store path cannot be empty")
+	}
+	if err := os.MkdirAll(storePath, 0o700); err != nil {
+		return nil, err
+	}
+	lock, err := lockfile.GetLockFile(filepath.Join(storePath, "index.lock"))
+	if err != nil {
+		return nil, err
+	}
+	as := &ArtifactStore{
+		SystemContext: sc,
+		storePath:     storePath,
+		lock:          lock,
+	}
+
+	as.lock.Lock()
+	defer as.lock.Unlock()
+	if err := as.initLayout(); err != nil {
+		return nil, fmt.Errorf("initializing artifact store %s: %w", storePath, err)
+	}
+	return as, nil
+}
+
+func (as *ArtifactStore) indexPath() string {
+	return filepath.Join(as.storePath, specV1.ImageIndexFile)
+}
+
+func (as *ArtifactStore) blobPath(d digest.Digest) (string, error) {
+	if err := d.Validate(); err != nil {
+		return "", err
+	}
+	return filepath.Join(as.storePath, specV1.ImageBlobsDir, d.Algorithm().String(), d.Encoded()), nil
+}
+
+// initLayout creates an empty OCI image layout, unless it already exists
+func (as *ArtifactStore) initLayout() error {
+	if err := fileutils.Exists(as.indexPath()); err == nil {
+		return nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	layoutBytes, err := json.Marshal(specV1.ImageLayout{Version: specV1.ImageLayoutVersion})
+	if err != nil {
+		return err
+	}
+	if err := ioutils.AtomicWriteFile(filepath.Join(as.storePath, specV1.ImageLayoutFile), layoutBytes, 0o644); err != nil {
+		return err
+	}
+	return as.writeIndex(&specV1.Index{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: specV1.MediaTypeImageIndex,
+		Manifests: []specV1.Descriptor{},
+	})
+}
+
+func (as *ArtifactStore) readIndex() (*specV1.Index, error) {
+	data, err := os.ReadFile(as.indexPath())
+	if err != nil {
+		return nil, err
+	}
+	index := &specV1.Index{}
+	if err := json.Unmarshal(data, index); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", as.indexPath(), err)
+	}
+	return index, nil
+}
+
+func (as *ArtifactStore) writeIndex(index *specV1.Index) error {
+	data, err := json.Marshal(index)
+	if err != nil {
+		return err
+	}
+	return ioutils.AtomicWriteFile(as.indexPath(), data, 0o644)
+}
+
+// getArtifacts returns the artifacts in the store. The caller must hold the
+// lock of the store.
+func (as *ArtifactStore) getArtifacts() (libartifact.ArtifactList, error) {
+	index, err := as.readIndex()
+	if err != nil {
+		return nil, err
+	}
+	artifacts := make(libartifact.ArtifactList, 0, len(index.Manifests))
+	for _, desc := range index.Manifests {
+		name := desc.Annotations[specV1.AnnotationRefName]
+		if name == "" || strings.HasSuffix(name, replacedSuffix) || desc.MediaType != specV1.MediaTypeImageManifest {
+			continue
+		}
+		path, err := as.blobPath(desc.Digest)
+		if err != nil {
+			return nil, err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading manifest of artifact %s: %w", name, err)
+		}
+		manifest := &specV1.Manifest{}
+		if err := json.Unmarshal(data, manifest); err != nil {
+			return nil, fmt.Errorf("parsing manifest of artifact %s: %w", name, err)
+		}
+		artifacts = append(artifacts, &libartifact.Artifact{
+			Name:     name,
+			Digest:   desc.Digest,
+			Manifest: manifest,
+		})
+	}
+	return artifacts, nil
+}
+
+// replace runs write, which stores a new manifest under name, and removes the
+// artifact previously stored under the name afterwards. Blobs shared by both
+// are kept. The caller must hold the lock of the store.
+func (as *ArtifactStore) replace(ctx context.Context, name string, write func() error) error {
+	renameTo := func(from, to string) (bool, error) {
+		index, err := as.readIndex()
+		if err != nil {
+			return false, err
+		}
+		found := false
+		for i := range index.Manifests {
+			if index.Manifests[i].Annotations[specV1.AnnotationRefName] == from {
+				index.Manifests[i].Annotations[specV1.AnnotationRefName] = to
+				found = true
+			}
+		}
+		if !found {
+			return false, nil
+		}
+		return true, as.writeIndex(index)
+	}
+
+	replacedName := name + replacedSuffix
+	replacing, err := renameTo(name, replacedName)
+	if err != nil {
+		return err
+	}
+	if err := write(); err != nil {
+		if replacing {
+			if _, renameErr := renameTo(replacedName, name); renameErr != nil {
+				logrus.Errorf("Restoring artifact %s: %v", name, renameErr)
+			}
+		}
+		return err
+	}
+	if !replacing {
+		return nil
+	}
+	ref, err := layout.NewReference(as.storePath, replacedName)
+	if err != nil {
+		return err
+	}
+	return ref.DeleteImage(ctx, as.SystemContext)
+}
+
+// List returns the artifacts in the store.
+func (as *ArtifactStore) List(ctx context.Context) (libartifact.ArtifactList, error) {
+	as.lock.RLock()
+	defer as.lock.Unlock()
+	return as.getArtifacts()
+}
+
+// Inspect returns the artifact with the given name or digest.
+func (as *ArtifactStore) Inspect(ctx context.Context, nameOrDigest string) (*libartifact.Artifact, error) {
+	as.lock.RLock()
+	defer as.lock.Unlock()
+	artifacts, err := as.getArtifacts()
+	if err != nil {
+		return nil, err
+	}
+	return artifacts.GetByNameOrDigest(nameOrDigest)
+}
+
+// Remove removes the artifact with the given name or digest and returns its
+// digest.
+func (as *ArtifactStore) Remove(ctx context.Context, nameOrDigest string) (*digest.Digest, error) {
+	as.lock.Lock()
+	defer as.lock.Unlock()
+	artifacts, err := as.getArtifacts()
+	if err != nil {
+		return nil, err
+	}
+	artifact, err := artifacts.GetByNameOrDigest(nameOrDigest)
+	if err != nil {
+		return nil, err
+	}
+	ref, err := layout.NewReference(as.storePath, artifact.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err := ref.DeleteImage(ctx, as.SystemContext); err != nil {
+		return nil, err
+	}
+	return &artifact.Digest, nil
+}
+
+// Add creates the artifact name from the files in paths, or adds them to the
+// existing artifact if options.Append is set, and returns its digest.
+func (as *ArtifactStore) Add(ctx context.Context, name string, paths []string, options *libartifact.AddOptions) (*digest.Digest, error) {
+	if len(paths) == 0 {
+		return nil, errors.New("at least one file must be added to an artifact")
+	}
+	name, err := libartifact.NormalizeName(name)
+	if err != nil {
+		return nil, err
+	}
+	fileType := options.FileType
+	if fileType == "" {
+		fileType = libartifact.DefaultFileMediaType
+	}
+
+	as.lock.Lock()
+	defer as.lock.Unlock()
+
+	manifest := specV1.Manifest{
+		Versioned:    specs.Versioned{SchemaVersion: 2},
+		MediaType:    specV1.MediaTypeImageManifest,
+		ArtifactType: options.ArtifactType,
+		Config:       specV1.DescriptorEmptyJSON,
+		Layers:       []specV1.Descriptor{},
+	}
+	artifacts, err := as.getArtifacts()
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range artifacts {
+		if a.Name != name {
+			continue
+		}
+		if !options.Append {
+			return nil, fmt.Errorf("%s: %w", name, define.ErrArtifactExists)
+		}
+		manifest = *a.Manifest
+		manifest.Layers = append([]specV1.Descriptor{}, a.Manifest.Layers...)
+		if options.ArtifactType != "" {
+			manifest.ArtifactType = options.ArtifactType
+		}
+		break
+	}
+	if len(options.Annotations) > 0 {
+		annotations := make(map[string]string, len(manifest.Annotations)+len(options.Annotations))
+		for k, v := range manifest.Annotations {
+			annotations[k] = v
+		}
+		for k, v := range options.Annotations {
+			annotations[k] = v
+		}
+		manifest.Annotations = annotations
+	}
+
+	titles := make(map[string]bool, len(manifest.Layers)+len(paths))
+	for _, layer := range manifest.Layers {
+		titles[libartifact.Title(layer)] = true
+	}
+	for _, path := range paths {
+		title := filepath.Base(path)
+		if titles[title] {
+			return nil, fmt.Errorf("file %s already exists in artifact %s", title, name)
+		}
+		titles[title] = true
+	}
+
+	var manifestDigest digest.Digest
+	err = as.replace(ctx, name, func() error {
+		ref, err := layout.NewReference(as.storePath, name)
+		if err != nil {
+			return err
+		}
+		dest, err := ref.NewImageDestination(ctx, as.SystemContext)
+		if err != nil {
+			return err
+		}
+		defer dest.Close()
+
+		for _, path := range paths {
+			layer, err := putFile(ctx, dest, path)
+			if err != nil {
+				return err
+			}
+			layer.MediaType = fileType
+			manifest.Layers = append(manifest.Layers, layer)
+		}
+		config := specV1.DescriptorEmptyJSON
+		if _, err := dest.PutBlob(ctx, bytes.NewReader(config.Data), types.BlobInfo{Digest: config.Digest, Size: config.Size}, none.NoCache, true); err != nil {
+			return err
+		}
+
+		data, err := json.Marshal(manifest)
+		if err != nil {
+			return err
+		}
+		if err := dest.PutManifest(ctx, data, nil); err != nil {
+			return err
+		}
+		manifestDigest = digest.FromBytes(data)
+		return dest.Commit(ctx, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &manifestDigest, nil
+}
+
+// putFile stores the file at path as a blob and returns its descriptor
+func putFile(ctx context.Context, dest types.ImageDestination, path string) (specV1.Descriptor, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return specV1.Descriptor{}, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return specV1.Descriptor{}, err
+	}
+	if !info.Mode().IsRegular() {
+		return specV1.Descriptor{}, fmt.Errorf("%s is not a regular file", path)
+	}
+	blob, err := dest.PutBlob(ctx, f, types.BlobInfo{Size: -1}, none.NoCache, false)
+	if err != nil {
+		return specV1.Descriptor{}, fmt.Errorf("adding %s: %w", path, err)
+	}
+	return specV1.Descriptor{
+		Digest: blob.Digest,
+		Size:   blob.Size,
+		Annotations: map[string]string{
+			specV1.AnnotationTitle: filepath.Base(path),
+		},
+	}, nil
+}
+
+// Pull pulls the artifact name from a registry into the store, replacing an
+// artifact with the same name, and returns its digest.
+func (as *ArtifactStore) Pull(ctx context.Context, name string, options libimage.CopyOptions) (*digest.Digest, error) {
+	name, err := libartifact.NormalizeName(name)
+	if err != nil {
+		return nil, err
+	}
+	srcRef, err := docker.ParseReference("//" + name)
+	if err != nil {
+		return nil, err
+	}
+
+	as.lock.Lock()
+	defer as.lock.Unlock()
+
+	var manifestDigest digest.Digest
+	err = as.replace(ctx, name, func() error {
+		destRef, err := layout.NewReference(as.storePath, name)
+		if err != nil {
+			return err
+		}
+		copier, err := libimage.NewCopier(&options, as.SystemContext, nil)
+		if err != nil {
+			return err
+		}
+		defer copier.Close()
+		manifestBytes, err := copier.Copy(ctx, srcRef, destRef)
+		if err != nil {
+			return err
+		}
+		manifestDigest = digest.FromBytes(manifestBytes)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &manifestDigest, nil
+}
+
+// Push pushes the artifact with the given name or digest to the registry
+// reference destination, or its own name if destination is empty, and
+// returns the digest of the pushed manifest.
+func (as *ArtifactStore) Push(ctx context.Context, nameOrDigest, destination string, options libimage.CopyOptions) (*digest.Digest, error) {
+	as.lock.RLock()
+	defer as.lock.Unlock()
+
+	artifacts, err := as.getArtifacts()
+	if err != nil {
+		return nil, err
+	}
+	artifact, err := artifacts.GetByNameOrDigest(nameOrDigest)
+	if err != nil {
+		return nil, err
+	}
+	if destination == "" {
+		destination = artifact.Name
+	}
+	destination, err = libartifact.NormalizeName(destination)
+	if err != nil {
+		return nil, err
+	}
+	destRef, err := docker.ParseReference("//" + destination)
+	if err != nil {
+		return nil, err
+	}
+	srcRef, err := layout.NewReference(as.storePath, artifact.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	copier, err := libimage.NewCopier(&options, as.SystemContext, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer copier.Close()
+	manifestBytes, err := copier.Copy(ctx, srcRef, destRef)
+	if err != nil {
+		return nil, err
+	}
+	manifestDigest := digest.FromBytes(manifestBytes)
+	return &manifestDigest, nil
+}
+
+// validFileName reports whether the title of a file of an artifact can be
+// used as a file name in a directory
+func validFileName(name string) bool {
+	return name != "" && name != "." && name != ".." && filepath.Base(name) == name && !strings.ContainsRune(name, '\\')
+}
+
+// Extract copies the files of the artifact with the given name or digest to
+// target. If target is a directory, each file is written into it under its
+// title. Otherwise exactly one file must be selected, which is written to
+// target.
+func (as *ArtifactStore) Extract(ctx context.Context, nameOrDigest, target string, options *libartifact.ExtractOptions) error {
+	as.lock.RLock()
+	defer as.lock.Unlock()
+
+	artifacts, err := as.getArtifacts()
+	if err != nil {
+		return err
+	}
+	artifact, err := artifacts.GetByNameOrDigest(nameOrDigest)
+	if err != nil {
+		return err
+	}
+	layers, err := artifact.SelectFiles(options)
+	if err != nil {
+		return err
+	}
+
+	info, err := os.Stat(target)
+	if err != nil || !info.IsDir() {
+		if len(layers) != 1 {
+			return fmt.Errorf("artifact %s has %d files, select one with a digest or title to extract it to a file", artifact.Name, len(layers))
+		}
+		return as.copyBlob(layers[0], target)
+	}
+	for _, layer := range layers {
+		name := libartifact.Title(layer)
+		if !validFileName(name) {
+			return fmt.Errorf("artifact %s has a file with the invalid title %q", artifact.Name, name)
+		}
+		if err := as.copyBlob(layer, filepath.Join(target, name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// BlobMountPaths returns the paths of the blobs of the files of the artifact
+// with the given name or digest selected by options, with their file names,
+// so that they can be bind-mounted into a container.
+func (as *ArtifactStore) BlobMountPaths(ctx context.Context, nameOrDigest string, options *libartifact.ExtractOptions) ([]libartifact.BlobMountPath, error) {
+	as.lock.RLock()
+	defer as.lock.Unlock()
+
+	artifacts, err := as.getArtifacts()
+	if err != nil {
+		return nil, err
+	}
+	artifact, err := artifacts.GetByNameOrDigest(nameOrDigest)
+	if err != nil {
+		return nil, err
+	}
+	layers, err := artifact.SelectFiles(options)
+	if err != nil {
+		return nil, err
+	}
+
+	mountPaths := make([]libartifact.BlobMountPath, 0, len(layers))
+	for _, layer := range layers {
+		name := libartifact.Title(layer)
+		if !validFileName(name) {
+			return nil, fmt.Errorf("artifact %s has a file with the invalid title %q", artifact.Name, name)
+		}
+		path, err := as.blobPath(layer.Digest)
+		if err != nil {
+			return nil, err
+		}
+		mountPaths = append(mountPaths, libartifact.BlobMountPath{SourcePath: path, Name: name})
+	}
+	return mountPaths, nil
+}
+
+func (as *ArtifactStore) copyBlob(layer specV1.Descriptor, dest string) error {
+	path, err := as.blobPath(layer.Digest)
+	if err != nil {
+		return err
+	}
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	dst, err := os.OpenFile(dest, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		return fmt.Errorf("extracting %s: %w", dest, err)
+	}
+	return dst.Close()
+}
+
+// ExtractTarStream writes the files of the artifact with the given name or
+// digest selected by options to w as a tar archive, each named after its
+// title.
+func (as *ArtifactStore) ExtractTarStream(ctx context.Context, w io.Writer, nameOrDigest string, options *libartifact.ExtractOptions) error {
+	as.lock.RLock()
+	defer as.lock.Unlock()
+
+	artifacts, err := as.getArtifacts()
+	if err != nil {
+		return err
+	}
+	artifact, err := artifacts.GetByNameOrDigest(nameOrDigest)
+	if err != nil {
+		return err
+	}
+	layers, err := artifact.SelectFiles(options)
+	if err != nil {
+		return err
+	}
+
+	tw := tar.NewWriter(w)
+	now := time.Now()
+	for _, layer := range layers {
+		name := libartifact.Title(layer)
+		if !validFileName(name) {
+			return fmt.Errorf("artifact %s has a file with the invalid title %q", artifact.Name, name)
+		}
+		path, err := as.blobPath(layer.Digest)
+		if err != nil {
+			return err
+		}
+		if err := writeTarFile(tw, path, name, layer.Size, now); err != nil {
+			return err
+		}
+	}
+	return tw.Close()
+}
+
+func writeTarFile(tw *tar.Writer, path, name string, size int64, modTime time.Time) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err := tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Size:     size,
+		Mode:     0o644,
+		ModTime:  modTime,
+	}); err != nil {
+		return err
+	}
+	_, err = io.Copy(tw, f)
+	return err
+}
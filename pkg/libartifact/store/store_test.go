@@ -0,0 +1,143 @@
+//go:build !remote
+
+package store
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/containers/image/v5/types"
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/pkg/libartifact"
+	specV1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestFile(t *testing.T, dir, name, content string) string {
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+func countBlobs(t *testing.T, storePath string) int {
+	entries, err := os.ReadDir(filepath.Join(storePath, specV1.ImageBlobsDir, "sha256"))
+	require.NoError(t, err)
+	return len(entries)
+}
+
+func TestArtifactStore(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	storePath := filepath.Join(dir, "artifacts")
+	as, err := NewArtifactStore(storePath, &types.SystemContext{})
+	require.NoError(t, err)
+
+	model := writeTestFile(t, dir, "model.bin", "model data")
+	config := writeTestFile(t, dir, "config.yaml", "key: value")
+
+	d, err := as.Add(ctx, "quay.io/test/model:v1", []string{model}, &libartifact.AddOptions{
+		ArtifactType: "application/vnd.test.model",
+		Annotations:  map[string]string{"org.opencontainers.image.version": "1"},
+	})
+	require.NoError(t, err)
+
+	artifacts, err := as.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, artifacts, 1)
+	assert.Equal(t, "quay.io/test/model:v1", artifacts[0].Name)
+	assert.Equal(t, *d, artifacts[0].Digest)
+	assert.Equal(t, "application/vnd.test.model", artifacts[0].Manifest.ArtifactType)
+	assert.Equal(t, specV1.MediaTypeEmptyJSON, artifacts[0].Manifest.Config.MediaType)
+	require.Len(t, artifacts[0].Manifest.Layers, 1)
+	assert.Equal(t, "model.bin", libartifact.Title(artifacts[0].Manifest.Layers[0]))
+	assert.Equal(t, libartifact.DefaultFileMediaType, artifacts[0].Manifest.Layers[0].MediaType)
+	assert.Equal(t, int64(len("model data")), artifacts[0].TotalSizeBytes())
+
+	// Adding to an existing artifact requires append
+	_, err = as.Add(ctx, "quay.io/test/model:v1", []string{config}, &libartifact.AddOptions{})
+	assert.ErrorIs(t, err, define.ErrArtifactExists)
+	_, err = as.Add(ctx, "quay.io/test/model:v1", []string{model}, &libartifact.AddOptions{Append: true})
+	assert.ErrorContains(t, err, "file model.bin already exists in artifact quay.io/test/model:v1")
+
+	appended, err := as.Add(ctx, "quay.io/test/model:v1", []string{config}, &libartifact.AddOptions{Append: true})
+	require.NoError(t, err)
+	assert.NotEqual(t, *d, *appended)
+	artifact, err := as.Inspect(ctx, appended.Encoded()[:12])
+	require.NoError(t, err)
+	assert.Len(t, artifact.Manifest.Layers, 2)
+	assert.Equal(t, "1", artifact.Manifest.Annotations["org.opencontainers.image.version"])
+	// The replaced manifest is removed, the files shared with it are kept:
+	// the config, both files and the new manifest
+	assert.Equal(t, 4, countBlobs(t, storePath))
+
+	// Extract a single file and all files into a directory
+	target := filepath.Join(dir, "out.yaml")
+	err = as.Extract(ctx, "quay.io/test/model:v1", target, &libartifact.ExtractOptions{})
+	assert.ErrorContains(t, err, "has 2 files")
+	require.NoError(t, as.Extract(ctx, "quay.io/test/model:v1", target, &libartifact.ExtractOptions{Title: "config.yaml"}))
+	content, err := os.ReadFile(target)
+	require.NoError(t, err)
+	assert.Equal(t, "key: value", string(content))
+
+	outDir := t.TempDir()
+	require.NoError(t, as.Extract(ctx, "quay.io/test/model:v1", outDir, &libartifact.ExtractOptions{}))
+	content, err = os.ReadFile(filepath.Join(outDir, "model.bin"))
+	require.NoError(t, err)
+	assert.Equal(t, "model data", string(content))
+
+	var buf bytes.Buffer
+	require.NoError(t, as.ExtractTarStream(ctx, &buf, "quay.io/test/model:v1", &libartifact.ExtractOptions{Digest: artifact.Manifest.Layers[1].Digest.String()}))
+	tr := tar.NewReader(&buf)
+	hdr, err := tr.Next()
+	require.NoError(t, err)
+	assert.Equal(t, "config.yaml", hdr.Name)
+	data, err := io.ReadAll(tr)
+	require.NoError(t, err)
+	assert.Equal(t, "key: value", string(data))
+	_, err = tr.Next()
+	assert.Equal(t, io.EOF, err)
+
+	mountPaths, err := as.BlobMountPaths(ctx, "quay.io/test/model:v1", &libartifact.ExtractOptions{})
+	require.NoError(t, err)
+	require.Len(t, mountPaths, 2)
+	assert.Equal(t, "model.bin", mountPaths[0].Name)
+	assert.Equal(t, "config.yaml", mountPaths[1].Name)
+	content, err = os.ReadFile(mountPaths[1].SourcePath)
+	require.NoError(t, err)
+	assert.Equal(t, "key: value", string(content))
+	_, err = as.BlobMountPaths(ctx, "quay.io/test/model:v1", &libartifact.ExtractOptions{Title: "missing"})
+	assert.ErrorContains(t, err, "no file matching")
+
+	removed, err := as.Remove(ctx, "quay.io/test/model:v1")
+	require.NoError(t, err)
+	assert.Equal(t, *appended, *removed)
+	_, err = as.Inspect(ctx, "quay.io/test/model:v1")
+	assert.ErrorIs(t, err, define.ErrNoSuchArtifact)
+	assert.Equal(t, 0, countBlobs(t, storePath))
+}
+
+func TestArtifactList_GetByNameOrDigest(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	as, err := NewArtifactStore(filepath.Join(dir, "artifacts"), &types.SystemContext{})
+	require.NoError(t, err)
+
+	file := writeTestFile(t, dir, "file", "data")
+	d, err := as.Add(ctx, "model", []string{file}, &libartifact.AddOptions{})
+	require.NoError(t, err)
+
+	for _, name := range []string{"model", "model:latest", "docker.io/library/model:latest", d.String(), d.Encoded(), d.Encoded()[:6]} {
+		artifact, err := as.Inspect(ctx, name)
+		if assert.NoError(t, err, name) {
+			assert.Equal(t, "docker.io/library/model:latest", artifact.Name)
+		}
+	}
+	_, err = as.Inspect(ctx, "other")
+	assert.ErrorIs(t, err, define.ErrNoSuchArtifact)
+}
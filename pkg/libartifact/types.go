@@ -0,0 +1,30 @@
+package libartifact
+
+// AddOptions are the options for adding files to an artifact
+type AddOptions struct {
+	// Annotations are added to the manifest of the artifact
+	Annotations map[string]string
+	// ArtifactType is the type of the artifact
+	ArtifactType string
+	// FileType is the media type of the added files, DefaultFileMediaType
+	// if empty
+	FileType string
+	// Append adds the files to an existing artifact instead of failing
+	Append bool
+}
+
+// ExtractOptions select the files of an artifact to extract
+type ExtractOptions struct {
+	// Digest selects the file with the digest
+	Digest string
+	// Title selects the file with the title
+	Title string
+}
+
+// BlobMountPath is a file of an artifact to bind-mount into a container
+type BlobMountPath struct {
+	// SourcePath is the path of the blob of the file in the store
+	SourcePath string
+	// Name is the file name of the file, from its title
+	Name string
+}
@@ -0,0 +1,232 @@
+package logforward
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/containers/podman/v5/libpod/define"
+)
+
+const (
+	// syslogTimeFormat and syslogTimeFormatMicro are the timestamp formats
+	// of RFC 5424 messages.
+	syslogTimeFormat      = time.RFC3339
+	syslogTimeFormatMicro = "2006-01-02T15:04:05.000000Z07:00"
+	// syslogAppNameMax is the maximum length of the APP-NAME field.
+	syslogAppNameMax = 48
+	// syslogHostnameMax is the maximum length of the HOSTNAME field.
+	syslogHostnameMax = 255
+
+	// gelfChunkSize is the maximum size of a GELF UDP datagram.
+	gelfChunkSize = 1420
+	// gelfChunkHeaderSize is the size of the header of a GELF chunk.
+	gelfChunkHeaderSize = 12
+	// gelfMaxChunks is the maximum number of chunks of a GELF message.
+	gelfMaxChunks = 128
+)
+
+// encode returns the packets to send to the collector for msg. stream is set
+// for stream transports, which need the messages to be framed.
+func encode(options *Options, info *Info, msg *Message, stream bool) ([][]byte, error) {
+	switch options.Driver {
+	case define.SyslogLogging:
+		data := encodeSyslog(options, info, msg)
+		if stream {
+			// Octet counting framing (RFC 6587).
+			data = append([]byte(strconv.Itoa(len(data))+" "), data...)
+		}
+		return [][]byte{data}, nil
+	case define.GELFLogging:
+		data, err := encodeGELF(info, msg)
+		if err != nil {
+			return nil, err
+		}
+		if stream {
+			return [][]byte{append(data, 0)}, nil
+		}
+		data, err = compressGELF(options.GELFCompression, data)
+		if err != nil {
+			return nil, err
+		}
+		return chunkGELF(data)
+	case define.FluentdLogging:
+		return [][]byte{encodeFluentd(info, msg)}, nil
+	}
+	return nil, fmt.Errorf("log driver %q does not forward logs: %w", options.Driver, define.ErrInvalidArg)
+}
+
+// severity returns the syslog severity of a message, errors for stderr and
+// informational for stdout.
+func severity(msg *Message) int {
+	if msg.Stream == "stderr" {
+		return 3
+	}
+	return 6
+}
+
+// encodeSyslog formats msg as RFC 5424 syslog message.
+func encodeSyslog(options *Options, info *Info, msg *Message) []byte {
+	timeFormat := syslogTimeFormat
+	if options.SyslogMicroseconds {
+		timeFormat = syslogTimeFormatMicro
+	}
+	return fmt.Appendf(nil, "<%d>1 %s %s %s - - - %s",
+		options.SyslogFacility*8+severity(msg),
+		msg.Time.Format(timeFormat),
+		syslogField(info.Hostname, syslogHostnameMax),
+		syslogField(info.Tag, syslogAppNameMax),
+		msg.Line)
+}
+
+// syslogField returns s as header field of a syslog message: printable ASCII
+// without spaces, at most maxLen characters, and "-" if empty.
+func syslogField(s string, maxLen int) string {
+	field := strings.Map(func(r rune) rune {
+		if r < 33 || r > 126 {
+			return '_'
+		}
+		return r
+	}, s)
+	if len(field) > maxLen {
+		field = field[:maxLen]
+	}
+	if field == "" {
+		return "-"
+	}
+	return field
+}
+
+// encodeGELF formats msg as GELF 1.1 message.
+func encodeGELF(info *Info, msg *Message) ([]byte, error) {
+	return json.Marshal(map[string]any{
+		"version":         "1.1",
+		"host":            info.Hostname,
+		"short_message":   msg.Line,
+		"timestamp":       float64(msg.Time.UnixMilli()) / 1000,
+		"level":           severity(msg),
+		"_container_id":   info.ContainerID,
+		"_container_name": info.ContainerName,
+		"_image_name":     info.ImageName,
+		"_tag":            info.Tag,
+		"_stream":         msg.Stream,
+	})
+}
+
+// compressGELF compresses a GELF message for UDP.
+func compressGELF(compression string, data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	switch compression {
+	case "gzip":
+		w := gzip.NewWriter(&buf)
+		if _, err := w.Write(data); err != nil {
+			return nil, err
+		}
+		if err := w.Close(); err != nil {
+			return nil, err
+		}
+	case "zlib":
+		w := zlib.NewWriter(&buf)
+		if _, err := w.Write(data); err != nil {
+			return nil, err
+		}
+		if err := w.Close(); err != nil {
+			return nil, err
+		}
+	default:
+		return data, nil
+	}
+	return buf.Bytes(), nil
+}
+
+// chunkGELF splits a GELF message into UDP datagrams.
+func chunkGELF(data []byte) ([][]byte, error) {
+	if len(data) <= gelfChunkSize {
+		return [][]byte{data}, nil
+	}
+	payloadSize := gelfChunkSize - gelfChunkHeaderSize
+	count := (len(data) + payloadSize - 1) / payloadSize
+	if count > gelfMaxChunks {
+		return nil, fmt.Errorf("GELF message of %d bytes is too large for UDP", len(data))
+	}
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+	chunks := make([][]byte, 0, count)
+	for i := 0; i < count; i++ {
+		end := min((i+1)*payloadSize, len(data))
+		chunk := make([]byte, 0, gelfChunkHeaderSize+end-i*payloadSize)
+		chunk = append(chunk, 0x1e, 0x0f)
+		chunk = append(chunk, id...)
+		chunk = append(chunk, byte(i), byte(count))
+		chunk = append(chunk, data[i*payloadSize:end]...)
+		chunks = append(chunks, chunk)
+	}
+	return chunks, nil
+}
+
+// encodeFluentd formats msg as an event of the fluentd forward protocol in
+// message mode: [tag, time, record], encoded with MessagePack.
+func encodeFluentd(info *Info, msg *Message) []byte {
+	record := map[string]string{
+		"log":            msg.Line,
+		"source":         msg.Stream,
+		"container_id":   info.ContainerID,
+		"container_name": info.ContainerName,
+	}
+	keys := make([]string, 0, len(record))
+	for key := range record {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	data := []byte{0x93}
+	data = appendMsgpackString(data, info.Tag)
+	// EventTime extension type, seconds and nanoseconds.
+	data = append(data, 0xd7, 0x00)
+	data = binary.BigEndian.AppendUint32(data, uint32(msg.Time.Unix()))
+	data = binary.BigEndian.AppendUint32(data, uint32(msg.Time.Nanosecond()))
+	data = appendMsgpackMapHeader(data, len(record))
+	for _, key := range keys {
+		data = appendMsgpackString(data, key)
+		data = appendMsgpackString(data, record[key])
+	}
+	return data
+}
+
+// appendMsgpackString appends s as MessagePack str to data.
+func appendMsgpackString(data []byte, s string) []byte {
+	n := len(s)
+	switch {
+	case n < 32:
+		data = append(data, 0xa0|byte(n))
+	case n < 1<<8:
+		data = append(data, 0xd9, byte(n))
+	case n < 1<<16:
+		data = append(data, 0xda)
+		data = binary.BigEndian.AppendUint16(data, uint16(n))
+	default:
+		data = append(data, 0xdb)
+		data = binary.BigEndian.AppendUint32(data, uint32(n))
+	}
+	return append(data, s...)
+}
+
+// appendMsgpackMapHeader appends the header of a MessagePack map with n
+// entries to data.
+func appendMsgpackMapHeader(data []byte, n int) []byte {
+	if n < 16 {
+		return append(data, 0x80|byte(n))
+	}
+	data = append(data, 0xde)
+	return binary.BigEndian.AppendUint16(data, uint16(n))
+}
@@ -0,0 +1,248 @@
+package logforward
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// dialTimeout is the timeout to connect to the collector.
+	dialTimeout = 5 * time.Second
+	// writeTimeout is the timeout to send one message to the collector.
+	writeTimeout = 10 * time.Second
+	// minRetryInterval and maxRetryInterval bound the interval between
+	// attempts to send a message the collector did not accept.
+	minRetryInterval = 100 * time.Millisecond
+	maxRetryInterval = 10 * time.Second
+	// dropWarningInterval is the minimum interval between warnings about
+	// dropped messages.
+	dropWarningInterval = time.Minute
+)
+
+// ErrClosed is returned when writing to a closed Forwarder.
+var ErrClosed = errors.New("log forwarder is closed")
+
+// Info describes the container whose logs are forwarded.
+type Info struct {
+	ContainerID   string
+	ContainerName string
+	ImageName     string
+	Hostname      string
+	// Tag identifies the container at the collector.
+	Tag string
+}
+
+// Message is one log line of a container.
+type Message struct {
+	Time time.Time
+	// Stream is "stdout" or "stderr".
+	Stream string
+	Line   string
+}
+
+// Forwarder sends log messages to a collector. Messages are buffered in
+// memory and sent in the background, so that a slow or unreachable collector
+// does not hold up the container. When the buffer is full, the oldest
+// messages are dropped, unless the forwarder is blocking.
+type Forwarder struct {
+	options *Options
+	info    Info
+
+	lock    sync.Mutex
+	cond    *sync.Cond
+	queue   []*Message
+	queued  int64
+	dropped uint64
+	closing bool
+	// lastDropWarning is the time of the last warning about dropped
+	// messages.
+	lastDropWarning time.Time
+
+	// conn is only used by the sending goroutine.
+	conn net.Conn
+	stop chan struct{}
+	done chan struct{}
+}
+
+// New returns a Forwarder sending to the collector configured by options.
+func New(options *Options, info Info) *Forwarder {
+	f := &Forwarder{
+		options: options,
+		info:    info,
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	f.cond = sync.NewCond(&f.lock)
+	go f.run()
+	return f
+}
+
+// Write queues msg for sending.
+func (f *Forwarder) Write(msg *Message) error {
+	size := int64(len(msg.Line))
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if f.closing {
+		return ErrClosed
+	}
+	if f.options.Blocking {
+		for len(f.queue) > 0 && f.queued+size > f.options.MaxBufferSize && !f.closing {
+			f.cond.Wait()
+		}
+		if f.closing {
+			return ErrClosed
+		}
+	} else {
+		dropped := false
+		for len(f.queue) > 0 && f.queued+size > f.options.MaxBufferSize {
+			f.popLocked()
+			f.dropped++
+			dropped = true
+		}
+		if dropped && time.Since(f.lastDropWarning) > dropWarningInterval {
+			logrus.Warnf("Log collector %s of container %s is too slow, dropped %d log messages so far", f.options.Address, f.info.ContainerName, f.dropped)
+			f.lastDropWarning = time.Now()
+		}
+	}
+
+	f.queue = append(f.queue, msg)
+	f.queued += size
+	f.cond.Broadcast()
+	return nil
+}
+
+// Dropped returns the number of messages dropped because the buffer was full.
+func (f *Forwarder) Dropped() uint64 {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.dropped
+}
+
+// Close sends the buffered messages and disconnects from the collector.
+// Messages which cannot be sent within timeout are dropped.
+func (f *Forwarder) Close(timeout time.Duration) error {
+	f.lock.Lock()
+	f.closing = true
+	f.cond.Broadcast()
+	f.lock.Unlock()
+
+	select {
+	case <-f.done:
+	case <-time.After(timeout):
+		close(f.stop)
+		<-f.done
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if lost := uint64(len(f.queue)) + f.dropped; lost > 0 {
+		return fmt.Errorf("%d log messages of container %s could not be forwarded to %s", lost, f.info.ContainerName, f.options.Address)
+	}
+	return nil
+}
+
+// popLocked removes the oldest message from the queue.
+func (f *Forwarder) popLocked() {
+	f.queued -= int64(len(f.queue[0].Line))
+	f.queue[0] = nil
+	f.queue = f.queue[1:]
+}
+
+// run sends the queued messages until the forwarder is closed.
+func (f *Forwarder) run() {
+	defer close(f.done)
+	defer f.disconnect()
+
+	retryInterval := minRetryInterval
+	failing := false
+	for {
+		f.lock.Lock()
+		for len(f.queue) == 0 && !f.closing {
+			f.cond.Wait()
+		}
+		if len(f.queue) == 0 {
+			f.lock.Unlock()
+			return
+		}
+		msg := f.queue[0]
+		f.lock.Unlock()
+
+		if err := f.send(msg); err != nil {
+			if !failing {
+				logrus.Warnf("Forwarding logs of container %s to %s: %v", f.info.ContainerName, f.options.Address, err)
+				failing = true
+			}
+			select {
+			case <-f.stop:
+				return
+			case <-time.After(retryInterval):
+			}
+			retryInterval = min(2*retryInterval, maxRetryInterval)
+			continue
+		}
+		retryInterval = minRetryInterval
+		failing = false
+
+		f.lock.Lock()
+		// The message may have been dropped while it was sent.
+		if len(f.queue) > 0 && f.queue[0] == msg {
+			f.popLocked()
+		}
+		f.cond.Broadcast()
+		f.lock.Unlock()
+	}
+}
+
+// send sends one message to the collector, connecting first if needed.
+func (f *Forwarder) send(msg *Message) error {
+	packets, err := encode(f.options, &f.info, msg, f.options.Network != "udp")
+	if err != nil {
+		// Retrying does not help, skip the message.
+		logrus.Warnf("Encoding log message of container %s: %v", f.info.ContainerName, err)
+		return nil
+	}
+
+	if f.conn == nil {
+		dialer := &net.Dialer{Timeout: dialTimeout}
+		if f.options.TLSConfig != nil {
+			f.conn, err = tls.DialWithDialer(dialer, f.options.Network, f.options.Address, f.options.TLSConfig)
+		} else {
+			f.conn, err = dialer.Dial(f.options.Network, f.options.Address)
+		}
+		if err != nil {
+			f.conn = nil
+			return err
+		}
+	}
+
+	if err := f.conn.SetWriteDeadline(time.Now().Add(writeTimeout)); err != nil {
+		f.disconnect()
+		return err
+	}
+	for _, packet := range packets {
+		if _, err := f.conn.Write(packet); err != nil {
+			f.disconnect()
+			return err
+		}
+	}
+	return nil
+}
+
+// disconnect closes the connection to the collector.
+func (f *Forwarder) disconnect() {
+	if f.conn == nil {
+		return
+	}
+	if err := f.conn.Close(); err != nil {
+		logrus.Debugf("Closing connection to log collector %s: %v", f.options.Address, err)
+	}
+	f.conn = nil
+}
@@ -0,0 +1,175 @@
+package logforward
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testInfo = Info{
+	ContainerID:   "0123456789abcdef",
+	ContainerName: "web",
+	ImageName:     "quay.io/libpod/alpine:latest",
+	Hostname:      "host1",
+	Tag:           "web",
+}
+
+func testMessage(line, stream string) *Message {
+	return &Message{
+		Time:   time.Date(2024, 5, 1, 12, 30, 45, 123456789, time.UTC),
+		Stream: stream,
+		Line:   line,
+	}
+}
+
+func TestForwardSyslogTCP(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	received := make(chan []string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		reader := bufio.NewReader(conn)
+		var messages []string
+		for len(messages) < 2 {
+			length, err := reader.ReadString(' ')
+			if err != nil {
+				return
+			}
+			n, err := strconv.Atoi(strings.TrimSpace(length))
+			if err != nil {
+				return
+			}
+			msg := make([]byte, n)
+			if _, err := io.ReadFull(reader, msg); err != nil {
+				return
+			}
+			messages = append(messages, string(msg))
+		}
+		received <- messages
+	}()
+
+	options, err := ParseOptions(define.SyslogLogging, map[string]string{
+		"syslog-address":  "tcp://" + listener.Addr().String(),
+		"syslog-facility": "local0",
+	})
+	require.NoError(t, err)
+	f := New(options, testInfo)
+	require.NoError(t, f.Write(testMessage("hello", "stdout")))
+	require.NoError(t, f.Write(testMessage("oops", "stderr")))
+	require.NoError(t, f.Close(5*time.Second))
+
+	select {
+	case messages := <-received:
+		assert.Equal(t, []string{
+			"<134>1 2024-05-01T12:30:45Z host1 web - - - hello",
+			"<131>1 2024-05-01T12:30:45Z host1 web - - - oops",
+		}, messages)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for syslog messages")
+	}
+}
+
+func TestForwardGELFUDP(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	options, err := ParseOptions(define.GELFLogging, map[string]string{
+		"gelf-address": "udp://" + conn.LocalAddr().String(),
+	})
+	require.NoError(t, err)
+	f := New(options, testInfo)
+	require.NoError(t, f.Write(testMessage("hello", "stdout")))
+	require.NoError(t, f.Close(5*time.Second))
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	buf := make([]byte, gelfChunkSize)
+	n, _, err := conn.ReadFrom(buf)
+	require.NoError(t, err)
+	reader, err := gzip.NewReader(bytes.NewReader(buf[:n]))
+	require.NoError(t, err)
+	var msg map[string]any
+	require.NoError(t, json.NewDecoder(reader).Decode(&msg))
+	assert.Equal(t, "1.1", msg["version"])
+	assert.Equal(t, "hello", msg["short_message"])
+	assert.Equal(t, "host1", msg["host"])
+	assert.Equal(t, float64(6), msg["level"])
+	assert.Equal(t, testInfo.ContainerID, msg["_container_id"])
+	assert.Equal(t, 1714566645.123, msg["timestamp"])
+}
+
+func TestChunkGELF(t *testing.T) {
+	data := bytes.Repeat([]byte("x"), 3*gelfChunkSize)
+	chunks, err := chunkGELF(data)
+	require.NoError(t, err)
+	require.Len(t, chunks, 4)
+	var joined []byte
+	for i, chunk := range chunks {
+		assert.Equal(t, []byte{0x1e, 0x0f}, chunk[:2])
+		assert.Equal(t, chunks[0][2:10], chunk[2:10], "message ID")
+		assert.Equal(t, []byte{byte(i), 4}, chunk[10:12])
+		assert.LessOrEqual(t, len(chunk), gelfChunkSize)
+		joined = append(joined, chunk[gelfChunkHeaderSize:]...)
+	}
+	assert.Equal(t, data, joined)
+
+	_, err = chunkGELF(bytes.Repeat([]byte("x"), gelfMaxChunks*gelfChunkSize))
+	assert.ErrorContains(t, err, "too large")
+}
+
+func TestEncodeFluentd(t *testing.T) {
+	info := testInfo
+	info.Tag = "t"
+	data := encodeFluentd(&info, testMessage("hi", "stdout"))
+	expected := []byte{0x93, 0xa1, 't', 0xd7, 0x00}
+	expected = append(expected, 0x66, 0x32, 0x35, 0xf5, 0x07, 0x5b, 0xcd, 0x15)
+	expected = append(expected, 0x84)
+	for _, s := range []string{"container_id", testInfo.ContainerID, "container_name", "web", "log", "hi", "source", "stdout"} {
+		expected = append(expected, 0xa0|byte(len(s)))
+		expected = append(expected, s...)
+	}
+	assert.Equal(t, expected, data)
+
+	long := strings.Repeat("y", 300)
+	assert.Equal(t, []byte{0xda, 0x01, 0x2c}, appendMsgpackString(nil, long)[:3])
+}
+
+func TestForwarderDropsOldest(t *testing.T) {
+	// Nothing listens on the address, so all messages stay queued.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	options, err := ParseOptions(define.FluentdLogging, map[string]string{
+		"fluentd-address": address,
+		"max-buffer-size": "10",
+	})
+	require.NoError(t, err)
+	f := New(options, testInfo)
+	for i := 0; i < 5; i++ {
+		require.NoError(t, f.Write(testMessage("1234", "stdout")))
+	}
+	assert.Equal(t, uint64(3), f.Dropped())
+
+	err = f.Close(100 * time.Millisecond)
+	assert.ErrorContains(t, err, "5 log messages of container web could not be forwarded")
+	assert.ErrorIs(t, f.Write(testMessage("late", "stdout")), ErrClosed)
+}
@@ -0,0 +1,256 @@
+// Package logforward sends container logs to remote collectors using the
+// syslog (RFC 5424), GELF and fluentd forward protocols.
+package logforward
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/docker/go-units"
+)
+
+const (
+	// DefaultMaxBufferSize is the default number of bytes of log messages
+	// buffered while the collector is slow or unreachable.
+	DefaultMaxBufferSize = 1024 * 1024
+
+	// ModeBlocking makes writers wait for the collector when the buffer
+	// is full. The container itself is not blocked: its log file is not
+	// rotated by default and keeps the messages not sent yet.
+	ModeBlocking = "blocking"
+	// ModeNonBlocking drops the oldest messages when the buffer is full.
+	ModeNonBlocking = "non-blocking"
+)
+
+// Options configures a Forwarder. They are parsed from the log options of a
+// container with ParseOptions.
+type Options struct {
+	// Driver is the log driver, one of define.SyslogLogging,
+	// define.GELFLogging and define.FluentdLogging.
+	Driver string
+	// Network is the network of the collector, "udp", "tcp" or "unix".
+	Network string
+	// Address is the address of the collector on Network.
+	Address string
+	// TLSConfig is set if the connection to the collector uses TLS.
+	TLSConfig *tls.Config
+	// Blocking makes Write wait for room in the buffer instead of
+	// dropping the oldest messages.
+	Blocking bool
+	// MaxBufferSize is the number of bytes of messages buffered in memory.
+	MaxBufferSize int64
+	// SyslogFacility is the facility of syslog messages.
+	SyslogFacility int
+	// SyslogMicroseconds adds microseconds to the timestamp of syslog
+	// messages.
+	SyslogMicroseconds bool
+	// GELFCompression is the compression of GELF messages sent over UDP,
+	// "gzip", "zlib" or "none".
+	GELFCompression string
+}
+
+var syslogFacilities = map[string]int{
+	"kern":     0,
+	"user":     1,
+	"mail":     2,
+	"daemon":   3,
+	"auth":     4,
+	"syslog":   5,
+	"lpr":      6,
+	"news":     7,
+	"uucp":     8,
+	"cron":     9,
+	"authpriv": 10,
+	"ftp":      11,
+	"local0":   16,
+	"local1":   17,
+	"local2":   18,
+	"local3":   19,
+	"local4":   20,
+	"local5":   21,
+	"local6":   22,
+	"local7":   23,
+}
+
+// ParseOptions parses the log options of a container using one of the
+// forwarding log drivers.
+func ParseOptions(driver string, logOptions map[string]string) (*Options, error) {
+	options := &Options{
+		Driver:        driver,
+		MaxBufferSize: DefaultMaxBufferSize,
+	}
+
+	var (
+		address     string
+		schemes     []string
+		defaultPort string
+	)
+	switch driver {
+	case define.SyslogLogging:
+		address = "udp://localhost:514"
+		schemes = []string{"udp", "tcp", "tcp+tls"}
+		defaultPort = "514"
+		options.SyslogFacility = syslogFacilities["daemon"]
+	case define.GELFLogging:
+		schemes = []string{"udp", "tcp"}
+		defaultPort = "12201"
+		options.GELFCompression = "gzip"
+	case define.FluentdLogging:
+		address = "tcp://localhost:24224"
+		schemes = []string{"tcp", "unix"}
+		defaultPort = "24224"
+	default:
+		return nil, fmt.Errorf("log driver %q does not forward logs: %w", driver, define.ErrInvalidArg)
+	}
+
+	var caCert, cert, key string
+	skipVerify := false
+	for name, value := range logOptions {
+		var err error
+		switch {
+		case name == "tag":
+			// The tag is kept in the container config.
+		case name == "mode":
+			switch value {
+			case ModeBlocking:
+				options.Blocking = true
+			case ModeNonBlocking:
+				options.Blocking = false
+			default:
+				return nil, fmt.Errorf("invalid log mode %q, must be %q or %q: %w", value, ModeBlocking, ModeNonBlocking, define.ErrInvalidArg)
+			}
+		case name == "max-buffer-size":
+			options.MaxBufferSize, err = units.FromHumanSize(value)
+			if err == nil && options.MaxBufferSize <= 0 {
+				err = fmt.Errorf("max-buffer-size must be positive: %w", define.ErrInvalidArg)
+			}
+		case driver == define.SyslogLogging && name == "syslog-address",
+			driver == define.GELFLogging && name == "gelf-address",
+			driver == define.FluentdLogging && name == "fluentd-address":
+			address = value
+		case driver == define.SyslogLogging && name == "syslog-facility":
+			facility, ok := syslogFacilities[value]
+			if !ok {
+				facility, err = strconv.Atoi(value)
+				if err == nil && (facility < 0 || facility > 23) {
+					err = fmt.Errorf("syslog facility %d out of range: %w", facility, define.ErrInvalidArg)
+				}
+			}
+			options.SyslogFacility = facility
+		case driver == define.SyslogLogging && name == "syslog-format":
+			switch value {
+			case "", "rfc5424":
+				options.SyslogMicroseconds = false
+			case "rfc5424micro":
+				options.SyslogMicroseconds = true
+			default:
+				err = fmt.Errorf("unsupported syslog format %q, must be rfc5424 or rfc5424micro: %w", value, define.ErrInvalidArg)
+			}
+		case driver == define.SyslogLogging && name == "syslog-tls-ca-cert":
+			caCert = value
+		case driver == define.SyslogLogging && name == "syslog-tls-cert":
+			cert = value
+		case driver == define.SyslogLogging && name == "syslog-tls-key":
+			key = value
+		case driver == define.SyslogLogging && name == "syslog-tls-skip-verify":
+			skipVerify, err = strconv.ParseBool(value)
+		case driver == define.GELFLogging && name == "gelf-compression-type":
+			switch value {
+			case "gzip", "zlib", "none":
+				options.GELFCompression = value
+			default:
+				err = fmt.Errorf("unsupported GELF compression %q, must be gzip, zlib or none: %w", value, define.ErrInvalidArg)
+			}
+		default:
+			return nil, fmt.Errorf("unknown log option %q for log driver %s: %w", name, driver, define.ErrInvalidArg)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parsing log option %s: %w", name, err)
+		}
+	}
+
+	if address == "" {
+		return nil, fmt.Errorf("log option %s-address is required by the %s log driver: %w", driver, driver, define.ErrInvalidArg)
+	}
+	// fluentd addresses are traditionally given as host:port.
+	if driver == define.FluentdLogging && !strings.Contains(address, "://") {
+		address = "tcp://" + address
+	}
+	u, err := url.Parse(address)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s address %q: %w", driver, address, err)
+	}
+	if !slices.Contains(schemes, u.Scheme) {
+		return nil, fmt.Errorf("unsupported %s address %q, the protocol must be one of %s: %w", driver, address, strings.Join(schemes, ", "), define.ErrInvalidArg)
+	}
+
+	switch u.Scheme {
+	case "unix":
+		options.Network = "unix"
+		options.Address = u.Path
+	default:
+		options.Network, _, _ = strings.Cut(u.Scheme, "+")
+		if u.Host == "" {
+			return nil, fmt.Errorf("%s address %q has no host: %w", driver, address, define.ErrInvalidArg)
+		}
+		options.Address = u.Host
+		if u.Port() == "" {
+			if u.Scheme == "tcp+tls" {
+				// syslog over TLS has its own port (RFC 5425).
+				defaultPort = "6514"
+			}
+			options.Address = net.JoinHostPort(u.Hostname(), defaultPort)
+		}
+	}
+
+	useTLS := u.Scheme == "tcp+tls"
+	if !useTLS {
+		if caCert != "" || cert != "" || key != "" || skipVerify {
+			return nil, fmt.Errorf("syslog TLS options require a tcp+tls:// syslog address: %w", define.ErrInvalidArg)
+		}
+		return options, nil
+	}
+	options.TLSConfig, err = newTLSConfig(u.Hostname(), caCert, cert, key, skipVerify)
+	if err != nil {
+		return nil, err
+	}
+	return options, nil
+}
+
+// newTLSConfig returns the TLS configuration to connect to serverName.
+func newTLSConfig(serverName, caCert, cert, key string, skipVerify bool) (*tls.Config, error) {
+	config := &tls.Config{
+		ServerName:         serverName,
+		InsecureSkipVerify: skipVerify, //nolint:gosec // explicitly requested by the user
+		MinVersion:         tls.VersionTLS12,
+	}
+	if caCert != "" {
+		pem, err := os.ReadFile(caCert)
+		if err != nil {
+			return nil, fmt.Errorf("reading syslog CA certificate: %w", err)
+		}
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in syslog CA certificate %s: %w", caCert, define.ErrInvalidArg)
+		}
+	}
+	if (cert == "") != (key == "") {
+		return nil, fmt.Errorf("syslog-tls-cert and syslog-tls-key must be set together: %w", define.ErrInvalidArg)
+	}
+	if cert != "" {
+		keyPair, err := tls.LoadX509KeyPair(cert, key)
+		if err != nil {
+			return nil, fmt.Errorf("loading syslog client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{keyPair}
+	}
+	return config, nil
+}
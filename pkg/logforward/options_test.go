@@ -0,0 +1,119 @@
+package logforward
+
+import (
+	"testing"
+
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOptions(t *testing.T) {
+	tests := []struct {
+		name     string
+		driver   string
+		options  map[string]string
+		network  string
+		address  string
+		blocking bool
+		err      string
+	}{
+		{
+			name:    "syslog default",
+			driver:  define.SyslogLogging,
+			network: "udp",
+			address: "localhost:514",
+		},
+		{
+			name:    "syslog tcp without port",
+			driver:  define.SyslogLogging,
+			options: map[string]string{"syslog-address": "tcp://logs.example.com"},
+			network: "tcp",
+			address: "logs.example.com:514",
+		},
+		{
+			name:    "syslog tls default port",
+			driver:  define.SyslogLogging,
+			options: map[string]string{"syslog-address": "tcp+tls://logs.example.com", "syslog-tls-skip-verify": "true"},
+			network: "tcp",
+			address: "logs.example.com:6514",
+		},
+		{
+			name:    "syslog tls options without tls",
+			driver:  define.SyslogLogging,
+			options: map[string]string{"syslog-address": "tcp://logs.example.com", "syslog-tls-skip-verify": "true"},
+			err:     "require a tcp+tls:// syslog address",
+		},
+		{
+			name:    "syslog bad facility",
+			driver:  define.SyslogLogging,
+			options: map[string]string{"syslog-facility": "nope"},
+			err:     "parsing log option syslog-facility",
+		},
+		{
+			name:   "gelf requires address",
+			driver: define.GELFLogging,
+			err:    "gelf-address is required",
+		},
+		{
+			name:     "gelf blocking",
+			driver:   define.GELFLogging,
+			options:  map[string]string{"gelf-address": "udp://10.0.0.1:12201", "mode": "blocking"},
+			network:  "udp",
+			address:  "10.0.0.1:12201",
+			blocking: true,
+		},
+		{
+			name:    "gelf unix",
+			driver:  define.GELFLogging,
+			options: map[string]string{"gelf-address": "unix:///run/gelf.sock"},
+			err:     "the protocol must be one of udp, tcp",
+		},
+		{
+			name:    "fluentd host and port",
+			driver:  define.FluentdLogging,
+			options: map[string]string{"fluentd-address": "fluentd:24225"},
+			network: "tcp",
+			address: "fluentd:24225",
+		},
+		{
+			name:    "fluentd unix",
+			driver:  define.FluentdLogging,
+			options: map[string]string{"fluentd-address": "unix:///run/fluentd.sock"},
+			network: "unix",
+			address: "/run/fluentd.sock",
+		},
+		{
+			name:    "option of another driver",
+			driver:  define.FluentdLogging,
+			options: map[string]string{"syslog-address": "udp://localhost"},
+			err:     `unknown log option "syslog-address" for log driver fluentd`,
+		},
+		{
+			name:    "bad mode",
+			driver:  define.FluentdLogging,
+			options: map[string]string{"mode": "sometimes"},
+			err:     `invalid log mode "sometimes"`,
+		},
+		{
+			name:   "not forwarding",
+			driver: define.KubernetesLogging,
+			err:    "does not forward logs",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options, err := ParseOptions(tt.driver, tt.options)
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.network, options.Network)
+			assert.Equal(t, tt.address, options.Address)
+			assert.Equal(t, tt.blocking, options.Blocking)
+			assert.Equal(t, int64(DefaultMaxBufferSize), options.MaxBufferSize)
+		})
+	}
+}
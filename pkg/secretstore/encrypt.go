@@ -0,0 +1,187 @@
+package secretstore
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/crypto/argon2"
+)
+
+const (
+	// EncryptOption is the file driver option selecting where the
+	// encryption key comes from. The secret data is stored in plaintext
+	// when unset.
+	EncryptOption = "encrypt"
+	// KeyfileOption is the file driver option with the path of the key
+	// file used with EncryptKeyfile.
+	KeyfileOption = "keyfile"
+	// KeyEnvOption is the file driver option naming the environment
+	// variable which contains the key used with EncryptEnv.
+	KeyEnvOption = "keyenv"
+
+	// EncryptKeyfile reads the key from the file given with KeyfileOption.
+	EncryptKeyfile = "keyfile"
+	// EncryptEnv reads the key from the environment variable given with
+	// KeyEnvOption or DefaultKeyEnv. The variable must be set in every
+	// process which reads the secret data, including the processes which
+	// start the containers using the secret.
+	EncryptEnv = "env"
+
+	// DefaultKeyEnv is the environment variable read by EncryptEnv when
+	// no KeyEnvOption is set.
+	DefaultKeyEnv = "PODMAN_SECRETS_KEY"
+)
+
+// saltSize is the size of the salt used to derive the data key
+const saltSize = 16
+
+// envelopeMagic prefixes all encrypted secret data
+var envelopeMagic = []byte("podsec1")
+
+// ErrInvalidEncryption indicates that the encryption options are invalid
+// or that the secret data cannot be decrypted with them.
+var ErrInvalidEncryption = errors.New("invalid secret encryption")
+
+// encryptionOptions are the driver options handled by the store.
+var encryptionOptions = []string{EncryptOption, KeyfileOption, KeyEnvOption}
+
+// encryption describes how the data of a secret is encrypted
+type encryption struct {
+	// mode is one of the Encrypt* constants
+	mode string
+	// keyfile is the path of the key file for EncryptKeyfile
+	keyfile string
+	// keyEnv is the environment variable read for EncryptEnv
+	keyEnv string
+}
+
+// newEncryption parses the encryption options of a secret. It returns nil if
+// the data is not encrypted.
+func newEncryption(opts map[string]string) (*encryption, error) {
+	enc := &encryption{
+		mode:    opts[EncryptOption],
+		keyfile: opts[KeyfileOption],
+		keyEnv:  opts[KeyEnvOption],
+	}
+	switch enc.mode {
+	case "":
+		if enc.keyfile != "" || enc.keyEnv != "" {
+			return nil, fmt.Errorf("%s and %s need the %s option: %w", KeyfileOption, KeyEnvOption, EncryptOption, ErrInvalidEncryption)
+		}
+		return nil, nil
+	case EncryptKeyfile:
+		if enc.keyfile == "" {
+			return nil, fmt.Errorf("%s=%s needs the %s option: %w", EncryptOption, EncryptKeyfile, KeyfileOption, ErrInvalidEncryption)
+		}
+		if !filepath.IsAbs(enc.keyfile) {
+			return nil, fmt.Errorf("%s must be an absolute path: %w", KeyfileOption, ErrInvalidEncryption)
+		}
+	case EncryptEnv:
+		if enc.keyfile != "" {
+			return nil, fmt.Errorf("%s is only used with %s=%s: %w", KeyfileOption, EncryptOption, EncryptKeyfile, ErrInvalidEncryption)
+		}
+		if enc.keyEnv == "" {
+			enc.keyEnv = DefaultKeyEnv
+		}
+	default:
+		return nil, fmt.Errorf("%s must be one of %s or %s: %w", EncryptOption, EncryptKeyfile, EncryptEnv, ErrInvalidEncryption)
+	}
+	return enc, nil
+}
+
+// KeyFromEnvironment returns true if the secret data stored with the given
+// driver options can only be decrypted with a key from the environment.
+func KeyFromEnvironment(driverType string, opts map[string]string) bool {
+	return driverType == "file" && opts[EncryptOption] == EncryptEnv
+}
+
+// keyMaterial returns the secret the data key is derived from.
+func (e *encryption) keyMaterial() ([]byte, error) {
+	switch e.mode {
+	case EncryptKeyfile:
+		key, err := os.ReadFile(e.keyfile)
+		if err != nil {
+			return nil, fmt.Errorf("reading secret key file: %w", err)
+		}
+		if len(key) == 0 {
+			return nil, fmt.Errorf("secret key file %s is empty: %w", e.keyfile, ErrInvalidEncryption)
+		}
+		return key, nil
+	case EncryptEnv:
+		key := os.Getenv(e.keyEnv)
+		if key == "" {
+			return nil, fmt.Errorf("environment variable %s with the secret key is not set: %w", e.keyEnv, ErrInvalidEncryption)
+		}
+		return []byte(key), nil
+	}
+	return nil, ErrInvalidEncryption
+}
+
+func (e *encryption) aead(material, salt []byte) (cipher.AEAD, error) {
+	key := argon2.IDKey(material, salt, 1, 64*1024, 4, 32)
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// seal encrypts the data.
+func (e *encryption) seal(data []byte) ([]byte, error) {
+	material, err := e.keyMaterial()
+	if err != nil {
+		return nil, err
+	}
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	aead, err := e.aead(material, salt)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	header := make([]byte, 0, len(envelopeMagic)+saltSize+len(nonce))
+	header = append(header, envelopeMagic...)
+	header = append(header, salt...)
+	header = append(header, nonce...)
+	// the header is authenticated as additional data
+	return aead.Seal(header, nonce, data, header), nil
+}
+
+// open decrypts the data of the secret with the given ID.
+func (e *encryption) open(id string, envelope []byte) ([]byte, error) {
+	offset := len(envelopeMagic)
+	if len(envelope) < offset+saltSize || string(envelope[:offset]) != string(envelopeMagic) {
+		return nil, fmt.Errorf("secret %s is not encrypted: %w", id, ErrInvalidEncryption)
+	}
+	salt := envelope[offset : offset+saltSize]
+	offset += saltSize
+
+	material, err := e.keyMaterial()
+	if err != nil {
+		return nil, err
+	}
+	aead, err := e.aead(material, salt)
+	if err != nil {
+		return nil, err
+	}
+	if len(envelope) < offset+aead.NonceSize() {
+		return nil, fmt.Errorf("secret %s data is truncated: %w", id, ErrInvalidEncryption)
+	}
+	nonce := envelope[offset : offset+aead.NonceSize()]
+	offset += aead.NonceSize()
+	data, err := aead.Open(nil, nonce, envelope[offset:], envelope[:offset])
+	if err != nil {
+		return nil, fmt.Errorf("decrypting secret %s, wrong key?: %w", id, err)
+	}
+	return data, nil
+}
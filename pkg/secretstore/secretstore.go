@@ -0,0 +1,589 @@
+// Package secretstore adds encryption at rest and versions of the secret
+// data to the secrets manager of containers/common.
+package secretstore
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/containers/common/pkg/secrets"
+	"github.com/containers/common/pkg/secrets/define"
+	"github.com/containers/common/pkg/secrets/filedriver"
+	"github.com/containers/common/pkg/secrets/passdriver"
+	"github.com/containers/common/pkg/secrets/shelldriver"
+	"github.com/containers/storage/pkg/ioutils"
+	"github.com/containers/storage/pkg/lockfile"
+	"github.com/sirupsen/logrus"
+)
+
+// maxSecretSize is the max size for secret data - 512kB
+const maxSecretSize = 512000
+
+// stateFile is the name of the file with the state of the secrets
+const stateFile = "podman-secrets.json"
+
+// errDataSize indicates that the secret data is too large or too small
+var errDataSize = errors.New("secret data must be larger than 0 and less than 512000 bytes")
+
+// errInvalidDriver indicates that the driver does not support the operation
+var errInvalidDriver = errors.New("invalid driver")
+
+// errInvalidDriverOpt indicates that a driver option is invalid
+var errInvalidDriverOpt = errors.New("invalid driver option")
+
+// Manager wraps the secrets manager of containers/common. It encrypts the
+// data of file driver secrets at rest and keeps numbered versions of the
+// secret data, so the data of a secret can be updated without changing its
+// ID.
+type Manager struct {
+	*secrets.SecretsManager
+	// statePath is the path to the file with the state of the secrets
+	statePath string
+	// lock protects the state file, it is always taken before the lock of
+	// the secrets manager
+	lock *lockfile.LockFile
+}
+
+// Version describes a version of the secret data
+type Version struct {
+	// Version is the number of the version, starting at 1
+	Version int `json:"version"`
+	// CreatedAt is when the version was created
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// secretState is the state of a secret not known to the secrets manager
+type secretState struct {
+	// DataID is the ID the driver stores the current data under while it
+	// is not stored under the secret ID, empty otherwise
+	DataID string `json:"dataID,omitempty"`
+	// Encryption are the encryption options of the secret data. They
+	// override the options stored with the secret, which cannot be changed
+	// without changing the secret ID.
+	Encryption map[string]string `json:"encryption,omitempty"`
+	// Versions lists the versions of the secret data, the last one is the
+	// current version. Only the data of the current version is stored.
+	Versions []Version `json:"versions,omitempty"`
+}
+
+// NewManager creates a new secrets manager
+// rootPath is the directory where the secrets data file resides
+func NewManager(rootPath string) (*Manager, error) {
+	manager, err := secrets.NewManager(rootPath)
+	if err != nil {
+		return nil, err
+	}
+	lock, err := lockfile.GetLockFile(filepath.Join(rootPath, "podman-secrets.lock"))
+	if err != nil {
+		return nil, err
+	}
+	return &Manager{
+		SecretsManager: manager,
+		statePath:      filepath.Join(rootPath, stateFile),
+		lock:           lock,
+	}, nil
+}
+
+// Store takes a name, creates a secret and stores the secret metadata and the
+// secret payload. With the file driver the payload is encrypted if the
+// EncryptOption driver option is set. When an existing secret is replaced
+// the new secret continues its versions.
+// It returns a generated ID that is associated with the secret.
+func (m *Manager) Store(name string, data []byte, driverType string, options secrets.StoreOptions) (string, error) {
+	if !(len(data) > 0 && len(data) < maxSecretSize) {
+		return "", errDataSize
+	}
+	enc, err := encryptionFor(driverType, options.DriverOpts)
+	if err != nil {
+		return "", err
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	state, err := m.loadState()
+	if err != nil {
+		return "", err
+	}
+
+	var old *secrets.Secret
+	if options.Replace {
+		if secret, err := m.SecretsManager.Lookup(name); err == nil && secret.Name == name {
+			old = secret
+			// the secrets manager only removes the old secret if the
+			// driver has data stored under its ID
+			if err := m.ensureData(old, state[old.ID]); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	if enc != nil {
+		data, err = enc.seal(data)
+		if err != nil {
+			return "", err
+		}
+	}
+	id, err := m.SecretsManager.Store(name, data, driverType, options)
+	if err != nil {
+		return "", err
+	}
+
+	st := &secretState{Versions: []Version{{Version: 1, CreatedAt: time.Now()}}}
+	if old != nil {
+		oldState := state[old.ID]
+		st.Versions = append(versions(old, oldState), Version{Version: currentVersion(old, oldState) + 1, CreatedAt: st.Versions[0].CreatedAt})
+		m.removeData(old, oldState)
+		delete(state, old.ID)
+	}
+	state[id] = st
+	if err := m.saveState(state); err != nil {
+		return "", fmt.Errorf("creating secret %s: %w", name, err)
+	}
+	return id, nil
+}
+
+// Delete removes all secret metadata and secret data associated with the
+// specified secret.
+// Delete takes a name, ID, or partial ID.
+func (m *Manager) Delete(nameOrID string) (string, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	state, err := m.loadState()
+	if err != nil {
+		return "", err
+	}
+	secret, err := m.SecretsManager.Lookup(nameOrID)
+	if err != nil {
+		return "", err
+	}
+	st := state[secret.ID]
+	if err := m.ensureData(secret, st); err != nil {
+		return "", err
+	}
+	id, err := m.SecretsManager.Delete(secret.ID)
+	if err != nil {
+		return "", err
+	}
+	m.removeData(secret, st)
+	if st != nil {
+		delete(state, id)
+		if err := m.saveState(state); err != nil {
+			return "", fmt.Errorf("deleting secret %s: %w", nameOrID, err)
+		}
+	}
+	return id, nil
+}
+
+// Lookup gives a secret's metadata given its name, ID, or partial ID.
+func (m *Manager) Lookup(nameOrID string) (*secrets.Secret, error) {
+	m.lock.RLock()
+	defer m.lock.Unlock()
+
+	state, err := m.loadState()
+	if err != nil {
+		return nil, err
+	}
+	secret, err := m.SecretsManager.Lookup(nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	applyState(secret, state[secret.ID])
+	return secret, nil
+}
+
+// List lists all secrets.
+func (m *Manager) List() ([]secrets.Secret, error) {
+	m.lock.RLock()
+	defer m.lock.Unlock()
+
+	state, err := m.loadState()
+	if err != nil {
+		return nil, err
+	}
+	list, err := m.SecretsManager.List()
+	if err != nil {
+		return nil, err
+	}
+	for i := range list {
+		applyState(&list[i], state[list[i].ID])
+	}
+	return list, nil
+}
+
+// LookupSecretData returns secret metadata as well as secret data in bytes.
+// Encrypted data is decrypted.
+// The secret data can be looked up using its name, ID, or partial ID.
+func (m *Manager) LookupSecretData(nameOrID string) (*secrets.Secret, []byte, error) {
+	m.lock.RLock()
+	defer m.lock.Unlock()
+
+	state, err := m.loadState()
+	if err != nil {
+		return nil, nil, err
+	}
+	secret, err := m.SecretsManager.Lookup(nameOrID)
+	if err != nil {
+		return nil, nil, err
+	}
+	st := state[secret.ID]
+	data, err := m.lookupData(secret, st)
+	if err != nil {
+		return nil, nil, err
+	}
+	applyState(secret, st)
+	enc, err := encryptionFor(secret.Driver, secret.DriverOptions)
+	if err != nil {
+		return nil, nil, err
+	}
+	if enc != nil {
+		data, err = enc.open(secret.ID, data)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return secret, data, nil
+}
+
+// Versions returns the versions of the data of the given secret, the last
+// one is the current version. Secrets created before versions were recorded
+// are at version 1.
+func (m *Manager) Versions(secret *secrets.Secret) ([]Version, error) {
+	m.lock.RLock()
+	defer m.lock.Unlock()
+
+	state, err := m.loadState()
+	if err != nil {
+		return nil, err
+	}
+	return versions(secret, state[secret.ID]), nil
+}
+
+// Update stores new data for an existing secret as a new version of the
+// secret. Unlike Store with Replace the secret keeps its ID, so containers
+// using the secret can pick up the new data. The old data is only removed
+// once the new data is stored. It returns the updated secret and the number
+// of the new version.
+func (m *Manager) Update(nameOrID string, data []byte) (*secrets.Secret, int, error) {
+	if !(len(data) > 0 && len(data) < maxSecretSize) {
+		return nil, 0, errDataSize
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	state, err := m.loadState()
+	if err != nil {
+		return nil, 0, err
+	}
+	secret, err := m.SecretsManager.Lookup(nameOrID)
+	if err != nil {
+		return nil, 0, err
+	}
+	st := stateFor(state, secret)
+	applyState(secret, st)
+	enc, err := encryptionFor(secret.Driver, secret.DriverOptions)
+	if err != nil {
+		return nil, 0, err
+	}
+	if enc != nil {
+		data, err = enc.seal(data)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	oldVersions := st.Versions
+	version := Version{Version: currentVersion(secret, st) + 1, CreatedAt: time.Now()}
+	st.Versions = append(slices.Clone(st.Versions), version)
+	if err := m.replaceData(secret, st, state, data); err != nil {
+		st.Versions = oldVersions
+		return nil, 0, fmt.Errorf("updating secret %s: %w", secret.Name, err)
+	}
+	secret.UpdatedAt = version.CreatedAt
+	return secret, version.Version, nil
+}
+
+// Rekey encrypts the data of a secret stored with the file driver again
+// with the given encryption options, which are merged into the existing
+// ones. An option with an empty value is removed. This rotates the key of
+// the secret without changing its ID or version, the data is only decrypted
+// in memory. It returns the ID of the secret.
+func (m *Manager) Rekey(nameOrID string, driverOpts map[string]string) (string, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	state, err := m.loadState()
+	if err != nil {
+		return "", err
+	}
+	secret, err := m.SecretsManager.Lookup(nameOrID)
+	if err != nil {
+		return "", err
+	}
+	if secret.Driver != "file" {
+		return "", fmt.Errorf("rekeying secret %s: only supported with the file driver: %w", secret.Name, errInvalidDriver)
+	}
+	st := stateFor(state, secret)
+	data, err := m.lookupData(secret, st)
+	if err != nil {
+		return "", fmt.Errorf("rekeying secret %s: %w", secret.Name, err)
+	}
+	applyState(secret, st)
+	enc, err := encryptionFor(secret.Driver, secret.DriverOptions)
+	if err != nil {
+		return "", err
+	}
+	if enc != nil {
+		data, err = enc.open(secret.ID, data)
+		if err != nil {
+			return "", fmt.Errorf("rekeying secret %s: %w", secret.Name, err)
+		}
+	}
+
+	newOpts := encryptionOf(secret.DriverOptions)
+	for key, value := range driverOpts {
+		if !slices.Contains(encryptionOptions, key) {
+			return "", fmt.Errorf("rekeying secret %s: option %s cannot be changed: %w", secret.Name, key, errInvalidDriverOpt)
+		}
+		if value == "" {
+			delete(newOpts, key)
+			continue
+		}
+		newOpts[key] = value
+	}
+	newEnc, err := newEncryption(newOpts)
+	if err != nil {
+		return "", err
+	}
+	if newEnc != nil {
+		data, err = newEnc.seal(data)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	oldEncryption := st.Encryption
+	st.Encryption = newOpts
+	if err := m.replaceData(secret, st, state, data); err != nil {
+		st.Encryption = oldEncryption
+		return "", fmt.Errorf("rekeying secret %s: %w", secret.Name, err)
+	}
+	return secret.ID, nil
+}
+
+// replaceData replaces the data of the secret and saves the state. The new
+// data is stored next to the current data first, so the secret keeps its
+// old data if anything fails.
+func (m *Manager) replaceData(secret *secrets.Secret, st *secretState, state map[string]*secretState, data []byte) error {
+	driver, err := getDriver(secret.Driver, secret.DriverOptions)
+	if err != nil {
+		return err
+	}
+	dataID := secret.ID + "-" + strconv.FormatInt(time.Now().UnixNano(), 10)
+	if err := driver.Store(dataID, data); err != nil {
+		return err
+	}
+	oldDataID := st.DataID
+	st.DataID = dataID
+	if err := m.saveState(state); err != nil {
+		st.DataID = oldDataID
+		if err := driver.Delete(dataID); err != nil {
+			logrus.Errorf("Removing new data of secret %s: %v", secret.Name, err)
+		}
+		return err
+	}
+	if oldDataID != "" {
+		if err := driver.Delete(oldDataID); err != nil && !errors.Is(err, define.ErrNoSuchSecret) {
+			logrus.Errorf("Removing old data of secret %s: %v", secret.Name, err)
+		}
+	}
+
+	// Store the data under the secret ID again, the secrets manager needs it
+	// there. The data stays available under dataID if this fails.
+	if err := driver.Delete(secret.ID); err != nil && !errors.Is(err, define.ErrNoSuchSecret) {
+		logrus.Debugf("Removing old data of secret %s: %v", secret.Name, err)
+		return nil
+	}
+	if err := driver.Store(secret.ID, data); err != nil {
+		logrus.Debugf("Storing data of secret %s: %v", secret.Name, err)
+		return nil
+	}
+	st.DataID = ""
+	if err := m.saveState(state); err != nil {
+		st.DataID = dataID
+		logrus.Debugf("Saving state of secret %s: %v", secret.Name, err)
+		return nil
+	}
+	if err := driver.Delete(dataID); err != nil {
+		logrus.Errorf("Removing new data of secret %s: %v", secret.Name, err)
+	}
+	return nil
+}
+
+// lookupData returns the data of the secret as stored by the driver.
+func (m *Manager) lookupData(secret *secrets.Secret, st *secretState) ([]byte, error) {
+	driver, err := getDriver(secret.Driver, secret.DriverOptions)
+	if err != nil {
+		return nil, err
+	}
+	if st != nil && st.DataID != "" {
+		return driver.Lookup(st.DataID)
+	}
+	return driver.Lookup(secret.ID)
+}
+
+// ensureData stores the current data of the secret under the secret ID if
+// the driver only has it under the data ID of the state.
+func (m *Manager) ensureData(secret *secrets.Secret, st *secretState) error {
+	if st == nil || st.DataID == "" {
+		return nil
+	}
+	driver, err := getDriver(secret.Driver, secret.DriverOptions)
+	if err != nil {
+		return err
+	}
+	if _, err := driver.Lookup(secret.ID); !errors.Is(err, define.ErrNoSuchSecret) {
+		return err
+	}
+	data, err := driver.Lookup(st.DataID)
+	if err != nil {
+		return err
+	}
+	return driver.Store(secret.ID, data)
+}
+
+// removeData removes the data stored under the data ID of the state.
+func (m *Manager) removeData(secret *secrets.Secret, st *secretState) {
+	if st == nil || st.DataID == "" {
+		return
+	}
+	driver, err := getDriver(secret.Driver, secret.DriverOptions)
+	if err == nil {
+		err = driver.Delete(st.DataID)
+	}
+	if err != nil && !errors.Is(err, define.ErrNoSuchSecret) {
+		logrus.Errorf("Removing data of secret %s: %v", secret.Name, err)
+	}
+}
+
+func (m *Manager) loadState() (map[string]*secretState, error) {
+	state := make(map[string]*secretState)
+	content, err := os.ReadFile(m.statePath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return state, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(content, &state); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", m.statePath, err)
+	}
+	return state, nil
+}
+
+func (m *Manager) saveState(state map[string]*secretState) error {
+	content, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutils.AtomicWriteFile(m.statePath, content, 0o600)
+}
+
+// stateFor returns the state of the secret, adding it to the state if it
+// is not known yet.
+func stateFor(state map[string]*secretState, secret *secrets.Secret) *secretState {
+	st, ok := state[secret.ID]
+	if !ok {
+		st = &secretState{}
+		state[secret.ID] = st
+	}
+	if len(st.Versions) == 0 {
+		st.Versions = versions(secret, nil)
+	}
+	return st
+}
+
+// applyState sets the encryption options and the update time of the state
+// on the secret.
+func applyState(secret *secrets.Secret, st *secretState) {
+	if st == nil {
+		return
+	}
+	if st.Encryption != nil {
+		opts := make(map[string]string, len(secret.DriverOptions))
+		for key, value := range secret.DriverOptions {
+			if !slices.Contains(encryptionOptions, key) {
+				opts[key] = value
+			}
+		}
+		maps.Copy(opts, st.Encryption)
+		secret.DriverOptions = opts
+	}
+	if len(st.Versions) > 0 {
+		if updated := st.Versions[len(st.Versions)-1].CreatedAt; updated.After(secret.UpdatedAt) {
+			secret.UpdatedAt = updated
+		}
+	}
+}
+
+func versions(secret *secrets.Secret, st *secretState) []Version {
+	if st == nil || len(st.Versions) == 0 {
+		return []Version{{Version: 1, CreatedAt: secret.CreatedAt}}
+	}
+	return st.Versions
+}
+
+func currentVersion(secret *secrets.Secret, st *secretState) int {
+	v := versions(secret, st)
+	return v[len(v)-1].Version
+}
+
+// encryptionOf returns the encryption options of the driver options. The
+// result is never nil, so it overrides the options stored with the secret.
+func encryptionOf(opts map[string]string) map[string]string {
+	enc := make(map[string]string)
+	for _, key := range encryptionOptions {
+		if value, ok := opts[key]; ok {
+			enc[key] = value
+		}
+	}
+	return enc
+}
+
+// encryptionFor parses the encryption options of the driver options. It
+// returns nil if the data is not encrypted.
+func encryptionFor(driverType string, opts map[string]string) (*encryption, error) {
+	if driverType != "file" {
+		for _, key := range encryptionOptions {
+			if _, ok := opts[key]; ok {
+				return nil, fmt.Errorf("option %s is only supported by the file driver: %w", key, errInvalidDriverOpt)
+			}
+		}
+		return nil, nil
+	}
+	return newEncryption(opts)
+}
+
+// getDriver creates a new driver.
+func getDriver(name string, opts map[string]string) (secrets.SecretsDriver, error) {
+	switch name {
+	case "file":
+		if path, ok := opts["path"]; ok {
+			return filedriver.NewDriver(path)
+		}
+		return nil, fmt.Errorf("need path for filedriver: %w", errInvalidDriverOpt)
+	case "pass":
+		return passdriver.NewDriver(opts)
+	case "shell":
+		return shelldriver.NewDriver(opts)
+	}
+	return nil, errInvalidDriver
+}
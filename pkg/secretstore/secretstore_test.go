@@ -0,0 +1,158 @@
+package secretstore
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/containers/common/pkg/secrets"
+	"github.com/containers/common/pkg/secrets/filedriver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setup(t *testing.T) (*Manager, string) {
+	dir := t.TempDir()
+	manager, err := NewManager(dir)
+	require.NoError(t, err)
+	return manager, filepath.Join(dir, "filedriver")
+}
+
+// storedData returns the data of the secret as stored by the file driver
+func storedData(t *testing.T, manager *Manager, path, nameOrID string) []byte {
+	secret, err := manager.SecretsManager.Lookup(nameOrID)
+	require.NoError(t, err)
+	state, err := manager.loadState()
+	require.NoError(t, err)
+	driver, err := filedriver.NewDriver(path)
+	require.NoError(t, err)
+	id := secret.ID
+	if st := state[secret.ID]; st != nil && st.DataID != "" {
+		id = st.DataID
+	}
+	data, err := driver.Lookup(id)
+	require.NoError(t, err)
+	return data
+}
+
+func TestStoreEncrypted(t *testing.T) {
+	manager, path := setup(t)
+	t.Setenv(DefaultKeyEnv, "passphrase")
+	keyfile := filepath.Join(t.TempDir(), "secrets.key")
+	require.NoError(t, os.WriteFile(keyfile, []byte("key"), 0o600))
+
+	for _, mode := range []string{EncryptEnv, EncryptKeyfile} {
+		name := "secret-" + mode
+		opts := map[string]string{"path": path, EncryptOption: mode}
+		if mode == EncryptKeyfile {
+			opts[KeyfileOption] = keyfile
+		}
+		id, err := manager.Store(name, []byte("mydata"), "file", secrets.StoreOptions{DriverOpts: opts})
+		require.NoError(t, err)
+
+		assert.NotContains(t, string(storedData(t, manager, path, id)), "mydata")
+		secret, data, err := manager.LookupSecretData(name)
+		require.NoError(t, err)
+		assert.Equal(t, "mydata", string(data))
+		assert.Equal(t, mode, secret.DriverOptions[EncryptOption])
+		assert.Equal(t, mode == EncryptEnv, KeyFromEnvironment(secret.Driver, secret.DriverOptions))
+	}
+
+	t.Setenv(DefaultKeyEnv, "wrong")
+	_, _, err := manager.LookupSecretData("secret-" + EncryptEnv)
+	assert.Error(t, err)
+
+	_, err = manager.Store("invalid", []byte("mydata"), "file", secrets.StoreOptions{DriverOpts: map[string]string{"path": path, EncryptOption: "local"}})
+	assert.ErrorIs(t, err, ErrInvalidEncryption)
+	_, err = manager.Store("invalid", []byte("mydata"), "file", secrets.StoreOptions{DriverOpts: map[string]string{"path": path, EncryptOption: EncryptEnv, KeyfileOption: keyfile}})
+	assert.ErrorIs(t, err, ErrInvalidEncryption)
+	_, err = manager.Store("invalid", []byte("mydata"), "shell", secrets.StoreOptions{DriverOpts: map[string]string{EncryptOption: EncryptEnv}})
+	assert.ErrorIs(t, err, errInvalidDriverOpt)
+}
+
+func TestRekey(t *testing.T) {
+	manager, path := setup(t)
+	t.Setenv(DefaultKeyEnv, "passphrase")
+
+	id, err := manager.Store("mysecret", []byte("mydata"), "file", secrets.StoreOptions{DriverOpts: map[string]string{"path": path}})
+	require.NoError(t, err)
+	assert.Equal(t, "mydata", string(storedData(t, manager, path, id)))
+
+	rekeyed, err := manager.Rekey("mysecret", map[string]string{EncryptOption: EncryptEnv})
+	require.NoError(t, err)
+	assert.Equal(t, id, rekeyed)
+	assert.NotContains(t, string(storedData(t, manager, path, id)), "mydata")
+	secret, data, err := manager.LookupSecretData(id)
+	require.NoError(t, err)
+	assert.Equal(t, "mydata", string(data))
+	assert.Equal(t, EncryptEnv, secret.DriverOptions[EncryptOption])
+
+	_, err = manager.Rekey("mysecret", map[string]string{EncryptOption: ""})
+	require.NoError(t, err)
+	assert.Equal(t, "mydata", string(storedData(t, manager, path, id)))
+	secret, err = manager.Lookup(id)
+	require.NoError(t, err)
+	assert.NotContains(t, secret.DriverOptions, EncryptOption)
+
+	_, err = manager.Rekey("mysecret", map[string]string{"path": t.TempDir()})
+	assert.ErrorIs(t, err, errInvalidDriverOpt)
+
+	// the secret can still be removed after the data was replaced
+	_, err = manager.Delete(id)
+	require.NoError(t, err)
+	driver, err := filedriver.NewDriver(path)
+	require.NoError(t, err)
+	ids, err := driver.List()
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+}
+
+func TestUpdate(t *testing.T) {
+	manager, path := setup(t)
+	t.Setenv(DefaultKeyEnv, "passphrase")
+
+	id, err := manager.Store("mysecret", []byte("v1"), "file", secrets.StoreOptions{DriverOpts: map[string]string{"path": path, EncryptOption: EncryptEnv}})
+	require.NoError(t, err)
+
+	for i, value := range []string{"v2", "v3"} {
+		secret, version, err := manager.Update("mysecret", []byte(value))
+		require.NoError(t, err)
+		assert.Equal(t, id, secret.ID)
+		assert.Equal(t, i+2, version)
+
+		_, data, err := manager.LookupSecretData(id)
+		require.NoError(t, err)
+		assert.Equal(t, value, string(data))
+	}
+	secret, err := manager.Lookup(id)
+	require.NoError(t, err)
+	versions, err := manager.Versions(secret)
+	require.NoError(t, err)
+	assert.Len(t, versions, 3)
+	assert.Equal(t, 3, versions[2].Version)
+	assert.Equal(t, versions[2].CreatedAt, secret.UpdatedAt)
+
+	_, _, err = manager.Update("mysecret", []byte(strings.Repeat("a", maxSecretSize)))
+	assert.ErrorIs(t, err, errDataSize)
+
+	// replacing the secret continues its versions
+	newID, err := manager.Store("mysecret", []byte("v4"), "file", secrets.StoreOptions{DriverOpts: map[string]string{"path": path}, Replace: true})
+	require.NoError(t, err)
+	assert.NotEqual(t, id, newID)
+	secret, data, err := manager.LookupSecretData("mysecret")
+	require.NoError(t, err)
+	assert.Equal(t, "v4", string(data))
+	versions, err = manager.Versions(secret)
+	require.NoError(t, err)
+	assert.Len(t, versions, 4)
+
+	state, err := manager.loadState()
+	require.NoError(t, err)
+	assert.NotContains(t, state, id)
+	driver, err := filedriver.NewDriver(path)
+	require.NoError(t, err)
+	ids, err := driver.List()
+	require.NoError(t, err)
+	assert.Equal(t, []string{newID}, ids)
+}
@@ -6,6 +6,7 @@ import (
 	"slices"
 	"strings"
 
+	"github.com/containers/common/pkg/config"
 	"github.com/containers/podman/v5/libpod/define"
 )
 
@@ -74,6 +75,11 @@ func (s *SpecGenerator) Validate() error {
 	if len(s.ContainerStorageConfig.Image) > 0 && len(s.ContainerStorageConfig.Rootfs) > 0 {
 		return exclusiveOptions("rootfs", "image")
 	}
+	if len(s.ContainerStorageConfig.ArtifactPullPolicy) > 0 {
+		if _, err := config.ParsePullPolicy(s.ContainerStorageConfig.ArtifactPullPolicy); err != nil {
+			return err
+		}
+	}
 	// imagevolumemode must be one of ignore, tmpfs, or anonymous if given
 	if len(s.ContainerStorageConfig.ImageVolumeMode) > 0 && !slices.Contains(ImageVolumeModeValues, strings.ToLower(s.ContainerStorageConfig.ImageVolumeMode)) {
 		return fmt.Errorf("invalid ImageVolumeMode %q, value must be one of %s",
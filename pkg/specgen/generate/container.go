@@ -478,6 +478,16 @@ func ConfigToSpec(rt *libpod.Runtime, specg *specgen.SpecGenerator, containerID
 		}
 	}
 	specg.ImageVolumes = image
+	var artifact []*specgen.ArtifactVolume
+	for _, v := range conf.ArtifactVolumes {
+		artifact = append(artifact, &specgen.ArtifactVolume{
+			Source:      v.Source,
+			Destination: v.Dest,
+			Title:       v.Title,
+			Digest:      v.Digest,
+		})
+	}
+	specg.ArtifactVolumes = artifact
 	var overlay []*specgen.OverlayVolume
 	if len(conf.OverlayVolumes) != 0 {
 		for _, v := range conf.OverlayVolumes {
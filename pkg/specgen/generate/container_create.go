@@ -226,6 +226,10 @@ func MakeContainer(ctx context.Context, rt *libpod.Runtime, s *specgen.SpecGener
 		return nil, nil, nil, err
 	}
 
+	if err := pullArtifacts(ctx, rt, s); err != nil {
+		return nil, nil, nil, err
+	}
+
 	if len(s.HostUsers) > 0 {
 		options = append(options, libpod.WithHostUsers(s.HostUsers))
 	}
@@ -463,6 +467,9 @@ func createContainerOptions(rt *libpod.Runtime, s *specgen.SpecGenerator, pod *l
 	for _, imageVolume := range s.ImageVolumes {
 		destinations = append(destinations, imageVolume.Destination)
 	}
+	for _, artifactVolume := range s.ArtifactVolumes {
+		destinations = append(destinations, artifactVolume.Destination)
+	}
 
 	if len(destinations) > 0 || !infraVolumes {
 		options = append(options, libpod.WithUserVolumes(destinations))
@@ -507,6 +514,19 @@ func createContainerOptions(rt *libpod.Runtime, s *specgen.SpecGenerator, pod *l
 		options = append(options, libpod.WithImageVolumes(vols))
 	}
 
+	if len(s.ArtifactVolumes) != 0 {
+		var vols []*libpod.ContainerArtifactVolume
+		for _, v := range s.ArtifactVolumes {
+			vols = append(vols, &libpod.ContainerArtifactVolume{
+				Dest:   v.Destination,
+				Source: v.Source,
+				Title:  v.Title,
+				Digest: v.Digest,
+			})
+		}
+		options = append(options, libpod.WithArtifactVolumes(vols))
+	}
+
 	if s.Command != nil {
 		options = append(options, libpod.WithCommand(s.Command))
 	}
@@ -544,6 +564,12 @@ func createContainerOptions(rt *libpod.Runtime, s *specgen.SpecGenerator, pod *l
 		if s.LogConfiguration.Size > 0 {
 			options = append(options, libpod.WithMaxLogSize(s.LogConfiguration.Size))
 		}
+		if s.LogConfiguration.MaxFile > 0 {
+			options = append(options, libpod.WithLogMaxFile(s.LogConfiguration.MaxFile))
+		}
+		if s.LogConfiguration.Compress {
+			options = append(options, libpod.WithLogCompress())
+		}
 		if len(s.LogConfiguration.Options) > 0 && s.LogConfiguration.Options["tag"] != "" {
 			options = append(options, libpod.WithLogTag(s.LogConfiguration.Options["tag"]))
 		}
@@ -551,6 +577,9 @@ func createContainerOptions(rt *libpod.Runtime, s *specgen.SpecGenerator, pod *l
 		if len(s.LogConfiguration.Driver) > 0 {
 			options = append(options, libpod.WithLogDriver(s.LogConfiguration.Driver))
 		}
+		if define.IsForwardingLogDriver(s.LogConfiguration.Driver) {
+			options = append(options, libpod.WithLogOptions(s.LogConfiguration.Options))
+		}
 	}
 	if s.LabelNested != nil {
 		options = append(options, libpod.WithLabelNested(*s.LabelNested))
@@ -637,6 +666,16 @@ func createContainerOptions(rt *libpod.Runtime, s *specgen.SpecGenerator, pod *l
 		options = append(options, libpod.WithStartupHealthcheck(s.ContainerHealthCheckConfig.StartupHealthConfig))
 		healthCheckSet = true
 	}
+	if s.ContainerHealthCheckConfig.ReadinessProbeConfig != nil {
+		options = append(options, libpod.WithReadinessProbe(s.ContainerHealthCheckConfig.ReadinessProbeConfig))
+	}
+
+	if len(s.PostStartHook) > 0 {
+		options = append(options, libpod.WithPostStartHook(s.PostStartHook))
+	}
+	if len(s.PreStopHook) > 0 {
+		options = append(options, libpod.WithPreStopHook(s.PreStopHook))
+	}
 
 	if s.ContainerHealthCheckConfig.HealthCheckOnFailureAction != define.HealthCheckOnFailureActionNone {
 		options = append(options, libpod.WithHealthCheckOnFailureAction(s.ContainerHealthCheckConfig.HealthCheckOnFailureAction))
@@ -661,12 +700,17 @@ func createContainerOptions(rt *libpod.Runtime, s *specgen.SpecGenerator, pod *l
 			if err != nil {
 				return nil, err
 			}
+			if s.Rotate && filepath.IsAbs(s.Target) {
+				return nil, fmt.Errorf("secret %s: rotate requires a target relative to /run/secrets: %w", s.Source, define.ErrInvalidArg)
+			}
 			secrs = append(secrs, &libpod.ContainerSecret{
-				Secret: secr,
-				UID:    s.UID,
-				GID:    s.GID,
-				Mode:   s.Mode,
-				Target: s.Target,
+				Secret:       secr,
+				UID:          s.UID,
+				GID:          s.GID,
+				Mode:         s.Mode,
+				Target:       s.Target,
+				Rotate:       s.Rotate,
+				RotateSignal: s.RotateSignal,
 			})
 		}
 		options = append(options, libpod.WithSecrets(secrs))
@@ -0,0 +1,268 @@
+//go:build !remote
+
+package kube
+
+import (
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronField describes the valid range and the accepted names of a single
+// field of a cron expression.
+type cronField struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	cronMinutes = cronField{name: "minute", min: 0, max: 59}
+	cronHours   = cronField{name: "hour", min: 0, max: 23}
+	cronDays    = cronField{name: "day of month", min: 1, max: 31}
+	cronMonths  = cronField{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// Both 0 and 7 are Sunday, 7 is folded into 0 after parsing.
+	cronWeekdays = cronField{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+
+	cronMacros = map[string]string{
+		"@yearly":   "0 0 1 1 *",
+		"@annually": "0 0 1 1 *",
+		"@monthly":  "0 0 1 * *",
+		"@weekly":   "0 0 * * 0",
+		"@daily":    "0 0 * * *",
+		"@midnight": "0 0 * * *",
+		"@hourly":   "0 * * * *",
+	}
+
+	systemdWeekdays = []string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"}
+)
+
+// CronSchedule is a parsed five field cron expression as used in the
+// schedule of a Kubernetes CronJob.
+type CronSchedule struct {
+	minutes, hours, days, months, weekdays uint64
+	// domStar and dowStar are set when the day of month or day of
+	// week field is unrestricted.  Cron only ORs both day fields
+	// when neither of them is.
+	domStar, dowStar bool
+	timeZone         string
+	location         *time.Location
+}
+
+// ParseCronSchedule parses the schedule of a Kubernetes CronJob.  The
+// schedule is evaluated in the given time zone, or in local time if the
+// time zone is empty.
+func ParseCronSchedule(schedule, timeZone string) (*CronSchedule, error) {
+	s := &CronSchedule{timeZone: timeZone, location: time.Local}
+	if timeZone != "" {
+		loc, err := time.LoadLocation(timeZone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid time zone %q: %w", timeZone, err)
+		}
+		s.location = loc
+	}
+
+	expr := strings.TrimSpace(schedule)
+	if macro, ok := cronMacros[strings.ToLower(expr)]; ok {
+		expr = macro
+	}
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid schedule %q: expected 5 fields, got %d", schedule, len(fields))
+	}
+
+	var err error
+	if s.minutes, err = parseCronField(fields[0], cronMinutes); err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: %w", schedule, err)
+	}
+	if s.hours, err = parseCronField(fields[1], cronHours); err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: %w", schedule, err)
+	}
+	if s.days, err = parseCronField(fields[2], cronDays); err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: %w", schedule, err)
+	}
+	if s.months, err = parseCronField(fields[3], cronMonths); err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: %w", schedule, err)
+	}
+	if s.weekdays, err = parseCronField(fields[4], cronWeekdays); err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: %w", schedule, err)
+	}
+	if s.weekdays&(1<<7) != 0 {
+		s.weekdays = s.weekdays&^(1<<7) | 1
+	}
+	s.domStar = strings.HasPrefix(fields[2], "*") || strings.HasPrefix(fields[2], "?")
+	s.dowStar = strings.HasPrefix(fields[4], "*") || strings.HasPrefix(fields[4], "?")
+
+	return s, nil
+}
+
+// parseCronField parses a comma separated list of values, ranges and
+// steps into a bitset.
+func parseCronField(value string, field cronField) (uint64, error) {
+	var set uint64
+	for _, part := range strings.Split(value, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepPart)
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid step %q in %s field", stepPart, field.name)
+			}
+			step = n
+		}
+
+		var low, high int
+		switch {
+		case rangePart == "*" || rangePart == "?":
+			low, high = field.min, field.max
+		case strings.Contains(rangePart, "-"):
+			lowPart, highPart, _ := strings.Cut(rangePart, "-")
+			var err error
+			if low, err = parseCronValue(lowPart, field); err != nil {
+				return 0, err
+			}
+			if high, err = parseCronValue(highPart, field); err != nil {
+				return 0, err
+			}
+			if low > high {
+				return 0, fmt.Errorf("invalid range %q in %s field", rangePart, field.name)
+			}
+		default:
+			var err error
+			if low, err = parseCronValue(rangePart, field); err != nil {
+				return 0, err
+			}
+			high = low
+			// "5/10" is short for "5-max/10".
+			if hasStep {
+				high = field.max
+			}
+		}
+
+		for i := low; i <= high; i += step {
+			set |= 1 << uint(i)
+		}
+	}
+	return set, nil
+}
+
+func parseCronValue(value string, field cronField) (int, error) {
+	if n, ok := field.names[strings.ToLower(value)]; ok {
+		return n, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < field.min || n > field.max {
+		return 0, fmt.Errorf("invalid value %q in %s field: must be between %d and %d", value, field.name, field.min, field.max)
+	}
+	return n, nil
+}
+
+// dayMatches reports whether the day of t matches the day of month and
+// day of week fields of the schedule.
+func (s *CronSchedule) dayMatches(t time.Time) bool {
+	dom := s.days&(1<<uint(t.Day())) != 0
+	dow := s.weekdays&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+// Next returns the first activation time of the schedule after t.  The
+// zero time is returned if the schedule never fires, for instance on the
+// 30th of February.
+func (s *CronSchedule) Next(t time.Time) time.Time {
+	t = t.In(s.location).Truncate(time.Minute).Add(time.Minute)
+	// Five years cover all leap year combinations of a valid schedule.
+	limit := t.Year() + 5
+
+	for t.Year() <= limit {
+		if s.months&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, s.location)
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, s.location)
+			continue
+		}
+		if s.hours&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, s.location)
+			continue
+		}
+		if s.minutes&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// OnCalendar converts the schedule into systemd calendar event
+// expressions suitable for OnCalendar= of a systemd timer.  A schedule
+// restricting both the day of month and the day of week results in two
+// expressions as systemd, unlike cron, requires both to match.
+func (s *CronSchedule) OnCalendar() []string {
+	months := calendarList(s.months, cronMonths)
+	days := calendarList(s.days, cronDays)
+	clock := fmt.Sprintf("%s:%s:00", calendarList(s.hours, cronHours), calendarList(s.minutes, cronMinutes))
+
+	// Sunday is folded into 0, so only the first 7 bits are relevant.
+	var weekdays []string
+	if s.weekdays&0x7f != 0x7f {
+		for i, name := range systemdWeekdays {
+			if s.weekdays&(1<<uint(i)) != 0 {
+				weekdays = append(weekdays, name)
+			}
+		}
+	}
+	dow := strings.Join(weekdays, ",")
+
+	dayEvent := fmt.Sprintf("*-%s-%s %s", months, days, clock)
+	weekdayEvent := fmt.Sprintf("*-%s-* %s", months, clock)
+	if dow != "" {
+		weekdayEvent = dow + " " + weekdayEvent
+	}
+
+	var events []string
+	switch {
+	case s.domStar || s.dowStar:
+		// Both day fields must match, like in systemd.  A field
+		// starting with a star may still be restricted by a step.
+		if dow != "" {
+			dayEvent = dow + " " + dayEvent
+		}
+		events = append(events, dayEvent)
+	default:
+		events = append(events, dayEvent, weekdayEvent)
+	}
+
+	if s.timeZone != "" {
+		for i := range events {
+			events[i] += " " + s.timeZone
+		}
+	}
+	return events
+}
+
+// calendarList renders a bitset as a systemd calendar component.
+func calendarList(set uint64, field cronField) string {
+	full := (uint64(1)<<uint(field.max-field.min+1) - 1) << uint(field.min)
+	if set&full == full {
+		return "*"
+	}
+	values := make([]string, 0, bits.OnesCount64(set))
+	for i := field.min; i <= field.max; i++ {
+		if set&(1<<uint(i)) != 0 {
+			values = append(values, strconv.Itoa(i))
+		}
+	}
+	return strings.Join(values, ",")
+}
@@ -0,0 +1,149 @@
+//go:build !remote
+
+package kube
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCronScheduleErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule string
+		timeZone string
+	}{
+		{"too few fields", "* * * *", ""},
+		{"too many fields", "* * * * * *", ""},
+		{"minute out of range", "60 * * * *", ""},
+		{"hour out of range", "0 24 * * *", ""},
+		{"day out of range", "0 0 0 * *", ""},
+		{"invalid month name", "0 0 1 foo *", ""},
+		{"inverted range", "0 10-5 * * *", ""},
+		{"zero step", "*/0 * * * *", ""},
+		{"invalid time zone", "* * * * *", "Not/AZone"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ParseCronSchedule(test.schedule, test.timeZone)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestCronScheduleNext(t *testing.T) {
+	// Wednesday
+	start := time.Date(2024, time.January, 10, 10, 7, 30, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		schedule string
+		expected time.Time
+	}{
+		{"every minute", "* * * * *", time.Date(2024, time.January, 10, 10, 8, 0, 0, time.UTC)},
+		{"every 15 minutes", "*/15 * * * *", time.Date(2024, time.January, 10, 10, 15, 0, 0, time.UTC)},
+		{"hourly macro", "@hourly", time.Date(2024, time.January, 10, 11, 0, 0, 0, time.UTC)},
+		{"daily at 3am", "0 3 * * *", time.Date(2024, time.January, 11, 3, 0, 0, 0, time.UTC)},
+		{"weekday names", "30 9 * * mon-fri", time.Date(2024, time.January, 11, 9, 30, 0, 0, time.UTC)},
+		{"sunday as 7", "0 0 * * 7", time.Date(2024, time.January, 14, 0, 0, 0, 0, time.UTC)},
+		{"month names", "0 0 1 mar *", time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)},
+		{"leap day", "0 0 29 2 *", time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		{"day of month or week", "0 0 20 * 5", time.Date(2024, time.January, 12, 0, 0, 0, 0, time.UTC)},
+		{"never", "0 0 30 2 *", time.Time{}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, err := ParseCronSchedule(test.schedule, "UTC")
+			require.NoError(t, err)
+			assert.True(t, test.expected.Equal(s.Next(start)), "expected %s, got %s", test.expected, s.Next(start))
+		})
+	}
+}
+
+func TestCronScheduleOnCalendar(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule string
+		timeZone string
+		expected []string
+	}{
+		{"every minute", "* * * * *", "", []string{"*-*-* *:*:00"}},
+		{"steps", "*/20 */6 * * *", "", []string{"*-*-* 0,6,12,18:0,20,40:00"}},
+		{"weekly", "@weekly", "", []string{"Sun *-*-* 0:0:00"}},
+		{"weekdays", "30 9 * * 1-5", "", []string{"Mon,Tue,Wed,Thu,Fri *-*-* 9:30:00"}},
+		{"monthly", "0 0 1 * *", "", []string{"*-*-1 0:0:00"}},
+		{"day of month or week", "0 0 1,15 * 1", "", []string{"*-*-1,15 0:0:00", "Mon *-*-* 0:0:00"}},
+		{"stepped day of month", "0 0 */10 * 1", "", []string{"Mon *-*-1,11,21,31 0:0:00"}},
+		{"stepped day of week", "0 0 1 * */2", "", []string{"Sun,Tue,Thu,Sat *-*-1 0:0:00"}},
+		{"time zone", "0 12 * 6 *", "Europe/Berlin", []string{"*-6-* 12:0:00 Europe/Berlin"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, err := ParseCronSchedule(test.schedule, test.timeZone)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, s.OnCalendar())
+		})
+	}
+}
+
+// calendarMatches reports whether the day of t matches a calendar event
+// generated by OnCalendar.  Only the weekday and date are evaluated.
+func calendarMatches(t *testing.T, event string, day time.Time) bool {
+	fields := strings.Fields(event)
+	if !strings.Contains(fields[0], "-") {
+		weekdays := strings.Split(fields[0], ",")
+		if !slices.Contains(weekdays, systemdWeekdays[day.Weekday()]) {
+			return false
+		}
+		fields = fields[1:]
+	}
+	date := strings.Split(fields[0], "-")
+	require.Len(t, date, 3, event)
+	listMatches := func(list string, value int) bool {
+		if list == "*" {
+			return true
+		}
+		for _, v := range strings.Split(list, ",") {
+			n, err := strconv.Atoi(v)
+			require.NoError(t, err, event)
+			if n == value {
+				return true
+			}
+		}
+		return false
+	}
+	return listMatches(date[1], int(day.Month())) && listMatches(date[2], day.Day())
+}
+
+func TestCronScheduleOnCalendarMatchesNext(t *testing.T) {
+	schedules := []string{
+		"0 0 */2 * 1",
+		"0 0 1 * */2",
+		"0 0 */3 * *",
+		"0 0 1,15 * 1",
+		"0 0 10-20 * sun",
+		"0 0 * 2-4 1-5",
+		"0 0 1-31/7 */3 *",
+	}
+	start := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	for _, schedule := range schedules {
+		t.Run(schedule, func(t *testing.T) {
+			s, err := ParseCronSchedule(schedule, "UTC")
+			require.NoError(t, err)
+			events := s.OnCalendar()
+			for day := start; day.Before(start.AddDate(1, 0, 0)); day = day.AddDate(0, 0, 1) {
+				fires := s.Next(day.Add(-time.Minute)).Equal(day)
+				matches := slices.ContainsFunc(events, func(event string) bool {
+					return calendarMatches(t, event, day)
+				})
+				assert.Equal(t, fires, matches, "%s on %s with %v", schedule, day.Format(time.DateOnly), events)
+			}
+		})
+	}
+}
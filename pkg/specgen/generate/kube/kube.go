@@ -21,7 +21,6 @@ import (
 	"github.com/containers/common/libnetwork/types"
 	"github.com/containers/common/pkg/config"
 	"github.com/containers/common/pkg/parse"
-	"github.com/containers/common/pkg/secrets"
 	"github.com/containers/image/v5/manifest"
 	itypes "github.com/containers/image/v5/types"
 	"github.com/containers/podman/v5/libpod/define"
@@ -30,6 +29,7 @@ import (
 	v1 "github.com/containers/podman/v5/pkg/k8s.io/api/core/v1"
 	"github.com/containers/podman/v5/pkg/k8s.io/apimachinery/pkg/api/resource"
 	"github.com/containers/podman/v5/pkg/k8s.io/apimachinery/pkg/util/intstr"
+	"github.com/containers/podman/v5/pkg/secretstore"
 	"github.com/containers/podman/v5/pkg/specgen"
 	"github.com/containers/podman/v5/pkg/specgen/generate"
 	systemdDefine "github.com/containers/podman/v5/pkg/systemd/define"
@@ -167,7 +167,7 @@ type CtrSpecGenOptions struct {
 	// UtsNSIsHost tells the container to use the host utsns
 	UtsNSIsHost bool
 	// SecretManager to access the secrets
-	SecretsManager *secrets.SecretsManager
+	SecretsManager *secretstore.Manager
 	// LogDriver which should be used for the container
 	LogDriver string
 	// LogOptions log options which should be used for the container
@@ -244,13 +244,25 @@ func ToSpecGen(ctx context.Context, opts *CtrSpecGenOptions) (*specgen.SpecGener
 				return nil, err
 			}
 			s.LogConfiguration.Size = logSize
+		case "max-file":
+			maxFile, err := strconv.ParseUint(val, 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("invalid log option max-file %q: %w", val, err)
+			}
+			s.LogConfiguration.MaxFile = uint(maxFile)
+		case "compress":
+			compress, err := strconv.ParseBool(val)
+			if err != nil {
+				return nil, fmt.Errorf("invalid log option compress %q: %w", val, err)
+			}
+			s.LogConfiguration.Compress = compress
 		default:
 			switch len(val) {
 			case 0:
 				return nil, fmt.Errorf("invalid log option: %w", define.ErrInvalidArg)
 			default:
-				// tags for journald only
-				if s.LogConfiguration.Driver == "" || s.LogConfiguration.Driver == define.JournaldLogging {
+				// tags for journald only, the forwarding log drivers have options of their own
+				if s.LogConfiguration.Driver == "" || s.LogConfiguration.Driver == define.JournaldLogging || define.IsForwardingLogDriver(s.LogConfiguration.Driver) {
 					s.LogConfiguration.Options[opt] = val
 				} else {
 					logrus.Warnf("Can only set tags with journald log driver but driver is %q", s.LogConfiguration.Driver)
@@ -270,6 +282,14 @@ func ToSpecGen(ctx context.Context, opts *CtrSpecGenOptions) (*specgen.SpecGener
 	if err != nil {
 		return nil, fmt.Errorf("failed to configure startupProbe: %w", err)
 	}
+	err = setupReadinessProbe(s, opts.Container)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure readinessProbe: %w", err)
+	}
+	err = setupLifecycleHooks(s, opts.Container)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure lifecycle hooks: %w", err)
+	}
 
 	// Since we prefix the container name with pod name to work-around the uniqueness requirement,
 	// the seccomp profile should reference the actual container name from the YAML
@@ -675,9 +695,18 @@ func parseMountPath(mountPath string, readOnly bool, propagationMode *v1.MountPr
 }
 
 func probeToHealthConfig(probe *v1.Probe, containerPorts []v1.ContainerPort) (*manifest.Schema2HealthConfig, error) {
+	commandString, err := handlerToCommandString(probe.Handler, containerPorts)
+	if err != nil {
+		return nil, err
+	}
+	return makeHealthCheck(commandString, probe.PeriodSeconds, probe.FailureThreshold, probe.TimeoutSeconds, probe.InitialDelaySeconds)
+}
+
+// handlerToCommandString converts the action of a probe or lifecycle handler
+// into a command string that can be passed to makeHealthCheck.
+func handlerToCommandString(probeHandler v1.Handler, containerPorts []v1.ContainerPort) (string, error) {
 	var commandString string
 	failureCmd := "exit 1"
-	probeHandler := probe.Handler
 	host := "localhost" // Kubernetes default is host IP, but with Podman currently we run inside the container
 
 	// configure healthcheck on the basis of Handler Actions.
@@ -686,7 +715,7 @@ func probeToHealthConfig(probe *v1.Probe, containerPorts []v1.ContainerPort) (*m
 		// `makeHealthCheck` function can accept a json array as the command.
 		cmd, err := json.Marshal(probeHandler.Exec.Command)
 		if err != nil {
-			return nil, err
+			return "", err
 		}
 		commandString = string(cmd)
 	case probeHandler.HTTPGet != nil:
@@ -704,20 +733,39 @@ func probeToHealthConfig(probe *v1.Probe, containerPorts []v1.ContainerPort) (*m
 		}
 		portNum, err := getPortNumber(probeHandler.HTTPGet.Port, containerPorts)
 		if err != nil {
-			return nil, err
+			return "", err
 		}
 		commandString = fmt.Sprintf("curl -f %s://%s:%d%s || %s", uriScheme, host, portNum, path, failureCmd)
 	case probeHandler.TCPSocket != nil:
 		portNum, err := getPortNumber(probeHandler.TCPSocket.Port, containerPorts)
 		if err != nil {
-			return nil, err
+			return "", err
 		}
 		if probeHandler.TCPSocket.Host != "" {
 			host = probeHandler.TCPSocket.Host
 		}
 		commandString = fmt.Sprintf("nc -z -v %s %d || %s", host, portNum, failureCmd)
 	}
-	return makeHealthCheck(commandString, probe.PeriodSeconds, probe.FailureThreshold, probe.TimeoutSeconds, probe.InitialDelaySeconds)
+	return commandString, nil
+}
+
+// lifecycleHandlerToCommand converts a lifecycle handler into the command to
+// execute in the container.
+func lifecycleHandlerToCommand(handler *v1.Handler, containerPorts []v1.ContainerPort) ([]string, error) {
+	if handler.Exec != nil {
+		if len(handler.Exec.Command) == 0 {
+			return nil, errors.New("exec handler requires a command")
+		}
+		return handler.Exec.Command, nil
+	}
+	commandString, err := handlerToCommandString(*handler, containerPorts)
+	if err != nil {
+		return nil, err
+	}
+	if commandString == "" {
+		return nil, errors.New("handler must define one of exec, httpGet or tcpSocket")
+	}
+	return []string{"/bin/sh", "-c", commandString}, nil
 }
 
 func getPortNumber(port intstr.IntOrString, containerPorts []v1.ContainerPort) (int, error) {
@@ -786,6 +834,48 @@ func setupStartupProbe(s *specgen.SpecGenerator, containerYAML v1.Container, res
 	return nil
 }
 
+func setupReadinessProbe(s *specgen.SpecGenerator, containerYAML v1.Container) error {
+	if containerYAML.ReadinessProbe == nil {
+		return nil
+	}
+	emptyHandler := v1.Handler{}
+	if containerYAML.ReadinessProbe.Handler == emptyHandler {
+		return nil
+	}
+	probe := containerYAML.ReadinessProbe
+	healthConfig, err := probeToHealthConfig(probe, containerYAML.Ports)
+	if err != nil {
+		return err
+	}
+
+	// The readiness probe is run on its own timer at its periodSeconds.
+	s.ReadinessProbeConfig = &define.ReadinessProbe{
+		Schema2HealthConfig: *healthConfig,
+		Successes:           int(probe.SuccessThreshold),
+	}
+	return nil
+}
+
+func setupLifecycleHooks(s *specgen.SpecGenerator, containerYAML v1.Container) error {
+	if containerYAML.Lifecycle == nil {
+		return nil
+	}
+	var err error
+	if containerYAML.Lifecycle.PostStart != nil {
+		s.PostStartHook, err = lifecycleHandlerToCommand(containerYAML.Lifecycle.PostStart, containerYAML.Ports)
+		if err != nil {
+			return fmt.Errorf("postStart: %w", err)
+		}
+	}
+	if containerYAML.Lifecycle.PreStop != nil {
+		s.PreStopHook, err = lifecycleHandlerToCommand(containerYAML.Lifecycle.PreStop, containerYAML.Ports)
+		if err != nil {
+			return fmt.Errorf("preStop: %w", err)
+		}
+	}
+	return nil
+}
+
 func makeHealthCheck(inCmd string, interval int32, retries int32, timeout int32, startPeriod int32) (*manifest.Schema2HealthConfig, error) {
 	// Every healthcheck requires a command
 	if len(inCmd) == 0 {
@@ -930,7 +1020,7 @@ func quantityToInt64(quantity *resource.Quantity) (int64, error) {
 
 // read a k8s secret in JSON/YAML format from the secret manager
 // k8s secret is stored as YAML, we have to read data as JSON for backward compatibility
-func k8sSecretFromSecretManager(name string, secretsManager *secrets.SecretsManager) (map[string][]byte, error) {
+func k8sSecretFromSecretManager(name string, secretsManager *secretstore.Manager) (map[string][]byte, error) {
 	_, inputSecret, err := secretsManager.LookupSecretData(name)
 	if err != nil {
 		return nil, err
@@ -6,7 +6,9 @@ import (
 	"math"
 	"runtime"
 	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/containers/common/pkg/secrets"
 	"github.com/containers/podman/v5/libpod/define"
@@ -14,14 +16,15 @@ import (
 	"github.com/containers/podman/v5/pkg/k8s.io/apimachinery/pkg/api/resource"
 	v12 "github.com/containers/podman/v5/pkg/k8s.io/apimachinery/pkg/apis/meta/v1"
 	"github.com/containers/podman/v5/pkg/k8s.io/apimachinery/pkg/util/intstr"
+	"github.com/containers/podman/v5/pkg/secretstore"
 	"github.com/containers/podman/v5/pkg/specgen"
 	"github.com/docker/docker/pkg/meminfo"
 	"github.com/stretchr/testify/assert"
 	"sigs.k8s.io/yaml"
 )
 
-func createSecrets(t *testing.T, d string) *secrets.SecretsManager {
-	secretsManager, err := secrets.NewManager(d)
+func createSecrets(t *testing.T, d string) *secretstore.Manager {
+	secretsManager, err := secretstore.NewManager(d)
 	assert.NoError(t, err)
 
 	driver := "file"
@@ -1400,3 +1403,250 @@ func TestTCPLivenessProbe(t *testing.T) {
 		})
 	}
 }
+
+func TestReadinessProbe(t *testing.T) {
+	tests := []struct {
+		name               string
+		specGenerator      specgen.SpecGenerator
+		container          v1.Container
+		succeed            bool
+		expectedCmd        string
+		expectedHCCmd      string
+		expectedSuccesses  int
+		expectedInterval   time.Duration
+		expectedHCInterval time.Duration
+	}{
+		{
+			"ReadinessProbeWithoutLivenessProbe",
+			specgen.SpecGenerator{},
+			v1.Container{
+				ReadinessProbe: &v1.Probe{
+					Handler: v1.Handler{
+						Exec: &v1.ExecAction{
+							Command: []string{"cat", "/ready"},
+						},
+					},
+					SuccessThreshold: 2,
+					PeriodSeconds:    2,
+				},
+			},
+			true,
+			"/ready",
+			"",
+			2,
+			2 * time.Second,
+			0,
+		},
+		{
+			"ReadinessProbeWithLivenessProbe",
+			specgen.SpecGenerator{},
+			v1.Container{
+				LivenessProbe: &v1.Probe{
+					Handler: v1.Handler{
+						Exec: &v1.ExecAction{
+							Command: []string{"cat", "/alive"},
+						},
+					},
+					PeriodSeconds: 5,
+				},
+				ReadinessProbe: &v1.Probe{
+					Handler: v1.Handler{
+						HTTPGet: &v1.HTTPGetAction{
+							Port: intstr.FromString("httpPort"),
+							Path: "/ready",
+						},
+					},
+					PeriodSeconds: 5,
+				},
+				Ports: []v1.ContainerPort{
+					{Name: "httpPort", ContainerPort: 8000},
+				},
+			},
+			true,
+			"http://localhost:8000/ready",
+			"/alive",
+			0,
+			5 * time.Second,
+			5 * time.Second,
+		},
+		{
+			"ReadinessProbeWithLivenessProbeDifferentPeriod",
+			specgen.SpecGenerator{},
+			v1.Container{
+				LivenessProbe: &v1.Probe{
+					Handler: v1.Handler{
+						Exec: &v1.ExecAction{
+							Command: []string{"cat", "/alive"},
+						},
+					},
+					PeriodSeconds: 5,
+				},
+				ReadinessProbe: &v1.Probe{
+					Handler: v1.Handler{
+						Exec: &v1.ExecAction{
+							Command: []string{"cat", "/ready"},
+						},
+					},
+					PeriodSeconds: 1,
+				},
+			},
+			true,
+			"/ready",
+			"/alive",
+			0,
+			time.Second,
+			5 * time.Second,
+		},
+		{
+			"ReadinessProbeWithStartupProbe",
+			specgen.SpecGenerator{},
+			v1.Container{
+				StartupProbe: &v1.Probe{
+					Handler: v1.Handler{
+						Exec: &v1.ExecAction{
+							Command: []string{"cat", "/started"},
+						},
+					},
+					PeriodSeconds: 5,
+				},
+				ReadinessProbe: &v1.Probe{
+					Handler: v1.Handler{
+						Exec: &v1.ExecAction{
+							Command: []string{"cat", "/ready"},
+						},
+					},
+					PeriodSeconds: 1,
+				},
+			},
+			true,
+			"/ready",
+			"exit 0",
+			0,
+			time.Second,
+			5 * time.Second,
+		},
+		{
+			"ReadinessProbeInvalidPortName",
+			specgen.SpecGenerator{},
+			v1.Container{
+				ReadinessProbe: &v1.Probe{
+					Handler: v1.Handler{
+						TCPSocket: &v1.TCPSocketAction{
+							Port: intstr.FromString("unknownPort"),
+						},
+					},
+				},
+			},
+			false,
+			"",
+			"",
+			0,
+			0,
+			0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := setupLivenessProbe(&test.specGenerator, test.container, "")
+			assert.NoError(t, err)
+			err = setupStartupProbe(&test.specGenerator, test.container, "")
+			assert.NoError(t, err)
+			err = setupReadinessProbe(&test.specGenerator, test.container)
+			assert.Equal(t, err == nil, test.succeed)
+			if err == nil {
+				readiness := test.specGenerator.ContainerHealthCheckConfig.ReadinessProbeConfig
+				assert.NotNil(t, readiness)
+				assert.Contains(t, strings.Join(readiness.Test, " "), test.expectedCmd)
+				assert.Equal(t, test.expectedSuccesses, readiness.Successes)
+				// the readiness probe is run by its own timer
+				assert.Equal(t, test.expectedInterval, readiness.Interval)
+				hc := test.specGenerator.ContainerHealthCheckConfig.HealthConfig
+				if test.expectedHCCmd == "" {
+					assert.Nil(t, hc)
+				} else {
+					assert.NotNil(t, hc)
+					assert.Contains(t, strings.Join(hc.Test, " "), test.expectedHCCmd)
+					assert.Equal(t, test.expectedHCInterval, hc.Interval)
+				}
+			}
+		})
+	}
+}
+
+func TestLifecycleHooks(t *testing.T) {
+	tests := []struct {
+		name              string
+		container         v1.Container
+		succeed           bool
+		expectedPostStart []string
+		expectedPreStop   []string
+	}{
+		{
+			"NoLifecycle",
+			v1.Container{},
+			true,
+			nil,
+			nil,
+		},
+		{
+			"ExecHooks",
+			v1.Container{
+				Lifecycle: &v1.Lifecycle{
+					PostStart: &v1.Handler{
+						Exec: &v1.ExecAction{
+							Command: []string{"touch", "/started"},
+						},
+					},
+					PreStop: &v1.Handler{
+						Exec: &v1.ExecAction{
+							Command: []string{"nginx", "-s", "quit"},
+						},
+					},
+				},
+			},
+			true,
+			[]string{"touch", "/started"},
+			[]string{"nginx", "-s", "quit"},
+		},
+		{
+			"HTTPGetPreStopHook",
+			v1.Container{
+				Lifecycle: &v1.Lifecycle{
+					PreStop: &v1.Handler{
+						HTTPGet: &v1.HTTPGetAction{
+							Port: intstr.FromInt(8080),
+							Path: "/shutdown",
+						},
+					},
+				},
+			},
+			true,
+			nil,
+			[]string{"/bin/sh", "-c", "curl -f http://localhost:8080/shutdown || exit 1"},
+		},
+		{
+			"EmptyHandler",
+			v1.Container{
+				Lifecycle: &v1.Lifecycle{
+					PostStart: &v1.Handler{},
+				},
+			},
+			false,
+			nil,
+			nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := specgen.SpecGenerator{}
+			err := setupLifecycleHooks(&s, test.container)
+			assert.Equal(t, err == nil, test.succeed)
+			if err == nil {
+				assert.Equal(t, test.expectedPostStart, s.PostStartHook)
+				assert.Equal(t, test.expectedPreStop, s.PreStopHook)
+			}
+		})
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/containers/common/pkg/secrets"
 	"github.com/containers/podman/v5/libpod"
 	v1 "github.com/containers/podman/v5/pkg/k8s.io/api/core/v1"
+	"github.com/containers/podman/v5/pkg/secretstore"
 	"github.com/containers/storage/pkg/fileutils"
 
 	"github.com/sirupsen/logrus"
@@ -141,7 +142,7 @@ func VolumeFromHostPath(hostPath *v1.HostPathVolumeSource, mountLabel string) (*
 }
 
 // VolumeFromSecret creates a new kube volume from a kube secret.
-func VolumeFromSecret(secretSource *v1.SecretVolumeSource, secretsManager *secrets.SecretsManager) (*KubeVolume, error) {
+func VolumeFromSecret(secretSource *v1.SecretVolumeSource, secretsManager *secretstore.Manager) (*KubeVolume, error) {
 	kv := &KubeVolume{
 		Type:        KubeVolumeTypeSecret,
 		Source:      secretSource.SecretName,
@@ -291,7 +292,7 @@ func VolumeFromImage(imageVolumeSource *v1.ImageVolumeSource, name string) (*Kub
 }
 
 // Create a KubeVolume from one of the supported VolumeSource
-func VolumeFromSource(volumeSource v1.VolumeSource, configMaps []v1.ConfigMap, secretsManager *secrets.SecretsManager, volName, mountLabel string) (*KubeVolume, error) {
+func VolumeFromSource(volumeSource v1.VolumeSource, configMaps []v1.ConfigMap, secretsManager *secretstore.Manager, volName, mountLabel string) (*KubeVolume, error) {
 	switch {
 	case volumeSource.HostPath != nil:
 		return VolumeFromHostPath(volumeSource.HostPath, mountLabel)
@@ -311,7 +312,7 @@ func VolumeFromSource(volumeSource v1.VolumeSource, configMaps []v1.ConfigMap, s
 }
 
 // Create a map of volume name to KubeVolume
-func InitializeVolumes(specVolumes []v1.Volume, configMaps []v1.ConfigMap, secretsManager *secrets.SecretsManager, mountLabel string) (map[string]*KubeVolume, error) {
+func InitializeVolumes(specVolumes []v1.Volume, configMaps []v1.ConfigMap, secretsManager *secretstore.Manager, mountLabel string) (map[string]*KubeVolume, error) {
 	volumes := make(map[string]*KubeVolume)
 
 	for _, specVolume := range specVolumes {
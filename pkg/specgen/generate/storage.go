@@ -16,6 +16,7 @@ import (
 	"github.com/containers/common/pkg/parse"
 	"github.com/containers/podman/v5/libpod"
 	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/pkg/libartifact"
 	"github.com/containers/podman/v5/pkg/specgen"
 	"github.com/containers/podman/v5/pkg/util"
 	"github.com/containers/storage/pkg/fileutils"
@@ -484,3 +485,51 @@ func addReadWriteTmpfsMounts(mounts map[string]spec.Mount, volumes []*specgen.Na
 	}
 	return mounts
 }
+
+// pullArtifacts pulls the artifacts of the artifact volumes of the container
+// according to its artifact pull policy, with the pull options set on the
+// generator, and checks that the files to mount exist.
+func pullArtifacts(ctx context.Context, rt *libpod.Runtime, s *specgen.SpecGenerator) error {
+	if len(s.ArtifactVolumes) == 0 {
+		return nil
+	}
+	pullPolicy := config.PullPolicyMissing
+	if s.ArtifactPullPolicy != "" {
+		policy, err := config.ParsePullPolicy(s.ArtifactPullPolicy)
+		if err != nil {
+			return err
+		}
+		pullPolicy = policy
+	}
+	artStore, err := rt.ArtifactStore()
+	if err != nil {
+		return err
+	}
+
+	for _, volume := range s.ArtifactVolumes {
+		_, err := artStore.Inspect(ctx, volume.Source)
+		if err != nil && !errors.Is(err, define.ErrNoSuchArtifact) {
+			return err
+		}
+		exists := err == nil
+		if pullPolicy == config.PullPolicyAlways || pullPolicy == config.PullPolicyNewer || (!exists && pullPolicy == config.PullPolicyMissing) {
+			if _, err := artStore.Pull(ctx, volume.Source, s.GetArtifactPullOptions()); err != nil {
+				// A newer artifact is only pulled if available, the
+				// local one is used otherwise
+				if !exists || pullPolicy != config.PullPolicyNewer {
+					return fmt.Errorf("pulling artifact %s: %w", volume.Source, err)
+				}
+				logrus.Warnf("Unable to pull artifact %s, using the local artifact: %v", volume.Source, err)
+			}
+		}
+
+		extractOpts := &libartifact.ExtractOptions{
+			Title:  volume.Title,
+			Digest: volume.Digest,
+		}
+		if _, err := artStore.BlobMountPaths(ctx, volume.Source, extractOpts); err != nil {
+			return fmt.Errorf("artifact volume %q:%q: %w", volume.Source, volume.Destination, err)
+		}
+	}
+	return nil
+}
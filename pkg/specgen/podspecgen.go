@@ -4,6 +4,7 @@ import (
 	"net"
 
 	"github.com/containers/common/libnetwork/types"
+	"github.com/containers/podman/v5/libpod/define"
 	storageTypes "github.com/containers/storage/types"
 	spec "github.com/opencontainers/runtime-spec/specs-go"
 )
@@ -239,6 +240,9 @@ type PodSpecGenerator struct {
 
 	// The ID of the pod's service container.
 	ServiceContainerID string `json:"serviceContainerID,omitempty"`
+
+	// CronJob is the schedule the pod is started on.
+	CronJob *define.PodCronJob `json:"-"`
 }
 
 type PodResourceConfig struct {
@@ -26,6 +26,14 @@ type LogConfig struct {
 	// Size is the maximum size of the log file
 	// Optional.
 	Size int64 `json:"size,omitempty"`
+	// MaxFile is the number of log files kept when the log file is
+	// rotated after reaching Size, including the current one.
+	// Only available if LogDriver is set to "json-file" or "k8s-file".
+	// Optional.
+	MaxFile uint `json:"max_file,omitempty"`
+	// Compress compresses the rotated log files.
+	// Optional.
+	Compress bool `json:"compress,omitempty"`
 	// A set of options to accompany the log driver.
 	// Optional.
 	Options map[string]string `json:"options,omitempty"`
@@ -88,6 +96,14 @@ type ContainerBasicConfig struct {
 	// instead.
 	// Optional.
 	StopTimeout *uint `json:"stop_timeout,omitempty"`
+	// PostStartHook is a command executed inside the container right
+	// after it has been started. If it fails, the container is killed.
+	// Optional.
+	PostStartHook []string `json:"post_start_hook,omitempty"`
+	// PreStopHook is a command executed inside the container before it
+	// is stopped. It must complete within StopTimeout.
+	// Optional.
+	PreStopHook []string `json:"pre_stop_hook,omitempty"`
 	// Timeout is a maximum time in seconds the container will run before
 	// main process is sent SIGKILL.
 	// If 0 is used, signal will not be sent. Container can run indefinitely
@@ -305,6 +321,15 @@ type ContainerStorageConfig struct {
 	// Image volumes bind-mount a container-image mount into the container.
 	// Optional.
 	ImageVolumes []*ImageVolume `json:"image_volumes,omitempty"`
+	// Artifact volumes bind-mount the files of an OCI artifact into the
+	// container.
+	// Optional.
+	ArtifactVolumes []*ArtifactVolume `json:"artifact_volumes,omitempty"`
+	// ArtifactPullPolicy is the policy to pull the artifacts of
+	// ArtifactVolumes when creating the container. The image of the
+	// container is not pulled.
+	// Optional. Defaults to "missing".
+	ArtifactPullPolicy string `json:"artifact_pull_policy,omitempty"`
 	// Devices are devices that will be added to the container.
 	// Optional.
 	Devices []spec.LinuxDevice `json:"devices,omitempty"`
@@ -598,6 +623,10 @@ type ContainerHealthCheckConfig struct {
 	// Requires that HealthConfig be set.
 	// Optional.
 	StartupHealthConfig *define.StartupHealthCheck `json:"startupHealthConfig,omitempty"`
+	// Readiness probe for a container.
+	// Requires that HealthConfig be set.
+	// Optional.
+	ReadinessProbeConfig *define.ReadinessProbe `json:"readinessProbeConfig,omitempty"`
 	// HealthLogDestination defines the destination where the log is stored
 	HealthLogDestination string `json:"healthLogDestination,omitempty"`
 	// HealthMaxLogCount is maximum number of attempts in the HealthCheck log file.
@@ -642,6 +671,12 @@ type Secret struct {
 	UID    uint32
 	GID    uint32
 	Mode   uint32
+	// Rotate updates the secret file in the running container when a new
+	// version of the secret is stored
+	Rotate bool `json:"rotate,omitempty"`
+	// RotateSignal is the signal sent to the container after the secret
+	// file was rotated
+	RotateSignal uint `json:"rotate_signal,omitempty"`
 }
 
 var (
@@ -5,8 +5,9 @@ package specgen
 import "github.com/containers/common/libimage"
 
 type cacheLibImage struct {
-	image             *libimage.Image `json:"-"`
-	resolvedImageName string          `json:"-"`
+	image               *libimage.Image      `json:"-"`
+	resolvedImageName   string               `json:"-"`
+	artifactPullOptions libimage.CopyOptions `json:"-"`
 }
 
 // SetImage sets the associated for the generator.
@@ -20,3 +21,15 @@ func (s *SpecGenerator) SetImage(image *libimage.Image, resolvedImageName string
 func (s *SpecGenerator) GetImage() (*libimage.Image, string) {
 	return s.image, s.resolvedImageName
 }
+
+// SetArtifactPullOptions sets the options, such as the credentials of the
+// request, used to pull the artifacts of the artifact volumes.
+func (s *SpecGenerator) SetArtifactPullOptions(options libimage.CopyOptions) {
+	s.artifactPullOptions = options
+}
+
+// GetArtifactPullOptions returns the options used to pull the artifacts of
+// the artifact volumes.
+func (s *SpecGenerator) GetArtifactPullOptions() libimage.CopyOptions {
+	return s.artifactPullOptions
+}
@@ -58,6 +58,23 @@ type ImageVolume struct {
 	SubPath string `json:"subPath,omitempty"`
 }
 
+// ArtifactVolume is a volume based on an OCI artifact in the local artifact
+// store. The files of the artifact are bind-mounted read-only into the
+// container.
+type ArtifactVolume struct {
+	// Source is the name or digest of the artifact.
+	Source string `json:"source"`
+	// Destination is the absolute path of the mount in the container. If
+	// a file is selected with Title or Digest, it is mounted at
+	// Destination. Otherwise all files of the artifact are mounted in the
+	// Destination directory, each named after its title.
+	Destination string `json:"destination"`
+	// Title selects the file of the artifact with this title.
+	Title string `json:"title,omitempty"`
+	// Digest selects the file of the artifact with this digest.
+	Digest string `json:"digest,omitempty"`
+}
+
 // GenVolumeMounts parses user input into mounts, volumes and overlay volumes
 func GenVolumeMounts(volumeFlag []string) (map[string]spec.Mount, map[string]*NamedVolume, map[string]*OverlayVolume, error) {
 	mounts := make(map[string]spec.Mount)
@@ -4,9 +4,35 @@ import (
 	"errors"
 
 	"github.com/containers/common/pkg/config"
+	"github.com/containers/podman/v5/libpod/define"
 	"github.com/containers/podman/v5/pkg/domain/entities"
 )
 
+// DefineCreateDefaults initializes the container create options with their
+// defaults from conf. A remote client leaves the defaults which depend on the
+// containers.conf of the server empty, so they are set by the server.
+func DefineCreateDefaults(opts *entities.ContainerCreateOptions, conf *config.Config, isRemote bool) {
+	if !isRemote {
+		opts.LogDriver = conf.Containers.LogDriver
+		opts.CgroupsMode = conf.Cgroups()
+		opts.Pull = conf.Engine.PullPolicy
+		opts.Ulimit = conf.Ulimits()
+		opts.Volume = conf.Volumes()
+	}
+	opts.MemorySwappiness = -1
+	opts.ImageVolume = conf.Engine.ImageVolumeMode
+	opts.ReadWriteTmpFS = true
+	opts.SdNotifyMode = define.SdNotifyModeContainer
+	opts.StopTimeout = conf.Engine.StopTimeout
+	opts.Systemd = "true"
+	opts.Timezone = conf.TZ()
+	opts.Umask = conf.Umask()
+	opts.SeccompPolicy = "default"
+	opts.HealthLogDestination = define.DefaultHealthCheckLocalDestination
+	opts.HealthMaxLogCount = define.DefaultHealthMaxLogCount
+	opts.HealthMaxLogSize = define.DefaultHealthMaxLogSize
+}
+
 // validate determines if the flags and values given by the user are valid. things checked
 // by validate must not need any state information on the flag (i.e. changed)
 func validate(c *entities.ContainerCreateOptions) error {
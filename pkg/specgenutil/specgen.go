@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path"
 	"strconv"
 	"strings"
 	"time"
@@ -16,6 +17,7 @@ import (
 	"github.com/containers/podman/v5/pkg/domain/entities"
 	envLib "github.com/containers/podman/v5/pkg/env"
 	"github.com/containers/podman/v5/pkg/namespaces"
+	"github.com/containers/podman/v5/pkg/signal"
 	"github.com/containers/podman/v5/pkg/specgen"
 	systemdDefine "github.com/containers/podman/v5/pkg/systemd/define"
 	"github.com/containers/podman/v5/pkg/util"
@@ -767,7 +769,7 @@ func FillOutSpecGen(s *specgen.SpecGenerator, c *entities.ContainerCreateOptions
 
 	// Only add read-only tmpfs mounts in case that we are read-only and the
 	// read-only tmpfs flag has been set.
-	mounts, volumes, overlayVolumes, imageVolumes, err := parseVolumes(rtc, c.Volume, c.Mount, c.TmpFS)
+	mounts, volumes, overlayVolumes, imageVolumes, artifactVolumes, err := parseVolumes(rtc, c.Volume, c.Mount, c.TmpFS)
 	if err != nil {
 		return err
 	}
@@ -795,6 +797,9 @@ func FillOutSpecGen(s *specgen.SpecGenerator, c *entities.ContainerCreateOptions
 	if len(s.ImageVolumes) == 0 {
 		s.ImageVolumes = imageVolumes
 	}
+	if len(s.ArtifactVolumes) == 0 {
+		s.ArtifactVolumes = artifactVolumes
+	}
 
 	devices := c.Devices
 	for _, gpu := range c.GPUs {
@@ -848,6 +853,18 @@ func FillOutSpecGen(s *specgen.SpecGenerator, c *entities.ContainerCreateOptions
 				return err
 			}
 			s.LogConfiguration.Size = logSize
+		case "max-file":
+			maxFile, err := strconv.ParseUint(val, 10, 32)
+			if err != nil {
+				return fmt.Errorf("invalid log option max-file %q: %w", val, err)
+			}
+			s.LogConfiguration.MaxFile = uint(maxFile)
+		case "compress":
+			compress, err := strconv.ParseBool(val)
+			if err != nil {
+				return fmt.Errorf("invalid log option compress %q: %w", val, err)
+			}
+			s.LogConfiguration.Compress = compress
 		default:
 			logOpts[key] = val
 		}
@@ -1101,6 +1118,8 @@ func parseSecrets(secrets []string) ([]specgen.Secret, map[string]string, error)
 		var uid, gid uint32
 		// default mode 444 octal = 292 decimal
 		var mode uint32 = 292
+		rotate := false
+		var rotateSignal uint
 		split := strings.Split(val, ",")
 
 		// --secret mysecret
@@ -1160,6 +1179,18 @@ func parseSecrets(secrets []string) ([]specgen.Secret, map[string]string, error)
 					return nil, nil, fmt.Errorf("GID %s invalid: %w", value, secretParseError)
 				}
 				gid = uint32(gid64)
+			case "rotate":
+				rotateBool, err := strconv.ParseBool(value)
+				if err != nil {
+					return nil, nil, fmt.Errorf("rotate %s invalid: %w", value, secretParseError)
+				}
+				rotate = rotateBool
+			case "signal":
+				sig, err := signal.ParseSignalNameOrNumber(value)
+				if err != nil {
+					return nil, nil, fmt.Errorf("signal %s invalid: %w", value, secretParseError)
+				}
+				rotateSignal = uint(sig)
 
 			default:
 				return nil, nil, fmt.Errorf("option %s invalid: %w", val, secretParseError)
@@ -1172,13 +1203,22 @@ func parseSecrets(secrets []string) ([]specgen.Secret, map[string]string, error)
 		if source == "" {
 			return nil, nil, fmt.Errorf("no source found %s: %w", val, secretParseError)
 		}
+		if rotateSignal != 0 && !rotate {
+			return nil, nil, fmt.Errorf("signal can only be set with rotate=true: %w", secretParseError)
+		}
+		// rotated secrets are written to the secrets dir of the container
+		if rotate && path.IsAbs(target) {
+			return nil, nil, fmt.Errorf("rotate option cannot be set with an absolute target %s: %w", target, secretParseError)
+		}
 		if secretType == "mount" {
 			mountSecret := specgen.Secret{
-				Source: source,
-				Target: target,
-				UID:    uid,
-				GID:    gid,
-				Mode:   mode,
+				Source:       source,
+				Target:       target,
+				UID:          uid,
+				GID:          gid,
+				Mode:         mode,
+				Rotate:       rotate,
+				RotateSignal: rotateSignal,
 			}
 			mount = append(mount, mountSecret)
 		}
@@ -1186,6 +1226,9 @@ func parseSecrets(secrets []string) ([]specgen.Secret, map[string]string, error)
 			if mountOnly {
 				return nil, nil, fmt.Errorf("UID, GID, Mode options cannot be set with secret type env: %w", secretParseError)
 			}
+			if rotate {
+				return nil, nil, fmt.Errorf("rotate option cannot be set with secret type env: %w", secretParseError)
+			}
 			if target == "" {
 				target = source
 			}
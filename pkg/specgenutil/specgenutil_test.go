@@ -7,6 +7,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/containers/common/pkg/config"
 	"github.com/containers/common/pkg/machine"
 	"github.com/containers/podman/v5/libpod/define"
 	"github.com/containers/podman/v5/pkg/domain/entities"
@@ -230,3 +231,40 @@ func TestFillOutSpecGenRecorsUserNs(t *testing.T) {
 	assert.True(t, ok, "UserNsAnnotation is set")
 	assert.Equal(t, "keep-id", v, "UserNsAnnotation is keep-id")
 }
+
+func TestParseSecretsRotate(t *testing.T) {
+	mounts, _, err := parseSecrets([]string{"mysecret,target=tls.key,rotate=true,signal=SIGHUP"})
+	assert.NoError(t, err)
+	assert.Len(t, mounts, 1)
+	assert.True(t, mounts[0].Rotate)
+	assert.Equal(t, "tls.key", mounts[0].Target)
+
+	_, _, err = parseSecrets([]string{"mysecret,target=/etc/tls.key,rotate=true"})
+	assert.ErrorContains(t, err, "absolute target")
+
+	_, _, err = parseSecrets([]string{"mysecret,type=env,rotate=true"})
+	assert.Error(t, err)
+}
+
+func TestDefineCreateDefaults(t *testing.T) {
+	conf := &config.Config{}
+	conf.Containers.LogDriver = "k8s-file"
+	conf.Engine.PullPolicy = "newer"
+	conf.Engine.StopTimeout = 20
+
+	var local entities.ContainerCreateOptions
+	DefineCreateDefaults(&local, conf, false)
+	assert.Equal(t, "k8s-file", local.LogDriver)
+	assert.Equal(t, "newer", local.Pull)
+	assert.Equal(t, uint(20), local.StopTimeout)
+	assert.Equal(t, "true", local.Systemd)
+	assert.Equal(t, int64(-1), local.MemorySwappiness)
+
+	// A remote client leaves the server defaults to the server
+	var remote entities.ContainerCreateOptions
+	DefineCreateDefaults(&remote, conf, true)
+	assert.Empty(t, remote.LogDriver)
+	assert.Empty(t, remote.Pull)
+	assert.Equal(t, uint(20), remote.StopTimeout)
+	assert.Equal(t, "true", remote.Systemd)
+}
@@ -267,7 +267,36 @@ func CreateExitCommandArgs(storageConfig storageTypes.StoreOptions, config *conf
 	// user of the API.
 	// As such, provide a way to specify a path to Podman, so we can
 	// still invoke a cleanup process.
+	command, err := CreatePodmanCommandArgs(storageConfig, config, syslog)
+	if err != nil {
+		return nil, err
+	}
+
+	// --stopped-only is used to ensure we only cleanup stopped containers and do not race
+	// against other processes that did a cleanup() + init() again before we had the chance to run
+	command = append(command, []string{"container", "cleanup", "--stopped-only"}...)
+
+	if rm {
+		command = append(command, "--rm")
+	}
+
+	if rmi {
+		command = append(command, "--rmi")
+	}
+
+	// This has to be absolutely last, to ensure that the exec session ID
+	// will be added after it by Libpod.
+	if exec {
+		command = append(command, "--exec")
+	}
 
+	return command, nil
+}
+
+// CreatePodmanCommandArgs returns the path of the running podman binary
+// followed by the global options which make a podman process started by
+// libpod use the same configuration.
+func CreatePodmanCommandArgs(storageConfig storageTypes.StoreOptions, config *config.Config, syslog bool) ([]string, error) {
 	podmanPath, err := os.Executable()
 	if err != nil {
 		return nil, err
@@ -311,23 +340,5 @@ func CreateExitCommandArgs(storageConfig storageTypes.StoreOptions, config *conf
 		command = append(command, "--module", module)
 	}
 
-	// --stopped-only is used to ensure we only cleanup stopped containers and do not race
-	// against other processes that did a cleanup() + init() again before we had the chance to run
-	command = append(command, []string{"container", "cleanup", "--stopped-only"}...)
-
-	if rm {
-		command = append(command, "--rm")
-	}
-
-	if rmi {
-		command = append(command, "--rmi")
-	}
-
-	// This has to be absolutely last, to ensure that the exec session ID
-	// will be added after it by Libpod.
-	if exec {
-		command = append(command, "--exec")
-	}
-
 	return command, nil
 }
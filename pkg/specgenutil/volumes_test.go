@@ -1,6 +1,11 @@
 package specgenutil
 
-import "testing"
+import (
+	"reflect"
+	"testing"
+
+	"github.com/containers/podman/v5/pkg/specgen"
+)
 
 func Test_validChownFlag(t *testing.T) {
 	type args struct {
@@ -66,3 +71,60 @@ func Test_validChownFlag(t *testing.T) {
 		})
 	}
 }
+
+func Test_getArtifactVolume(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    *specgen.ArtifactVolume
+		wantErr bool
+	}{
+		{
+			name: "all files",
+			args: []string{"src=quay.io/foo/model:v1", "dst=/model/"},
+			want: &specgen.ArtifactVolume{Source: "quay.io/foo/model:v1", Destination: "/model"},
+		},
+		{
+			name: "single file by title",
+			args: []string{"source=model", "target=/model.bin", "title=model.bin", "ro"},
+			want: &specgen.ArtifactVolume{Source: "model", Destination: "/model.bin", Title: "model.bin"},
+		},
+		{
+			name: "single file by digest",
+			args: []string{"source=model", "destination=/model.bin", "digest=sha256:1234"},
+			want: &specgen.ArtifactVolume{Source: "model", Destination: "/model.bin", Digest: "sha256:1234"},
+		},
+		{
+			name:    "missing destination",
+			args:    []string{"source=model"},
+			wantErr: true,
+		},
+		{
+			name:    "relative destination",
+			args:    []string{"source=model", "dst=model"},
+			wantErr: true,
+		},
+		{
+			name:    "read-write",
+			args:    []string{"source=model", "dst=/model", "rw=true"},
+			wantErr: true,
+		},
+		{
+			name:    "writable",
+			args:    []string{"source=model", "dst=/model", "ro=false"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getArtifactVolume(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("getArtifactVolume() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getArtifactVolume() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -27,6 +27,10 @@ type unitLine struct {
 	key       string
 	value     string
 	isComment bool
+
+	// Location the line was parsed from, if any
+	path   string
+	lineNr int
 }
 
 type unitGroup struct {
@@ -50,6 +54,21 @@ type UnitFileParser struct {
 	pendingComments []*unitLine
 }
 
+// ParseError is a syntax error in a unit file
+type ParseError struct {
+	// Line is the number of the line the error is in
+	Line int
+	Err  error
+}
+
+func (e *ParseError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
 func newUnitLine(key string, value string, isComment bool) *unitLine {
 	l := &unitLine{
 		key:       key,
@@ -64,7 +83,10 @@ func (l *unitLine) set(value string) {
 }
 
 func (l *unitLine) dup() *unitLine {
-	return newUnitLine(l.key, l.value, l.isComment)
+	d := newUnitLine(l.key, l.value, l.isComment)
+	d.path = l.path
+	d.lineNr = l.lineNr
+	return d
 }
 
 func (l *unitLine) isKey(key string) bool {
@@ -314,7 +336,7 @@ func (p *UnitFileParser) parseGroup(line string) error {
 	return nil
 }
 
-func (p *UnitFileParser) parseKeyValuePair(line string) error {
+func (p *UnitFileParser) parseKeyValuePair(line string, lineNr int) error {
 	if p.currentGroup == nil {
 		return fmt.Errorf("key file does not start with a group")
 	}
@@ -341,22 +363,30 @@ func (p *UnitFileParser) parseKeyValuePair(line string) error {
 
 	p.flushPendingComments(false)
 
-	p.currentGroup.add(key, value)
+	l := newUnitLine(key, value, false)
+	l.path = p.file.Path
+	l.lineNr = lineNr
+	p.currentGroup.addLine(l)
 
 	return nil
 }
 
 func (p *UnitFileParser) parseLine(line string, lineNr int) error {
+	var err error
 	switch {
 	case lineIsComment(line):
-		return p.parseComment(line)
+		err = p.parseComment(line)
 	case lineIsGroup(line):
-		return p.parseGroup(line)
+		err = p.parseGroup(line)
 	case lineIsKeyValuePair(line):
-		return p.parseKeyValuePair(line)
+		err = p.parseKeyValuePair(line, lineNr)
 	default:
-		return fmt.Errorf("file contains line %d: “%s” which is not a key-value pair, group, or comment", lineNr, line)
+		err = fmt.Errorf("file contains line %d: “%s” which is not a key-value pair, group, or comment", lineNr, line)
 	}
+	if err != nil {
+		return &ParseError{Line: lineNr, Err: err}
+	}
+	return nil
 }
 
 func (p *UnitFileParser) flushPendingComments(toComment bool) {
@@ -383,6 +413,8 @@ func (f *UnitFile) Parse(data string) error {
 
 	lines := strings.Split(strings.TrimSuffix(data, "\n"), "\n")
 	remaining := ""
+	// Number of the first line of a continued line
+	startNr := 0
 
 	for lineNr, line := range lines {
 		line = strings.TrimSpace(line)
@@ -391,7 +423,11 @@ func (f *UnitFile) Parse(data string) error {
 			if remaining != "" {
 				continue
 			}
+			startNr = lineNr
 		} else {
+			if remaining == "" {
+				startNr = lineNr
+			}
 			if strings.HasSuffix(line, "\\") {
 				line = line[:len(line)-1]
 				if lineNr != len(lines)-1 {
@@ -405,7 +441,7 @@ func (f *UnitFile) Parse(data string) error {
 				remaining = ""
 			}
 		}
-		if err := p.parseLine(line, lineNr+1); err != nil {
+		if err := p.parseLine(line, startNr+1); err != nil {
 			return err
 		}
 	}
@@ -591,6 +627,23 @@ func (f *UnitFile) LookupLastRaw(groupName string, key string) (string, bool) {
 	return line.value, true
 }
 
+// Look up the file and line number the last instance of the named key in
+// the group was parsed from. The path is empty for keys which were not
+// parsed from a file, and the line number zero for keys which were set.
+func (f *UnitFile) LookupLocation(groupName string, key string) (string, int, bool) {
+	g, ok := f.groupByName[groupName]
+	if !ok {
+		return "", 0, false
+	}
+
+	line := g.findLast(key)
+	if line == nil {
+		return "", 0, false
+	}
+
+	return line.path, line.lineNr, true
+}
+
 func (f *UnitFile) HasKey(groupName string, key string) bool {
 	_, ok := f.LookupLastRaw(groupName, key)
 	return ok
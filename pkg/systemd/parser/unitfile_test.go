@@ -326,3 +326,52 @@ func FuzzParser(f *testing.F) {
 		_ = unitFile.Parse(string(orig))
 	})
 }
+
+func TestUnitFile_LookupLocation(t *testing.T) {
+	f := NewUnitFile()
+	f.Path = "/etc/containers/systemd/app.container"
+	err := f.Parse(`# comment
+[Container]
+Image=quay.io/libpod/alpine
+Exec=sleep \
+  infinity
+Label=a=b
+Label=c=d
+`)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		key  string
+		line int
+	}{
+		{"Image", 3},
+		{"Exec", 4},
+		{"Label", 7},
+	}
+	for _, tt := range tests {
+		path, line, ok := f.LookupLocation("Container", tt.key)
+		assert.True(t, ok, tt.key)
+		assert.Equal(t, f.Path, path, tt.key)
+		assert.Equal(t, tt.line, line, tt.key)
+	}
+
+	_, _, ok := f.LookupLocation("Container", "Volume")
+	assert.False(t, ok)
+
+	dropin := NewUnitFile()
+	dropin.Path = "/etc/containers/systemd/app.container.d/10.conf"
+	assert.NoError(t, dropin.Parse("[Container]\n\nImage=quay.io/libpod/busybox\n"))
+	f.Merge(dropin)
+	path, line, ok := f.LookupLocation("Container", "Image")
+	assert.True(t, ok)
+	assert.Equal(t, dropin.Path, path)
+	assert.Equal(t, 3, line)
+}
+
+func TestUnitFile_ParseErrorLine(t *testing.T) {
+	f := NewUnitFile()
+	err := f.Parse("[Container]\nImage=quay.io/libpod/alpine\n\nnot a key\n")
+	var parseErr *ParseError
+	assert.ErrorAs(t, err, &parseErr)
+	assert.Equal(t, 4, parseErr.Line)
+}
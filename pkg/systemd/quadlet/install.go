@@ -0,0 +1,143 @@
+package quadlet
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/containers/podman/v5/pkg/systemd/parser"
+)
+
+// quadletExtensions are the file extensions of the units the Quadlet
+// generator converts to services
+var quadletExtensions = map[string]func(*parser.UnitFile) string{
+	".container": GetContainerServiceName,
+	".volume":    GetVolumeServiceName,
+	".kube":      GetKubeServiceName,
+	".network":   GetNetworkServiceName,
+	".image":     GetImageServiceName,
+	".build":     GetBuildServiceName,
+	".pod":       GetPodServiceName,
+}
+
+// InstallUnit is a Quadlet unit file to install, with the files it references
+type InstallUnit struct {
+	// Path of the unit file
+	Path string
+	// Assets are the paths of the files the unit references, relative to
+	// the directory of the unit file
+	Assets []string
+}
+
+// IsQuadletFile returns whether name is the name of a Quadlet unit file
+func IsQuadletFile(name string) bool {
+	_, ok := quadletExtensions[filepath.Ext(name)]
+	return ok
+}
+
+// ServiceName returns the name of the systemd service the Quadlet generator
+// creates for unit
+func ServiceName(unit *parser.UnitFile) (string, error) {
+	getName, ok := quadletExtensions[filepath.Ext(unit.Filename)]
+	if !ok {
+		return "", fmt.Errorf("%s is not a Quadlet file", unit.Filename)
+	}
+	return getName(unit) + ".service", nil
+}
+
+// UnitDir returns the directory Quadlet units of the current user are
+// installed into. The first directory of $QUADLET_UNIT_DIRS is used when set.
+func UnitDir(rootless bool) (string, error) {
+	if unitDirs := os.Getenv("QUADLET_UNIT_DIRS"); unitDirs != "" {
+		return strings.Split(unitDirs, ":")[0], nil
+	}
+	if !rootless {
+		return UnitDirAdmin, nil
+	}
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "containers", "systemd"), nil
+}
+
+// ReferencedFiles returns the files referenced by unit with a path relative
+// to the unit file, which have to be installed alongside it
+func ReferencedFiles(unit *parser.UnitFile) ([]string, error) {
+	var refs []string
+	switch filepath.Ext(unit.Filename) {
+	case ".container":
+		refs = unit.LookupAllArgs(ContainerGroup, KeyEnvironmentFile)
+	case ".kube":
+		if yamlPath, ok := unit.Lookup(KubeGroup, KeyYaml); ok {
+			refs = append(refs, yamlPath)
+		}
+		refs = append(refs, unit.LookupAllStrv(KubeGroup, KeyConfigMap)...)
+	}
+
+	assets := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		if ref == "" || filepath.IsAbs(ref) || startsWithSystemdSpecifier(ref) || isURL(ref) {
+			continue
+		}
+		ref = filepath.Clean(ref)
+		if ref == ".." || strings.HasPrefix(ref, "../") {
+			return nil, fmt.Errorf("%s references %q outside of its directory", unit.Filename, ref)
+		}
+		assets = append(assets, ref)
+	}
+	return assets, nil
+}
+
+// FindInstallUnits returns the Quadlet units to install from paths, which are
+// unit files or directories containing unit files
+func FindInstallUnits(paths []string) ([]InstallUnit, error) {
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			if !IsQuadletFile(path) {
+				return nil, fmt.Errorf("%s is not a Quadlet file", path)
+			}
+			files = append(files, path)
+			continue
+		}
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() && IsQuadletFile(entry.Name()) {
+				files = append(files, filepath.Join(path, entry.Name()))
+			}
+		}
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no Quadlet files found in %s", strings.Join(paths, ", "))
+	}
+
+	seen := make(map[string]string, len(files))
+	units := make([]InstallUnit, 0, len(files))
+	for _, file := range files {
+		name := filepath.Base(file)
+		if prev, ok := seen[name]; ok {
+			return nil, fmt.Errorf("Quadlet file %s conflicts with %s", file, prev)
+		}
+		seen[name] = file
+
+		unit, err := parser.ParseUnitFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", file, err)
+		}
+		assets, err := ReferencedFiles(unit)
+		if err != nil {
+			return nil, err
+		}
+		units = append(units, InstallUnit{Path: file, Assets: assets})
+	}
+	return units, nil
+}
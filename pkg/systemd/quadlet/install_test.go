@@ -0,0 +1,109 @@
+package quadlet
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/containers/podman/v5/pkg/systemd/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuadlet_ReferencedFiles(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		data     string
+		assets   []string
+		err      string
+	}{
+		{
+			name:     "container env files",
+			filename: "app.container",
+			data:     "[Container]\nEnvironmentFile=app.env /etc/app.env %h/app.env\nEnvironmentFile=./conf/app.env\n",
+			assets:   []string{"app.env", "conf/app.env"},
+		},
+		{
+			name:     "kube yaml and configmaps",
+			filename: "app.kube",
+			data:     "[Kube]\nYaml=app.yaml\nConfigMap=cm.yaml https://example.com/cm.yaml\n",
+			assets:   []string{"app.yaml", "cm.yaml"},
+		},
+		{
+			name:     "no references",
+			filename: "app.volume",
+			data:     "[Volume]\n",
+			assets:   []string{},
+		},
+		{
+			name:     "outside of the directory",
+			filename: "app.kube",
+			data:     "[Kube]\nYaml=conf/../../app.yaml\n",
+			err:      `app.kube references "../app.yaml" outside of its directory`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unit := parser.NewUnitFile()
+			unit.Filename = tt.filename
+			require.NoError(t, unit.Parse(tt.data))
+			assets, err := ReferencedFiles(unit)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.assets, assets)
+		})
+	}
+}
+
+func TestQuadlet_FindInstallUnits(t *testing.T) {
+	dir := t.TempDir()
+	other := t.TempDir()
+	for path, data := range map[string]string{
+		filepath.Join(dir, "app.container"):   "[Container]\nImage=alpine\nEnvironmentFile=app.env\n",
+		filepath.Join(dir, "app.env"):         "FOO=bar\n",
+		filepath.Join(dir, "README.md"):       "docs\n",
+		filepath.Join(other, "db.volume"):     "[Volume]\n",
+		filepath.Join(other, "app.container"): "[Container]\nImage=alpine\n",
+	} {
+		require.NoError(t, os.WriteFile(path, []byte(data), 0o644))
+	}
+
+	units, err := FindInstallUnits([]string{dir, filepath.Join(other, "db.volume")})
+	require.NoError(t, err)
+	assert.Equal(t, []InstallUnit{
+		{Path: filepath.Join(dir, "app.container"), Assets: []string{"app.env"}},
+		{Path: filepath.Join(other, "db.volume"), Assets: []string{}},
+	}, units)
+
+	_, err = FindInstallUnits([]string{filepath.Join(dir, "README.md")})
+	assert.EqualError(t, err, filepath.Join(dir, "README.md")+" is not a Quadlet file")
+
+	_, err = FindInstallUnits([]string{dir, other})
+	assert.ErrorContains(t, err, "conflicts with")
+
+	_, err = FindInstallUnits([]string{t.TempDir()})
+	assert.ErrorContains(t, err, "no Quadlet files found")
+}
+
+func TestQuadlet_ServiceName(t *testing.T) {
+	for filename, expected := range map[string]string{
+		"app.container": "app.service",
+		"app.pod":       "app-pod.service",
+		"data.volume":   "data-volume.service",
+	} {
+		unit := parser.NewUnitFile()
+		unit.Filename = filename
+		name, err := ServiceName(unit)
+		require.NoError(t, err)
+		assert.Equal(t, expected, name)
+	}
+
+	unit := parser.NewUnitFile()
+	unit.Filename = "app.service"
+	_, err := ServiceName(unit)
+	assert.Error(t, err)
+}
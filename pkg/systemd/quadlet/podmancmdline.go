@@ -1,10 +1,18 @@
 package quadlet
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path"
+	"path/filepath"
 	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/containers/podman/v5/pkg/systemd/parser"
+	"github.com/containers/storage/pkg/regexp"
+	"github.com/docker/go-units"
 )
 
 // Overwritten at build time
@@ -74,3 +82,129 @@ func NewPodmanCmdline(args ...string) *PodmanCmdline {
 	c.add(args...)
 	return c
 }
+
+var validCPUSet = regexp.Delayed(`^\d+(-\d+)?(,\d+(-\d+)?)*$`)
+
+// Resource limit keys, in the order they are added to the podman command line,
+// with the podman option they translate to and the validation of their values.
+// Keys marked as multiple can be listed multiple times and hold a space separated list.
+var resourceLimitKeys = []struct {
+	key      string
+	arg      string
+	multiple bool
+	validate func(string) error
+}{
+	{KeyMemory, "--memory", false, validateMemory},
+	{KeyMemoryReservation, "--memory-reservation", false, validateMemory},
+	{KeyMemorySwap, "--memory-swap", false, validateMemorySwap},
+	{KeyMemorySwappiness, "--memory-swappiness", false, validateMemorySwappiness},
+	{KeyCPUs, "--cpus", false, validateCPUs},
+	{KeyCPUShares, "--cpu-shares", false, validateUint},
+	{KeyCPUPeriod, "--cpu-period", false, validateUint},
+	{KeyCPUQuota, "--cpu-quota", false, validateCPUQuota},
+	{KeyCPUSetCPUs, "--cpuset-cpus", false, validateCPUSet},
+	{KeyCPUSetMems, "--cpuset-mems", false, validateCPUSet},
+	{KeyBlkioWeight, "--blkio-weight", false, validateBlkioWeight},
+	{KeyBlkioWeightDevice, "--blkio-weight-device", true, validateDeviceLimit(validateBlkioWeight)},
+	{KeyDeviceReadBps, "--device-read-bps", true, validateDeviceLimit(validateMemory)},
+	{KeyDeviceWriteBps, "--device-write-bps", true, validateDeviceLimit(validateMemory)},
+	{KeyDeviceReadIOPS, "--device-read-iops", true, validateDeviceLimit(validateUint)},
+	{KeyDeviceWriteIOPS, "--device-write-iops", true, validateDeviceLimit(validateUint)},
+}
+
+// handleResourceLimits adds the podman options for the resource limit keys
+// set in the group, failing on invalid values so they are reported when
+// generating the service rather than when starting it.
+func handleResourceLimits(unitFile *parser.UnitFile, groupName string, podman *PodmanCmdline) error {
+	for _, limit := range resourceLimitKeys {
+		var values []string
+		if limit.multiple {
+			values = unitFile.LookupAllStrv(groupName, limit.key)
+		} else if val, ok := unitFile.Lookup(groupName, limit.key); ok && len(val) > 0 {
+			values = []string{val}
+		}
+		for _, val := range values {
+			if err := limit.validate(val); err != nil {
+				return keyError(groupName, limit.key, fmt.Errorf("invalid %s '%s': %w", limit.key, val, err))
+			}
+			podman.addf("%s=%s", limit.arg, val)
+		}
+	}
+	return nil
+}
+
+func validateMemory(val string) error {
+	size, err := units.RAMInBytes(val)
+	if err != nil {
+		return err
+	}
+	if size < 0 {
+		return errors.New("must not be negative")
+	}
+	return nil
+}
+
+func validateMemorySwap(val string) error {
+	// -1 allows unlimited swap
+	if val == "-1" {
+		return nil
+	}
+	return validateMemory(val)
+}
+
+func validateMemorySwappiness(val string) error {
+	swappiness, err := strconv.ParseUint(val, 10, 64)
+	if err != nil || swappiness > 100 {
+		return errors.New("must be a number between 0 and 100")
+	}
+	return nil
+}
+
+func validateCPUs(val string) error {
+	cpus, err := strconv.ParseFloat(val, 64)
+	if err != nil || !(cpus >= 0) {
+		return errors.New("must be a non-negative number")
+	}
+	return nil
+}
+
+func validateCPUQuota(val string) error {
+	if _, err := strconv.ParseInt(val, 10, 64); err != nil {
+		return errors.New("must be a number")
+	}
+	return nil
+}
+
+func validateCPUSet(val string) error {
+	if !validCPUSet.MatchString(val) {
+		return errors.New("must be a list of numbers or ranges, for example 0-3,8")
+	}
+	return nil
+}
+
+func validateUint(val string) error {
+	if _, err := strconv.ParseUint(val, 10, 64); err != nil {
+		return errors.New("must be a non-negative number")
+	}
+	return nil
+}
+
+func validateBlkioWeight(val string) error {
+	weight, err := strconv.ParseUint(val, 10, 16)
+	if err != nil || weight < 10 || weight > 1000 {
+		return errors.New("must be a number between 10 and 1000")
+	}
+	return nil
+}
+
+// validateDeviceLimit returns the validation of a device:limit pair, with
+// the limit validated by validateLimit.
+func validateDeviceLimit(validateLimit func(string) error) func(string) error {
+	return func(val string) error {
+		device, limit, ok := strings.Cut(val, ":")
+		if !ok || !filepath.IsAbs(device) {
+			return errors.New("must be of the form /path/to/device:limit")
+		}
+		return validateLimit(limit)
+	}
+}
@@ -64,16 +64,28 @@ const (
 	KeyArch                  = "Arch"
 	KeyAuthFile              = "AuthFile"
 	KeyAutoUpdate            = "AutoUpdate"
+	KeyBlkioWeight           = "BlkioWeight"
+	KeyBlkioWeightDevice     = "BlkioWeightDevice"
 	KeyCertDir               = "CertDir"
 	KeyCgroupsMode           = "CgroupsMode"
 	KeyConfigMap             = "ConfigMap"
 	KeyContainerName         = "ContainerName"
 	KeyContainersConfModule  = "ContainersConfModule"
 	KeyCopy                  = "Copy"
+	KeyCPUPeriod             = "CPUPeriod"
+	KeyCPUQuota              = "CPUQuota"
+	KeyCPUs                  = "CPUs"
+	KeyCPUSetCPUs            = "CPUSetCPUs"
+	KeyCPUSetMems            = "CPUSetMems"
+	KeyCPUShares             = "CPUShares"
 	KeyCreds                 = "Creds"
 	KeyDecryptionKey         = "DecryptionKey"
 	KeyDefaultDependencies   = "DefaultDependencies"
 	KeyDevice                = "Device"
+	KeyDeviceReadBps         = "DeviceReadBps"
+	KeyDeviceReadIOPS        = "DeviceReadIOPS"
+	KeyDeviceWriteBps        = "DeviceWriteBps"
+	KeyDeviceWriteIOPS       = "DeviceWriteIOPS"
 	KeyDisableDNS            = "DisableDNS"
 	KeyDNS                   = "DNS"
 	KeyDNSOption             = "DNSOption"
@@ -122,6 +134,10 @@ const (
 	KeyLogDriver             = "LogDriver"
 	KeyLogOpt                = "LogOpt"
 	KeyMask                  = "Mask"
+	KeyMemory                = "Memory"
+	KeyMemoryReservation     = "MemoryReservation"
+	KeyMemorySwap            = "MemorySwap"
+	KeyMemorySwappiness      = "MemorySwappiness"
 	KeyMount                 = "Mount"
 	KeyNetwork               = "Network"
 	KeyNetworkAlias          = "NetworkAlias"
@@ -191,6 +207,18 @@ type UnitInfo struct {
 }
 
 var (
+	// Key: Extension
+	// Value: Processing order for resource naming dependencies
+	SupportedExtensions = map[string]int{
+		".container": 4,
+		".volume":    2,
+		".kube":      4,
+		".network":   2,
+		".image":     1,
+		".build":     3,
+		".pod":       5,
+	}
+
 	URL            = regexp.Delayed(`^((https?)|(git)://)|(github\.com/).+$`)
 	validPortRange = regexp.Delayed(`\d+(-\d+)?(/udp|/tcp)?$`)
 
@@ -201,9 +229,21 @@ var (
 		KeyAddHost:               true,
 		KeyAnnotation:            true,
 		KeyAutoUpdate:            true,
+		KeyBlkioWeight:           true,
+		KeyBlkioWeightDevice:     true,
 		KeyCgroupsMode:           true,
 		KeyContainerName:         true,
 		KeyContainersConfModule:  true,
+		KeyCPUPeriod:             true,
+		KeyCPUQuota:              true,
+		KeyCPUs:                  true,
+		KeyCPUSetCPUs:            true,
+		KeyCPUSetMems:            true,
+		KeyCPUShares:             true,
+		KeyDeviceReadBps:         true,
+		KeyDeviceReadIOPS:        true,
+		KeyDeviceWriteBps:        true,
+		KeyDeviceWriteIOPS:       true,
 		KeyDNS:                   true,
 		KeyDNSOption:             true,
 		KeyDNSSearch:             true,
@@ -240,6 +280,10 @@ var (
 		KeyLogDriver:             true,
 		KeyLogOpt:                true,
 		KeyMask:                  true,
+		KeyMemory:                true,
+		KeyMemoryReservation:     true,
+		KeyMemorySwap:            true,
+		KeyMemorySwappiness:      true,
 		KeyMount:                 true,
 		KeyNetwork:               true,
 		KeyNetworkAlias:          true,
@@ -395,7 +439,15 @@ var (
 
 	supportedPodKeys = map[string]bool{
 		KeyAddHost:              true,
+		KeyBlkioWeight:          true,
+		KeyBlkioWeightDevice:    true,
 		KeyContainersConfModule: true,
+		KeyCPUs:                 true,
+		KeyCPUSetCPUs:           true,
+		KeyCPUSetMems:           true,
+		KeyCPUShares:            true,
+		KeyDeviceReadBps:        true,
+		KeyDeviceWriteBps:       true,
 		KeyDNS:                  true,
 		KeyDNSOption:            true,
 		KeyDNSSearch:            true,
@@ -403,6 +455,8 @@ var (
 		KeyGlobalArgs:           true,
 		KeyIP:                   true,
 		KeyIP6:                  true,
+		KeyMemory:               true,
+		KeyMemorySwap:           true,
 		KeyNetwork:              true,
 		KeyNetworkAlias:         true,
 		KeyPodName:              true,
@@ -453,7 +507,7 @@ func checkForUnknownKeysInSpecificGroup(unit *parser.UnitFile, groupName string,
 	keys := unit.ListKeys(groupName)
 	for _, key := range keys {
 		if !supportedKeys[key] {
-			return fmt.Errorf("unsupported key '%s' in group '%s' in %s", key, groupName, unit.Path)
+			return keyError(groupName, key, fmt.Errorf("unsupported key '%s' in group '%s' in %s", key, groupName, unit.Path))
 		}
 	}
 
@@ -568,7 +622,7 @@ func ConvertContainer(container *parser.UnitFile, isUser bool, unitsInfoMap map[
 	killMode, ok := service.Lookup(ServiceGroup, "KillMode")
 	if !ok || !(killMode == "mixed" || killMode == "control-group") {
 		if ok {
-			return nil, fmt.Errorf("invalid KillMode '%s'", killMode)
+			return nil, keyError(ServiceGroup, "KillMode", fmt.Errorf("invalid KillMode '%s'", killMode))
 		}
 
 		// We default to mixed instead of control-group, because it lets conmon do its thing
@@ -662,7 +716,7 @@ func ConvertContainer(container *parser.UnitFile, isUser bool, unitsInfoMap map[
 
 	serviceType, ok := service.Lookup(ServiceGroup, "Type")
 	if ok && serviceType != "notify" && serviceType != "oneshot" {
-		return nil, fmt.Errorf("invalid service Type '%s'", serviceType)
+		return nil, keyError(ServiceGroup, "Type", fmt.Errorf("invalid service Type '%s'", serviceType))
 	}
 
 	if serviceType != "oneshot" {
@@ -790,7 +844,7 @@ func ConvertContainer(container *parser.UnitFile, isUser bool, unitsInfoMap map[
 		exposedPort = strings.TrimSpace(exposedPort) // Allow whitespace after
 
 		if !isPortRange(exposedPort) {
-			return nil, fmt.Errorf("invalid port format '%s'", exposedPort)
+			return nil, keyError(ContainerGroup, KeyExposeHostPort, fmt.Errorf("invalid port format '%s'", exposedPort))
 		}
 
 		podman.add("--expose", exposedPort)
@@ -841,6 +895,10 @@ func ConvertContainer(container *parser.UnitFile, isUser bool, unitsInfoMap map[
 
 	handleHealth(container, ContainerGroup, podman)
 
+	if err := handleResourceLimits(container, ContainerGroup, podman); err != nil {
+		return nil, err
+	}
+
 	if err := handlePod(container, service, ContainerGroup, unitsInfoMap, podman); err != nil {
 		return nil, err
 	}
@@ -975,10 +1033,10 @@ func ConvertNetwork(network *parser.UnitFile, name string, unitsInfoMap map[stri
 	ipRanges := network.LookupAll(NetworkGroup, KeyIPRange)
 	if len(subnets) > 0 {
 		if len(gateways) > len(subnets) {
-			return nil, fmt.Errorf("cannot set more gateways than subnets")
+			return nil, keyError(NetworkGroup, KeyGateway, fmt.Errorf("cannot set more gateways than subnets"))
 		}
 		if len(ipRanges) > len(subnets) {
-			return nil, fmt.Errorf("cannot set more ranges than subnets")
+			return nil, keyError(NetworkGroup, KeyIPRange, fmt.Errorf("cannot set more ranges than subnets"))
 		}
 		for i := range subnets {
 			podman.add("--subnet", subnets[i])
@@ -990,7 +1048,11 @@ func ConvertNetwork(network *parser.UnitFile, name string, unitsInfoMap map[stri
 			}
 		}
 	} else if len(ipRanges) > 0 || len(gateways) > 0 {
-		return nil, fmt.Errorf("cannot set gateway or range without subnet")
+		key := KeyGateway
+		if len(ipRanges) > 0 {
+			key = KeyIPRange
+		}
+		return nil, keyError(NetworkGroup, key, fmt.Errorf("cannot set gateway or range without subnet"))
 	}
 
 	networkOptions := network.LookupAllKeyVal(NetworkGroup, KeyOptions)
@@ -1122,7 +1184,7 @@ func ConvertVolume(volume *parser.UnitFile, name string, unitsInfoMap map[string
 			if devValid {
 				podman.add("--opt", fmt.Sprintf("type=%s", devType))
 			} else {
-				return nil, fmt.Errorf("key Type can't be used without Device")
+				return nil, keyError(VolumeGroup, KeyType, fmt.Errorf("key Type can't be used without Device"))
 			}
 		}
 
@@ -1134,7 +1196,7 @@ func ConvertVolume(volume *parser.UnitFile, name string, unitsInfoMap map[string
 				}
 				opts.WriteString(mountOpts)
 			} else {
-				return nil, fmt.Errorf("key Options can't be used without Device")
+				return nil, keyError(VolumeGroup, KeyOptions, fmt.Errorf("key Options can't be used without Device"))
 			}
 		}
 	}
@@ -1198,7 +1260,7 @@ func ConvertKube(kube *parser.UnitFile, unitsInfoMap map[string]*UnitInfo, isUse
 	killMode, ok := service.Lookup(ServiceGroup, "KillMode")
 	if !ok || !(killMode == "mixed" || killMode == "control-group") {
 		if ok {
-			return nil, fmt.Errorf("invalid KillMode '%s'", killMode)
+			return nil, keyError(ServiceGroup, "KillMode", fmt.Errorf("invalid KillMode '%s'", killMode))
 		}
 
 		// We default to mixed instead of control-group, because it lets conmon do its thing
@@ -1214,7 +1276,7 @@ func ConvertKube(kube *parser.UnitFile, unitsInfoMap map[string]*UnitInfo, isUse
 	// Allow users to set the Service Type to oneshot to allow resources only kube yaml
 	serviceType, ok := service.Lookup(ServiceGroup, "Type")
 	if ok && serviceType != "notify" && serviceType != "oneshot" {
-		return nil, fmt.Errorf("invalid service Type '%s'", serviceType)
+		return nil, keyError(ServiceGroup, "Type", fmt.Errorf("invalid service Type '%s'", serviceType))
 	}
 
 	if serviceType != "oneshot" {
@@ -1544,6 +1606,52 @@ func getServiceName(quadletUnitFile *parser.UnitFile, groupName string, defaultE
 	return removeExtension(quadletUnitFile.Filename, "", defaultExtraSuffix)
 }
 
+// GenerateUnitsInfoMap returns the information about the services and
+// resources of the units, which units need to refer to each other
+func GenerateUnitsInfoMap(units []*parser.UnitFile) map[string]*UnitInfo {
+	unitsInfoMap := make(map[string]*UnitInfo)
+	for _, unit := range units {
+		var serviceName string
+		var containers []string
+		var resourceName string
+
+		switch {
+		case strings.HasSuffix(unit.Filename, ".container"):
+			serviceName = GetContainerServiceName(unit)
+			// Prefill resouceNames for .container files. This solves network reusing.
+			resourceName = GetContainerResourceName(unit)
+		case strings.HasSuffix(unit.Filename, ".volume"):
+			serviceName = GetVolumeServiceName(unit)
+		case strings.HasSuffix(unit.Filename, ".kube"):
+			serviceName = GetKubeServiceName(unit)
+		case strings.HasSuffix(unit.Filename, ".network"):
+			serviceName = GetNetworkServiceName(unit)
+		case strings.HasSuffix(unit.Filename, ".image"):
+			serviceName = GetImageServiceName(unit)
+		case strings.HasSuffix(unit.Filename, ".build"):
+			serviceName = GetBuildServiceName(unit)
+			// Prefill resouceNames for .build files. This is significantly less complex than
+			// pre-computing all resourceNames for all Quadlet types (which is rather complex for a few
+			// types), but still breaks the dependency cycle between .volume and .build ([Volume] can
+			// have Image=some.build, and [Build] can have Volume=some.volume:/some-volume)
+			resourceName = GetBuiltImageName(unit)
+		case strings.HasSuffix(unit.Filename, ".pod"):
+			serviceName = GetPodServiceName(unit)
+			containers = make([]string, 0)
+		default:
+			continue
+		}
+
+		unitsInfoMap[unit.Filename] = &UnitInfo{
+			ServiceName:       serviceName,
+			ContainersToStart: containers,
+			ResourceName:      resourceName,
+		}
+	}
+
+	return unitsInfoMap
+}
+
 func ConvertPod(podUnit *parser.UnitFile, name string, unitsInfoMap map[string]*UnitInfo, isUser bool) (*parser.UnitFile, error) {
 	unitInfo, ok := unitsInfoMap[podUnit.Filename]
 	if !ok {
@@ -1647,6 +1755,10 @@ func ConvertPod(podUnit *parser.UnitFile, name string, unitsInfoMap map[string]*
 		return nil, err
 	}
 
+	if err := handleResourceLimits(podUnit, PodGroup, execStartPre); err != nil {
+		return nil, err
+	}
+
 	execStartPre.add("--infra-name", fmt.Sprintf("%s-infra", podName))
 	execStartPre.add("--name", podName)
 
@@ -1673,7 +1785,7 @@ func handleUser(unitFile *parser.UnitFile, groupName string, podman *PodmanCmdli
 
 	if !okUser {
 		if okGroup {
-			return fmt.Errorf("invalid Group set without User")
+			return keyError(groupName, KeyGroup, fmt.Errorf("invalid Group set without User"))
 		}
 		return nil
 	}
@@ -1739,10 +1851,10 @@ func handleUserRemap(unitFile *parser.UnitFile, groupName string, podman *Podman
 	switch remapUsers {
 	case "":
 		if len(uidMaps) > 0 {
-			return fmt.Errorf("UidMap set without RemapUsers")
+			return keyError(groupName, KeyRemapUid, fmt.Errorf("UidMap set without RemapUsers"))
 		}
 		if len(gidMaps) > 0 {
-			return fmt.Errorf("GidMap set without RemapUsers")
+			return keyError(groupName, KeyRemapGid, fmt.Errorf("GidMap set without RemapUsers"))
 		}
 	case "manual":
 		if supportManual {
@@ -1753,7 +1865,7 @@ func handleUserRemap(unitFile *parser.UnitFile, groupName string, podman *Podman
 				podman.add("--gidmap", gidMap)
 			}
 		} else {
-			return fmt.Errorf("RemapUsers=manual is not supported")
+			return keyError(groupName, KeyRemapUsers, fmt.Errorf("RemapUsers=manual is not supported"))
 		}
 	case "auto":
 		autoOpts := make([]string, 0)
@@ -1773,13 +1885,13 @@ func handleUserRemap(unitFile *parser.UnitFile, groupName string, podman *Podman
 		keepidOpts := make([]string, 0)
 		if len(uidMaps) > 0 {
 			if len(uidMaps) > 1 {
-				return fmt.Errorf("RemapUsers=keep-id supports only a single value for UID mapping")
+				return keyError(groupName, KeyRemapUid, fmt.Errorf("RemapUsers=keep-id supports only a single value for UID mapping"))
 			}
 			keepidOpts = append(keepidOpts, "uid="+uidMaps[0])
 		}
 		if len(gidMaps) > 0 {
 			if len(gidMaps) > 1 {
-				return fmt.Errorf("RemapUsers=keep-id supports only a single value for GID mapping")
+				return keyError(groupName, KeyRemapGid, fmt.Errorf("RemapUsers=keep-id supports only a single value for GID mapping"))
 			}
 			keepidOpts = append(keepidOpts, "gid="+gidMaps[0])
 		}
@@ -1787,7 +1899,7 @@ func handleUserRemap(unitFile *parser.UnitFile, groupName string, podman *Podman
 		podman.add("--userns", usernsOpts("keep-id", keepidOpts))
 
 	default:
-		return fmt.Errorf("unsupported RemapUsers option '%s'", remapUsers)
+		return keyError(groupName, KeyRemapUsers, fmt.Errorf("unsupported RemapUsers option '%s'", remapUsers))
 	}
 
 	return nil
@@ -1805,7 +1917,7 @@ func addNetworks(quadletUnitFile *parser.UnitFile, groupName string, serviceUnit
 			if isNetworkUnit || isContainerUnit {
 				unitInfo, ok := unitsInfoMap[quadletNetworkName]
 				if !ok {
-					return fmt.Errorf("requested Quadlet unit %s was not found", quadletNetworkName)
+					return keyError(groupName, KeyNetwork, fmt.Errorf("requested Quadlet unit %s was not found", quadletNetworkName))
 				}
 
 				// XXX: this is usually because a '@' in service name
@@ -1821,7 +1933,7 @@ func addNetworks(quadletUnitFile *parser.UnitFile, groupName string, serviceUnit
 
 				if found {
 					if isContainerUnit {
-						return fmt.Errorf("extra options are not supported when joining another container's network")
+						return keyError(groupName, KeyNetwork, fmt.Errorf("extra options are not supported when joining another container's network"))
 					}
 					network = fmt.Sprintf("%s:%s", unitInfo.ResourceName, options)
 				} else {
@@ -2140,12 +2252,12 @@ func handlePod(quadletUnitFile, serviceUnitFile *parser.UnitFile, groupName stri
 	pod, ok := quadletUnitFile.Lookup(groupName, KeyPod)
 	if ok && len(pod) > 0 {
 		if !strings.HasSuffix(pod, ".pod") {
-			return fmt.Errorf("pod %s is not Quadlet based", pod)
+			return keyError(groupName, KeyPod, fmt.Errorf("pod %s is not Quadlet based", pod))
 		}
 
 		podInfo, ok := unitsInfoMap[pod]
 		if !ok {
-			return fmt.Errorf("quadlet pod unit %s does not exist", pod)
+			return keyError(groupName, KeyPod, fmt.Errorf("quadlet pod unit %s does not exist", pod))
 		}
 
 		podman.add("--pod-id-file", fmt.Sprintf("%%t/%s.pod-id", podInfo.ServiceName))
@@ -3,6 +3,7 @@ package quadlet
 import (
 	"testing"
 
+	"github.com/containers/podman/v5/pkg/systemd/parser"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -43,3 +44,40 @@ func TestQuadlet_SplitPorts(t *testing.T) {
 	assert.Equal(t, parts[0], "foo")
 	assert.Equal(t, parts[1], "abc[foo::barxyz:bar")
 }
+
+func TestQuadlet_HandleResourceLimits(t *testing.T) {
+	tests := []struct {
+		key   string
+		value string
+		arg   string
+		err   string
+	}{
+		{KeyMemory, "512m", "--memory=512m", ""},
+		{KeyMemory, "-5", "", "invalid Memory '-5'"},
+		{KeyMemorySwap, "-1", "--memory-swap=-1", ""},
+		{KeyMemorySwappiness, "101", "", "invalid MemorySwappiness '101': must be a number between 0 and 100"},
+		{KeyCPUs, "0.5", "--cpus=0.5", ""},
+		{KeyCPUs, "-1", "", "invalid CPUs '-1': must be a non-negative number"},
+		{KeyCPUQuota, "-1", "--cpu-quota=-1", ""},
+		{KeyCPUSetCPUs, "0-3,8", "--cpuset-cpus=0-3,8", ""},
+		{KeyCPUSetCPUs, "0-", "", "invalid CPUSetCPUs '0-'"},
+		{KeyBlkioWeight, "1001", "", "invalid BlkioWeight '1001': must be a number between 10 and 1000"},
+		{KeyDeviceReadBps, "/dev/sda:1mb", "--device-read-bps=/dev/sda:1mb", ""},
+		{KeyDeviceReadBps, "sda:1mb", "", "invalid DeviceReadBps 'sda:1mb': must be of the form /path/to/device:limit"},
+		{KeyDeviceWriteIOPS, "/dev/sda:abc", "", "invalid DeviceWriteIOPS '/dev/sda:abc': must be a non-negative number"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key+"="+tt.value, func(t *testing.T) {
+			unit := parser.NewUnitFile()
+			unit.Add(ContainerGroup, tt.key, tt.value)
+			podman := NewPodmanCmdline()
+			err := handleResourceLimits(unit, ContainerGroup, podman)
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, []string{podmanBinary(), tt.arg}, podman.Args)
+		})
+	}
+}
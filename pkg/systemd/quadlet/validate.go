@@ -0,0 +1,392 @@
+package quadlet
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/containers/podman/v5/pkg/specgenutilexternal"
+	"github.com/containers/podman/v5/pkg/systemd/parser"
+)
+
+// Severity of a problem found in a unit file
+type Severity string
+
+const (
+	// SeverityError is used for problems which prevent generating the service
+	SeverityError Severity = "error"
+	// SeverityWarning is used for problems which likely are mistakes
+	SeverityWarning Severity = "warning"
+)
+
+// Diagnostic describes a problem found in a unit file or one of its drop-ins
+type Diagnostic struct {
+	// File is the path of the unit or drop-in file the problem is in
+	File string `json:"file"`
+	// Line is the number of the line the problem is in, if known
+	Line int `json:"line,omitempty"`
+	// Group and Key are the key the problem is in, if any
+	Group    string   `json:"group,omitempty"`
+	Key      string   `json:"key,omitempty"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+}
+
+func (d Diagnostic) String() string {
+	location := d.File
+	if d.Line > 0 {
+		location = fmt.Sprintf("%s:%d", location, d.Line)
+	}
+	if d.Key != "" {
+		return fmt.Sprintf("%s: %s: [%s] %s: %s", location, d.Severity, d.Group, d.Key, d.Message)
+	}
+	return fmt.Sprintf("%s: %s: %s", location, d.Severity, d.Message)
+}
+
+// KeyError is an error caused by the value of a key in a unit file
+type KeyError struct {
+	Group string
+	Key   string
+	Err   error
+}
+
+func (e *KeyError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *KeyError) Unwrap() error {
+	return e.Err
+}
+
+func keyError(group, key string, err error) error {
+	return &KeyError{Group: group, Key: key, Err: err}
+}
+
+// unitTypes maps the extensions of unit files to their main group and the
+// keys supported in it
+var unitTypes = map[string]struct {
+	group string
+	keys  map[string]bool
+}{
+	".container": {ContainerGroup, supportedContainerKeys},
+	".volume":    {VolumeGroup, supportedVolumeKeys},
+	".kube":      {KubeGroup, supportedKubeKeys},
+	".network":   {NetworkGroup, supportedNetworkKeys},
+	".image":     {ImageGroup, supportedImageKeys},
+	".build":     {BuildGroup, supportedBuildKeys},
+	".pod":       {PodGroup, supportedPodKeys},
+}
+
+// unitReference is a reference from a key of a unit to another unit
+type unitReference struct {
+	group string
+	key   string
+	unit  string
+}
+
+// keyDiagnostic returns a diagnostic located at the last instance of the key
+func keyDiagnostic(unit *parser.UnitFile, group, key string, severity Severity, msg string) Diagnostic {
+	d := Diagnostic{
+		File:     unit.Path,
+		Group:    group,
+		Key:      key,
+		Severity: severity,
+		Message:  msg,
+	}
+	if path, line, ok := unit.LookupLocation(group, key); ok {
+		if path != "" {
+			d.File = path
+		}
+		d.Line = line
+	}
+	return d
+}
+
+// ErrorDiagnostic converts an error of loading or converting unit to a
+// diagnostic, located at the key or line which caused it if known
+func ErrorDiagnostic(path string, unit *parser.UnitFile, err error) Diagnostic {
+	var keyErr *KeyError
+	if unit != nil && errors.As(err, &keyErr) {
+		return keyDiagnostic(unit, keyErr.Group, keyErr.Key, SeverityError, err.Error())
+	}
+	d := Diagnostic{File: path, Severity: SeverityError, Message: err.Error()}
+	var parseErr *parser.ParseError
+	if errors.As(err, &parseErr) {
+		d.Line = parseErr.Line
+		d.Message = parseErr.Err.Error()
+	}
+	return d
+}
+
+// checkUnitKeys reports unknown groups and keys in unit
+func checkUnitKeys(unit *parser.UnitFile) []Diagnostic {
+	unitType := unitTypes[filepath.Ext(unit.Filename)]
+	var diags []Diagnostic
+	for _, group := range unit.ListGroups() {
+		var supportedKeys map[string]bool
+		switch {
+		case group == unitType.group:
+			supportedKeys = unitType.keys
+		case group == QuadletGroup:
+			supportedKeys = supportedQuadletKeys
+		case group == "" || group == UnitGroup || group == ServiceGroup || group == InstallGroup || strings.HasPrefix(group, "X-"):
+			continue
+		default:
+			diags = append(diags, Diagnostic{
+				File:     unit.Path,
+				Group:    group,
+				Severity: SeverityWarning,
+				Message:  fmt.Sprintf("unknown group '%s' is ignored", group),
+			})
+			continue
+		}
+		for _, key := range unit.ListKeys(group) {
+			if !supportedKeys[key] {
+				diags = append(diags, keyDiagnostic(unit, group, key, SeverityError, fmt.Sprintf("unsupported key '%s' in group '%s'", key, group)))
+			}
+		}
+	}
+	return diags
+}
+
+// unitReferences returns the references of unit to other Quadlet units
+func unitReferences(unit *parser.UnitFile) []unitReference {
+	var refs []unitReference
+	add := func(group, key, name string, extensions ...string) {
+		for _, ext := range extensions {
+			if strings.HasSuffix(name, ext) {
+				refs = append(refs, unitReference{group: group, key: key, unit: name})
+				return
+			}
+		}
+	}
+	addNetworks := func(group string) {
+		for _, network := range unit.LookupAll(group, KeyNetwork) {
+			name, _, _ := strings.Cut(network, ":")
+			add(group, KeyNetwork, name, ".network", ".container")
+		}
+	}
+	addVolumes := func(group string) {
+		for _, volume := range unit.LookupAll(group, KeyVolume) {
+			source, _, _ := strings.Cut(volume, ":")
+			add(group, KeyVolume, source, ".volume")
+		}
+	}
+
+	group := unitTypes[filepath.Ext(unit.Filename)].group
+	switch group {
+	case ContainerGroup:
+		if image, ok := unit.Lookup(group, KeyImage); ok {
+			add(group, KeyImage, image, ".image", ".build")
+		}
+		if pod, ok := unit.Lookup(group, KeyPod); ok {
+			add(group, KeyPod, pod, ".pod")
+		}
+		addNetworks(group)
+		addVolumes(group)
+		for _, mount := range unit.LookupAllArgs(group, KeyMount) {
+			_, tokens, err := specgenutilexternal.FindMountType(mount)
+			if err != nil {
+				continue
+			}
+			for _, token := range tokens {
+				if key, val, _ := strings.Cut(token, "="); key == "source" || key == "src" {
+					add(group, KeyMount, val, ".volume", ".image")
+				}
+			}
+		}
+	case VolumeGroup:
+		if image, ok := unit.Lookup(group, KeyImage); ok {
+			add(group, KeyImage, image, ".image", ".build")
+		}
+	case PodGroup, BuildGroup:
+		addNetworks(group)
+		addVolumes(group)
+	case KubeGroup:
+		addNetworks(group)
+	}
+	return refs
+}
+
+// findDependencyCycles returns the dependency cycles between the units, each
+// starting at its lowest unit name
+func findDependencyCycles(deps map[string][]string) [][]string {
+	names := make([]string, 0, len(deps))
+	for name := range deps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int, len(deps))
+	seen := make(map[string]bool)
+	var cycles [][]string
+	var stack []string
+	var visit func(name string)
+	visit = func(name string) {
+		state[name] = visiting
+		stack = append(stack, name)
+		for _, dep := range deps[name] {
+			switch state[dep] {
+			case unvisited:
+				if _, ok := deps[dep]; ok {
+					visit(dep)
+				}
+			case visiting:
+				// Found a cycle, rotate it to start at its lowest name
+				// to report it only once
+				start := len(stack) - 1
+				for stack[start] != dep {
+					start--
+				}
+				cycle := append([]string{}, stack[start:]...)
+				lowest := 0
+				for i := range cycle {
+					if cycle[i] < cycle[lowest] {
+						lowest = i
+					}
+				}
+				cycle = append(cycle[lowest:], cycle[:lowest]...)
+				if key := strings.Join(cycle, " "); !seen[key] {
+					seen[key] = true
+					cycles = append(cycles, cycle)
+				}
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[name] = visited
+	}
+	for _, name := range names {
+		if state[name] == unvisited {
+			visit(name)
+		}
+	}
+	return cycles
+}
+
+// ConvertUnit converts a Quadlet unit to the systemd service for it
+func ConvertUnit(unit *parser.UnitFile, unitsInfoMap map[string]*UnitInfo, isUser bool) (*parser.UnitFile, error) {
+	switch filepath.Ext(unit.Filename) {
+	case ".container":
+		return ConvertContainer(unit, isUser, unitsInfoMap)
+	case ".volume":
+		return ConvertVolume(unit, unit.Filename, unitsInfoMap, isUser)
+	case ".kube":
+		return ConvertKube(unit, unitsInfoMap, isUser)
+	case ".network":
+		return ConvertNetwork(unit, unit.Filename, unitsInfoMap, isUser)
+	case ".image":
+		return ConvertImage(unit, unitsInfoMap, isUser)
+	case ".build":
+		return ConvertBuild(unit, unitsInfoMap, isUser)
+	case ".pod":
+		return ConvertPod(unit, unit.Filename, unitsInfoMap, isUser)
+	default:
+		return nil, fmt.Errorf("unsupported file type %q", unit.Filename)
+	}
+}
+
+// convertUnitErrors converts unit and returns a diagnostic for every invalid
+// value found. As the conversion stops at the first error, every key which
+// failed is removed from a copy of the unit before converting it again, so
+// each key is reported at most once. The unknown keys were already reported
+// and are removed up front.
+func convertUnitErrors(unit *parser.UnitFile, unitsInfoMap map[string]*UnitInfo, isUser bool, unknownKeys []Diagnostic) []Diagnostic {
+	remaining := unit.Dup()
+	remaining.Path = unit.Path
+	for _, d := range unknownKeys {
+		if d.Key != "" {
+			remaining.Unset(d.Group, d.Key)
+		}
+	}
+
+	var diags []Diagnostic
+	for {
+		_, err := ConvertUnit(remaining, unitsInfoMap, isUser)
+		if err == nil {
+			return diags
+		}
+		diags = append(diags, ErrorDiagnostic(unit.Path, unit, err))
+		var keyErr *KeyError
+		if !errors.As(err, &keyErr) || !remaining.HasKey(keyErr.Group, keyErr.Key) {
+			// The error is not caused by a key which can be
+			// removed, so the conversion can not get any further
+			return diags
+		}
+		remaining.Unset(keyErr.Group, keyErr.Key)
+	}
+}
+
+// ValidateUnits checks the units, with their drop-ins merged, and returns all
+// problems found: unknown groups and keys, references to units which do not
+// exist, dependency cycles between units and invalid values. The diagnostics
+// are sorted by file and line.
+func ValidateUnits(units []*parser.UnitFile, isUser bool) []Diagnostic {
+	diags := []Diagnostic{}
+	unknownKeys := make(map[string][]Diagnostic, len(units))
+	unitsByName := make(map[string]*parser.UnitFile, len(units))
+	for _, unit := range units {
+		unitsByName[unit.Filename] = unit
+	}
+
+	deps := make(map[string][]string, len(units))
+	missing := make(map[string]bool)
+	for _, unit := range units {
+		found := checkUnitKeys(unit)
+		unknownKeys[unit.Filename] = found
+		for _, ref := range unitReferences(unit) {
+			if _, ok := unitsByName[ref.unit]; !ok {
+				found = append(found, keyDiagnostic(unit, ref.group, ref.key, SeverityError, fmt.Sprintf("requested Quadlet unit %s was not found", ref.unit)))
+				missing[ref.unit] = true
+				continue
+			}
+			deps[unit.Filename] = append(deps[unit.Filename], ref.unit)
+		}
+		if _, ok := deps[unit.Filename]; !ok {
+			deps[unit.Filename] = nil
+		}
+		diags = append(diags, found...)
+	}
+
+	for _, cycle := range findDependencyCycles(deps) {
+		diags = append(diags, Diagnostic{
+			File:     unitsByName[cycle[0]].Path,
+			Severity: SeverityError,
+			Message:  fmt.Sprintf("dependency cycle: %s -> %s", strings.Join(cycle, " -> "), cycle[0]),
+		})
+	}
+
+	// Convert all units, in the order of the generator, to find invalid
+	// values. Missing units get a placeholder so that the units referring
+	// to them can still be checked.
+	sorted := make([]*parser.UnitFile, len(units))
+	copy(sorted, units)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return SupportedExtensions[filepath.Ext(sorted[i].Filename)] < SupportedExtensions[filepath.Ext(sorted[j].Filename)]
+	})
+	unitsInfoMap := GenerateUnitsInfoMap(sorted)
+	for name := range missing {
+		unitsInfoMap[name] = &UnitInfo{
+			ServiceName:       removeExtension(name, "", ""),
+			ResourceName:      removeExtension(name, "systemd-", ""),
+			ContainersToStart: []string{},
+		}
+	}
+	for _, unit := range sorted {
+		diags = append(diags, convertUnitErrors(unit, unitsInfoMap, isUser, unknownKeys[unit.Filename])...)
+	}
+
+	sort.SliceStable(diags, func(i, j int) bool {
+		if diags[i].File != diags[j].File {
+			return diags[i].File < diags[j].File
+		}
+		return diags[i].Line < diags[j].Line
+	})
+	return diags
+}
@@ -0,0 +1,146 @@
+package quadlet
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/containers/podman/v5/pkg/systemd/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func parseTestUnit(t *testing.T, path, data string) *parser.UnitFile {
+	unit := parser.NewUnitFile()
+	unit.Filename = filepath.Base(path)
+	unit.Path = filepath.Join("/quadlets", path)
+	require.NoError(t, unit.Parse(data))
+	return unit
+}
+
+func TestQuadlet_ValidateUnits(t *testing.T) {
+	tests := []struct {
+		name  string
+		units map[string]string
+		diags []Diagnostic
+	}{
+		{
+			name: "valid units",
+			units: map[string]string{
+				"app.container": "[Container]\nImage=app.image\nNetwork=app.network\nVolume=app.volume:/data\n",
+				"app.image":     "[Image]\nImage=quay.io/libpod/alpine\n",
+				"app.network":   "[Network]\n",
+				"app.volume":    "[Volume]\n",
+			},
+			diags: []Diagnostic{},
+		},
+		{
+			name: "unknown keys and groups",
+			units: map[string]string{
+				"app.container": "[Container]\nImage=quay.io/libpod/alpine\nMemmory=1g\n[X-Custom]\nFoo=bar\n[Custom]\nFoo=bar\n",
+			},
+			diags: []Diagnostic{
+				{File: "/quadlets/app.container", Severity: SeverityWarning, Group: "Custom", Message: "unknown group 'Custom' is ignored"},
+				{File: "/quadlets/app.container", Line: 3, Group: ContainerGroup, Key: "Memmory", Severity: SeverityError, Message: "unsupported key 'Memmory' in group 'Container'"},
+			},
+		},
+		{
+			name: "missing references",
+			units: map[string]string{
+				"app.container": "[Container]\nImage=quay.io/libpod/alpine\nPod=app.pod\nNetwork=app.network:ip=10.0.0.2\nMount=type=volume,source=app.volume,destination=/data\n",
+			},
+			diags: []Diagnostic{
+				{File: "/quadlets/app.container", Line: 3, Group: ContainerGroup, Key: KeyPod, Severity: SeverityError, Message: "requested Quadlet unit app.pod was not found"},
+				{File: "/quadlets/app.container", Line: 4, Group: ContainerGroup, Key: KeyNetwork, Severity: SeverityError, Message: "requested Quadlet unit app.network was not found"},
+				{File: "/quadlets/app.container", Line: 5, Group: ContainerGroup, Key: KeyMount, Severity: SeverityError, Message: "requested Quadlet unit app.volume was not found"},
+			},
+		},
+		{
+			name: "dependency cycle",
+			units: map[string]string{
+				"data.volume": "[Volume]\nDriver=image\nImage=app.build\n",
+				"app.build":   "[Build]\nImageTag=localhost/app\nFile=/src/Containerfile\nVolume=data.volume:/data\n",
+			},
+			diags: []Diagnostic{
+				{File: "/quadlets/app.build", Severity: SeverityError, Message: "dependency cycle: app.build -> data.volume -> app.build"},
+			},
+		},
+		{
+			name: "invalid value",
+			units: map[string]string{
+				"app.container": "[Container]\nImage=quay.io/libpod/alpine\nExposeHostPort=foo\n",
+			},
+			diags: []Diagnostic{
+				{File: "/quadlets/app.container", Line: 3, Group: ContainerGroup, Key: KeyExposeHostPort, Severity: SeverityError, Message: "invalid port format 'foo'"},
+			},
+		},
+		{
+			name: "all invalid values",
+			units: map[string]string{
+				"app.container": "[Container]\nImage=quay.io/libpod/alpine\nExposeHostPort=foo\nCPUs=-1\n[Service]\nKillMode=none\n",
+			},
+			diags: []Diagnostic{
+				{File: "/quadlets/app.container", Line: 3, Group: ContainerGroup, Key: KeyExposeHostPort, Severity: SeverityError, Message: "invalid port format 'foo'"},
+				{File: "/quadlets/app.container", Line: 4, Group: ContainerGroup, Key: KeyCPUs, Severity: SeverityError, Message: "invalid CPUs '-1': must be a non-negative number"},
+				{File: "/quadlets/app.container", Line: 6, Group: ServiceGroup, Key: "KillMode", Severity: SeverityError, Message: "invalid KillMode 'none'"},
+			},
+		},
+		{
+			name: "invalid values with other problems",
+			units: map[string]string{
+				"app.container": "[Container]\nImage=quay.io/libpod/alpine\nMemmory=1g\nNetwork=app.network\nExposeHostPort=foo\n",
+			},
+			diags: []Diagnostic{
+				{File: "/quadlets/app.container", Line: 3, Group: ContainerGroup, Key: "Memmory", Severity: SeverityError, Message: "unsupported key 'Memmory' in group 'Container'"},
+				{File: "/quadlets/app.container", Line: 4, Group: ContainerGroup, Key: KeyNetwork, Severity: SeverityError, Message: "requested Quadlet unit app.network was not found"},
+				{File: "/quadlets/app.container", Line: 5, Group: ContainerGroup, Key: KeyExposeHostPort, Severity: SeverityError, Message: "invalid port format 'foo'"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			units := make([]*parser.UnitFile, 0, len(tt.units))
+			for filename, data := range tt.units {
+				units = append(units, parseTestUnit(t, filename, data))
+			}
+			assert.Equal(t, tt.diags, ValidateUnits(units, false))
+		})
+	}
+}
+
+func TestQuadlet_ValidateUnitsDropin(t *testing.T) {
+	unit := parseTestUnit(t, "app.container", "[Container]\nImage=quay.io/libpod/alpine\n")
+	dropin := parseTestUnit(t, "app.container.d/10-memory.conf", "[Container]\n# limits\nMemory=1g\nNetwork=missing.network\n")
+	unit.Merge(dropin)
+
+	diags := ValidateUnits([]*parser.UnitFile{unit}, false)
+	assert.Equal(t, []Diagnostic{
+		{File: dropin.Path, Line: 4, Group: ContainerGroup, Key: KeyNetwork, Severity: SeverityError, Message: "requested Quadlet unit missing.network was not found"},
+	}, diags)
+}
+
+func TestQuadlet_ErrorDiagnostic(t *testing.T) {
+	unit := parseTestUnit(t, "app.network", "[Network]\nGateway=10.0.0.1\n")
+	_, err := ConvertNetwork(unit, unit.Filename, GenerateUnitsInfoMap([]*parser.UnitFile{unit}), false)
+	require.Error(t, err)
+	assert.Equal(t, Diagnostic{
+		File:     unit.Path,
+		Line:     2,
+		Group:    NetworkGroup,
+		Key:      KeyGateway,
+		Severity: SeverityError,
+		Message:  "cannot set gateway or range without subnet",
+	}, ErrorDiagnostic(unit.Path, unit, err))
+
+	parseErr := parser.NewUnitFile().Parse("[Network]\nnot a key\n")
+	d := ErrorDiagnostic("/quadlets/bad.network", nil, parseErr)
+	assert.Equal(t, 2, d.Line)
+	assert.Equal(t, SeverityError, d.Severity)
+}
+
+func TestQuadlet_DiagnosticString(t *testing.T) {
+	d := Diagnostic{File: "app.container", Line: 3, Group: ContainerGroup, Key: KeyPod, Severity: SeverityError, Message: "requested Quadlet unit app.pod was not found"}
+	assert.Equal(t, "app.container:3: error: [Container] Pod: requested Quadlet unit app.pod was not found", d.String())
+	d = Diagnostic{File: "app.pod", Severity: SeverityWarning, Message: "unknown group 'Custom' is ignored"}
+	assert.Equal(t, "app.pod: warning: unknown group 'Custom' is ignored", d.String())
+}
@@ -157,6 +157,35 @@ func getPolicy(policyPath string) (policyContent, error) {
 	return policyContentStruct, nil
 }
 
+// RequirementTypes returns the types of the requirements (e.g., "signedBy" or
+// "insecureAcceptAnything") the policy in policyPath applies to ref.  Like
+// c/image/v5/signature, the most specific scope of ref's transport is used,
+// falling back to the default requirements.
+func RequirementTypes(policyPath string, ref types.ImageReference) ([]string, error) {
+	policyContentStruct, err := getPolicy(policyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	reqs := policyContentStruct.Default
+	if scopes, ok := policyContentStruct.Transports[ref.Transport().Name()]; ok {
+		names := append([]string{ref.PolicyConfigurationIdentity()}, ref.PolicyConfigurationNamespaces()...)
+		names = append(names, "")
+		for _, name := range names {
+			if scopeReqs, ok := scopes[name]; ok {
+				reqs = scopeReqs
+				break
+			}
+		}
+	}
+
+	reqTypes := make([]string, 0, len(reqs))
+	for _, req := range reqs {
+		reqTypes = append(reqTypes, req.Type)
+	}
+	return reqTypes, nil
+}
+
 var typeDescription = map[string]string{"insecureAcceptAnything": "accept", "signedBy": "signed", "sigstoreSigned": "sigstoreSigned", "reject": "reject"}
 
 func trustTypeDescription(trustType string) string {
@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/containers/image/v5/docker"
 	"github.com/containers/image/v5/signature"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -194,3 +195,37 @@ func xNewPRSigstoreSignedKeyPath(t *testing.T, keyPath string, signedIdentity si
 	require.NoError(t, err)
 	return pr
 }
+
+func TestRequirementTypes(t *testing.T) {
+	policyPath := filepath.Join(t.TempDir(), "policy.json")
+	err := os.WriteFile(policyPath, []byte(`{
+		"default": [{"type": "insecureAcceptAnything"}],
+		"transports": {
+			"docker": {
+				"quay.io": [{"type": "reject"}],
+				"quay.io/podman": [{"type": "signedBy", "keyType": "GPGKeys", "keyPath": "/key.gpg"}],
+				"quay.io/podman/stable:latest": [{"type": "sigstoreSigned", "keyPath": "/key.pub"}, {"type": "signedBy", "keyType": "GPGKeys", "keyPath": "/key.gpg"}]
+			}
+		}
+	}`), 0o600)
+	require.NoError(t, err)
+
+	for _, c := range []struct {
+		image    string
+		expected []string
+	}{
+		{"docker.io/library/alpine:latest", []string{"insecureAcceptAnything"}},
+		{"quay.io/libpod/alpine:latest", []string{"reject"}},
+		{"quay.io/podman/hello:latest", []string{"signedBy"}},
+		{"quay.io/podman/stable:latest", []string{"sigstoreSigned", "signedBy"}},
+	} {
+		ref, err := docker.ParseReference("//" + c.image)
+		require.NoError(t, err)
+		reqTypes, err := RequirementTypes(policyPath, ref)
+		require.NoError(t, err)
+		assert.Equal(t, c.expected, reqTypes, c.image)
+	}
+
+	_, err = RequirementTypes(filepath.Join(t.TempDir(), "missing.json"), nil)
+	assert.Error(t, err)
+}
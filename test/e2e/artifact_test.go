@@ -0,0 +1,179 @@
+//go:build linux || freebsd
+
+package integration
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+
+	. "github.com/containers/podman/v5/test/utils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Podman artifact", func() {
+	var srcDir string
+
+	BeforeEach(func() {
+		srcDir = filepath.Join(podmanTest.TempDir, "artifact-src")
+		Expect(os.MkdirAll(srcDir, 0o755)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(srcDir, "model.bin"), []byte("model data"), 0o644)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(srcDir, "config.json"), []byte("{}"), 0o644)).To(Succeed())
+	})
+
+	It("podman artifact add, ls, inspect, extract and rm", func() {
+		name := "quay.io/podman/test-artifact:v1"
+		add := podmanTest.Podman([]string{"artifact", "add", "--type", "application/vnd.podman.test", "--annotation", "version=1", name, filepath.Join(srcDir, "model.bin")})
+		add.WaitWithDefaultTimeout()
+		Expect(add).Should(ExitCleanly())
+		firstDigest := add.OutputToString()
+
+		session := podmanTest.Podman([]string{"artifact", "add", name, filepath.Join(srcDir, "config.json")})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "artifact already exists"))
+
+		add = podmanTest.Podman([]string{"artifact", "add", "--append", name, filepath.Join(srcDir, "config.json")})
+		add.WaitWithDefaultTimeout()
+		Expect(add).Should(ExitCleanly())
+		digest := add.OutputToString()
+		Expect(digest).ToNot(Equal(firstDigest))
+
+		session = podmanTest.Podman([]string{"artifact", "ls", "--format", "{{.Repository}} {{.Tag}} {{.Digest}}"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(session.OutputToString()).To(Equal("quay.io/podman/test-artifact v1 " + digest[:12]))
+
+		session = podmanTest.Podman([]string{"artifact", "inspect", digest[:12]})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(session.OutputToString()).To(BeValidJSON())
+		Expect(session.OutputToString()).To(ContainSubstring(`"artifactType": "application/vnd.podman.test"`))
+		Expect(session.OutputToString()).To(ContainSubstring(`"org.opencontainers.image.title": "config.json"`))
+
+		outDir := filepath.Join(podmanTest.TempDir, "artifact-out")
+		Expect(os.MkdirAll(outDir, 0o755)).To(Succeed())
+		session = podmanTest.Podman([]string{"artifact", "extract", name, outDir})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		content, err := os.ReadFile(filepath.Join(outDir, "model.bin"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(content)).To(Equal("model data"))
+		Expect(filepath.Join(outDir, "config.json")).To(BeARegularFile())
+
+		outFile := filepath.Join(podmanTest.TempDir, "model")
+		session = podmanTest.Podman([]string{"artifact", "extract", name, outFile})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "has 2 files, select one with a digest or title"))
+		session = podmanTest.Podman([]string{"artifact", "extract", "--title", "model.bin", name, outFile})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		content, err = os.ReadFile(outFile)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(content)).To(Equal("model data"))
+
+		session = podmanTest.Podman([]string{"artifact", "rm", name})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(session.OutputToString()).To(Equal(digest))
+
+		session = podmanTest.Podman([]string{"artifact", "inspect", name})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "no such artifact"))
+
+		session = podmanTest.Podman([]string{"artifact", "rm"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "`podman artifact rm` requires one argument, or the --all flag"))
+	})
+
+	It("podman artifact push and pull to local registry", func() {
+		if podmanTest.Host.Arch == "ppc64le" {
+			Skip("No registry image for ppc64le")
+		}
+		if isRootless() {
+			err := podmanTest.RestoreArtifact(REGISTRY_IMAGE)
+			Expect(err).ToNot(HaveOccurred())
+		}
+		port := strconv.Itoa(GetPort())
+		lock := GetPortLock(port)
+		defer lock.Unlock()
+		session := podmanTest.Podman([]string{"run", "-d", "--name", "registry", "-p", port + ":5000", REGISTRY_IMAGE, "/entrypoint.sh", "/etc/docker/registry/config.yml"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+
+		if !WaitContainerReady(podmanTest, "registry", "listening on", 20, 1) {
+			Skip("Cannot start docker registry.")
+		}
+
+		name := "localhost:" + port + "/test-artifact:v1"
+		add := podmanTest.Podman([]string{"artifact", "add", "local-artifact", filepath.Join(srcDir, "model.bin"), filepath.Join(srcDir, "config.json")})
+		add.WaitWithDefaultTimeout()
+		Expect(add).Should(ExitCleanly())
+
+		push := podmanTest.Podman([]string{"artifact", "push", "-q", "--tls-verify=false", "local-artifact", name})
+		push.WaitWithDefaultTimeout()
+		Expect(push).Should(ExitCleanly())
+		Expect(push.OutputToString()).To(Equal(add.OutputToString()))
+
+		session = podmanTest.Podman([]string{"artifact", "rm", "--all"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+
+		pull := podmanTest.Podman([]string{"artifact", "pull", "-q", "--tls-verify=false", name})
+		pull.WaitWithDefaultTimeout()
+		Expect(pull).Should(ExitCleanly())
+		Expect(pull.OutputToString()).To(Equal(add.OutputToString()))
+
+		outDir := filepath.Join(podmanTest.TempDir, "artifact-pulled")
+		Expect(os.MkdirAll(outDir, 0o755)).To(Succeed())
+		session = podmanTest.Podman([]string{"artifact", "extract", name, outDir})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		content, err := os.ReadFile(filepath.Join(outDir, "model.bin"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(content)).To(Equal("model data"))
+	})
+
+	It("podman run with artifact mount", func() {
+		name := "quay.io/podman/test-artifact:mount"
+		add := podmanTest.Podman([]string{"artifact", "add", name, filepath.Join(srcDir, "model.bin"), filepath.Join(srcDir, "config.json")})
+		add.WaitWithDefaultTimeout()
+		Expect(add).Should(ExitCleanly())
+
+		session := podmanTest.Podman([]string{"run", "--rm", "--pull", "never", "--mount", "type=artifact,src=" + name + ",dst=/artifact", ALPINE, "cat", "/artifact/model.bin", "/artifact/config.json"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(session.OutputToString()).To(Equal("model data{}"))
+
+		session = podmanTest.Podman([]string{"run", "--rm", "--mount", "type=artifact,src=" + name + ",dst=/model.bin,title=model.bin", ALPINE, "cat", "/model.bin"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(session.OutputToString()).To(Equal("model data"))
+
+		// Artifact mounts are read-only
+		session = podmanTest.Podman([]string{"run", "--rm", "--mount", "type=artifact,src=" + name + ",dst=/model.bin,title=model.bin", ALPINE, "sh", "-c", "echo new > /model.bin"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(1, "Read-only file system"))
+
+		session = podmanTest.Podman([]string{"create", "--name", "artifactctr", "--mount", "type=artifact,src=" + name + ",dst=/artifact", ALPINE})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		session = podmanTest.Podman([]string{"inspect", "--format", "{{range .Mounts}}{{.Type}} {{.Source}} {{.Destination}}{{end}}", "artifactctr"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(session.OutputToString()).To(Equal("artifact " + name + " /artifact"))
+
+		// Artifacts used by containers are only removed with --force
+		session = podmanTest.Podman([]string{"artifact", "rm", name})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "artifact is in use by a container"))
+		session = podmanTest.Podman([]string{"artifact", "rm", "--force", name})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(podmanTest.NumberOfContainers()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"create", "--pull", "never", "--mount", "type=artifact,src=quay.io/podman/missing-artifact:v1,dst=/artifact", ALPINE, "true"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "no such artifact"))
+	})
+})
@@ -12,6 +12,7 @@ import (
 
 	"github.com/containers/podman/v5/libpod/define"
 
+	v1apps "github.com/containers/podman/v5/pkg/k8s.io/api/apps/v1"
 	v1 "github.com/containers/podman/v5/pkg/k8s.io/api/core/v1"
 	"github.com/containers/podman/v5/pkg/util"
 	. "github.com/containers/podman/v5/test/utils"
@@ -1589,6 +1590,81 @@ USER test1`
 		Expect(kube).Should(ExitWithError(125, "k8s Jobs can not have restartPolicy set to Always; only Never and OnFailure policies allowed"))
 	})
 
+	It("on pod with --type=statefulset", func() {
+		podName := "test-pod"
+		session := podmanTest.Podman([]string{"pod", "create", podName})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+
+		session = podmanTest.Podman([]string{"create", "--pod", podName, "--restart", "always", "-v", "data:/data", CITEST_IMAGE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+
+		kube := podmanTest.Podman([]string{"kube", "generate", "--type", "statefulset", "--replicas", "3", podName})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube).Should(ExitCleanly())
+
+		set := new(v1apps.StatefulSet)
+		err := yaml.Unmarshal(kube.Out.Contents(), set)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(set.Name).To(Equal(podName + "-statefulset"))
+		Expect(set.Spec.ServiceName).To(Equal(podName))
+		Expect(set.Spec.Selector.MatchLabels).To(HaveKeyWithValue("app", podName))
+		var intthree int32 = 3
+		Expect(set.Spec.Replicas).To(Equal(&intthree))
+
+		// Named volumes are turned into volume claim templates
+		Expect(set.Spec.VolumeClaimTemplates).To(HaveLen(1))
+		Expect(set.Spec.VolumeClaimTemplates[0].Name).To(Equal("data-pvc"))
+		Expect(set.Spec.Template.Spec.Volumes).To(BeEmpty())
+		Expect(set.Spec.Template.Spec.Containers).To(HaveLen(1))
+		Expect(set.Spec.Template.Spec.Containers[0].VolumeMounts).To(HaveLen(1))
+		Expect(set.Spec.Template.Spec.Containers[0].VolumeMounts[0].Name).To(Equal("data-pvc"))
+	})
+
+	It("on pod with --type=cronjob", func() {
+		podName := "test-pod"
+		session := podmanTest.Podman([]string{"pod", "create", podName})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+
+		session = podmanTest.Podman([]string{"create", "--pod", podName, CITEST_IMAGE, "true"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+
+		kube := podmanTest.Podman([]string{"kube", "generate", "--type", "cronjob", podName})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube).Should(ExitWithError(125, "a schedule is required to generate a k8s CronJob"))
+
+		kube = podmanTest.Podman([]string{"kube", "generate", "--type", "cronjob", "--schedule", "0 3 * * *", podName})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube).Should(ExitCleanly())
+
+		cronJob := new(v1.CronJob)
+		err := yaml.Unmarshal(kube.Out.Contents(), cronJob)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cronJob.Kind).To(Equal("CronJob"))
+		Expect(cronJob.APIVersion).To(Equal("batch/v1"))
+		Expect(cronJob.Name).To(Equal(podName + "-cronjob"))
+		Expect(cronJob.Spec.Schedule).To(Equal("0 3 * * *"))
+		Expect(cronJob.Spec.JobTemplate.Spec.Template.Spec.Containers).To(HaveLen(1))
+	})
+
+	It("with --schedule and without --type=cronjob should fail", func() {
+		podName := "test-pod"
+		session := podmanTest.Podman([]string{"pod", "create", podName})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+
+		kube := podmanTest.Podman([]string{"kube", "generate", "--schedule", "@daily", podName})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube).Should(ExitWithError(125, "--schedule can only be set when --type is set to cronjob"))
+
+		kube = podmanTest.Podman([]string{"kube", "generate", "--type", "cronjob", "--schedule", "61 * * * *", podName})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube).Should(ExitWithError(125, `invalid schedule "61 * * * *": invalid value "61" in minute field: must be between 0 and 59`))
+	})
+
 	It("on pod with invalid name", func() {
 		podName := "test_pod"
 		session := podmanTest.Podman([]string{"pod", "create", podName})
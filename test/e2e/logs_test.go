@@ -4,8 +4,14 @@ package integration
 
 import (
 	"fmt"
+	"net"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
 	"time"
 
 	. "github.com/containers/podman/v5/test/utils"
@@ -26,6 +32,27 @@ func isEventBackendJournald(podmanTest *PodmanTestIntegration) bool {
 	return info.OutputToString() == "journald"
 }
 
+// killLogHelper kills the log helper process name of the container cid.
+func killLogHelper(name, cid string) {
+	cmdlines, err := filepath.Glob("/proc/[0-9]*/cmdline")
+	Expect(err).ToNot(HaveOccurred())
+	for _, cmdline := range cmdlines {
+		content, err := os.ReadFile(cmdline)
+		if err != nil {
+			continue
+		}
+		args := strings.Split(string(content), "\x00")
+		if !slices.Contains(args, name) || !slices.Contains(args, cid) {
+			continue
+		}
+		pid, err := strconv.Atoi(filepath.Base(filepath.Dir(cmdline)))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(syscall.Kill(pid, syscall.SIGKILL)).To(Succeed())
+		return
+	}
+	Fail(fmt.Sprintf("log helper %s of container %s is not running", name, cid))
+}
+
 var _ = Describe("Podman logs", func() {
 
 	It("podman logs on not existent container", func() {
@@ -585,6 +612,179 @@ var _ = Describe("Podman logs", func() {
 		Expect(logs).To(ExitWithError(125, "this container is using the 'none' log driver, cannot read logs: this container is not logging output"))
 	})
 
+	It("podman logs with log-driver=syslog forwards and keeps logs", func() {
+		collector, err := net.ListenPacket("udp", "127.0.0.1:0")
+		Expect(err).ToNot(HaveOccurred())
+		defer collector.Close()
+
+		ctrName := "syslogctr"
+		logc := podmanTest.Podman([]string{"run", "--name", ctrName, "--log-driver", "syslog",
+			"--log-opt", "syslog-address=udp://" + collector.LocalAddr().String(), "--log-opt", "syslog-facility=local0",
+			ALPINE, "sh", "-c", "sleep 1; echo forwarded"})
+		logc.WaitWithDefaultTimeout()
+		Expect(logc).To(ExitCleanly())
+		Expect(logc.OutputToString()).To(Equal("forwarded"))
+
+		Expect(collector.SetReadDeadline(time.Now().Add(10 * time.Second))).To(Succeed())
+		buf := make([]byte, 2048)
+		n, _, err := collector.ReadFrom(buf)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(buf[:n])).To(MatchRegexp(`^<134>1 \S+ \S+ %s - - - forwarded$`, ctrName))
+
+		logs := podmanTest.Podman([]string{"logs", ctrName})
+		logs.WaitWithDefaultTimeout()
+		Expect(logs).To(ExitCleanly())
+		Expect(logs.OutputToString()).To(Equal("forwarded"))
+
+		inspect := podmanTest.Podman([]string{"inspect", "--format", "{{.HostConfig.LogConfig.Type}} {{.HostConfig.LogConfig.Config}}", ctrName})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect).To(ExitCleanly())
+		Expect(inspect.OutputToString()).To(HavePrefix("syslog map[syslog-address:udp://"))
+	})
+
+	It("podman logs with log-driver=syslog forwards the logs of a killed forwarding helper in the cleanup", func() {
+		collector, err := net.ListenPacket("udp", "127.0.0.1:0")
+		Expect(err).ToNot(HaveOccurred())
+		defer collector.Close()
+
+		logc := podmanTest.Podman([]string{"run", "-d", "--log-driver", "syslog",
+			"--log-opt", "syslog-address=udp://" + collector.LocalAddr().String(),
+			ALPINE, "sh", "-c", "echo before; sleep 3; echo after"})
+		logc.WaitWithDefaultTimeout()
+		Expect(logc).To(ExitCleanly())
+		cid := logc.OutputToString()
+
+		// receive waits for the message with the line, or for the deadline.
+		receive := func(line string, deadline time.Duration) {
+			Expect(collector.SetReadDeadline(time.Now().Add(deadline))).To(Succeed())
+			buf := make([]byte, 2048)
+			for {
+				n, _, err := collector.ReadFrom(buf)
+				Expect(err).ToNot(HaveOccurred())
+				if strings.HasSuffix(string(buf[:n]), " "+line) {
+					return
+				}
+			}
+		}
+		receive("before", 5*time.Second)
+
+		killLogHelper("logforward", cid)
+
+		// The container does not depend on the helper.
+		inspect := podmanTest.Podman([]string{"inspect", "--format", "{{.State.Running}}", cid})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect).To(ExitCleanly())
+		Expect(inspect.OutputToString()).To(Equal("true"))
+
+		wait := podmanTest.Podman([]string{"wait", cid})
+		wait.WaitWithDefaultTimeout()
+		Expect(wait).To(ExitCleanly())
+		Expect(wait.OutputToString()).To(Equal("0"))
+
+		// The cleanup of the container forwards the lines the helper missed.
+		receive("after", 10*time.Second)
+
+		logs := podmanTest.Podman([]string{"logs", cid})
+		logs.WaitWithDefaultTimeout()
+		Expect(logs).To(ExitCleanly())
+		Expect(logs.OutputToStringArray()).To(Equal([]string{"before", "after"}))
+	})
+
+	It("podman logs reads rotated log files", func() {
+		// Each burst of 100 lines is about 5k, the log is rotated twice.
+		logc := podmanTest.Podman([]string{"run", "--log-driver", "k8s-file", "--log-opt", "max-size=10k", "--log-opt", "max-file=3",
+			"--log-opt", "compress=true", "-d", ALPINE, "sh", "-c", "for i in $(seq 1 400); do echo line$i; if [ $((i % 100)) = 0 ]; then sleep 1; fi; done"})
+		logc.WaitWithDefaultTimeout()
+		Expect(logc).To(ExitCleanly())
+		cid := logc.OutputToString()
+
+		wait := podmanTest.Podman([]string{"wait", cid})
+		wait.WaitWithDefaultTimeout()
+		Expect(wait).To(ExitCleanly())
+
+		inspect := podmanTest.Podman([]string{"container", "inspect", "--format", "{{.HostConfig.LogConfig.Config}}", cid})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect).To(ExitCleanly())
+		Expect(inspect.OutputToString()).To(Equal("map[compress:true max-file:3]"))
+
+		Eventually(func(g Gomega) {
+			results := podmanTest.Podman([]string{"logs", cid})
+			results.WaitWithDefaultTimeout()
+			g.Expect(results).To(ExitCleanly())
+			lines := results.OutputToStringArray()
+			g.Expect(lines).To(HaveLen(400))
+			g.Expect(lines[0]).To(Equal("line1"))
+			g.Expect(lines[399]).To(Equal("line400"))
+		}).WithTimeout(5 * time.Second).Should(Succeed())
+
+		results := podmanTest.Podman([]string{"logs", "--tail", "250", cid})
+		results.WaitWithDefaultTimeout()
+		Expect(results).To(ExitCleanly())
+		lines := results.OutputToStringArray()
+		Expect(lines).To(HaveLen(250))
+		Expect(lines[0]).To(Equal("line151"))
+
+		if !IsRemote() {
+			logPath := podmanTest.Podman([]string{"container", "inspect", "--format", "{{.HostConfig.LogConfig.Path}}", cid})
+			logPath.WaitWithDefaultTimeout()
+			Expect(logPath).To(ExitCleanly())
+			Expect(logPath.OutputToString() + ".1.gz").To(BeAnExistingFile())
+			Expect(logPath.OutputToString() + ".2.gz").To(BeAnExistingFile())
+		}
+	})
+
+	It("podman run with max-file keeps running when the rotation helper is killed", func() {
+		logc := podmanTest.Podman([]string{"run", "--log-driver", "k8s-file", "--log-opt", "max-size=10k", "--log-opt", "max-file=3",
+			"-d", ALPINE, "sh", "-c", "echo before; sleep 3; for i in $(seq 1 400); do echo line$i; done"})
+		logc.WaitWithDefaultTimeout()
+		Expect(logc).To(ExitCleanly())
+		cid := logc.OutputToString()
+
+		killLogHelper("logrotate", cid)
+
+		// The container does not depend on the helper, conmon keeps
+		// replacing the log file at its maximum size.
+		inspect := podmanTest.Podman([]string{"inspect", "--format", "{{.State.Running}}", cid})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect).To(ExitCleanly())
+		Expect(inspect.OutputToString()).To(Equal("true"))
+
+		wait := podmanTest.Podman([]string{"wait", cid})
+		wait.WaitWithDefaultTimeout()
+		Expect(wait).To(ExitCleanly())
+		Expect(wait.OutputToString()).To(Equal("0"))
+
+		results := podmanTest.Podman([]string{"logs", "--tail", "1", cid})
+		results.WaitWithDefaultTimeout()
+		Expect(results).To(ExitCleanly())
+		Expect(results.OutputToString()).To(Equal("line400"))
+
+		// The cleanup does not wait for the killed helper.
+		rm := podmanTest.Podman([]string{"rm", cid})
+		rm.WaitWithDefaultTimeout()
+		Expect(rm).To(ExitCleanly())
+	})
+
+	It("podman run with invalid log options for max-file fails", func() {
+		session := podmanTest.Podman([]string{"create", "--log-driver", "journald", "--log-opt", "max-file=3", ALPINE})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError(125, "log options max-file and compress are not supported by the journald log driver: invalid argument"))
+
+		session = podmanTest.Podman([]string{"create", "--log-driver", "k8s-file", "--log-opt", "max-size=10k", "--log-opt", "compress=true", ALPINE})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError(125, "log option compress requires max-file to be greater than 1: invalid argument"))
+	})
+
+	It("podman run with invalid log options for log-driver=gelf fails", func() {
+		session := podmanTest.Podman([]string{"create", "--log-driver", "gelf", ALPINE})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError(125, "log option gelf-address is required by the gelf log driver: invalid argument"))
+
+		session = podmanTest.Podman([]string{"create", "--log-driver", "gelf", "--log-opt", "gelf-address=udp://localhost", "--log-opt", "fluentd-address=localhost", ALPINE})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError(125, `unknown log option "fluentd-address" for log driver gelf: invalid argument`))
+	})
+
 	It("podman logs with non ASCII log tag fails without correct LANG", func() {
 		SkipIfJournaldUnavailable()
 		// need to set the LANG to something that does not support german umlaute to trigger the failure case
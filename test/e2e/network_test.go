@@ -532,6 +532,57 @@ var _ = Describe("Podman network", func() {
 		Expect(c3).Should(ExitCleanly())
 	})
 
+	It("podman network update subnets, labels and options", func() {
+		SkipIfCNI(podmanTest)
+		netName := createNetworkName("updateTest")
+		session := podmanTest.Podman([]string{"network", "create", "--subnet", "10.99.1.0/24", "--label", "foo=bar", netName})
+		session.WaitWithDefaultTimeout()
+		defer podmanTest.removeNetwork(netName)
+		Expect(session).Should(ExitCleanly())
+
+		ctr := podmanTest.Podman([]string{"run", "-d", "--network", netName, ALPINE, "top"})
+		ctr.WaitWithDefaultTimeout()
+		Expect(ctr).Should(ExitCleanly())
+		cid := ctr.OutputToString()
+
+		session = podmanTest.Podman([]string{"network", "update", netName, "--subnet-add", "fd99:1::/64",
+			"--ip-range-add", "10.99.1.128/25", "--opt-add", "mtu=1400", "--label-add", "new=label", "--label-drop", "foo", "--internal"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(session.OutputToString()).To(Equal(netName))
+
+		session = podmanTest.Podman([]string{"network", "inspect", netName})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		var results []entities.NetworkInspectReport
+		err := json.Unmarshal([]byte(session.OutputToString()), &results)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(results).To(HaveLen(1))
+		result := results[0]
+		Expect(result.Subnets).To(HaveLen(2))
+		Expect(result.Subnets[0].LeaseRange.StartIP.String()).To(Equal("10.99.1.129"))
+		Expect(result.Subnets[1].Subnet.String()).To(Equal("fd99:1::/64"))
+		Expect(result.IPv6Enabled).To(BeTrue())
+		Expect(result.Internal).To(BeTrue())
+		Expect(result.Options).To(HaveKeyWithValue("mtu", "1400"))
+		Expect(result.Labels).To(Equal(map[string]string{"new": "label"}))
+
+		// the running container was set up again and got an ip in the new subnet
+		inspect := podmanTest.Podman([]string{"exec", cid, "ip", "addr", "show", "eth0"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect).Should(ExitCleanly())
+		Expect(inspect.OutputToString()).To(ContainSubstring("mtu 1400"))
+		Expect(inspect.OutputToString()).To(ContainSubstring("inet6 fd99:1::"))
+
+		session = podmanTest.Podman([]string{"network", "update", netName, "--subnet-drop", "10.99.1.0/24", "--subnet-drop", "fd99:1::/64"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "needs at least one subnet"))
+
+		session = podmanTest.Podman([]string{"network", "update", netName, "--opt-add", "foo=bar"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "unsupported bridge network option foo"))
+	})
+
 	It("podman network create/remove macvlan", func() {
 		net := "macvlan" + stringid.GenerateRandomID()
 		nc := podmanTest.Podman([]string{"network", "create", "--macvlan", "lo", net})
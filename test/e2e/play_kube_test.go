@@ -489,6 +489,119 @@ spec:
           periodSeconds: 1
 `
 
+var readinessProbePodYaml = `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: readiness-probe
+spec:
+  restartPolicy: Never
+  containers:
+  - command:
+    - top
+    - -d
+    - "1.5"
+    name: testimage
+    image: ` + CITEST_IMAGE + `
+    readinessProbe:
+      exec:
+        command:
+        - cat
+        - /ready
+      initialDelaySeconds: 0
+      periodSeconds: 1
+`
+
+var lifecycleHooksPodYaml = `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: lifecycle-hooks
+spec:
+  volumes:
+  - name: hooks
+    hostPath:
+      path: %s
+      type: Directory
+  containers:
+  - command:
+    - top
+    - -d
+    - "1.5"
+    name: testimage
+    image: ` + CITEST_IMAGE + `
+    volumeMounts:
+    - name: hooks
+      mountPath: /hooks
+    lifecycle:
+      postStart:
+        exec:
+          command:
+          - touch
+          - /hooks/postStart
+      preStop:
+        exec:
+          command:
+          - touch
+          - /hooks/preStop
+`
+
+var statefulSetYaml = `
+apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  name: web
+spec:
+  serviceName: web
+  replicas: 2
+  selector:
+    matchLabels:
+      app: web
+  template:
+    metadata:
+      labels:
+        app: web
+    spec:
+      containers:
+      - command:
+        - top
+        name: testimage
+        image: ` + CITEST_IMAGE + `
+        volumeMounts:
+        - name: data
+          mountPath: /data
+  volumeClaimTemplates:
+  - metadata:
+      name: data
+    spec:
+      accessModes:
+      - ReadWriteOnce
+      resources:
+        requests:
+          storage: 1Gi
+`
+
+var cronJobYaml = `
+apiVersion: batch/v1
+kind: CronJob
+metadata:
+  name: backup
+spec:
+  schedule: "*/5 * * * *"
+  concurrencyPolicy: Replace
+  suspend: true
+  jobTemplate:
+    spec:
+      template:
+        spec:
+          restartPolicy: Never
+          containers:
+          - command:
+            - "true"
+            name: testimage
+            image: ` + CITEST_IMAGE + `
+`
+
 var selinuxLabelPodYaml = `
 apiVersion: v1
 kind: Pod
@@ -1345,6 +1458,36 @@ spec:
       protocol: tcp
 `
 
+var publishPortsWithService = `
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+spec:
+  type: NodePort
+  selector:
+    app: web
+  ports:
+  - port: 80
+    targetPort: http
+    nodePort: 19013
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: nginx
+  labels:
+    app: web
+spec:
+  containers:
+  - name: nginx
+    image: ` + NGINX_IMAGE + `
+    imagePullPolicy: missing
+    ports:
+    - name: http
+      containerPort: 80
+`
+
 var podWithHostPIDDefined = `
 apiVersion: v1
 kind: Pod
@@ -2599,6 +2742,102 @@ var _ = Describe("Podman kube play", func() {
 		Expect(inspect[0].State.Health).To(HaveField("Status", define.HealthCheckHealthy))
 	})
 
+	It("support container readiness probe", func() {
+		SkipIfRemote("readiness probes can only be run locally")
+		ctrName := "readiness-probe-testimage"
+		err := writeYaml(readinessProbePodYaml, kubeYaml)
+		Expect(err).ToNot(HaveOccurred())
+
+		kube := podmanTest.Podman([]string{"kube", "play", kubeYaml})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube).Should(ExitCleanly())
+
+		inspect := podmanTest.InspectContainer(ctrName)
+		Expect(inspect[0].Config.ReadinessProbe).ToNot(BeNil())
+		Expect(inspect[0].Config.ReadinessProbe.Test).To(Equal([]string{"CMD", "cat", "/ready"}))
+		// the probe is run by its own timer, without a healthcheck
+		Expect(inspect[0].Config.Healthcheck).To(BeNil())
+		Expect(inspect[0].Config.ReadinessProbe.Interval).To(BeNumerically("==", time.Second))
+		Expect(*inspect[0].State.Ready).To(BeFalse())
+
+		hc := podmanTest.Podman([]string{"healthcheck", "readiness", ctrName})
+		hc.WaitWithDefaultTimeout()
+		Expect(hc).Should(ExitCleanly())
+
+		podInspect := podmanTest.Podman([]string{"pod", "inspect", "readiness-probe", "--format", "{{range .Containers}}{{.Name}}={{.Ready}} {{end}}"})
+		podInspect.WaitWithDefaultTimeout()
+		Expect(podInspect).Should(ExitCleanly())
+		Expect(podInspect.OutputToString()).To(ContainSubstring(ctrName + "=false"))
+
+		exec := podmanTest.Podman([]string{"exec", ctrName, "touch", "/ready"})
+		exec.WaitWithDefaultTimeout()
+		Expect(exec).Should(ExitCleanly())
+
+		hc = podmanTest.Podman([]string{"healthcheck", "readiness", ctrName})
+		hc.WaitWithDefaultTimeout()
+		Expect(hc).Should(ExitCleanly())
+
+		inspect = podmanTest.InspectContainer(ctrName)
+		Expect(*inspect[0].State.Ready).To(BeTrue())
+
+		podInspect = podmanTest.Podman([]string{"pod", "inspect", "readiness-probe", "--format", "{{range .Containers}}{{.Name}}={{.Ready}} {{end}}"})
+		podInspect.WaitWithDefaultTimeout()
+		Expect(podInspect).Should(ExitCleanly())
+		Expect(podInspect.OutputToString()).To(ContainSubstring(ctrName + "=true"))
+
+		wait := podmanTest.Podman([]string{"wait", "--condition", "ready", ctrName})
+		wait.WaitWithDefaultTimeout()
+		Expect(wait).Should(ExitCleanly())
+	})
+
+	It("support container lifecycle hooks", func() {
+		ctrName := "lifecycle-hooks-testimage"
+		hooksDir := filepath.Join(podmanTest.TempDir, "hooks")
+		err := os.Mkdir(hooksDir, 0755)
+		Expect(err).ToNot(HaveOccurred())
+		err = writeYaml(fmt.Sprintf(lifecycleHooksPodYaml, hooksDir), kubeYaml)
+		Expect(err).ToNot(HaveOccurred())
+
+		kube := podmanTest.Podman([]string{"kube", "play", kubeYaml})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube).Should(ExitCleanly())
+
+		inspect := podmanTest.InspectContainer(ctrName)
+		Expect(inspect[0].Config.PostStartHook).To(Equal([]string{"touch", "/hooks/postStart"}))
+		Expect(inspect[0].Config.PreStopHook).To(Equal([]string{"touch", "/hooks/preStop"}))
+		Expect(filepath.Join(hooksDir, "postStart")).To(BeAnExistingFile())
+		Expect(filepath.Join(hooksDir, "preStop")).ToNot(BeAnExistingFile())
+
+		stop := podmanTest.Podman([]string{"stop", "-t", "2", ctrName})
+		stop.WaitWithDefaultTimeout()
+		Expect(stop).Should(ExitCleanly())
+		Expect(filepath.Join(hooksDir, "preStop")).To(BeAnExistingFile())
+	})
+
+	It("stops a preStop hook exceeding the stop timeout", func() {
+		ctrName := "lifecycle-hooks-testimage"
+		hooksDir := filepath.Join(podmanTest.TempDir, "hooks")
+		err := os.Mkdir(hooksDir, 0755)
+		Expect(err).ToNot(HaveOccurred())
+		podYaml := strings.Replace(fmt.Sprintf(lifecycleHooksPodYaml, hooksDir), "- touch\n          - /hooks/preStop", "- sleep\n          - \"100\"", 1)
+		err = writeYaml(podYaml, kubeYaml)
+		Expect(err).ToNot(HaveOccurred())
+
+		kube := podmanTest.Podman([]string{"kube", "play", kubeYaml})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube).Should(ExitCleanly())
+
+		start := time.Now()
+		stop := podmanTest.Podman([]string{"stop", "-t", "2", ctrName})
+		stop.WaitWithDefaultTimeout()
+		Expect(stop).Should(Exit(0))
+		Expect(time.Since(start)).To(BeNumerically("<", 30*time.Second))
+
+		inspect := podmanTest.InspectContainer(ctrName)
+		Expect(inspect[0].State.Running).To(BeFalse())
+		Expect(inspect[0].ExecIDs).To(BeEmpty())
+	})
+
 	It("fail with nonexistent authfile", func() {
 		err := generateKubeYaml("pod", getPod(), kubeYaml)
 		Expect(err).ToNot(HaveOccurred())
@@ -3564,6 +3803,78 @@ spec:
 		Expect(inspect.OutputToString()).To(ContainSubstring(strings.Join(defaultCtrCmd, " ")))
 	})
 
+	It("statefulset with volume claim templates", func() {
+		err := writeYaml(statefulSetYaml, kubeYaml)
+		Expect(err).ToNot(HaveOccurred())
+
+		kube := podmanTest.Podman([]string{"kube", "play", kubeYaml})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube).Should(ExitCleanly())
+
+		for _, podName := range []string{"web-0", "web-1"} {
+			inspect := podmanTest.Podman([]string{"pod", "inspect", podName, "--format", "{{.Hostname}} {{.Labels}}"})
+			inspect.WaitWithDefaultTimeout()
+			Expect(inspect).Should(ExitCleanly())
+			Expect(inspect.OutputToString()).To(ContainSubstring(podName))
+			Expect(inspect.OutputToString()).To(ContainSubstring("statefulset.kubernetes.io/pod-name:" + podName))
+
+			ctr := podmanTest.InspectContainer(getCtrNameInPod(&Pod{Name: podName}))
+			Expect(ctr[0].Mounts).To(HaveLen(1))
+			Expect(ctr[0].Mounts[0]).To(HaveField("Name", "data-"+podName))
+			Expect(ctr[0].Mounts[0]).To(HaveField("Destination", "/data"))
+		}
+
+		down := podmanTest.Podman([]string{"kube", "down", "--force", kubeYaml})
+		down.WaitWithDefaultTimeout()
+		Expect(down).Should(ExitCleanly())
+		Expect(down.OutputToString()).To(ContainSubstring("data-web-1"))
+
+		volumes := podmanTest.Podman([]string{"volume", "ls", "-q"})
+		volumes.WaitWithDefaultTimeout()
+		Expect(volumes).Should(ExitCleanly())
+		Expect(volumes.OutputToStringArray()).To(BeEmpty())
+	})
+
+	It("cronjob", func() {
+		err := writeYaml(cronJobYaml, kubeYaml)
+		Expect(err).ToNot(HaveOccurred())
+
+		kube := podmanTest.Podman([]string{"kube", "play", kubeYaml})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube).Should(ExitCleanly())
+		Expect(kube.OutputToString()).To(ContainSubstring("backup (suspended)"))
+
+		inspect := podmanTest.Podman([]string{"pod", "inspect", "backup-pod", "--format", "{{.State}} {{.CronJob.Schedule}} {{.CronJob.ConcurrencyPolicy}} {{.CronJob.Calendar}}"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect).Should(ExitCleanly())
+		Expect(inspect.OutputToString()).To(Equal("Created */5 * * * * Replace [*-*-* *:0,5,10,15,20,25,30,35,40,45,50,55:00]"))
+
+		down := podmanTest.Podman([]string{"kube", "down", kubeYaml})
+		down.WaitWithDefaultTimeout()
+		Expect(down).Should(ExitCleanly())
+
+		exists := podmanTest.Podman([]string{"pod", "exists", "backup-pod"})
+		exists.WaitWithDefaultTimeout()
+		Expect(exists).Should(ExitWithError(1, ""))
+	})
+
+	It("cronjob treats concurrencyPolicy Allow as Forbid", func() {
+		err := writeYaml(strings.Replace(cronJobYaml, "concurrencyPolicy: Replace", "concurrencyPolicy: Allow", 1), kubeYaml)
+		Expect(err).ToNot(HaveOccurred())
+
+		kube := podmanTest.Podman([]string{"kube", "play", kubeYaml})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube).Should(Exit(0))
+		if !IsRemote() {
+			Expect(kube.ErrorToString()).To(ContainSubstring("CronJob backup: concurrent runs are not supported by Podman"))
+		}
+
+		inspect := podmanTest.Podman([]string{"pod", "inspect", "backup-pod", "--format", "{{.CronJob.ConcurrencyPolicy}}"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect).Should(ExitCleanly())
+		Expect(inspect.OutputToString()).To(Equal("Forbid"))
+	})
+
 	It("--ip and --mac-address", func() {
 		var i, numReplicas int32
 		numReplicas = 3
@@ -6061,6 +6372,23 @@ spec:
 		verifyPodPorts(podmanTest, "network-echo", "19008/tcp:[{0.0.0.0 19011}]", "19008/udp:[{0.0.0.0 19012}]")
 	})
 
+	It("with Service publishes ports and resolves the Service name", func() {
+		err := writeYaml(publishPortsWithService, kubeYaml)
+		Expect(err).ToNot(HaveOccurred())
+
+		kube := podmanTest.Podman([]string{"kube", "play", kubeYaml})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube).Should(ExitCleanly())
+
+		verifyPodPorts(podmanTest, "nginx", "80/tcp:[{0.0.0.0 19013}]")
+		testHTTPServer("19013", false, "podman rulez")
+
+		session := podmanTest.Podman([]string{"run", "--rm", "--network", "podman-default-kube-network", CITEST_IMAGE, "wget", "-qO-", "http://web"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(session.OutputToString()).To(ContainSubstring("podman rulez"))
+	})
+
 	It("with replicas limits the count to 1 and emits a warning", func() {
 		deployment := getDeployment(withReplicas(10))
 		err := generateKubeYaml("deployment", deployment, kubeYaml)
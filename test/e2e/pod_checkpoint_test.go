@@ -0,0 +1,142 @@
+//go:build linux || freebsd
+
+package integration
+
+import (
+	"fmt"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/containers/podman/v5/pkg/checkpoint/crutils"
+	"github.com/containers/podman/v5/pkg/criu"
+	. "github.com/containers/podman/v5/test/utils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("Podman pod checkpoint", func() {
+
+	BeforeEach(func() {
+		SkipIfRootless("checkpoint not supported in rootless mode")
+
+		cmd := exec.Command(podmanTest.OCIRuntime, "checkpoint", "--help")
+		if err := cmd.Start(); err != nil {
+			Skip("OCI runtime does not support checkpoint/restore")
+		}
+		if err := cmd.Wait(); err != nil {
+			Skip("OCI runtime does not support checkpoint/restore")
+		}
+
+		if err := criu.CheckForCriu(criu.PodCriuVersion); err != nil {
+			Skip(fmt.Sprintf("check CRIU pod version error: %v", err))
+		}
+		if !crutils.CRRuntimeSupportsPodCheckpointRestore(podmanTest.OCIRuntime) {
+			Skip("runtime does not support pod restore: " + podmanTest.OCIRuntime)
+		}
+	})
+
+	It("podman pod checkpoint bogus pod", func() {
+		fileName := filepath.Join(podmanTest.TempDir, "bogus.tar.zst")
+		session := podmanTest.Podman([]string{"pod", "checkpoint", "--export", fileName, "foobar"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "no pod with name or ID foobar found: no such pod"))
+	})
+
+	It("podman pod checkpoint without export", func() {
+		session := podmanTest.Podman([]string{"pod", "checkpoint", "foobar"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, `required flag(s) "export" not set`))
+	})
+
+	It("podman pod checkpoint pod without running containers", func() {
+		_, ec, podID := podmanTest.CreatePod(nil)
+		Expect(ec).To(Equal(0))
+
+		fileName := filepath.Join(podmanTest.TempDir, "empty.tar.zst")
+		session := podmanTest.Podman([]string{"pod", "checkpoint", "--export", fileName, podID})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "has no containers to checkpoint"))
+	})
+
+	It("podman pod checkpoint and restore", func() {
+		// The infra container and its network configuration are part of the checkpoint.
+		session := podmanTest.Podman([]string{"pod", "create", "--name", "cppod"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		podID := session.OutputToString()
+
+		for _, name := range []string{"cppod-top", "sleeper"} {
+			session := podmanTest.Podman([]string{"run", "-d", "--pod", podID, "--name", name, ALPINE, "top"})
+			session.WaitWithDefaultTimeout()
+			Expect(session).Should(ExitCleanly())
+		}
+
+		fileName := filepath.Join(podmanTest.TempDir, "pod-checkpoint.tar.gz")
+		session = podmanTest.Podman([]string{"pod", "checkpoint", "--compress", "gzip", "--export", fileName, "cppod"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(0))
+		Expect(session.OutputToString()).To(Equal("cppod"))
+
+		ps := podmanTest.Podman([]string{"ps", "--filter", "pod=cppod", "--filter", "status=exited", "--format", "{{.Names}}"})
+		ps.WaitWithDefaultTimeout()
+		Expect(ps).Should(ExitCleanly())
+		Expect(ps.OutputToStringArray()).To(ConsistOf("cppod-top", "sleeper"))
+
+		// Restore a copy of the pod next to the original one.
+		session = podmanTest.Podman([]string{"pod", "restore", "--import", fileName, "--name", "cppod-copy"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(0))
+		copyID := session.OutputToString()
+		Expect(copyID).ToNot(Equal(podID))
+
+		ps = podmanTest.Podman([]string{"ps", "--filter", "pod=cppod-copy", "--format", "{{.Names}} {{.State}}"})
+		ps.WaitWithDefaultTimeout()
+		Expect(ps).Should(ExitCleanly())
+		Expect(ps.OutputToStringArray()).To(ConsistOf("cppod-copy-top running", "cppod-copy-sleeper running"))
+
+		inspect := podmanTest.Podman([]string{"pod", "inspect", "--format", "{{.State}}", "cppod-copy"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect).Should(ExitCleanly())
+		Expect(inspect.OutputToString()).To(Equal("Running"))
+
+		// The original pod cannot be restored while it still exists.
+		session = podmanTest.Podman([]string{"pod", "restore", "--import", fileName})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "pod already exists"))
+
+		session = podmanTest.Podman([]string{"pod", "rm", "-f", "cppod"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+
+		session = podmanTest.Podman([]string{"pod", "restore", "--import", fileName})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(0))
+		Expect(session.OutputToString()).To(Equal(podID))
+
+		ps = podmanTest.Podman([]string{"ps", "--filter", "pod=cppod", "--format", "{{.Names}} {{.State}}"})
+		ps.WaitWithDefaultTimeout()
+		Expect(ps).Should(ExitCleanly())
+		Expect(ps.OutputToStringArray()).To(ConsistOf("cppod-top running", "sleeper running"))
+	})
+
+	It("podman pod checkpoint --leave-running", func() {
+		_, ec, podID := podmanTest.CreatePod(nil)
+		Expect(ec).To(Equal(0))
+
+		session := podmanTest.RunTopContainerInPod("", podID)
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		cid := session.OutputToString()
+
+		fileName := filepath.Join(podmanTest.TempDir, "pod-checkpoint.tar")
+		session = podmanTest.Podman([]string{"pod", "checkpoint", "--leave-running", "--compress", "none", "--export", fileName, podID})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(0))
+
+		inspect := podmanTest.Podman([]string{"inspect", "--format", "{{.State.Status}}", cid})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect).Should(ExitCleanly())
+		Expect(inspect.OutputToString()).To(Equal("running"))
+	})
+})
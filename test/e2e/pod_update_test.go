@@ -0,0 +1,114 @@
+//go:build linux || freebsd
+
+package integration
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/containers/podman/v5/test/utils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Podman pod update", func() {
+
+	It("podman pod update bogus pod", func() {
+		session := podmanTest.Podman([]string{"pod", "update", "--cpus", "1", "123"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "no pod with name or ID 123 found: no such pod"))
+	})
+
+	It("podman pod update without options", func() {
+		_, ec, podid := podmanTest.CreatePod(nil)
+		Expect(ec).To(Equal(0))
+
+		session := podmanTest.Podman([]string{"pod", "update", podid})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "no changes specified, set at least one option to update the pod"))
+	})
+
+	It("podman pod update resource limits", func() {
+		SkipIfRootlessCgroupsV1("rootless cannot use cgroups with cgroupsv1")
+		SkipIfRootless("many of these handlers are not enabled while rootless in CI")
+		_, ec, podid := podmanTest.CreatePod(map[string][]string{"--cpus": {"1"}})
+		Expect(ec).To(Equal(0))
+
+		session := podmanTest.RunTopContainerInPod("", podid)
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+
+		session = podmanTest.Podman([]string{"pod", "update", "--cpus", "2", "--memory", "1g", podid})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(session.OutputToString()).To(Equal(podid))
+
+		inspect := podmanTest.Podman([]string{"pod", "inspect", "--format", "{{.CPUQuota}} {{.CPUPeriod}} {{.MemoryLimit}}", podid})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect).Should(ExitCleanly())
+		Expect(inspect.OutputToString()).To(Equal("200000 100000 1073741824"))
+
+		if CGROUPSV2 && !IsRemote() {
+			inspect = podmanTest.Podman([]string{"pod", "inspect", "--format", "{{.CgroupPath}}", podid})
+			inspect.WaitWithDefaultTimeout()
+			Expect(inspect).Should(ExitCleanly())
+			cgroupPath := filepath.Join("/sys/fs/cgroup", inspect.OutputToString())
+
+			cpuMax, err := os.ReadFile(filepath.Join(cgroupPath, "cpu.max"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(cpuMax)).To(HavePrefix("200000 100000"))
+
+			memoryMax, err := os.ReadFile(filepath.Join(cgroupPath, "memory.max"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(memoryMax)).To(HavePrefix("1073741824"))
+		}
+
+		// The limits are persisted and survive a restart of the pod.
+		session = podmanTest.Podman([]string{"pod", "restart", podid})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+
+		inspect = podmanTest.Podman([]string{"pod", "inspect", "--format", "{{.CPUQuota}} {{.MemoryLimit}}", podid})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect).Should(ExitCleanly())
+		Expect(inspect.OutputToString()).To(Equal("200000 1073741824"))
+	})
+
+	It("podman pod update restart policy and health on failure", func() {
+		_, ec, podid := podmanTest.CreatePod(map[string][]string{"--name": {"updatepod"}})
+		Expect(ec).To(Equal(0))
+
+		session := podmanTest.Podman([]string{"create", "--pod", podid, "--name", "updatectr", "--health-cmd", "true", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+
+		session = podmanTest.Podman([]string{"pod", "update", "--restart", "on-failure:3", "--health-on-failure", "kill", "updatepod"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+
+		inspect := podmanTest.Podman([]string{"pod", "inspect", "--format", "{{.RestartPolicy}}", podid})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect).Should(ExitCleanly())
+		Expect(inspect.OutputToString()).To(Equal("on-failure"))
+
+		inspect = podmanTest.Podman([]string{"inspect", "--format", "{{.HostConfig.RestartPolicy.Name}} {{.HostConfig.RestartPolicy.MaximumRetryCount}} {{.Config.HealthcheckOnFailureAction}}", "updatectr"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect).Should(ExitCleanly())
+		Expect(inspect.OutputToString()).To(Equal("on-failure 3 kill"))
+
+		// The infra container keeps its own restart policy.
+		inspect = podmanTest.Podman([]string{"pod", "inspect", "--format", "{{.InfraContainerID}}", podid})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect).Should(ExitCleanly())
+		infraID := inspect.OutputToString()
+
+		inspect = podmanTest.Podman([]string{"inspect", "--format", "{{.HostConfig.RestartPolicy.Name}}", infraID})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect).Should(ExitCleanly())
+		Expect(inspect.OutputToString()).ToNot(Equal("on-failure"))
+
+		session = podmanTest.Podman([]string{"pod", "update", "--health-on-failure", "bogus", podid})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, `invalid on-failure action "bogus" for health check: supported actions are none,kill,restart,stop`))
+	})
+})
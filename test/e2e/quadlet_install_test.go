@@ -0,0 +1,116 @@
+//go:build linux || freebsd
+
+package integration
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/containers/podman/v5/test/utils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Podman quadlet install", func() {
+	var unitDir, srcDir string
+
+	BeforeEach(func() {
+		unitDir = filepath.Join(podmanTest.TempDir, "quadlets")
+		srcDir = filepath.Join(podmanTest.TempDir, "quadlet-src")
+		Expect(os.MkdirAll(filepath.Join(srcDir, "conf"), 0o755)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(srcDir, "app.container"), []byte("[Container]\nImage=quay.io/libpod/alpine\nEnvironmentFile=conf/app.env\n"), 0o644)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(srcDir, "conf", "app.env"), []byte("FOO=bar\n"), 0o644)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(srcDir, "play.kube"), []byte("[Kube]\nYaml=play.yaml\n"), 0o644)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(srcDir, "play.yaml"), []byte("apiVersion: v1\nkind: Pod\n"), 0o644)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(srcDir, "README"), []byte("not a quadlet\n"), 0o644)).To(Succeed())
+
+		os.Setenv("QUADLET_UNIT_DIRS", unitDir)
+		if IsRemote() {
+			podmanTest.RestartRemoteService()
+		}
+	})
+
+	AfterEach(func() {
+		os.Unsetenv("QUADLET_UNIT_DIRS")
+	})
+
+	It("podman quadlet install, list, print and rm", func() {
+		session := podmanTest.Podman([]string{"quadlet", "install", "--reload-systemd=false", srcDir})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(session.OutputToStringArray()).To(ConsistOf(filepath.Join(unitDir, "app.container"), filepath.Join(unitDir, "play.kube")))
+
+		for _, file := range []string{"app.container", "conf/app.env", "play.kube", "play.yaml"} {
+			Expect(filepath.Join(unitDir, file)).To(BeAnExistingFile())
+		}
+		Expect(filepath.Join(unitDir, "README")).ToNot(BeAnExistingFile())
+
+		session = podmanTest.Podman([]string{"quadlet", "install", "--reload-systemd=false", filepath.Join(srcDir, "app.container")})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "app.container is already installed in "+unitDir+": quadlet already exists"))
+
+		session = podmanTest.Podman([]string{"quadlet", "install", "--reload-systemd=false", "--replace", filepath.Join(srcDir, "app.container")})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+
+		session = podmanTest.Podman([]string{"quadlet", "list", "--format", "{{.Name}} {{.UnitName}}"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(session.OutputToStringArray()).To(Equal([]string{"app.container app.service", "play.kube play.service"}))
+
+		session = podmanTest.Podman([]string{"quadlet", "print", "play.kube"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(session.OutputToStringArray()).To(Equal([]string{"[Kube]", "Yaml=play.yaml"}))
+
+		session = podmanTest.Podman([]string{"quadlet", "print", "missing.container"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "missing.container: no such quadlet"))
+
+		session = podmanTest.Podman([]string{"quadlet", "rm", "--reload-systemd=false", "app.container"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(session.OutputToString()).To(Equal("app.container"))
+		Expect(filepath.Join(unitDir, "app.container")).ToNot(BeAnExistingFile())
+		Expect(filepath.Join(unitDir, "conf/app.env")).ToNot(BeAnExistingFile())
+		Expect(filepath.Join(unitDir, "play.yaml")).To(BeAnExistingFile())
+
+		session = podmanTest.Podman([]string{"quadlet", "rm", "--reload-systemd=false", "app.container"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "app.container: no such quadlet"))
+
+		session = podmanTest.Podman([]string{"quadlet", "rm", "--reload-systemd=false", "--ignore", "app.container"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+
+		session = podmanTest.Podman([]string{"quadlet", "rm", "--reload-systemd=false", "--all"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(session.OutputToString()).To(Equal("play.kube"))
+		Expect(filepath.Join(unitDir, "play.yaml")).ToNot(BeAnExistingFile())
+	})
+
+	It("podman quadlet install rejects files outside of the Quadlet directory", func() {
+		Expect(os.WriteFile(filepath.Join(srcDir, "escape.kube"), []byte("[Kube]\nYaml=../play.yaml\n"), 0o644)).To(Succeed())
+
+		session := podmanTest.Podman([]string{"quadlet", "install", "--reload-systemd=false", filepath.Join(srcDir, "escape.kube")})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, `escape.kube references "../play.yaml" outside of its directory`))
+
+		session = podmanTest.Podman([]string{"quadlet", "install", "--reload-systemd=false", filepath.Join(srcDir, "README")})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "is not a Quadlet file"))
+	})
+
+	It("podman quadlet install rejects different files with the same name", func() {
+		otherDir := filepath.Join(podmanTest.TempDir, "quadlet-other")
+		Expect(os.MkdirAll(filepath.Join(otherDir, "conf"), 0o755)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(otherDir, "web.container"), []byte("[Container]\nImage=quay.io/libpod/alpine\nEnvironmentFile=conf/app.env\n"), 0o644)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(otherDir, "conf", "app.env"), []byte("FOO=baz\n"), 0o644)).To(Succeed())
+
+		session := podmanTest.Podman([]string{"quadlet", "install", "--reload-systemd=false", filepath.Join(srcDir, "app.container"), filepath.Join(otherDir, "web.container")})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "installing conf/app.env of Quadlet web.container: "+filepath.Join(srcDir, "conf", "app.env")+" and "+filepath.Join(otherDir, "conf", "app.env")+" are both installed as conf/app.env: invalid argument"))
+		Expect(filepath.Join(unitDir, "app.container")).ToNot(BeAnExistingFile())
+	})
+})
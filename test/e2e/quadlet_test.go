@@ -4,6 +4,7 @@ package integration
 
 import (
 	"encoding/csv"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -12,6 +13,7 @@ import (
 	"strings"
 
 	"github.com/containers/podman/v5/pkg/systemd/parser"
+	"github.com/containers/podman/v5/pkg/systemd/quadlet"
 	. "github.com/containers/podman/v5/test/utils"
 	"github.com/containers/podman/v5/version"
 	"github.com/mattn/go-shellwords"
@@ -763,6 +765,48 @@ BOGUS=foo
 			Expect(session.ErrorToString()).To(ContainSubstring("converting \"bogus.container\": unsupported key 'BOGUS' in group 'Container' in " + quadletfilePath))
 		})
 
+		It("Should report all problems in validate mode", func() {
+			quadletfile := fmt.Sprintf(`[Container]
+Image=%s
+BOGUS=foo
+Network=missing.network
+ExposeHostPort=foo
+`, ALPINE)
+			err = os.WriteFile(filepath.Join(quadletDir, "bogus.container"), []byte(quadletfile), 0644)
+			Expect(err).ToNot(HaveOccurred())
+			err = os.MkdirAll(filepath.Join(quadletDir, "bogus.container.d"), 0755)
+			Expect(err).ToNot(HaveOccurred())
+			dropinPath := filepath.Join(quadletDir, "bogus.container.d", "10-bogus.conf")
+			err = os.WriteFile(dropinPath, []byte("[Container]\nALSOBOGUS=bar\n"), 0644)
+			Expect(err).ToNot(HaveOccurred())
+
+			session := podmanTest.Quadlet([]string{"-validate"}, quadletDir)
+			session.WaitWithDefaultTimeout()
+			Expect(session).Should(Exit(1))
+			Expect(session.OutputToStringArray()).To(Equal([]string{
+				filepath.Join(quadletDir, "bogus.container") + ":3: error: [Container] BOGUS: unsupported key 'BOGUS' in group 'Container'",
+				filepath.Join(quadletDir, "bogus.container") + ":4: error: [Container] Network: requested Quadlet unit missing.network was not found",
+				filepath.Join(quadletDir, "bogus.container") + ":5: error: [Container] ExposeHostPort: invalid port format 'foo'",
+				dropinPath + ":2: error: [Container] ALSOBOGUS: unsupported key 'ALSOBOGUS' in group 'Container'",
+			}))
+
+			session = podmanTest.Quadlet([]string{"-validate", "-json"}, quadletDir)
+			session.WaitWithDefaultTimeout()
+			Expect(session).Should(Exit(1))
+			var diags []quadlet.Diagnostic
+			err = json.Unmarshal(session.Out.Contents(), &diags)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(diags).To(HaveLen(4))
+			Expect(diags[3]).To(Equal(quadlet.Diagnostic{
+				File:     dropinPath,
+				Line:     2,
+				Group:    "Container",
+				Key:      "ALSOBOGUS",
+				Severity: quadlet.SeverityError,
+				Message:  "unsupported key 'ALSOBOGUS' in group 'Container'",
+			}))
+		})
+
 		It("Should scan and return output for files in subdirectories", func() {
 			dirName := "test_subdir"
 
@@ -899,6 +943,7 @@ BOGUS=foo
 		Entry("remap-keep-id.container", "remap-keep-id.container"),
 		Entry("remap-keep-id2.container", "remap-keep-id2.container"),
 		Entry("remap-manual.container", "remap-manual.container"),
+		Entry("resources.container", "resources.container"),
 		Entry("rootfs.container", "rootfs.container"),
 		Entry("seccomp.container", "seccomp.container"),
 		Entry("secrets.container", "secrets.container"),
@@ -1042,6 +1087,7 @@ BOGUS=foo
 		Entry("Pod - Remap auto2", "remap-auto2.pod"),
 		Entry("Pod - Remap keep-id", "remap-keep-id.pod"),
 		Entry("Pod - Remap manual", "remap-manual.pod"),
+		Entry("Pod - Resources", "resources.pod"),
 	)
 
 	DescribeTable("Running expected warning quadlet test case",
@@ -1055,6 +1101,7 @@ BOGUS=foo
 		Entry("noimage.container", "noimage.container", "converting \"noimage.container\": no Image or Rootfs key specified"),
 		Entry("pod.non-quadlet.container", "pod.non-quadlet.container", "converting \"pod.non-quadlet.container\": pod test-pod is not Quadlet based"),
 		Entry("pod.not-found.container", "pod.not-found.container", "converting \"pod.not-found.container\": quadlet pod unit not-found.pod does not exist"),
+		Entry("resources-invalid.container", "resources-invalid.container", "converting \"resources-invalid.container\": invalid Memory '512x': invalid suffix: 'x'"),
 		Entry("subidmapping-with-remap.container", "subidmapping-with-remap.container", "converting \"subidmapping-with-remap.container\": deprecated Remap keys are set along with explicit mapping keys"),
 		Entry("userns-with-remap.container", "userns-with-remap.container", "converting \"userns-with-remap.container\": deprecated Remap keys are set along with explicit mapping keys"),
 
@@ -1064,6 +1111,8 @@ BOGUS=foo
 
 		Entry("Kube - User Remap Manual", "remap-manual.kube", "converting \"remap-manual.kube\": RemapUsers=manual is not supported"),
 
+		Entry("Pod - Invalid resource limit", "resources-invalid.pod", "converting \"resources-invalid.pod\": invalid BlkioWeight '5': must be a number between 10 and 1000"),
+
 		Entry("Network - Gateway not enough Subnet", "gateway.less-subnet.network", "converting \"gateway.less-subnet.network\": cannot set more gateways than subnets"),
 		Entry("Network - Gateway without Subnet", "gateway.no-subnet.network", "converting \"gateway.no-subnet.network\": cannot set gateway or range without subnet"),
 		Entry("Network - Range not enough Subnet", "range.less-subnet.network", "converting \"range.less-subnet.network\": cannot set more ranges than subnets"),
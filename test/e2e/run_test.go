@@ -2169,6 +2169,49 @@ WORKDIR /madethis`, BB)
 		Expect(session.OutputToString()).To(Equal(secretsString))
 	})
 
+	It("podman run --secret with rotate", func() {
+		secretFilePath := filepath.Join(podmanTest.TempDir, "secret")
+		err := os.WriteFile(secretFilePath, []byte("v1"), 0755)
+		Expect(err).ToNot(HaveOccurred())
+
+		session := podmanTest.Podman([]string{"secret", "create", "mysecret", secretFilePath})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+
+		session = podmanTest.Podman([]string{"run", "-d", "--secret", "mysecret,target=rotated,rotate=true,signal=SIGHUP", "--name", "rotate", ALPINE,
+			"sh", "-c", "trap 'cat /run/secrets/rotated > /hup' HUP; while :; do sleep 0.1; done"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+
+		session = podmanTest.Podman([]string{"exec", "rotate", "cat", "/run/secrets/rotated"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(session.OutputToString()).To(Equal("v1"))
+
+		err = os.WriteFile(secretFilePath, []byte("v2"), 0755)
+		Expect(err).ToNot(HaveOccurred())
+		session = podmanTest.Podman([]string{"secret", "update", "mysecret", secretFilePath})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+
+		session = podmanTest.Podman([]string{"exec", "rotate", "cat", "/run/secrets/rotated"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(session.OutputToString()).To(Equal("v2"))
+
+		// the signal handler reads the new data
+		Eventually(func() string {
+			session := podmanTest.Podman([]string{"exec", "rotate", "cat", "/hup"})
+			session.WaitWithDefaultTimeout()
+			return session.OutputToString()
+		}, "5s", "200ms").Should(Equal("v2"))
+
+		session = podmanTest.Podman([]string{"secret", "inspect", "--format", "{{ len .Versions }}", "mysecret"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(session.OutputToString()).To(Equal("2"))
+	})
+
 	It("podman run invalid secret option", func() {
 		secretsString := "somesecretdata"
 		secretFilePath := filepath.Join(podmanTest.TempDir, "secret")
@@ -2199,6 +2242,21 @@ WORKDIR /madethis`, BB)
 		session.WaitWithDefaultTimeout()
 		Expect(session).To(ExitWithError(125, "UID, GID, Mode options cannot be set with secret type env: parsing secret"))
 
+		// rotate option with env type
+		session = podmanTest.Podman([]string{"run", "--secret", "source=mysecret,type=env,rotate=true", "--name", "secr", ALPINE, "printenv", "mysecret"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError(125, "rotate option cannot be set with secret type env: parsing secret"))
+
+		// signal without rotate
+		session = podmanTest.Podman([]string{"run", "--secret", "source=mysecret,signal=SIGHUP", "--name", "secr", ALPINE, "true"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError(125, "signal can only be set with rotate=true: parsing secret"))
+
+		// rotate with absolute target
+		session = podmanTest.Podman([]string{"run", "--secret", "source=mysecret,target=/tmp/mysecret,rotate=true", "--name", "secr", ALPINE, "true"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError(125, "secret mysecret: rotate requires a target relative to /run/secrets: invalid argument"))
+
 		// No source given
 		session = podmanTest.Podman([]string{"run", "--secret", "type=env", "--name", "secr", ALPINE, "printenv", "mysecret"})
 		session.WaitWithDefaultTimeout()
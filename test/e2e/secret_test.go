@@ -57,6 +57,128 @@ var _ = Describe("Podman secret", func() {
 		Expect(inspect.OutputToString()).To(ContainSubstring("opt1:val1"))
 	})
 
+	It("podman secret create encrypted and rekey", func() {
+		secretFilePath := filepath.Join(podmanTest.TempDir, "secret")
+		err := os.WriteFile(secretFilePath, []byte("mysecret"), 0755)
+		Expect(err).ToNot(HaveOccurred())
+		keyFile := filepath.Join(podmanTest.TempDir, "secret.key")
+		err = os.WriteFile(keyFile, []byte(stringid.GenerateRandomID()), 0600)
+		Expect(err).ToNot(HaveOccurred())
+
+		session := podmanTest.Podman([]string{"secret", "create", "--driver-opts", "encrypt=keyfile,keyfile=" + keyFile, "a", secretFilePath})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		secrID := session.OutputToString()
+
+		session = podmanTest.Podman([]string{"secret", "create", "--driver-opts", "encrypt=keyfile", "b", secretFilePath})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "encrypt=keyfile needs the keyfile option"))
+
+		inspect := podmanTest.Podman([]string{"secret", "inspect", "--showsecret", "--format", "{{ .SecretData }}", "a"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect).Should(ExitCleanly())
+		Expect(inspect.OutputToString()).To(Equal("mysecret"))
+
+		newKeyFile := filepath.Join(podmanTest.TempDir, "secret-new.key")
+		err = os.WriteFile(newKeyFile, []byte(stringid.GenerateRandomID()), 0600)
+		Expect(err).ToNot(HaveOccurred())
+		session = podmanTest.Podman([]string{"secret", "rekey", "--driver-opts", "keyfile=" + newKeyFile, "a"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(session.OutputToString()).To(Equal(secrID))
+
+		// the old key must not be needed anymore
+		err = os.Remove(keyFile)
+		Expect(err).ToNot(HaveOccurred())
+		inspect = podmanTest.Podman([]string{"secret", "inspect", "--showsecret", "--format", "{{ .SecretData }} {{ .Spec.Driver.Options.keyfile }}", "a"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect).Should(ExitCleanly())
+		Expect(inspect.OutputToString()).To(Equal("mysecret " + newKeyFile))
+
+		session = podmanTest.Podman([]string{"secret", "rekey", "--all", "--driver-opts", "encrypt=,keyfile="})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+
+		err = os.Remove(newKeyFile)
+		Expect(err).ToNot(HaveOccurred())
+		inspect = podmanTest.Podman([]string{"secret", "inspect", "--showsecret", "--format", "{{ .SecretData }} {{ .Spec.Driver.Options }}", "a"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect).Should(ExitCleanly())
+		Expect(inspect.OutputToString()).To(HavePrefix("mysecret map["))
+		Expect(inspect.OutputToString()).ToNot(ContainSubstring("encrypt"))
+		Expect(inspect.OutputToString()).ToNot(ContainSubstring("keyfile"))
+
+		session = podmanTest.Podman([]string{"secret", "create", "--driver-opts", "encrypt=local", "b", secretFilePath})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "encrypt must be one of keyfile or env"))
+
+		session = podmanTest.Podman([]string{"secret", "rekey", "--all", "a"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "requires one argument, or the --all flag"))
+	})
+
+	It("podman secret encrypted with a key from the environment and restart policy", func() {
+		// the environment of the test is not passed to the remote service
+		SkipIfRemote("the key is read by the podman process")
+		os.Setenv("PODMAN_SECRETS_KEY", stringid.GenerateRandomID())
+		defer os.Unsetenv("PODMAN_SECRETS_KEY")
+		secretFilePath := filepath.Join(podmanTest.TempDir, "secret")
+		err := os.WriteFile(secretFilePath, []byte("mysecret"), 0755)
+		Expect(err).ToNot(HaveOccurred())
+
+		session := podmanTest.Podman([]string{"secret", "create", "--driver-opts", "encrypt=env", "a", secretFilePath})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+
+		session = podmanTest.Podman([]string{"create", "--restart", "always", "--secret", "a", ALPINE})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "secret a is encrypted with a key from the environment"))
+
+		session = podmanTest.Podman([]string{"create", "--name", "ctr", "--secret", "a", ALPINE})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		session = podmanTest.Podman([]string{"update", "--restart", "always", "ctr"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "secret a is encrypted with a key from the environment"))
+
+		session = podmanTest.Podman([]string{"secret", "create", "b", secretFilePath})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		session = podmanTest.Podman([]string{"create", "--restart", "always", "--secret", "b", ALPINE})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		session = podmanTest.Podman([]string{"secret", "rekey", "--driver-opts", "encrypt=env", "b"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "with restart policy always, which cannot read a key from the environment"))
+	})
+
+	It("podman secret update", func() {
+		secretFilePath := filepath.Join(podmanTest.TempDir, "secret")
+		err := os.WriteFile(secretFilePath, []byte("v1"), 0755)
+		Expect(err).ToNot(HaveOccurred())
+
+		session := podmanTest.Podman([]string{"secret", "create", "a", secretFilePath})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		secrID := session.OutputToString()
+
+		err = os.WriteFile(secretFilePath, []byte("v2"), 0755)
+		Expect(err).ToNot(HaveOccurred())
+		session = podmanTest.Podman([]string{"secret", "update", "a", secretFilePath})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(session.OutputToString()).To(Equal(secrID))
+
+		inspect := podmanTest.Podman([]string{"secret", "inspect", "--showsecret", "--format", "{{ .SecretData }}{{ range .Versions }} {{ .Version }}{{ end }}", "a"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect).Should(ExitCleanly())
+		Expect(inspect.OutputToString()).To(Equal("v2 1 2"))
+
+		session = podmanTest.Podman([]string{"secret", "update", "b", secretFilePath})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "no such secret"))
+	})
+
 	It("podman secret create bad name should fail", func() {
 		secretFilePath := filepath.Join(podmanTest.TempDir, "secret")
 		err := os.WriteFile(secretFilePath, []byte("mysecret"), 0755)
@@ -179,6 +179,27 @@ var _ = Describe("Podman volume create", func() {
 		Expect(inspectOpts.OutputToString()).To(Equal(optionStrFormatExpect))
 	})
 
+	It("podman create volume with size quota", func() {
+		SkipIfRootless("volume size quotas are not supported as rootless")
+		volName := "quotavol"
+		session := podmanTest.Podman([]string{"volume", "create", "--opt", "size=64M", volName})
+		session.WaitWithDefaultTimeout()
+		if session.ExitCode() != 0 {
+			Skip("volume size quotas are not supported on this system: " + session.ErrorToString())
+		}
+
+		inspect := podmanTest.Podman([]string{"volume", "inspect", "--format", "{{ .Quota.Backend }} {{ .Quota.Size }} {{ .Options.o }}", volName})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect).Should(ExitCleanly())
+		Expect(inspect.OutputToString()).To(MatchRegexp(`^(project|loop) 64000000 size=64M$`))
+
+		session = podmanTest.Podman([]string{"run", "--rm", "-v", volName + ":/data", ALPINE, "dd", "if=/dev/zero", "of=/data/big", "bs=1M", "count=100"})
+		session.WaitWithDefaultTimeout()
+		// Project quotas fail with EDQUOT, loopback images with ENOSPC.
+		Expect(session).Should(ExitWithError(1, ""))
+		Expect(session.ErrorToString()).To(MatchRegexp("Disk quota exceeded|No space left on device"))
+	})
+
 	It("image-backed volume basic functionality", func() {
 		podmanTest.AddImageToRWStore(fedoraMinimal)
 		volName := "testvol"
@@ -0,0 +1,159 @@
+//go:build linux || freebsd
+
+package integration
+
+import (
+	. "github.com/containers/podman/v5/test/utils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Podman volume snapshot", func() {
+
+	AfterEach(func() {
+		podmanTest.CleanupVolume()
+	})
+
+	It("podman volume snapshot create, restore and rm", func() {
+		session := podmanTest.Podman([]string{"volume", "create", "myvol"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		session = podmanTest.Podman([]string{"run", "--rm", "-v", "myvol:/data", ALPINE, "sh", "-c", "echo one > /data/file"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+
+		session = podmanTest.Podman([]string{"volume", "snapshot", "create", "myvol", "snap1"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(session.OutputToString()).To(Equal("snap1"))
+
+		session = podmanTest.Podman([]string{"volume", "snapshot", "create", "myvol", "snap1"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "snapshot snap1 of volume myvol: volume snapshot already exists"))
+
+		session = podmanTest.Podman([]string{"volume", "snapshot", "create", "myvol"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		snap2 := session.OutputToString()
+		Expect(snap2).To(MatchRegexp(`^\d{8}T\d{6}Z$`))
+
+		session = podmanTest.Podman([]string{"volume", "snapshot", "ls", "--quiet", "myvol"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(session.OutputToStringArray()).To(Equal([]string{"snap1", snap2}))
+
+		session = podmanTest.Podman([]string{"volume", "inspect", "--format", "{{range .Snapshots}}{{.Name}} {{end}}", "myvol"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(session.OutputToString()).To(Equal("snap1 " + snap2))
+
+		session = podmanTest.Podman([]string{"run", "--rm", "-v", "myvol:/data", ALPINE, "sh", "-c", "echo two > /data/file"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		session = podmanTest.Podman([]string{"volume", "snapshot", "restore", "myvol", "snap1"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		session = podmanTest.Podman([]string{"run", "--rm", "-v", "myvol:/data", ALPINE, "cat", "/data/file"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(session.OutputToString()).To(Equal("one"))
+
+		session = podmanTest.Podman([]string{"volume", "snapshot", "restore", "myvol", "bogus"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "snapshot bogus of volume myvol: no such snapshot"))
+
+		session = podmanTest.Podman([]string{"volume", "snapshot", "rm", "myvol", "snap1", "bogus"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "snapshot bogus of volume myvol: no such snapshot"))
+		Expect(session.OutputToString()).To(Equal("snap1"))
+
+		session = podmanTest.Podman([]string{"volume", "snapshot", "ls", "--quiet", "myvol"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(session.OutputToStringArray()).To(Equal([]string{snap2}))
+	})
+
+	It("podman volume snapshot restore with running container", func() {
+		session := podmanTest.Podman([]string{"volume", "create", "myvol"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		session = podmanTest.Podman([]string{"volume", "snapshot", "create", "myvol", "snap1"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		session = podmanTest.Podman([]string{"run", "-d", "-v", "myvol:/data", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		cid := session.OutputToString()
+
+		session = podmanTest.Podman([]string{"volume", "snapshot", "restore", "myvol", "snap1"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(2, "volume myvol is being used by the running container "+cid+": volume is being used"))
+
+		session = podmanTest.Podman([]string{"rm", "-f", "-t0", cid})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		session = podmanTest.Podman([]string{"volume", "snapshot", "restore", "myvol", "snap1"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+	})
+
+	It("podman volume snapshot with mount options", func() {
+		SkipIfRootless("cannot create tmpfs volumes as rootless")
+		session := podmanTest.Podman([]string{"volume", "create", "--opt", "type=tmpfs", "--opt", "device=tmpfs", "myvol"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+
+		session = podmanTest.Podman([]string{"volume", "snapshot", "create", "myvol"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "volume myvol has mount options, snapshots are only supported for volumes without them"))
+
+		session = podmanTest.Podman([]string{"volume", "clone", "myvol"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "volume myvol has mount options, snapshots are only supported for volumes without them"))
+	})
+
+	It("podman volume clone", func() {
+		session := podmanTest.Podman([]string{"volume", "create", "--label", "foo=bar", "myvol"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		session = podmanTest.Podman([]string{"run", "--rm", "-v", "myvol:/data", ALPINE, "sh", "-c", "echo one > /data/file"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		session = podmanTest.Podman([]string{"volume", "snapshot", "create", "myvol", "snap1"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		session = podmanTest.Podman([]string{"run", "--rm", "-v", "myvol:/data", ALPINE, "sh", "-c", "echo two > /data/file"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+
+		session = podmanTest.Podman([]string{"volume", "clone", "myvol", "clone1"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(session.OutputToString()).To(Equal("clone1"))
+		session = podmanTest.Podman([]string{"run", "--rm", "-v", "clone1:/data", ALPINE, "cat", "/data/file"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(session.OutputToString()).To(Equal("two"))
+		session = podmanTest.Podman([]string{"volume", "inspect", "--format", "{{.Labels.foo}} {{len .Snapshots}}", "clone1"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(session.OutputToString()).To(Equal("bar 0"))
+
+		session = podmanTest.Podman([]string{"volume", "clone", "--snapshot", "snap1", "myvol"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		clone2 := session.OutputToString()
+		session = podmanTest.Podman([]string{"run", "--rm", "-v", clone2 + ":/data", ALPINE, "cat", "/data/file"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(session.OutputToString()).To(Equal("one"))
+
+		session = podmanTest.Podman([]string{"volume", "clone", "myvol", "clone1"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "volume with name clone1 already exists"))
+
+		session = podmanTest.Podman([]string{"volume", "clone", "--snapshot", "bogus", "myvol"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "snapshot bogus of volume myvol: no such snapshot"))
+	})
+})
@@ -347,6 +347,245 @@ EOF
     _confirm_update $cname $newID
 }
 
+@test "podman auto-update - recreate container without systemd" {
+    cname=c_local_$(random_string)
+    image=quay.io/libpod/localtest:latest
+    run_podman tag $IMAGE $image
+    run_podman run -d --name $cname --label io.containers.autoupdate=local \
+               --restart=always -v ${cname}_vol:/vol $image top -d 120
+    oldID="$output"
+
+    run_podman commit --change CMD=/bin/bash $cname $image
+    run_podman image inspect --format "{{.ID}}" $image
+    newImageID="$output"
+
+    run_podman auto-update --recreate --dry-run --format "{{.Unit}},{{.ContainerName}},{{.Updated}},{{.Policy}}"
+    is "$output" ",$cname,pending,local" "Update is pending"
+
+    run_podman auto-update --recreate --format "{{.Unit}},{{.ContainerName}},{{.Updated}},{{.Policy}}"
+    is "$output" ",$cname,true,local" "Container is recreated"
+
+    run_podman container inspect --format "{{.ID}} {{.Image}} {{.State.Status}} {{.HostConfig.RestartPolicy.Name}} {{range .Mounts}}{{.Name}}{{end}} {{.Config.Cmd}}" $cname
+    is "$output" "[0-9a-f]\{64\} $newImageID running always ${cname}_vol \[top -d 120\]" \
+       "Container uses the new image and keeps its configuration"
+    assert "${output:0:64}" != "$oldID" "Container has been recreated"
+
+    run_podman ps -a --format "{{.Names}}"
+    is "$output" "$cname" "Previous container has been removed"
+
+    # Without --recreate containers outside of systemd units are not updated
+    run_podman commit --change CMD=/bin/sh $cname $image
+    run_podman 125 auto-update
+    is "$output" ".*auto-updating container .*: no PODMAN_SYSTEMD_UNIT label found.*" \
+       "Container without systemd unit is not recreated"
+
+    run_podman rm -f -t0 $cname
+    run_podman volume rm ${cname}_vol
+}
+
+@test "podman auto-update - recreate container without systemd with rollback" {
+    cname=c_local_$(random_string)
+    image=quay.io/libpod/localtest:latest
+    run_podman tag $IMAGE $image
+    run_podman run -d --name $cname --label io.containers.autoupdate=local \
+               --health-cmd "test ! -e /broken" --health-interval 1s --health-retries 2 \
+               $image top -d 120
+    oldID="$output"
+
+    dockerfile=$PODMAN_TMPDIR/Dockerfile
+    cat >$dockerfile <<EOF
+FROM $image
+RUN touch /broken
+EOF
+    run_podman build -t $image -f $dockerfile
+
+    run_podman 125 auto-update --recreate --format "{{.Unit}},{{.ContainerName}},{{.Updated}},{{.Policy}}"
+    is "$output" ".*,$cname,rolled back,local.*" "Container is rolled back"
+    is "$output" ".*is not healthy.*" "Healthcheck of new container failed"
+
+    run_podman container inspect --format "{{.ID}} {{.State.Status}}" $cname
+    is "$output" "$oldID running" "Previous container is restored"
+
+    run_podman ps -a --format "{{.Names}}"
+    is "$output" "$cname" "New container has been removed"
+
+    run_podman rm -f -t0 $cname
+}
+
+@test "podman auto-update - label io.containers.autoupdate=registry-semver" {
+    registry=localhost:${PODMAN_LOGIN_REGISTRY_PORT}
+    repo=$registry/semver$(random_string 8 | tr A-Z a-z)
+    authfile=$PODMAN_TMPDIR/authfile.json
+    cname=c_semver_$(random_string)
+
+    start_registry
+    run_podman login --authfile=$authfile \
+        --tls-verify=false \
+        --username ${PODMAN_LOGIN_USER} \
+        --password ${PODMAN_LOGIN_PASS} \
+        $registry
+
+    dockerfile=$PODMAN_TMPDIR/Dockerfile
+    cat >$dockerfile <<EOF
+FROM $IMAGE
+RUN touch /newer
+EOF
+    run_podman build -t $repo:newer -f $dockerfile
+
+    # 1.4.2 is the highest tag matching ~1.4, 1.5.0, 2.0 and the
+    # pre-release must be ignored.
+    for tag in 1.4.0 v1.5.0 2.0; do
+        run_podman push --tls-verify=false --authfile=$authfile $IMAGE $repo:$tag
+    done
+    for tag in 1.4.2 1.4.3-rc1; do
+        run_podman push --tls-verify=false --authfile=$authfile $repo:newer $repo:$tag
+    done
+    run_podman rmi $repo:newer
+    run_podman pull --tls-verify=false --authfile=$authfile $repo:1.4.0
+
+    run_podman run -d --name $cname \
+               --label io.containers.autoupdate=registry-semver:~1.4 \
+               --label io.containers.autoupdate.authfile=$authfile \
+               $repo:1.4.0 top -d 120
+
+    run_podman auto-update --recreate --tls-verify=false --dry-run --format "{{.ContainerName}},{{.Image}},{{.NewImage}},{{.Updated}},{{.Policy}}"
+    is "$output" "$cname,$repo:1.4.0,$repo:1.4.2,pending,registry-semver" "Update to the highest matching tag is pending"
+
+    run_podman auto-update --recreate --tls-verify=false --format "{{.ContainerName}},{{.NewImage}},{{.Updated}}"
+    is "$output" "$cname,$repo:1.4.2,true" "Container is updated"
+
+    run_podman container inspect --format "{{.ImageName}} {{.State.AutoUpdateTag}} {{.State.Status}}" $cname
+    is "$output" "$repo:1.4.2 1.4.2 running" "Container runs the chosen tag and records it"
+
+    run_podman auto-update --recreate --tls-verify=false --format "{{.ContainerName}},{{.NewImage}},{{.Updated}}"
+    is "$output" "$cname,,false" "Container is up to date"
+
+    run_podman rm -f -t0 $cname
+    run_podman rmi $repo:1.4.0 $repo:1.4.2
+
+    # The unit creates a new container from its image reference which is
+    # tagged to the chosen image, the tag is recorded in its state.
+    run_podman pull --tls-verify=false --authfile=$authfile $repo:1.4.0
+    cname=c_semver_unit_$(random_string)
+    run_podman create --name $cname \
+               --label io.containers.autoupdate=registry-semver:~1.4 \
+               --label io.containers.autoupdate.authfile=$authfile \
+               $repo:1.4.0 top -d 120
+    (cd $UNIT_DIR; run_podman generate systemd --new --files --name $cname)
+    echo "container-$cname" >> $SNAME_FILE
+    run_podman rm -t 0 -f $cname
+    systemctl daemon-reload
+    systemctl_start container-$cname
+    _wait_service_ready container-$cname.service
+    run_podman inspect --format "{{.Image}}" $cname
+    ori_image=$output
+
+    run_podman auto-update --tls-verify=false --format "{{.Unit}},{{.NewImage}},{{.Updated}}"
+    is "$output" "container-$cname.service,$repo:1.4.2,true" "Unit is updated"
+    _confirm_update $cname $ori_image
+
+    run_podman container inspect --format "{{.ImageName}} {{.State.AutoUpdateTag}}" $cname
+    is "$output" "$repo:1.4.0 1.4.2" "Container of the unit records the chosen tag"
+
+    systemctl stop container-$cname.service
+    run_podman rm -f -t0 --ignore $cname
+    run_podman rmi -f $repo:1.4.0 $repo:1.4.2
+
+    # Invalid constraints are reported
+    run_podman run -d --label io.containers.autoupdate=registry-semver:~foo $IMAGE top -d 120
+    cid="$output"
+    run_podman 125 auto-update
+    is "$output" ".*invalid version constraint of auto-update policy.*" "invalid constraint"
+    run_podman rm -f -t0 $cid
+}
+
+@test "podman auto-update --require-signatures" {
+    cname=c_local_$(random_string)
+    image=quay.io/libpod/localtest:latest
+    run_podman tag $IMAGE $image
+    run_podman run -d --name $cname --label io.containers.autoupdate=local $image top -d 120
+
+    run_podman commit --change CMD=/bin/bash $cname $image
+
+    run_podman 125 auto-update --recreate --require-signatures --format "{{.ContainerName}},{{.Updated}}"
+    is "$output" ".*,$cname,failed.*" "Update is refused"
+    is "$output" ".*signatures of images in the local storage cannot be verified.*"
+
+    run_podman rm -f -t0 $cname
+
+    # Unsigned images on registries are refused by the default policy.
+    registry=localhost:${PODMAN_LOGIN_REGISTRY_PORT}
+    image_on_local_registry=$registry/signed$(random_string 8 | tr A-Z a-z):latest
+    authfile=$PODMAN_TMPDIR/authfile.json
+    start_registry
+    run_podman login --authfile=$authfile --tls-verify=false \
+        --username ${PODMAN_LOGIN_USER} --password ${PODMAN_LOGIN_PASS} $registry
+    run_podman push --tls-verify=false --authfile=$authfile $IMAGE $image_on_local_registry
+    run_podman pull --tls-verify=false --authfile=$authfile $image_on_local_registry
+    run_podman push --tls-verify=false --authfile=$authfile $image $image_on_local_registry
+
+    run_podman run -d --name $cname --label io.containers.autoupdate=registry \
+               --label io.containers.autoupdate.authfile=$authfile $image_on_local_registry top -d 120
+    run_podman 125 auto-update --recreate --require-signatures --tls-verify=false --dry-run --format "{{.ContainerName}},{{.Updated}}"
+    is "$output" ".*,$cname,failed.*" "Update is refused"
+    is "$output" ".*refusing unsigned update: signature policy .* does not require signatures for $image_on_local_registry.*"
+
+    run_podman rm -f -t0 $cname
+    run_podman rmi $image_on_local_registry
+}
+
+@test "podman auto-update --soak-period" {
+    image=quay.io/libpod/localtest:latest
+    run_podman tag $IMAGE $image
+    cname1=c1_local_$(random_string)
+    cname2=c2_local_$(random_string)
+    for cname in $cname1 $cname2; do
+        run_podman run -d --name $cname --label io.containers.autoupdate=local \
+                   --health-cmd "test ! -e /broken" --health-interval 1s --health-retries 2 \
+                   $image top -d 120
+    done
+
+    # The canary fails its healthcheck, so the update of the second
+    # container is held back.
+    dockerfile=$PODMAN_TMPDIR/Dockerfile
+    cat >$dockerfile <<EOF
+FROM $image
+RUN touch /broken
+EOF
+    run_podman build -t $image -f $dockerfile
+
+    since=$(date --iso-8601=seconds)
+    run_podman 125 auto-update --recreate --soak-period 2s --format "{{.ContainerName}},{{.Updated}},{{.Stage}}"
+    is "$output" ".*$cname1,rolled back,canary.*" "Canary is rolled back"
+    is "$output" ".*$cname2,false,held.*" "Update is held back"
+
+    run_podman events --since $since --stream=false --filter type=container --filter event=auto-update \
+               --format "{{.Name}} {{index .Attributes \"io.containers.autoupdate.stage\"}}"
+    assert "$output" = "$cname1 canary
+$cname1 soak-failed
+$cname2 held" "Stages are recorded in events"
+
+    # A healthy canary is followed by the second container.
+    cat >$dockerfile <<EOF
+FROM $image
+RUN touch /newer
+EOF
+    run_podman build -t $image -f $dockerfile
+
+    since=$(date --iso-8601=seconds)
+    run_podman auto-update --recreate --soak-period 2s --format "{{.ContainerName}},{{.Updated}},{{.Stage}}"
+    is "$output" ".*$cname1,true,canary.*" "Canary is updated"
+    is "$output" ".*$cname2,true,rollout.*" "Canary is followed by the rollout"
+
+    run_podman events --since $since --stream=false --filter type=container --filter event=auto-update \
+               --format "{{.Name}} {{index .Attributes \"io.containers.autoupdate.stage\"}}"
+    assert "$output" = "$cname1 canary
+$cname1 soaked
+$cname2 rollout" "Stages are recorded in events"
+
+    run_podman rm -f -t0 $cname1 $cname2
+}
+
 @test "podman auto-update with multiple services" {
     # Preserve original image ID, to confirm that it changes (or not)
     run_podman inspect --format "{{.Id}}" $IMAGE
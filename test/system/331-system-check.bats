@@ -246,3 +246,33 @@ function testing_make_image_metadata_for_layer_blobs() {
 }
 
 # vim: filetype=sh
+
+@test "podman system check - storage of container missing" {
+    run_podman create $IMAGE
+    containerID="$output"
+    run_podman_testing remove-container --container=$containerID
+    run_podman 125 system check
+    assert "$output" =~ "storage container does not exist" "output from 'podman system check' with missing container storage"
+    run_podman 125 system check -r
+    assert "$output" =~ "container $containerID can only be repaired by removing it, use --force to remove it" "output from 'podman system check -r' with missing container storage"
+    run_podman 0+w system check -r -f
+    assert "$output" =~ "Deleted damaged container: $containerID" "output from 'podman system check -r -f' with missing container storage"
+    run_podman system check
+    run_podman 1 container exists $containerID
+}
+
+@test "podman system check - volume mountpoint missing" {
+    skip_if_remote "volume mountpoint is not accessible over remote"
+    volname=v-$(safename)
+    run_podman volume create $volname
+    run_podman volume inspect --format '{{.Mountpoint}}' $volname
+    mountpoint="$output"
+    rm -rf $(dirname $mountpoint)
+    run_podman 125 system check
+    assert "$output" =~ "mountpoint $mountpoint does not exist" "output from 'podman system check' with missing volume mountpoint"
+    run_podman system check -r
+    assert "$output" =~ "Recreated mountpoint of volume: $volname" "output from 'podman system check -r' with missing volume mountpoint"
+    assert "$(stat -c %F $mountpoint)" = "directory" "mountpoint of volume was recreated"
+    run_podman system check
+    run_podman volume rm $volname
+}
@@ -0,0 +1,79 @@
+#!/usr/bin/env bats   -*- bats -*-
+#
+# tests for podman system migrate
+#
+
+load helpers
+
+function setup() {
+    basic_setup
+
+    skip_if_remote "podman system migrate is not available remote"
+}
+
+@test "podman system migrate --database" {
+    local safe_opts=$(podman_isolation_opts ${PODMAN_TMPDIR})
+
+    run_podman info --format '{{.Host.DatabaseBackend}}'
+    local default_backend="$output"
+
+    run_podman $safe_opts info --format '{{.Host.DatabaseBackend}}'
+    local old_backend="$output"
+    local new_backend=boltdb
+    if [[ "$old_backend" == "boltdb" ]]; then
+        new_backend=sqlite
+    fi
+
+    local volname=v-$(safename)
+    local podname=p-$(safename)
+    local cname=c-$(safename)
+    run_podman $safe_opts volume create $volname
+    run_podman $safe_opts pod create --name $podname
+    run_podman $safe_opts run -d --pod $podname --name $cname -v $volname:/vol $IMAGE top
+    local cid="$output"
+
+    # A backend set in containers.conf is switched with a drop-in in the
+    # config home of the user
+    export XDG_CONFIG_HOME=$PODMAN_TMPDIR/config
+    local dropin=$XDG_CONFIG_HOME/containers/containers.conf.d/podman-database-backend.conf
+
+    run_podman $safe_opts system migrate --database $new_backend
+
+    run_podman $safe_opts info --format '{{.Host.DatabaseBackend}}'
+    is "$output" "$new_backend" "database backend after migration"
+
+    if [[ -e $dropin ]]; then
+        assert "$(< $dropin)" =~ "database_backend = \"$new_backend\"" "configured backend is switched"
+    else
+        # Stores with their own database are not switched
+        run_podman info --format '{{.Host.DatabaseBackend}}'
+        is "$output" "$default_backend" "database backend of the default store"
+    fi
+
+    run_podman $safe_opts ps -a --format '{{.ID}} {{.Names}} {{.PodName}}' --filter id=$cid
+    is "$output" "${cid:0:12} $cname $podname" "container and its pod survive the migration"
+    run_podman $safe_opts volume ls -q
+    assert "$output" =~ "$volname" "volume survives the migration"
+
+    # Migrating to the backend in use is an error
+    run_podman 125 $safe_opts system migrate --database $new_backend
+    assert "$output" =~ "already using the $new_backend database backend"
+
+    # The containers were stopped, and still work
+    run_podman $safe_opts pod start $podname
+    run_podman $safe_opts exec $cname ls /vol
+
+    # And back again
+    run_podman $safe_opts system migrate --database $old_backend
+    run_podman $safe_opts info --format '{{.Host.DatabaseBackend}}'
+    is "$output" "$old_backend" "database backend after migrating back"
+    if [[ -e $dropin ]]; then
+        assert "$(< $dropin)" =~ "database_backend = \"$old_backend\"" "configured backend is switched back"
+    fi
+    run_podman $safe_opts container inspect --format '{{.Name}} {{.Pod}}' $cid
+    assert "$output" =~ "^$cname [0-9a-f]{64}$" "container after migrating back"
+
+    run_podman $safe_opts system reset --force
+}
+
+# vim: filetype=sh